@@ -17,23 +17,40 @@ package artifact
 
 import (
 	"context"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	artifactconfig "github.com/falcosecurity/falcoctl/cmd/artifact/config"
+	"github.com/falcosecurity/falcoctl/cmd/artifact/export"
 	"github.com/falcosecurity/falcoctl/cmd/artifact/follow"
 	"github.com/falcosecurity/falcoctl/cmd/artifact/info"
 	"github.com/falcosecurity/falcoctl/cmd/artifact/install"
 	"github.com/falcosecurity/falcoctl/cmd/artifact/list"
 	"github.com/falcosecurity/falcoctl/cmd/artifact/manifest"
+	"github.com/falcosecurity/falcoctl/cmd/artifact/pull"
+	"github.com/falcosecurity/falcoctl/cmd/artifact/push"
+	"github.com/falcosecurity/falcoctl/cmd/artifact/referrers"
+	"github.com/falcosecurity/falcoctl/cmd/artifact/remove"
+	"github.com/falcosecurity/falcoctl/cmd/artifact/sbom"
 	"github.com/falcosecurity/falcoctl/cmd/artifact/search"
+	"github.com/falcosecurity/falcoctl/cmd/artifact/verify"
 	"github.com/falcosecurity/falcoctl/internal/config"
 	"github.com/falcosecurity/falcoctl/pkg/index/cache"
 	commonoptions "github.com/falcosecurity/falcoctl/pkg/options"
 )
 
+// FlagLenientIndexes is the name of the flag to skip the indexes failing to load instead of failing.
+const FlagLenientIndexes = "lenient-indexes"
+
+// FlagIndexMaxAge is the name of the flag to refresh the cached indexes older than the given age.
+const FlagIndexMaxAge = "index-max-age"
+
 // NewArtifactCmd return the artifact command.
 func NewArtifactCmd(ctx context.Context, opt *commonoptions.Common) *cobra.Command {
+	var lenientIndexes bool
+	var indexMaxAge time.Duration
+
 	cmd := &cobra.Command{
 		Use:                   "artifact",
 		DisableFlagsInUseLine: true,
@@ -45,7 +62,7 @@ func NewArtifactCmd(ctx context.Context, opt *commonoptions.Common) *cobra.Comma
 			var err error
 
 			opt.Initialize()
-			if err = config.Load(opt.ConfigFile); err != nil {
+			if err = opt.LoadConfig(cmd); err != nil {
 				return err
 			}
 
@@ -56,9 +73,37 @@ func NewArtifactCmd(ctx context.Context, opt *commonoptions.Common) *cobra.Comma
 			}
 
 			// Create the index cache.
-			if indexCache, err = cache.NewFromConfig(ctx, config.IndexesFile, config.IndexesDir, indexes); err != nil {
+			var cacheOpts []cache.Option
+			if lenientIndexes {
+				cacheOpts = append(cacheOpts, cache.WithLenient())
+			}
+			if indexMaxAge > 0 {
+				cacheOpts = append(cacheOpts, cache.WithMaxAge(indexMaxAge))
+			}
+			if indexCache, err = cache.NewFromConfig(ctx, config.IndexesFile, config.IndexesDir, indexes, cacheOpts...); err != nil {
 				return err
 			}
+			for _, f := range indexCache.Failures() {
+				opt.Printer.Logger.Warn("Unable to load index, skipping it",
+					opt.Printer.Logger.Args("index", f.Name, "reason", f.Err.Error()))
+			}
+			for _, s := range indexCache.StaleIndexes() {
+				args := opt.Printer.Logger.Args("index", s.Name, "age", s.Age.Round(time.Second).String())
+				switch {
+				case s.Err == nil:
+					opt.Printer.Logger.Debug("Index older than the maximum age, refreshed", args)
+				case s.Refreshed:
+					opt.Printer.Logger.Warn("Index refreshed but it could not be saved",
+						append(args, opt.Printer.Logger.Args("reason", s.Err.Error())...))
+				default:
+					opt.Printer.Logger.Warn("Index is stale and could not be refreshed, using the cached one",
+						append(args, opt.Printer.Logger.Args("reason", s.Err.Error())...))
+				}
+			}
+			for _, c := range indexCache.Collisions() {
+				opt.Printer.Logger.Warn("Artifact defined in more than one index, using the first configured one",
+					opt.Printer.Logger.Args("name", c.Name, "index", c.Index, "ignored", c.Ignored))
+			}
 			// Save the index cache for later use by the sub commands.
 			opt.Initialize(commonoptions.WithIndexCache(indexCache))
 
@@ -66,13 +111,27 @@ func NewArtifactCmd(ctx context.Context, opt *commonoptions.Common) *cobra.Comma
 		},
 	}
 
+	cmd.PersistentFlags().BoolVar(&lenientIndexes, FlagLenientIndexes, false,
+		"skip the indexes that cannot be loaded, e.g. a corrupt cached index, with a warning instead of failing, "+
+			"as long as at least one of them is loaded")
+	cmd.PersistentFlags().DurationVar(&indexMaxAge, FlagIndexMaxAge, 0,
+		"refresh the cached indexes older than the given age, e.g. 24h, warning if they are stale and cannot be refreshed "+
+			"(0 never refreshes them)")
+
 	cmd.AddCommand(search.NewArtifactSearchCmd(ctx, opt))
 	cmd.AddCommand(install.NewArtifactInstallCmd(ctx, opt))
+	cmd.AddCommand(remove.NewArtifactRemoveCmd(ctx, opt))
 	cmd.AddCommand(list.NewArtifactListCmd(ctx, opt))
 	cmd.AddCommand(info.NewArtifactInfoCmd(ctx, opt))
 	cmd.AddCommand(follow.NewArtifactFollowCmd(ctx, opt))
 	cmd.AddCommand(artifactconfig.NewArtifactConfigCmd(ctx, opt))
 	cmd.AddCommand(manifest.NewArtifactManifestCmd(ctx, opt))
+	cmd.AddCommand(export.NewArtifactExportCmd(ctx, opt))
+	cmd.AddCommand(verify.NewArtifactVerifyCmd(ctx, opt))
+	cmd.AddCommand(pull.NewArtifactPullCmd(ctx, opt))
+	cmd.AddCommand(push.NewArtifactPushCmd(ctx, opt))
+	cmd.AddCommand(referrers.NewArtifactReferrersCmd(ctx, opt))
+	cmd.AddCommand(sbom.NewArtifactSbomCmd(ctx, opt))
 
 	return cmd
 }
@@ -29,13 +29,32 @@ var usage = `Usage:
   falcoctl artifact config [ref] [flags]
 
 Flags:
-  -h, --help         help for config
-      --plain-http   allows interacting with remote registry via plain http requests
+      --anonymous                           interact with remote registries anonymously, without looking up credentials in any credential store or helper
+      --ca-cert string                      PEM encoded file with the CA certificates used, in addition to the system ones, to verify the remote registries
+  -h, --help                                help for config
+      --identity-token string               identity token exchanged for bearer tokens with the registries instead of the stored credentials, read from a file if given as @<path> or from the standard input if "-"
+      --insecure-skip-tls-verify            skip the verification of the remote registries certificates, making the connections insecure
+      --no-proxy string                     comma-separated list of hosts, domains and CIDRs reached without proxy, overrides the NO_PROXY environment variable
+      --plain-http                          allows interacting with remote registry via plain http requests
+      --proxy string                        proxy URL used to connect to remote registries, overrides the HTTP_PROXY and HTTPS_PROXY environment variables
+      --registry-config string              Docker config file whose credentials and credential helpers are used when falcoctl has none for a registry, defaults to $DOCKER_CONFIG/config.json or $HOME/.docker/config.json
+      --registry-connect-timeout duration   maximum duration to establish a connection to the registry (0 means no timeout) (default 30s)
+      --registry-mirror strings             mirror registry tried, in the given order, when pulling from the registry of an artifact fails. It can be repeated multiple times
+      --registry-timeout duration           maximum duration of each registry operation, e.g. checking the connection or pulling an artifact (0 means no timeout) (default 1m0s)
+      --registry-token string               bearer token sent to the registries instead of the stored credentials, read from a file if given as @<path> or from the standard input if "-"
+      --registry-token-host strings         registry, as host[:port], the --registry-token or --identity-token is sent to, the other registries use the stored credentials. It can be repeated multiple times
 
 Global Flags:
-      --config string       config file to be used for falcoctl (default "/etc/falcoctl/falcoctl.yaml")
-      --log-format string   Set formatting for logs (color, text, json) (default "color")
-      --log-level string    Set level for logs (info, warn, debug, trace) (default "info")
+      --config string            config file to be used for falcoctl (default "/etc/falcoctl/falcoctl.yaml")
+      --falcoctl-dir string      directory where falcoctl stores the indexes and the client credentials (default "$HOME/.config/falcoctl")
+      --index-max-age duration   refresh the cached indexes older than the given age, e.g. 24h, warning if they are stale and cannot be refreshed (0 never refreshes them)
+      --indexes-file string      file listing the configured indexes (default "indexes.yaml" in the falcoctl directory)
+      --lenient-indexes          skip the indexes that cannot be loaded, e.g. a corrupt cached index, with a warning instead of failing, as long as at least one of them is loaded
+      --log-format string        Set formatting for logs (color, text, json) (default "color")
+      --log-level string         Set level for logs (info, warn, debug, trace) (default "info")
+      --no-color                 Disable colors, spinners and progress bars, as the NO_COLOR environment variable does. They are disabled as well when not attached to a tty
+      --output string            Set format for the results of the commands supporting it (text, json, yaml, annotations), messages are written to stderr when set to json or yaml (default "text")
+  -q, --quiet                    Only print errors, to stderr, and the results of the commands, silencing the other logs, spinners and progress bars
 `
 
 var help = `Get the config layer of an artifact
@@ -44,14 +63,33 @@ Usage:
   falcoctl artifact config [ref] [flags]
 
 Flags:
-  -h, --help              help for config
-      --plain-http        allows interacting with remote registry via plain http requests
-      --platform string   os and architecture of the artifact in OS/ARCH format (default "linux/amd64")
+      --anonymous                           interact with remote registries anonymously, without looking up credentials in any credential store or helper
+      --ca-cert string                      PEM encoded file with the CA certificates used, in addition to the system ones, to verify the remote registries
+  -h, --help                                help for config
+      --identity-token string               identity token exchanged for bearer tokens with the registries instead of the stored credentials, read from a file if given as @<path> or from the standard input if "-"
+      --insecure-skip-tls-verify            skip the verification of the remote registries certificates, making the connections insecure
+      --no-proxy string                     comma-separated list of hosts, domains and CIDRs reached without proxy, overrides the NO_PROXY environment variable
+      --plain-http                          allows interacting with remote registry via plain http requests
+      --platform string                     os and architecture of the artifact in OS/ARCH format (default "linux/amd64")
+      --proxy string                        proxy URL used to connect to remote registries, overrides the HTTP_PROXY and HTTPS_PROXY environment variables
+      --registry-config string              Docker config file whose credentials and credential helpers are used when falcoctl has none for a registry, defaults to $DOCKER_CONFIG/config.json or $HOME/.docker/config.json
+      --registry-connect-timeout duration   maximum duration to establish a connection to the registry (0 means no timeout) (default 30s)
+      --registry-mirror strings             mirror registry tried, in the given order, when pulling from the registry of an artifact fails. It can be repeated multiple times
+      --registry-timeout duration           maximum duration of each registry operation, e.g. checking the connection or pulling an artifact (0 means no timeout) (default 1m0s)
+      --registry-token string               bearer token sent to the registries instead of the stored credentials, read from a file if given as @<path> or from the standard input if "-"
+      --registry-token-host strings         registry, as host[:port], the --registry-token or --identity-token is sent to, the other registries use the stored credentials. It can be repeated multiple times
 
 Global Flags:
-      --config string       config file to be used for falcoctl (default "/etc/falcoctl/falcoctl.yaml")
-      --log-format string   Set formatting for logs (color, text, json) (default "color")
-      --log-level string    Set level for logs (info, warn, debug, trace) (default "info")
+      --config string            config file to be used for falcoctl (default "/etc/falcoctl/falcoctl.yaml")
+      --falcoctl-dir string      directory where falcoctl stores the indexes and the client credentials (default "$HOME/.config/falcoctl")
+      --index-max-age duration   refresh the cached indexes older than the given age, e.g. 24h, warning if they are stale and cannot be refreshed (0 never refreshes them)
+      --indexes-file string      file listing the configured indexes (default "indexes.yaml" in the falcoctl directory)
+      --lenient-indexes          skip the indexes that cannot be loaded, e.g. a corrupt cached index, with a warning instead of failing, as long as at least one of them is loaded
+      --log-format string        Set formatting for logs (color, text, json) (default "color")
+      --log-level string         Set level for logs (info, warn, debug, trace) (default "info")
+      --no-color                 Disable colors, spinners and progress bars, as the NO_COLOR environment variable does. They are disabled as well when not attached to a tty
+      --output string            Set format for the results of the commands supporting it (text, json, yaml, annotations), messages are written to stderr when set to json or yaml (default "text")
+  -q, --quiet                    Only print errors, to stderr, and the results of the commands, silencing the other logs, spinners and progress bars
 `
 
 var _ = Describe("Config", func() {
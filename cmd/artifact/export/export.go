@@ -0,0 +1,214 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2"
+	orasoci "oras.land/oras-go/v2/content/oci"
+
+	"github.com/falcosecurity/falcoctl/internal/utils"
+	"github.com/falcosecurity/falcoctl/pkg/oci"
+	"github.com/falcosecurity/falcoctl/pkg/oci/builder"
+	ociutils "github.com/falcosecurity/falcoctl/pkg/oci/utils"
+	"github.com/falcosecurity/falcoctl/pkg/options"
+	"github.com/falcosecurity/falcoctl/pkg/output"
+)
+
+const (
+	longExport = `This command allows you to export one or more artifacts to a single tar archive of an OCI image layout.
+
+The archive can be copied to an air-gapped host and used to install the artifacts without contacting any registry,
+by passing it to "falcoctl artifact install --from-tar". Manifests and digests are preserved, along with the cosign
+signatures attached to the artifacts, so that the artifacts can be verified or pushed to another registry as they are.
+
+By default all the platforms of an artifact are exported. When a platform is selected, only the manifest for
+that platform is exported and signatures are not.
+
+Artifacts not published yet can be exported from local files, given as REF=PATH: the file, directory or tar.gz
+archive at PATH is built as an artifact of the type given by --type, tagged as REF in the layout. Plugins are built
+for the platform given by --platform, or the one where falcoctl is running.
+
+Example - Export "k8saudit-rules" and "k8saudit" from the configured indexes:
+	falcoctl artifact export k8saudit-rules k8saudit --output bundle.tar
+
+Example - Export only the linux/arm64 version of the "k8saudit" plugin:
+	falcoctl artifact export ghcr.io/falcosecurity/plugins/plugin/k8saudit:latest --platform linux/arm64 --output bundle.tar
+
+Example - Export the local directory of rulesfiles "rules.d" as the "my-rules" rulesfile, along with "k8saudit":
+	falcoctl artifact export ghcr.io/my-org/rules/my-rules:0.1.0=./rules.d k8saudit --type rulesfile --output bundle.tar
+`
+
+	// FlagOutput is the name of the flag to specify the path of the exported archive.
+	FlagOutput = "output"
+
+	// FlagPlatform is the name of the flag to specify the only platform to be exported.
+	FlagPlatform = "platform"
+
+	// FlagType is the name of the flag to specify the type of the artifacts built from local files.
+	FlagType = "type"
+)
+
+type artifactExportOptions struct {
+	*options.Common
+	*options.Registry
+	output       string
+	platform     string
+	os, arch     string
+	artifactType oci.ArtifactType
+}
+
+// Validate validates the options passed by the user.
+func (o *artifactExportOptions) Validate(args []string) error {
+	for _, arg := range args {
+		if _, _, local := strings.Cut(arg, "="); local && o.artifactType == "" {
+			return fmt.Errorf("--%s is needed to export the local files %q", FlagType, arg)
+		}
+	}
+
+	if o.platform == "" {
+		return nil
+	}
+
+	tokens := strings.Split(o.platform, "/")
+	if len(tokens) != 2 || tokens[0] == "" || tokens[1] == "" {
+		return fmt.Errorf("invalid platform format %q: needs to be in OS/ARCH format", o.platform)
+	}
+	o.os, o.arch = tokens[0], tokens[1]
+
+	return nil
+}
+
+// NewArtifactExportCmd returns the artifact export command.
+func NewArtifactExportCmd(ctx context.Context, opt *options.Common) *cobra.Command {
+	o := artifactExportOptions{
+		Common:   opt,
+		Registry: &options.Registry{},
+	}
+
+	cmd := &cobra.Command{
+		Use:                   "export [ref1 [ref2 ...]] [flags]",
+		DisableFlagsInUseLine: true,
+		Short:                 "Export one or more artifacts to an OCI image layout archive",
+		Long:                  longExport,
+		Args:                  cobra.MinimumNArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return o.Validate(args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.RunArtifactExport(ctx, args)
+		},
+	}
+
+	o.Registry.AddFlags(cmd)
+	cmd.Flags().StringVarP(&o.output, FlagOutput, "o", "", "path of the tar archive where the artifacts are exported")
+	cmd.Flags().StringVar(&o.platform, FlagPlatform, "",
+		"os and architecture in OS/ARCH format of the only platform to be exported. If not set, all platforms are exported")
+	cmd.Flags().Var(&o.artifactType, FlagType,
+		`type of the artifacts built from local files given as REF=PATH. Allowed values: "rulesfile", "plugin", "asset"`)
+	if err := cmd.MarkFlagRequired(FlagOutput); err != nil {
+		output.ExitOnErr(o.Printer, fmt.Errorf("unable to mark flag %q as required", FlagOutput))
+	}
+
+	return cmd
+}
+
+// RunArtifactExport executes the business logic for the artifact export command.
+func (o *artifactExportOptions) RunArtifactExport(ctx context.Context, args []string) error {
+	logger := o.Printer.Logger
+
+	puller, err := ociutils.Puller(o.Registry, o.Printer)
+	if err != nil {
+		return err
+	}
+
+	// The layout is first written to a temporary directory and then archived.
+	layoutDir, err := os.MkdirTemp("", "falcoctl-export-")
+	if err != nil {
+		return fmt.Errorf("cannot create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(layoutDir)
+
+	store, err := orasoci.New(layoutDir)
+	if err != nil {
+		return fmt.Errorf("unable to create OCI layout: %w", err)
+	}
+
+	for _, arg := range args {
+		if ref, path, local := strings.Cut(arg, "="); local {
+			if err := o.exportFiles(ctx, store, ref, path); err != nil {
+				return err
+			}
+			continue
+		}
+
+		ref, err := o.IndexCache.ResolveReference(arg)
+		if err != nil {
+			return err
+		}
+
+		logger.Info("Exporting artifact", logger.Args("ref", ref))
+		opCtx, cancel := o.OperationContext(ctx)
+		desc, err := puller.Export(opCtx, ref, store, o.os, o.arch)
+		cancel()
+		if err != nil {
+			return err
+		}
+		logger.Info("Artifact exported", logger.Args("ref", ref, "digest", desc.Digest.String()))
+	}
+
+	if err := utils.CreateTarArchive(layoutDir, o.output); err != nil {
+		return fmt.Errorf("unable to write archive %q: %w", o.output, err)
+	}
+
+	logger.Info("Artifacts successfully exported", logger.Args("output", o.output))
+	return nil
+}
+
+// exportFiles builds in store the artifact of the local file, directory or tar.gz archive at path, tagged as ref.
+func (o *artifactExportOptions) exportFiles(ctx context.Context, store oras.Target, ref, path string) error {
+	logger := o.Printer.Logger
+
+	name, err := utils.NameFromRef(ref)
+	if err != nil {
+		return err
+	}
+	layer := builder.Layer{Path: path}
+	if o.artifactType == oci.Plugin {
+		layer.Platform = o.platform
+		if layer.Platform == "" {
+			layer.Platform = fmt.Sprintf("%s/%s", runtime.GOOS, runtime.GOARCH)
+		}
+	}
+
+	logger.Info("Exporting local files", logger.Args("ref", ref, "path", path, "type", o.artifactType))
+	desc, err := builder.Build(ctx, store, o.artifactType, []builder.Layer{layer},
+		builder.WithConfig(oci.ArtifactConfig{Name: name}))
+	if err != nil {
+		return err
+	}
+	if err := store.Tag(ctx, *desc, ref); err != nil {
+		return fmt.Errorf("unable to tag artifact as %q: %w", ref, err)
+	}
+	logger.Info("Artifact exported", logger.Args("ref", ref, "digest", desc.Digest.String()))
+	return nil
+}
@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/falcosecurity/falcoctl/cmd"
+)
+
+var _ = Describe("export", func() {
+	const (
+		rulesRef  = "ghcr.io/my-org/rules/my-rules:0.1.0"
+		pluginRef = "ghcr.io/my-org/plugins/plugin/cloudtrail:0.1.0"
+	)
+
+	var (
+		baseDir, configFile, bundle string
+		args                        []string
+		err                         error
+	)
+
+	BeforeEach(func() {
+		baseDir = GinkgoT().TempDir()
+		configFile = filepath.Join(baseDir, "config.yaml")
+		Expect(os.WriteFile(configFile, []byte("indexes: []\n"), 0o600)).To(Succeed())
+		bundle = filepath.Join(baseDir, "bundle.tar")
+	})
+
+	JustBeforeEach(func() {
+		rootCmd = cmd.New(ctx, opt)
+		err = executeRoot(args)
+	})
+
+	AfterEach(func() {
+		Expect(output.Clear()).To(Succeed())
+	})
+
+	When("exporting a local rulesfile", func() {
+		BeforeEach(func() {
+			args = []string{"artifact", "export", rulesRef + "=" + rulesfileyaml, "--type", "rulesfile",
+				"--output", bundle, "--config", configFile}
+		})
+
+		It("should build it in the archive, so that it can be installed offline", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(bundle).To(BeARegularFile())
+
+			rulesDir := filepath.Join(baseDir, "rules")
+			Expect(os.MkdirAll(rulesDir, 0o755)).To(Succeed())
+			rootCmd = cmd.New(ctx, opt)
+			Expect(executeRoot([]string{"artifact", "install", rulesRef, "--from-tar", bundle, "--rulesfiles-dir", rulesDir,
+				"--lock-file", filepath.Join(baseDir, "falcoctl.lock.yaml"), "--resolve-deps=false", "--no-verify",
+				"--ignore-falco-version", "--config", configFile})).To(Succeed())
+			Expect(filepath.Join(rulesDir, filepath.Base(rulesfileyaml))).To(BeARegularFile())
+		})
+	})
+
+	When("exporting a local plugin for a platform", func() {
+		BeforeEach(func() {
+			args = []string{"artifact", "export", pluginRef + "=" + plugintgz, "--type", "plugin", "--platform", "linux/arm64",
+				"--output", bundle, "--config", configFile}
+		})
+
+		It("should build it for that platform only", func() {
+			Expect(err).ToNot(HaveOccurred())
+
+			pluginsDir := filepath.Join(baseDir, "plugins")
+			Expect(os.MkdirAll(pluginsDir, 0o755)).To(Succeed())
+			installArgs := []string{"artifact", "install", pluginRef, "--from-tar", bundle, "--plugins-dir", pluginsDir,
+				"--lock-file", filepath.Join(baseDir, "falcoctl.lock.yaml"), "--resolve-deps=false", "--no-verify",
+				"--ignore-falco-version", "--config", configFile}
+
+			rootCmd = cmd.New(ctx, opt)
+			Expect(executeRoot(append(installArgs, "--platform", "linux/amd64"))).To(MatchError(ContainSubstring("linux/amd64")))
+			rootCmd = cmd.New(ctx, opt)
+			Expect(executeRoot(append(installArgs, "--platform", "linux/arm64"))).To(Succeed())
+			Expect(filepath.Join(pluginsDir, "libcloudtrail.so")).To(BeARegularFile())
+		})
+	})
+
+	When("exporting local files without type", func() {
+		BeforeEach(func() {
+			args = []string{"artifact", "export", rulesRef + "=" + rulesfileyaml, "--output", bundle, "--config", configFile}
+		})
+
+		It("should fail asking for the type", func() {
+			Expect(err).To(MatchError(ContainSubstring("--type is needed to export the local files")))
+			Expect(bundle).ToNot(BeAnExistingFile())
+		})
+	})
+})
@@ -29,17 +29,21 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
-	"github.com/falcosecurity/falcoctl/cmd/artifact/install"
 	"github.com/falcosecurity/falcoctl/internal/config"
 	"github.com/falcosecurity/falcoctl/internal/follower"
+	"github.com/falcosecurity/falcoctl/internal/utils"
+	"github.com/falcosecurity/falcoctl/pkg/artifact/install"
 	"github.com/falcosecurity/falcoctl/pkg/index/index"
 	"github.com/falcosecurity/falcoctl/pkg/oci"
+	ociutils "github.com/falcosecurity/falcoctl/pkg/oci/utils"
 	"github.com/falcosecurity/falcoctl/pkg/options"
 	"github.com/falcosecurity/falcoctl/pkg/output"
 )
 
 const (
 	timeout = time.Second * 5
+	// defaultRetryBackoff is the default wait time before checking again after a failed check.
+	defaultRetryBackoff = 30 * time.Second
 
 	longFollow = `This command allows you to keep up-to-date one or more given artifacts.
 It checks for updates on a periodic basis and then downloads and installs the latest version, 
@@ -75,6 +79,9 @@ Example - Install and follow all updates from "k8saudit-rules" 0.5.x release ser
 
 Example - Install and follow "cloudtrail" plugins using a fully qualified reference:
 	falcoctl artifact follow ghcr.io/falcosecurity/plugins/ruleset/k8saudit:latest
+
+Example - Check for a new version of "cloudtrail" every hour, and make the running Falco reload it once installed:
+	falcoctl artifact follow cloudtrail --every 1h --reload
 `
 )
 
@@ -91,6 +98,8 @@ type artifactFollowOptions struct {
 	closeChan     chan bool
 	allowedTypes  oci.ArtifactTypeSlice
 	noVerify      bool
+	reload        bool
+	retryBackoff  time.Duration
 }
 
 // NewArtifactFollowCmd returns the artifact follow command.
@@ -146,40 +155,10 @@ func NewArtifactFollowCmd(ctx context.Context, opt *options.Common) *cobra.Comma
 				}
 			}
 
-			// Override "rulesfiles-dir" flag with viper config if not set by user.
-			f = cmd.Flags().Lookup(options.FlagRulesFilesDir)
-			if f == nil {
-				// should never happen
-				return fmt.Errorf("unable to retrieve flag %q", options.FlagRulesFilesDir)
-			} else if !f.Changed && viper.IsSet(config.ArtifactFollowRulesfilesDirKey) {
-				val := viper.Get(config.ArtifactFollowRulesfilesDirKey)
-				if err := cmd.Flags().Set(f.Name, fmt.Sprintf("%v", val)); err != nil {
-					return fmt.Errorf("unable to overwrite %q flag: %w", options.FlagRulesFilesDir, err)
-				}
-			}
-
-			// Override "plugins-dir" flag with viper config if not set by user.
-			f = cmd.Flags().Lookup(options.FlagPluginsFilesDir)
-			if f == nil {
-				// should never happen
-				return fmt.Errorf("unable to retrieve flag %q", options.FlagPluginsFilesDir)
-			} else if !f.Changed && viper.IsSet(config.ArtifactFollowPluginsDirKey) {
-				val := viper.Get(config.ArtifactFollowPluginsDirKey)
-				if err := cmd.Flags().Set(f.Name, fmt.Sprintf("%v", val)); err != nil {
-					return fmt.Errorf("unable to overwrite %q flag: %w", options.FlagPluginsFilesDir, err)
-				}
-			}
-
-			// Override "assets-dir" flag with viper config if not set by user.
-			f = cmd.Flags().Lookup(options.FlagAssetsFilesDir)
-			if f == nil {
-				// should never happen
-				return fmt.Errorf("unable to retrieve flag %q", options.FlagAssetsFilesDir)
-			} else if !f.Changed && viper.IsSet(config.ArtifactFollowAssetsDirKey) {
-				val := viper.Get(config.ArtifactFollowAssetsDirKey)
-				if err := cmd.Flags().Set(f.Name, fmt.Sprintf("%v", val)); err != nil {
-					return fmt.Errorf("unable to overwrite %q flag: %w", options.FlagAssetsFilesDir, err)
-				}
+			// Override the directories flags with viper config if not set by user.
+			if err := o.Directory.OverrideFromConfig(cmd, config.ArtifactFollowRulesfilesDirKey, config.ArtifactFollowPluginsDirKey,
+				config.ArtifactFollowAssetsDirKey); err != nil {
+				return err
 			}
 
 			// Override "tmp-dir" flag with viper config if not set by user.
@@ -221,6 +200,13 @@ func NewArtifactFollowCmd(ctx context.Context, opt *options.Common) *cobra.Comma
 				}
 			}
 
+			if err := o.Directory.Expand(); err != nil {
+				return err
+			}
+			if err := utils.ExpandPaths(&o.tmpDir); err != nil {
+				return err
+			}
+
 			// Get Falco versions via HTTP endpoint
 			if err := o.retrieveFalcoVersions(ctx); err != nil {
 				return fmt.Errorf("unable to retrieve Falco versions, please check if it is running "+
@@ -252,6 +238,11 @@ Examples:
 	--%s=rulesfile --%s=plugin`, install.FlagAllowedTypes, install.FlagAllowedTypes, install.FlagAllowedTypes))
 	cmd.Flags().BoolVar(&o.noVerify, install.FlagNoVerify, false,
 		"whether this command should skip signature verification")
+	cmd.Flags().BoolVar(&o.reload, install.FlagReload, false,
+		"send SIGHUP to the running falco processes, so that they reload, each time a new version of an artifact has been installed")
+	cmd.Flags().DurationVar(&o.retryBackoff, install.FlagRetryBackoff, defaultRetryBackoff,
+		"wait time before checking again for a new version after a failed check, doubled at each consecutive failure "+
+			"and never longer than the check interval (0 means waiting for the next check)")
 	cmd.MarkFlagsMutuallyExclusive("cron", "every")
 
 	return cmd
@@ -284,6 +275,16 @@ func (o *artifactFollowOptions) RunArtifactFollow(ctx context.Context, args []st
 		sched = scheduledDuration{o.every}
 	}
 
+	clientOpts, err := o.ClientOptions(o.Printer)
+	if err != nil {
+		return err
+	}
+
+	mirrors, err := ociutils.Mirrors(o.Registry)
+	if err != nil {
+		return err
+	}
+
 	var wg sync.WaitGroup
 	// For each artifact create a follower.
 	var followers = make(map[string]*follower.Follower, 0)
@@ -311,11 +312,20 @@ func (o *artifactFollowOptions) RunArtifactFollow(ctx context.Context, args []st
 			AssetsDir:         o.AssetsDir,
 			ArtifactReference: ref,
 			PlainHTTP:         o.PlainHTTP,
+			ClientOptions:     clientOpts,
+			Mirrors:           mirrors,
+			RegistryTimeout:   o.Timeout,
 			CloseChan:         o.closeChan,
 			TmpDir:            o.tmpDir,
 			FalcoVersions:     o.versions,
 			AllowedTypes:      o.allowedTypes,
 			Signature:         sig,
+			RetryBackoff:      o.retryBackoff,
+		}
+		if o.reload {
+			cfg.PostInstall = func(context.Context) error {
+				return install.ReloadFalco(logger)
+			}
 		}
 		fol, err := follower.New(ref, o.Printer, cfg)
 		if err != nil {
@@ -17,8 +17,11 @@ package info
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"runtime"
+	"sort"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -30,9 +33,51 @@ import (
 	"github.com/falcosecurity/falcoctl/pkg/output"
 )
 
+const (
+	longInfo = `Retrieve all available versions of a given artifact.
+
+When the --metadata flag is set, the manifest and config layer of the artifacts are fetched instead, and for each
+artifact its type, available platforms, layers, annotations and dependencies are printed as JSON. The layers are
+not downloaded. If no tag or digest is given, "latest" is used.
+
+When the global --output flag is set to "annotations", only the annotations of the manifest and of the config layer
+descriptor of the artifacts are printed, sorted by key, one per line in key=value form. As with --metadata, the
+layers are not downloaded.
+
+Example - List the versions of the "k8saudit" plugin:
+	falcoctl artifact info k8saudit
+
+Example - Show the metadata of the linux/arm64 version of the "k8saudit" plugin:
+	falcoctl artifact info ghcr.io/falcosecurity/plugins/plugin/k8saudit:latest --metadata --platform linux/arm64
+
+Example - Print the annotations of the "k8saudit-rules" rulesfile:
+	falcoctl artifact info k8saudit-rules --output annotations
+`
+
+	// FlagMetadata is the name of the flag to print the metadata of the artifacts.
+	FlagMetadata = "metadata"
+
+	// FlagPlatform is the name of the flag to specify the platform whose metadata are printed.
+	FlagPlatform = "platform"
+)
+
 type artifactInfoOptions struct {
 	*options.Common
 	*options.Registry
+	metadata bool
+	platform string
+	os, arch string
+}
+
+// Validate validates the options passed by the user.
+func (o *artifactInfoOptions) Validate() error {
+	tokens := strings.Split(o.platform, "/")
+	if len(tokens) != 2 || tokens[0] == "" || tokens[1] == "" {
+		return fmt.Errorf("invalid platform format %q: needs to be in OS/ARCH format", o.platform)
+	}
+	o.os, o.arch = tokens[0], tokens[1]
+
+	return nil
 }
 
 // NewArtifactInfoCmd returns the artifact info command.
@@ -46,23 +91,47 @@ func NewArtifactInfoCmd(ctx context.Context, opt *options.Common) *cobra.Command
 		Use:                   "info [ref1 [ref2 ...]] [flags]",
 		DisableFlagsInUseLine: true,
 		Short:                 "Retrieve all available versions of a given artifact",
-		Long:                  "Retrieve all available versions of a given artifact",
+		Long:                  longInfo,
 		Args:                  cobra.MinimumNArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return o.Validate()
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if o.AnnotationsOutput() {
+				return o.RunArtifactAnnotations(ctx, args)
+			}
+			if o.metadata {
+				return o.RunArtifactMetadata(ctx, args)
+			}
 			return o.RunArtifactInfo(ctx, args)
 		},
 	}
 
 	o.Registry.AddFlags(cmd)
+	cmd.Flags().BoolVar(&o.metadata, FlagMetadata, false,
+		"print the metadata of the artifacts, taken from their manifest and config layer, instead of their versions")
+	cmd.Flags().StringVar(&o.platform, FlagPlatform, fmt.Sprintf("%s/%s", runtime.GOOS, runtime.GOARCH),
+		"os and architecture in OS/ARCH format of the artifacts whose metadata are printed")
 
 	return cmd
 }
 
+// artifactVersions are the versions of an artifact, as printed in the structured output.
+type artifactVersions struct {
+	Ref  string   `json:"ref"`
+	Tags []string `json:"tags"`
+}
+
 func (o *artifactInfoOptions) RunArtifactInfo(ctx context.Context, args []string) error {
 	var data [][]string
+	results := []artifactVersions{}
 	logger := o.Printer.Logger
 
-	client, err := ociutils.Client(true)
+	clientOpts, err := o.ClientOptions(o.Printer)
+	if err != nil {
+		return err
+	}
+	client, err := ociutils.Client(true, clientOpts...)
 	if err != nil {
 		return err
 	}
@@ -90,7 +159,9 @@ func (o *artifactInfoOptions) RunArtifactInfo(ctx context.Context, args []string
 			return err
 		}
 
-		tags, err := repo.Tags(ctx)
+		opCtx, cancel := o.OperationContext(ctx)
+		tags, err := repo.Tags(opCtx)
+		cancel()
 		if err != nil && !errors.Is(err, context.Canceled) {
 			logger.Warn("Cannot retrieve tags from", logger.Args("ref", ref, "reason", err.Error()))
 			continue
@@ -101,12 +172,96 @@ func (o *artifactInfoOptions) RunArtifactInfo(ctx context.Context, args []string
 
 		joinedTags := strings.Join(tags, ", ")
 		data = append(data, []string{ref, joinedTags})
+		results = append(results, artifactVersions{Ref: ref, Tags: tags})
 	}
 
-	// Print the table header + data only if there is data.
-	if len(data) > 0 {
-		return o.Printer.PrintTable(output.ArtifactInfo, data)
+	// Print the table header + data only if there is data, the structured output is always printed.
+	if len(data) > 0 || o.Printer.StructuredOutput() {
+		return o.Printer.PrintResults(results, output.ArtifactInfo, data)
 	}
 
 	return nil
 }
+
+// RunArtifactMetadata prints the metadata of the given artifacts, without pulling their layers.
+func (o *artifactInfoOptions) RunArtifactMetadata(ctx context.Context, args []string) error {
+	puller, err := ociutils.Puller(o.Registry, o.Printer)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range args {
+		ref, err := o.IndexCache.ResolveReference(name)
+		if err != nil {
+			return err
+		}
+
+		opCtx, cancel := o.OperationContext(ctx)
+		metadata, err := puller.Metadata(opCtx, ref, o.os, o.arch)
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		if o.Printer.StructuredOutput() {
+			if err := o.Printer.PrintStructured(metadata); err != nil {
+				return err
+			}
+			continue
+		}
+
+		data, err := json.MarshalIndent(metadata, "", "  ")
+		if err != nil {
+			return fmt.Errorf("unable to marshal metadata of %q: %w", ref, err)
+		}
+		o.Printer.DefaultText.Println(string(data))
+	}
+
+	return nil
+}
+
+// RunArtifactAnnotations prints the annotations of the manifest and config layer descriptor of the given artifacts
+// as key=value lines, without pulling their layers.
+func (o *artifactInfoOptions) RunArtifactAnnotations(ctx context.Context, args []string) error {
+	puller, err := ociutils.Puller(o.Registry, o.Printer)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range args {
+		ref, err := o.IndexCache.ResolveReference(name)
+		if err != nil {
+			return err
+		}
+
+		opCtx, cancel := o.OperationContext(ctx)
+		metadata, err := puller.Metadata(opCtx, ref, o.os, o.arch)
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		for _, annotations := range []map[string]string{metadata.Annotations, metadata.ConfigAnnotations} {
+			for _, line := range annotationLines(annotations) {
+				o.Printer.DefaultText.Println(line)
+			}
+		}
+	}
+
+	return nil
+}
+
+// annotationLines returns the given annotations as key=value lines, sorted by key.
+func annotationLines(annotations map[string]string) []string {
+	keys := make([]string, 0, len(annotations))
+	for k := range annotations {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	lines := make([]string, 0, len(keys))
+	for _, k := range keys {
+		lines = append(lines, k+"="+annotations[k])
+	}
+	return lines
+}
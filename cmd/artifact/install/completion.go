@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package install
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/falcosecurity/falcoctl/internal/config"
+	artifactinstall "github.com/falcosecurity/falcoctl/pkg/artifact/install"
+	"github.com/falcosecurity/falcoctl/pkg/index/cache"
+)
+
+// completeArtifactNames returns the completion function of the arguments of the install command, which suggests
+// the names of the artifacts listed in the configured indexes starting with the argument being completed, leaving
+// out the ones already given. Local paths are completed as files instead.
+func (o *artifactInstallOptions) completeArtifactNames(ctx context.Context) func(cmd *cobra.Command, args []string,
+	toComplete string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if artifactinstall.IsSource(toComplete) {
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+
+		// The persistent pre-runs are not executed when completing, hence the indexes are loaded here.
+		if err := o.LoadConfig(cmd); err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		indexes, err := config.Indexes()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		indexCache, err := cache.NewFromConfig(ctx, config.IndexesFile, config.IndexesDir, indexes)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+
+		var names []string
+		for _, name := range indexCache.Names() {
+			if strings.HasPrefix(name, toComplete) && !slices.Contains(args, name) {
+				names = append(names, name)
+			}
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	}
+}
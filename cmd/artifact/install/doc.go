@@ -13,6 +13,6 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// Package install defines options, and logic used to pull an artifact from a remote repository
-// and install it in the local system.
+// Package install defines the artifact install command, installing the artifacts through the
+// github.com/falcosecurity/falcoctl/pkg/artifact/install package.
 package install
@@ -17,21 +17,16 @@ package install
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"os"
-	"path/filepath"
-	"runtime"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
 	"github.com/falcosecurity/falcoctl/internal/config"
-	"github.com/falcosecurity/falcoctl/internal/signature"
-	"github.com/falcosecurity/falcoctl/internal/utils"
-	"github.com/falcosecurity/falcoctl/pkg/index/index"
-	"github.com/falcosecurity/falcoctl/pkg/oci"
-	ociutils "github.com/falcosecurity/falcoctl/pkg/oci/utils"
+	artifactinstall "github.com/falcosecurity/falcoctl/pkg/artifact/install"
 	"github.com/falcosecurity/falcoctl/pkg/options"
+	"github.com/falcosecurity/falcoctl/pkg/output"
 )
 
 const (
@@ -53,6 +48,8 @@ an underscore "_".
 
 A reference is either a simple name or a fully qualified reference ("<registry>/<repository>"), 
 optionally followed by ":<tag>" (":latest" is assumed by default when no tag is given).
+It can also be followed by "@<constraint>", where the semver constraint (e.g. "^1.2.0", "~1.2.0"
+or ">=1.2.0 <1.5.0") selects the highest matching version among the tags of the repository.
 
 When providing just the name of the artifact, the command will search for the artifacts in 
 the configured index files, and if found, it will use the registry and repository specified 
@@ -64,27 +61,68 @@ Example - Install "latest" tag of "k8saudit-rules" artifact by relying on index
 Example - Install all updates from "k8saudit-rules" 0.5.x release series:
 	falcoctl artifact install k8saudit-rules:0.5
 
+Example - Install the highest version of "k8saudit" compatible with 0.6.0, by listing the tags of its repository:
+	falcoctl artifact install k8saudit@^0.6.0
+
+Example - Install every artifact of the configured indexes whose repository is under "falcosecurity/plugins/ruleset":
+	falcoctl artifact install 'falcosecurity/plugins/ruleset/*'
+
 Example - Install "cloudtrail" plugins using a fully qualified reference:
 	falcoctl artifact install ghcr.io/falcosecurity/plugins/ruleset/k8saudit:latest
+
+Example - Install "k8saudit-rules" only if it is signed with the given public key:
+	falcoctl artifact install k8saudit-rules --verify-signature --key cosign.pub
+
+Example - Install "cloudtrail" plugin for a different platform than the current one:
+	falcoctl artifact install cloudtrail --platform linux/arm64
+
+Example - Install "cloudtrail" plugin for armv7, when its image index has several variants of linux/arm:
+	falcoctl artifact install cloudtrail --platform linux/arm --platform-variant v7
+
+Example - Install "cloudtrail" plugin for all its platforms, e.g. in "/usr/share/falco/plugins/linux-amd64":
+	falcoctl artifact install cloudtrail --all-platforms --resolve-deps=false
+
+Example - Install exactly the digests recorded in a lockfile by a previous install:
+	falcoctl artifact install --from-lock falcoctl.lock.yaml
+
+Example - Install "k8saudit-rules" without network access, from a previously exported OCI layout:
+	falcoctl artifact install ghcr.io/falcosecurity/rules/k8saudit-rules:latest --from-dir ./oci-layout --no-verify
+
+Example - Install "k8saudit-rules" and make the running Falco reload it:
+	falcoctl artifact install k8saudit-rules --reload
+
+Example - Install a rules file from a local archive and a plugin from an HTTPS URL, without going through a registry:
+	falcoctl artifact install ./my-rules.tar.gz
+	falcoctl artifact install https://example.com/my-plugin-0.1.0-linux-x86_64.tar.gz --source-type plugin
+
+Example - Install a rules file archive produced upstream in a pipeline and piped to the standard input:
+	cat my-rules.tar.gz | falcoctl artifact install --from-stdin --source-type rulesfile
+
+Example - Install the artifacts listed, one per line, in a file:
+	falcoctl artifact install --from-file artifacts.txt
 `
 )
 
+// artifactInstallOptions binds the flags of the install command to the options of the installer, which is built once
+// the flags are parsed and the config overrides applied.
 type artifactInstallOptions struct {
-	*options.Common
-	*options.Registry
-	*options.Directory
-	allowedTypes oci.ArtifactTypeSlice
-	resolveDeps  bool
-	noVerify     bool
+	*artifactinstall.Options
+	// printPlan prints the structured plan of the artifacts that would be installed, see --print-plan.
+	printPlan bool
+	installer *artifactinstall.Installer
+}
+
+// registryConfigKeys maps the flags tuning the pulls from the registries to the config keys providing their default
+// values. They are not shared with the other commands, e.g. follow has a --retry-backoff of its own.
+var registryConfigKeys = map[string]string{
+	artifactinstall.FlagMaxRetries:   config.RegistryMaxRetriesKey,
+	artifactinstall.FlagRetryBackoff: config.RegistryRetryBackoffKey,
+	artifactinstall.FlagConcurrency:  config.RegistryConcurrencyKey,
 }
 
 // NewArtifactInstallCmd returns the artifact install command.
 func NewArtifactInstallCmd(ctx context.Context, opt *options.Common) *cobra.Command {
-	o := artifactInstallOptions{
-		Common:    opt,
-		Registry:  &options.Registry{},
-		Directory: &options.Directory{},
-	}
+	o := artifactInstallOptions{Options: artifactinstall.NewOptions(opt)}
 
 	cmd := &cobra.Command{
 		Use:                   "install [ref1 [ref2 ...]] [flags]",
@@ -92,105 +130,208 @@ func NewArtifactInstallCmd(ctx context.Context, opt *options.Common) *cobra.Comm
 		Short:                 "Install a list of artifacts",
 		Long:                  longInstall,
 		PreRunE: func(cmd *cobra.Command, args []string) error {
-			// Override "rulesfiles-dir" flag with viper config if not set by user.
-			f := cmd.Flags().Lookup(options.FlagRulesFilesDir)
-			if f == nil {
-				// should never happen
-				return fmt.Errorf("unable to retrieve flag %q", options.FlagRulesFilesDir)
-			} else if !f.Changed && viper.IsSet(config.ArtifactInstallRulesfilesDirKey) {
-				val := viper.Get(config.ArtifactInstallRulesfilesDirKey)
-				if err := cmd.Flags().Set(f.Name, fmt.Sprintf("%v", val)); err != nil {
-					return fmt.Errorf("unable to overwrite %q flag: %w", options.FlagRulesFilesDir, err)
-				}
+			// Override the registry tunables with viper config if not set by user, before validating them.
+			if err := options.OverrideFlags(cmd.Flags(), registryConfigKeys); err != nil {
+				return err
 			}
 
-			// Override "plugins-dir" flag with viper config if not set by user.
-			f = cmd.Flags().Lookup(options.FlagPluginsFilesDir)
-			if f == nil {
-				// should never happen
-				return fmt.Errorf("unable to retrieve flag %q", options.FlagPluginsFilesDir)
-			} else if !f.Changed && viper.IsSet(config.ArtifactInstallPluginsDirKey) {
-				val := viper.Get(config.ArtifactInstallPluginsDirKey)
-				if err := cmd.Flags().Set(f.Name, fmt.Sprintf("%v", val)); err != nil {
-					return fmt.Errorf("unable to overwrite %q flag: %w", options.FlagPluginsFilesDir, err)
-				}
+			// There is no manifest to read the type of a piped archive from.
+			if o.FromStdin && !cmd.Flags().Changed(artifactinstall.FlagSourceType) {
+				return fmt.Errorf("--%s needs the type of the piped artifact, set through --%s", artifactinstall.FlagFromStdin, artifactinstall.FlagSourceType)
 			}
+			o.Stdin = cmd.InOrStdin()
 
-			// Override "assets-dir" flag with viper config if not set by user.
-			f = cmd.Flags().Lookup(options.FlagAssetsFilesDir)
-			if f == nil {
-				// should never happen
-				return fmt.Errorf("unable to retrieve flag %q", options.FlagAssetsFilesDir)
-			} else if !f.Changed && viper.IsSet(config.ArtifactFollowAssetsDirKey) {
-				val := viper.Get(config.ArtifactFollowAssetsDirKey)
-				if err := cmd.Flags().Set(f.Name, fmt.Sprintf("%v", val)); err != nil {
-					return fmt.Errorf("unable to overwrite %q flag: %w", options.FlagAssetsFilesDir, err)
-				}
+			// Override the directories flags with viper config if not set by user.
+			if err := o.Directory.OverrideFromConfig(cmd, config.ArtifactInstallRulesfilesDirKey, config.ArtifactInstallPluginsDirKey,
+				config.ArtifactFollowAssetsDirKey); err != nil {
+				return err
 			}
 
 			// Override "allowed-types" flag with viper config if not set by user.
-			f = cmd.Flags().Lookup(FlagAllowedTypes)
+			f := cmd.Flags().Lookup(artifactinstall.FlagAllowedTypes)
 			if f == nil {
 				// should never happen
-				return fmt.Errorf("unable to retrieve flag %q", FlagAllowedTypes)
+				return fmt.Errorf("unable to retrieve flag %q", artifactinstall.FlagAllowedTypes)
 			} else if !f.Changed && viper.IsSet(config.ArtifactAllowedTypesKey) {
 				val, err := config.ArtifactAllowedTypes()
 				if err != nil {
 					return err
 				}
 				if err := cmd.Flags().Set(f.Name, val.String()); err != nil {
-					return fmt.Errorf("unable to overwrite %s flag: %w", FlagAllowedTypes, err)
+					return fmt.Errorf("unable to overwrite %s flag: %w", artifactinstall.FlagAllowedTypes, err)
 				}
 			}
 
-			f = cmd.Flags().Lookup(FlagResolveDeps)
+			f = cmd.Flags().Lookup(artifactinstall.FlagResolveDeps)
 			if f == nil {
 				// should never happen
-				return fmt.Errorf("unable to retrieve flag %q", FlagResolveDeps)
+				return fmt.Errorf("unable to retrieve flag %q", artifactinstall.FlagResolveDeps)
 			} else if !f.Changed && viper.IsSet(config.ArtifactInstallResolveDepsKey) {
 				val := viper.Get(config.ArtifactInstallResolveDepsKey)
 				if err := cmd.Flags().Set(f.Name, fmt.Sprintf("%v", val)); err != nil {
-					return fmt.Errorf("unable to overwrite %q flag: %w", FlagResolveDeps, err)
+					return fmt.Errorf("unable to overwrite %q flag: %w", artifactinstall.FlagResolveDeps, err)
 				}
 			}
 
-			f = cmd.Flags().Lookup(FlagNoVerify)
+			f = cmd.Flags().Lookup(artifactinstall.FlagNoVerify)
 			if f == nil {
 				// should never happen
-				return fmt.Errorf("unable to retrieve flag %q", FlagNoVerify)
+				return fmt.Errorf("unable to retrieve flag %q", artifactinstall.FlagNoVerify)
 			} else if !f.Changed && viper.IsSet(config.ArtifactNoVerifyKey) {
 				val := viper.Get(config.ArtifactNoVerifyKey)
 				if err := cmd.Flags().Set(f.Name, fmt.Sprintf("%v", val)); err != nil {
-					return fmt.Errorf("unable to overwrite %q flag: %w", FlagNoVerify, err)
+					return fmt.Errorf("unable to overwrite %q flag: %w", artifactinstall.FlagNoVerify, err)
 				}
 			}
 
+			// The plan is the only thing printed to stdout, the messages being moved to stderr as for the structured output.
+			if o.printPlan {
+				o.DryRun = true
+				o.Common.Initialize(options.WithStructuredOutput())
+			}
+
+			// The options are validated, and the paths expanded, once overridden, since the config file is not read
+			// through a shell.
+			installer, err := artifactinstall.New(o.Options)
+			if err != nil {
+				return err
+			}
+			o.installer = installer
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return o.RunArtifactInstall(ctx, args)
 		},
+		ValidArgsFunction: o.completeArtifactNames(ctx),
 	}
 
 	o.Registry.AddFlags(cmd)
 	o.Directory.AddFlags(cmd)
-	cmd.Flags().Var(&o.allowedTypes, FlagAllowedTypes,
+	cmd.Flags().Var(&o.AllowedTypes, artifactinstall.FlagAllowedTypes,
 		fmt.Sprintf(`list of artifact types that can be installed. If not specified or configured, all types are allowed.
 It accepts comma separated values or it can be repeated multiple times.
 Examples: 
 	--%s="rulesfile,plugin"
-	--%s=rulesfile --%s=plugin`, FlagAllowedTypes, FlagAllowedTypes, FlagAllowedTypes))
-	cmd.Flags().BoolVar(&o.resolveDeps, FlagResolveDeps, true,
+	--%s=rulesfile --%s=plugin`, artifactinstall.FlagAllowedTypes, artifactinstall.FlagAllowedTypes, artifactinstall.FlagAllowedTypes))
+	cmd.Flags().BoolVar(&o.StrictTypes, artifactinstall.FlagStrictAllowedTypes, false,
+		"fail when an artifact type is not allowed, instead of skipping the artifact with a warning")
+	cmd.Flags().BoolVar(&o.ResolveDeps, artifactinstall.FlagResolveDeps, o.ResolveDeps,
 		"whether this command should resolve dependencies or not")
-	cmd.Flags().BoolVar(&o.noVerify, FlagNoVerify, false,
+	cmd.Flags().BoolVar(&o.NoVerify, artifactinstall.FlagNoVerify, false,
 		"whether this command should skip signature verification")
+	cmd.Flags().IntVar(&o.Parallelism, artifactinstall.FlagParallelism, o.Parallelism,
+		"maximum number of artifacts pulled and installed concurrently, the number of CPUs by default. "+
+			"Progress bars are disabled and a single spinner shows the artifacts being installed when greater than 1")
+	cmd.Flags().BoolVar(&o.SkipDigestCheck, artifactinstall.FlagSkipDigestCheck, false,
+		"whether this command should skip the digest verification of pulled artifacts, useful for debugging only")
+	cmd.Flags().BoolVar(&o.VerifySignature, artifactinstall.FlagVerifySignature, false,
+		"whether this command should refuse to install artifacts without a valid signature")
+	cmd.Flags().StringVar(&o.Signature.KeyRef, artifactinstall.FlagKey, "",
+		"path to the PEM encoded public key, KMS URI or name of a key trusted with \"falcoctl key add\", used to verify the artifacts signature")
+	cmd.Flags().StringVar(&o.Signature.CertificateIdentity, artifactinstall.FlagCertificateIdentity, "",
+		"identity expected in the certificate for keyless signature verification")
+	cmd.Flags().StringVar(&o.Signature.CertificateIdentityRegexp, artifactinstall.FlagCertificateIdentityRegexp, "",
+		"regular expression matching the identity expected in the certificate for keyless signature verification")
+	cmd.Flags().StringVar(&o.Signature.CertificateOidcIssuer, artifactinstall.FlagCertificateOidcIssuer, "",
+		"OIDC issuer expected in the certificate for keyless signature verification")
+	cmd.Flags().StringVar(&o.Signature.CertificateOidcIssuerRegexp, artifactinstall.FlagCertificateOidcIssuerRegexp, "",
+		"regular expression matching the OIDC issuer expected in the certificate for keyless signature verification")
+	cmd.Flags().StringVar(&o.Platform, artifactinstall.FlagPlatform, o.Platform,
+		"os and architecture of the artifacts to install in OS/ARCH format (only for plugins artifacts)")
+	cmd.Flags().StringVar(&o.LockFile, artifactinstall.FlagLockFile, o.LockFile,
+		"path of the lockfile where the digests of the installed artifacts are recorded")
+	cmd.Flags().StringVar(&o.FromLock, artifactinstall.FlagFromLock, "",
+		"path of a lockfile from which to install exactly the recorded digests, instead of the given artifacts")
+	cmd.Flags().IntVar(&o.MaxRetries, artifactinstall.FlagMaxRetries, o.MaxRetries,
+		"maximum number of times a request to the registry is retried on transient errors. Set to 0 to disable retries")
+	cmd.Flags().DurationVar(&o.RetryBackoff, artifactinstall.FlagRetryBackoff, o.RetryBackoff,
+		"initial wait time between retries, doubled at each attempt")
+	cmd.Flags().StringVar(&o.FromDir, artifactinstall.FlagFromDir, "",
+		"install the artifacts from the OCI image layout in the given directory instead of pulling them from the registries")
+	cmd.Flags().StringVar(&o.DestDirMapping, artifactinstall.FlagDestDirMapping, "",
+		"YAML file mapping artifact names or types to the directories where they are installed, taking precedence over the directory flags")
+	cmd.Flags().StringVar(&o.FromTar, artifactinstall.FlagFromTar, "",
+		"install the artifacts from the given tar archive of an OCI image layout instead of pulling them from the registries")
+	cmd.Flags().BoolVar(&o.DryRun, artifactinstall.FlagDryRun, false,
+		"print the artifacts that would be installed and their destination without pulling or writing anything")
+	cmd.Flags().BoolVar(&o.printPlan, artifactinstall.FlagPrintPlan, false,
+		"print the install plan as a JSON document, or YAML with --output yaml, listing the name, reference, digest, type, "+
+			"destination and action of each artifact, to the standard output only. Like --"+artifactinstall.FlagDryRun+", nothing is pulled or written")
+	cmd.Flags().BoolVar(&o.Reload, artifactinstall.FlagReload, false,
+		"send SIGHUP to the running falco processes, so that they reload, once at least one artifact has been installed")
+	cmd.Flags().StringVar(&o.PostInstallCmd, artifactinstall.FlagPostInstallCmd, "",
+		"shell command run once at least one artifact has been installed, with the installed references in $"+artifactinstall.InstalledArtifactsEnv)
+	cmd.Flags().BoolVar(&o.NoCache, artifactinstall.FlagNoCache, false,
+		"always download the artifacts from the registries, without reading or storing them in the local cache")
+	cmd.Flags().IntVar(&o.Concurrency, artifactinstall.FlagConcurrency, o.Concurrency,
+		"maximum number of chunks of a layer downloaded in parallel through range requests, when supported by the registry")
+	cmd.Flags().BoolVar(&o.Force, artifactinstall.FlagForce, false,
+		"overwrite the installed files modified locally, instead of writing the new versions next to them with the "+artifactinstall.NewFileExt+" extension. "+
+			"With --"+artifactinstall.FlagOnlyNewer+", it installs again the artifacts already up to date as well")
+	cmd.Flags().BoolVar(&o.OnlyNewer, artifactinstall.FlagOnlyNewer, false,
+		"skip the artifacts whose resolved digest matches the one recorded in the lockfile, as long as their files are still installed, "+
+			"without pulling them again")
+	cmd.Flags().BoolVar(&o.UpdateAll, artifactinstall.FlagUpdateAll, false,
+		"install again the artifacts recorded in the lockfile from the references they track, e.g. their tag, skipping the ones "+
+			"whose digest did not change as --"+artifactinstall.FlagOnlyNewer+" does, and print a summary of the updated ones")
+	cmd.Flags().StringSliceVar(&o.Selectors, artifactinstall.FlagSelector, nil,
+		"install only the artifacts whose manifest annotations match all the given key=value or key!=value selectors, skipping the others. It can be repeated multiple times")
+	cmd.Flags().StringVar(&o.FalcoVersion, artifactinstall.FlagFalcoVersion, "",
+		"version of Falco the artifacts must be compatible with, detected running \"falco --version\" if not given")
+	cmd.Flags().BoolVar(&o.IgnoreFalcoVersion, artifactinstall.FlagIgnoreFalcoVersion, false,
+		"install the artifacts even if the range of Falco versions declared in their annotations excludes the Falco version")
+	cmd.Flags().BoolVar(&o.RequireDigest, artifactinstall.FlagRequireDigest, false,
+		"refuse to install artifacts, dependencies included, whose references are not pinned to a digest (e.g. \"<ref>@sha256:<digest>\")")
+	cmd.Flags().BoolVar(&o.AllPlatforms, artifactinstall.FlagAllPlatforms, false,
+		"install the artifacts for every platform of their image index, each in a subdirectory named after it (e.g. \"linux-amd64\"), "+
+			"instead of the one given by --"+artifactinstall.FlagPlatform)
+	cmd.MarkFlagsMutuallyExclusive(artifactinstall.FlagPlatform, artifactinstall.FlagAllPlatforms)
+	cmd.Flags().StringVar(&o.PlatformVariant, artifactinstall.FlagPlatformVariant, "",
+		`variant of the platform of the plugins to install, e.g. "v7" for "linux/arm", used to select among the manifests `+
+			"of several variants, preferring the one without variant when not given")
+	cmd.MarkFlagsMutuallyExclusive(artifactinstall.FlagPlatformVariant, artifactinstall.FlagAllPlatforms)
+	cmd.Flags().StringSliceVar(&o.Exclude, artifactinstall.FlagExclude, nil,
+		"skip the files of the artifacts matching the given glob pattern (e.g. \"*.example.yaml\"), against their path, "+
+			"base name or parent directories in the archive. It can be repeated multiple times")
+	cmd.Flags().IntVar(&o.StripComponents, artifactinstall.FlagStripComponents, 0,
+		"strip the given number of leading path components from the files of the artifacts, like tar does, "+
+			"skipping the files with fewer components")
+	cmd.Flags().DurationVar(&o.Timeout, artifactinstall.FlagInstallTimeout, 0,
+		"maximum duration of the whole install, after which the pulls and extractions still running are cancelled (0 means no timeout)")
+	cmd.Flags().DurationVar(&o.LockTimeout, artifactinstall.FlagLockTimeout, o.LockTimeout,
+		"maximum time to wait for the other installs running on the node to complete, since installs are serialized. "+
+			"Set to 0 to fail right away when another install is running")
+	cmd.Flags().Var(&o.SourceType, artifactinstall.FlagSourceType,
+		`type of the artifacts installed from HTTP(S) URLs or local archives. Allowed values: "rulesfile", "plugin", "asset"`)
+	cmd.Flags().BoolVar(&o.FromStdin, artifactinstall.FlagFromStdin, false,
+		"install the tar archive read from the standard input, typed by --"+artifactinstall.FlagSourceType+" which must be given, "+
+			"extracting it straight into its destination. Nothing is recorded in the lockfile")
+	cmd.Flags().StringVar(&o.FromFile, artifactinstall.FlagFromFile, "",
+		"path of a file listing the artifacts to install, in addition to the given ones, with the same syntax as the arguments. "+
+			"Artifacts are separated by new lines, commas or spaces, and the text following a # is a comment. "+
+			"An artifact with a version constraint spans up to the next comma or the end of the line")
+	cmd.Flags().BoolVar(&o.RequireAttestation, artifactinstall.FlagRequireAttestation, false,
+		"whether this command should refuse to install artifacts without a SLSA provenance attestation. "+
+			"The attestations found are written next to the lockfile in any case")
+	cmd.Flags().BoolVar(&o.VersionedLayout, artifactinstall.FlagVersionedLayout, false,
+		"install each rulesfile in <rulesfiles-dir>/<name>/<version>, keeping the previous versions for rollback, and point the "+
+			"<rulesfiles-dir>/<name>/"+artifactinstall.CurrentLink+" symlink to the installed one")
+	cmd.Flags().BoolVar(&o.Validate, artifactinstall.FlagValidate, false,
+		"validate the rulesfiles running \"falco --validate\" on their files before installing them, failing the install, "+
+			"without writing anything, when the validation fails")
+	cmd.Flags().StringVar(&o.FalcoBin, artifactinstall.FlagFalcoBin, o.FalcoBin,
+		"falco binary, or its path, run to validate the rulesfiles with --"+artifactinstall.FlagValidate+" and to detect the Falco version")
+	cmd.Flags().StringVar(&o.SaveSBOM, artifactinstall.FlagSaveSBOM, "",
+		"directory where the SBOMs attached to the installed artifacts are saved, e.g. next to the lockfile for compliance, "+
+			"and recorded in the lockfile. The artifacts without an SBOM are installed anyway, with a warning")
+	cmd.Flags().Var(&o.MaxSize, artifactinstall.FlagMaxSize,
+		`maximum size of each artifact, bounding both the bytes downloaded and the total size of the files extracted from its archive, e.g. "512MiB" (0 means no limit)`)
 
 	return cmd
 }
 
 // RunArtifactInstall executes the business logic for the artifact install command.
 func (o *artifactInstallOptions) RunArtifactInstall(ctx context.Context, args []string) error {
-	logger := o.Printer.Logger
 	// Retrieve configuration for installer
 	configuredInstaller, err := config.Installer()
 	if err != nil {
@@ -198,160 +339,58 @@ func (o *artifactInstallOptions) RunArtifactInstall(ctx context.Context, args []
 	}
 
 	// Set args as configured if no arg was passed
-	if len(args) == 0 {
+	if len(args) == 0 && o.FromFile == "" && o.FromLock == "" && !o.UpdateAll && !o.FromStdin {
 		if len(configuredInstaller.Artifacts) == 0 {
 			return fmt.Errorf("no artifacts to install, please configure artifacts or pass them as arguments to this command")
 		}
 		args = configuredInstaller.Artifacts
 	}
 
-	// Create temp dir where to put pulled artifacts
-	tmpDir, err := os.MkdirTemp("", "falcoctl")
-	if err != nil {
-		return fmt.Errorf("cannot create temporary directory: %w", err)
-	}
-	defer os.RemoveAll(tmpDir)
-
-	// Create registry puller with auto login enabled
-	puller, err := ociutils.Puller(o.PlainHTTP, o.Printer)
-	if err != nil {
-		return err
-	}
-
-	// Specify how to pull config layer for each artifact requested by user.
-	resolver := artifactConfigResolver(func(ref string) (*oci.RegistryResult, error) {
-		ref, err := o.IndexCache.ResolveReference(ref)
-		if err != nil {
-			return nil, err
-		}
-
-		artifactConfig, err := puller.ArtifactConfig(ctx, ref, runtime.GOOS, runtime.GOARCH)
-		if err != nil {
-			return nil, err
-		}
-
-		return &oci.RegistryResult{
-			Config: *artifactConfig,
-		}, nil
-	})
-
-	signatures := make(map[string]*index.Signature)
-
-	// Compute input to install dependencies
-	for i, arg := range args {
-		ref, err := o.IndexCache.ResolveReference(arg)
-		if err != nil {
-			return err
-		}
-		if sig := o.IndexCache.SignatureForIndexRef(arg); sig != nil {
-			signatures[ref] = sig
-		}
-		args[i] = ref
-	}
-
-	var refs []string
-	if o.resolveDeps {
-		// Solve dependencies
-		logger.Info("Resolving dependencies ...")
-		refs, err = ResolveDeps(resolver, args...)
-		if err != nil {
-			return err
-		}
-	} else {
-		refs = args
-	}
-
-	logger.Info("Installing artifacts", logger.Args("refs", refs))
-
-	for _, ref := range refs {
-		ref, err = o.IndexCache.ResolveReference(ref)
-		if err != nil {
-			return err
-		}
-
-		logger.Info("Preparing to pull artifact", logger.Args("ref", ref))
-
-		if err := puller.CheckAllowedType(ctx, ref, runtime.GOOS, runtime.GOARCH, o.allowedTypes.Types); err != nil {
-			return err
-		}
-
-		// Install will always install artifact for the current OS and architecture
-		result, err := puller.Pull(ctx, ref, tmpDir, runtime.GOOS, runtime.GOARCH)
+	results, err := o.installer.Install(ctx, args...)
+	if o.DryRun {
 		if err != nil {
 			return err
 		}
-
-		sig, ok := signatures[ref]
-		if !ok {
-			// try to get the signature from the index
-			sig = o.IndexCache.SignatureForIndexRef(ref)
-		}
-
-		if sig != nil && !o.noVerify {
-			repo, err := utils.RepositoryFromRef(ref)
+		if o.printPlan {
+			entries, err := o.installer.PlanEntries(results)
 			if err != nil {
 				return err
 			}
-
-			// In order to prevent TOCTOU issues we'll perform signature verification after we complete a pull
-			// and obtained a digest but before files are written to disk. This way we ensure that we're verifying
-			// the exact digest that we just pulled, even if the tag gets overwritten in the meantime.
-			digestRef := fmt.Sprintf("%s@%s", repo, result.RootDigest)
-
-			logger.Info("Verifying signature for artifact", logger.Args("digest", digestRef))
-			err = signature.Verify(ctx, digestRef, sig)
-			if err != nil {
-				return fmt.Errorf("error while verifying signature for %s: %w", digestRef, err)
-			}
-			logger.Info("Signature successfully verified!")
-		}
-
-		var destDir string
-		switch result.Type {
-		case oci.Plugin:
-			destDir = o.PluginsDir
-		case oci.Rulesfile:
-			destDir = o.RulesfilesDir
-		case oci.Asset:
-			destDir = o.AssetsDir
-		default:
-			return fmt.Errorf("unrecognized result type %q while pulling artifact", result.Type)
+			return o.Printer.PrintStructured(entries)
 		}
+		return o.printPlanTable(results)
+	}
 
-		// Check if directory exists and is writable.
-		err = utils.ExistsAndIsWritable(destDir)
-		if err != nil {
-			return fmt.Errorf("cannot use directory %q as install destination: %w", destDir, err)
-		}
-
-		logger.Info("Extracting and installing artifact", logger.Args("type", result.Type, "file", result.Filename))
-
-		if !o.Printer.DisableStyling {
-			o.Printer.Spinner, _ = o.Printer.Spinner.Start("Extracting and installing")
+	// Results are not printed when failing before installing anything.
+	if results != nil && o.Printer.StructuredOutput() {
+		if printErr := o.Printer.PrintStructured(results); printErr != nil {
+			err = errors.Join(err, printErr)
 		}
+	}
 
-		result.Filename = filepath.Join(tmpDir, result.Filename)
+	// With the structured output the summary is written to stderr along with the other messages, so that stdout can
+	// still be parsed.
+	if results != nil {
+		o.installer.PrintSummary(results)
+	}
 
-		f, err := os.Open(result.Filename)
-		if err != nil {
-			return err
-		}
-		// Extract artifact and move it to its destination directory
-		_, err = utils.ExtractTarGz(ctx, f, destDir, 0)
-		if err != nil {
-			return fmt.Errorf("cannot extract %q to %q: %w", result.Filename, destDir, err)
-		}
+	return err
+}
 
-		err = os.Remove(result.Filename)
-		if err != nil {
-			return err
+// printPlanTable prints the planned results of a dry run, where the values that could not be resolved are shown as unknown.
+func (o *artifactInstallOptions) printPlanTable(results []*artifactinstall.Result) error {
+	const unknown = "<unknown>"
+	orUnknown := func(val string) string {
+		if val == "" {
+			return unknown
 		}
+		return val
+	}
 
-		if o.Printer.Spinner != nil {
-			_ = o.Printer.Spinner.Stop()
-		}
-		logger.Info("Artifact successfully installed", logger.Args("name", ref, "type", result.Type, "digest", result.Digest, "directory", destDir))
+	data := make([][]string, 0, len(results))
+	for _, res := range results {
+		data = append(data, []string{res.Ref, orUnknown(res.Digest), orUnknown(res.Type), orUnknown(res.DestDir)})
 	}
 
-	return nil
+	return o.Printer.PrintResults(results, output.InstallPlan, data)
 }
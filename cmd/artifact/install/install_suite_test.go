@@ -17,6 +17,8 @@ package install_test
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
@@ -29,10 +31,16 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/gbytes"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2"
 	"oras.land/oras-go/v2/registry/remote"
 
 	"github.com/falcosecurity/falcoctl/cmd"
+	falcoctlconfig "github.com/falcosecurity/falcoctl/internal/config"
+	"github.com/falcosecurity/falcoctl/pkg/oci"
+	ocipuller "github.com/falcosecurity/falcoctl/pkg/oci/puller"
+	"github.com/falcosecurity/falcoctl/pkg/oci/repository"
 	commonoptions "github.com/falcosecurity/falcoctl/pkg/options"
 	testutils "github.com/falcosecurity/falcoctl/pkg/test"
 )
@@ -96,6 +104,9 @@ var _ = BeforeSuite(func() {
 	configFile, err = testutils.CreateEmptyFile("falcoctl.yaml")
 	Expect(err).Should(Succeed())
 
+	// Keep the cache of pulled artifacts next to the configuration file, so that it is removed with it.
+	falcoctlconfig.CacheDir = filepath.Join(filepath.Dir(configFile), "cache")
+
 })
 
 var _ = AfterSuite(func() {
@@ -109,3 +120,72 @@ func executeRoot(args []string) error {
 	rootCmd.SetOut(output)
 	return cmd.Execute(rootCmd, opt)
 }
+
+// attachProvenance attaches to the artifact pointed by ref, following the cosign convention, a DSSE envelope
+// wrapping an in-toto statement with the given predicate type.
+//
+//nolint:unused // false positive
+func attachProvenance(ref, predicateType string) error {
+	repo, err := repository.NewRepository(ref, repository.WithPlainHTTP(true))
+	if err != nil {
+		return err
+	}
+
+	desc, err := repo.Resolve(ctx, repo.Reference.Reference)
+	if err != nil {
+		return err
+	}
+
+	statement, err := json.Marshal(map[string]any{
+		"_type":         "https://in-toto.io/Statement/v1",
+		"predicateType": predicateType,
+		"predicate":     map[string]any{},
+	})
+	if err != nil {
+		return err
+	}
+	envelope, err := json.MarshalIndent(map[string]string{
+		"payloadType": "application/vnd.in-toto+json",
+		"payload":     base64.StdEncoding.EncodeToString(statement),
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	layer, err := oras.PushBytes(ctx, repo, oci.DSSEEnvelopeMediaType, envelope)
+	if err != nil {
+		return err
+	}
+	manifest, err := oras.PackManifest(ctx, repo, oras.PackManifestVersion1_1, oci.DSSEEnvelopeMediaType,
+		oras.PackManifestOptions{Layers: []v1.Descriptor{layer}})
+	if err != nil {
+		return err
+	}
+
+	return repo.Tag(ctx, manifest, ocipuller.AttestationTag(desc.Digest.String()))
+}
+
+// attachSBOM attaches to the artifact pointed by ref, as a referrer, an SBOM of the given type with the given content.
+//
+//nolint:unused // false positive
+func attachSBOM(ref, artifactType, document string) error {
+	repo, err := repository.NewRepository(ref, repository.WithPlainHTTP(true))
+	if err != nil {
+		return err
+	}
+	// The test registry does not allow deleting manifests, hence the superseded referrers indexes are kept.
+	repo.SkipReferrersGC = true
+
+	desc, err := repo.Resolve(ctx, repo.Reference.Reference)
+	if err != nil {
+		return err
+	}
+
+	layer, err := oras.PushBytes(ctx, repo, artifactType, []byte(document))
+	if err != nil {
+		return err
+	}
+	_, err = oras.PackManifest(ctx, repo, oras.PackManifestVersion1_1, artifactType,
+		oras.PackManifestOptions{Subject: &desc, Layers: []v1.Descriptor{layer}})
+	return err
+}
@@ -16,20 +16,36 @@
 package install_test
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
+	"strings"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/gbytes"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+	"oras.land/oras-go/v2/registry/remote"
 	"oras.land/oras-go/v2/registry/remote/auth"
 
 	"github.com/falcosecurity/falcoctl/cmd"
+	falcoctlconfig "github.com/falcosecurity/falcoctl/internal/config"
+	"github.com/falcosecurity/falcoctl/internal/utils"
+	"github.com/falcosecurity/falcoctl/pkg/lockfile"
 	"github.com/falcosecurity/falcoctl/pkg/oci"
 	"github.com/falcosecurity/falcoctl/pkg/oci/authn"
 	ocipusher "github.com/falcosecurity/falcoctl/pkg/oci/pusher"
+	commonoptions "github.com/falcosecurity/falcoctl/pkg/options"
 	out "github.com/falcosecurity/falcoctl/pkg/output"
 )
 
@@ -38,21 +54,69 @@ var artifactInstallUsage = `Usage:
   falcoctl artifact install [ref1 [ref2 ...]] [flags]
 
 Flags:
-      --allowed-types ArtifactTypeSlice   list of artifact types that can be installed. If not specified or configured, all types are allowed.
-                                          It accepts comma separated values or it can be repeated multiple times.
-                                          Examples:
-                                                --allowed-types="rulesfile,plugin"
-                                                --allowed-types=rulesfile --allowed-types=plugin
-  -h, --help                              help for install
-      --plain-http                        allows interacting with remote registry via plain http requests
-      --plugins-dir string                directory where to install plugins. (default "/usr/share/falco/plugins")
-      --resolve-deps                      whether this command should resolve dependencies or not (default true)
-      --rulesfiles-dir string             directory where to install rules. (default "/etc/falco")
+      --all-platforms                       install the artifacts for every platform of their image index, each in a subdirectory named after it (e.g. "linux-amd64"), instead of the one given by --platform
+      --allowed-types ArtifactTypeSlice     list of artifact types that can be installed. If not specified or configured, all types are allowed.
+                                            It accepts comma separated values or it can be repeated multiple times.
+                                            Examples:
+                                                  --allowed-types="rulesfile,plugin"
+                                                  --allowed-types=rulesfile --allowed-types=plugin
+      --anonymous                           interact with remote registries anonymously, without looking up credentials in any credential store or helper
+      --ca-cert string                      PEM encoded file with the CA certificates used, in addition to the system ones, to verify the remote registries
+      --concurrency int                     maximum number of chunks of a layer downloaded in parallel through range requests, when supported by the registry (default 1)
+      --dest-dir-mapping string             YAML file mapping artifact names or types to the directories where they are installed, taking precedence over the directory flags
+      --exclude strings                     skip the files of the artifacts matching the given glob pattern (e.g. "*.example.yaml"), against their path, base name or parent directories in the archive. It can be repeated multiple times
+      --falco-bin string                    falco binary, or its path, run to validate the rulesfiles with --validate and to detect the Falco version (default "falco")
+      --falco-version string                version of Falco the artifacts must be compatible with, detected running "falco --version" if not given
+      --force                               overwrite the installed files modified locally, instead of writing the new versions next to them with the .new extension. With --only-newer, it installs again the artifacts already up to date as well
+      --from-file string                    path of a file listing the artifacts to install, in addition to the given ones, with the same syntax as the arguments. Artifacts are separated by new lines, commas or spaces, and the text following a # is a comment. An artifact with a version constraint spans up to the next comma or the end of the line
+      --from-stdin                          install the tar archive read from the standard input, typed by --source-type which must be given, extracting it straight into its destination. Nothing is recorded in the lockfile
+  -h, --help                                help for install
+      --identity-token string               identity token exchanged for bearer tokens with the registries instead of the stored credentials, read from a file if given as @<path> or from the standard input if "-"
+      --ignore-falco-version                install the artifacts even if the range of Falco versions declared in their annotations excludes the Falco version
+      --insecure-skip-tls-verify            skip the verification of the remote registries certificates, making the connections insecure
+      --install-timeout duration            maximum duration of the whole install, after which the pulls and extractions still running are cancelled (0 means no timeout)
+      --lock-timeout duration               maximum time to wait for the other installs running on the node to complete, since installs are serialized. Set to 0 to fail right away when another install is running (default 5m0s)
+      --max-size size                       maximum size of each artifact, bounding both the bytes downloaded and the total size of the files extracted from its archive, e.g. "512MiB" (0 means no limit) (default 1GiB)
+      --no-cache                            always download the artifacts from the registries, without reading or storing them in the local cache
+      --no-proxy string                     comma-separated list of hosts, domains and CIDRs reached without proxy, overrides the NO_PROXY environment variable
+      --only-newer                          skip the artifacts whose resolved digest matches the one recorded in the lockfile, as long as their files are still installed, without pulling them again
+      --plain-http                          allows interacting with remote registry via plain http requests
+      --platform-variant string             variant of the platform of the plugins to install, e.g. "v7" for "linux/arm", used to select among the manifests of several variants, preferring the one without variant when not given
+      --plugins-dir string                  directory where to install plugins. (default "/usr/share/falco/plugins")
+      --post-install-cmd string             shell command run once at least one artifact has been installed, with the installed references in $FALCOCTL_INSTALLED_ARTIFACTS
+      --print-plan                          print the install plan as a JSON document, or YAML with --output yaml, listing the name, reference, digest, type, destination and action of each artifact, to the standard output only. Like --dry-run, nothing is pulled or written
+      --proxy string                        proxy URL used to connect to remote registries, overrides the HTTP_PROXY and HTTPS_PROXY environment variables
+      --registry-config string              Docker config file whose credentials and credential helpers are used when falcoctl has none for a registry, defaults to $DOCKER_CONFIG/config.json or $HOME/.docker/config.json
+      --registry-connect-timeout duration   maximum duration to establish a connection to the registry (0 means no timeout) (default 30s)
+      --registry-mirror strings             mirror registry tried, in the given order, when pulling from the registry of an artifact fails. It can be repeated multiple times
+      --registry-timeout duration           maximum duration of each registry operation, e.g. checking the connection or pulling an artifact (0 means no timeout) (default 1m0s)
+      --registry-token string               bearer token sent to the registries instead of the stored credentials, read from a file if given as @<path> or from the standard input if "-"
+      --registry-token-host strings         registry, as host[:port], the --registry-token or --identity-token is sent to, the other registries use the stored credentials. It can be repeated multiple times
+      --reload                              send SIGHUP to the running falco processes, so that they reload, once at least one artifact has been installed
+      --require-attestation                 whether this command should refuse to install artifacts without a SLSA provenance attestation. The attestations found are written next to the lockfile in any case
+      --require-digest                      refuse to install artifacts, dependencies included, whose references are not pinned to a digest (e.g. "<ref>@sha256:<digest>")
+      --resolve-deps                        whether this command should resolve dependencies or not (default true)
+      --rulesfiles-dir string               directory where to install rules. (default "/etc/falco")
+      --save-sbom string                    directory where the SBOMs attached to the installed artifacts are saved, e.g. next to the lockfile for compliance, and recorded in the lockfile. The artifacts without an SBOM are installed anyway, with a warning
+      --selector strings                    install only the artifacts whose manifest annotations match all the given key=value or key!=value selectors, skipping the others. It can be repeated multiple times
+      --source-type ArtifactType            type of the artifacts installed from HTTP(S) URLs or local archives. Allowed values: "rulesfile", "plugin", "asset" (default rulesfile)
+      --strict-allowed-types                fail when an artifact type is not allowed, instead of skipping the artifact with a warning
+      --strip-components int                strip the given number of leading path components from the files of the artifacts, like tar does, skipping the files with fewer components
+      --update-all                          install again the artifacts recorded in the lockfile from the references they track, e.g. their tag, skipping the ones whose digest did not change as --only-newer does, and print a summary of the updated ones
+      --validate                            validate the rulesfiles running "falco --validate" on their files before installing them, failing the install, without writing anything, when the validation fails
+      --versioned-layout                    install each rulesfile in <rulesfiles-dir>/<name>/<version>, keeping the previous versions for rollback, and point the <rulesfiles-dir>/<name>/current symlink to the installed one
 
 Global Flags:
-      --config string     config file to be used for falcoctl (default "/etc/falcoctl/falcoctl.yaml")
-      --log-format string   Set formatting for logs (color, text, json) (default "color")
-      --log-level string    Set level for logs (info, warn, debug, trace) (default "info")
+      --config string            config file to be used for falcoctl (default "/etc/falcoctl/falcoctl.yaml")
+      --falcoctl-dir string      directory where falcoctl stores the indexes and the client credentials (default "$HOME/.config/falcoctl")
+      --index-max-age duration   refresh the cached indexes older than the given age, e.g. 24h, warning if they are stale and cannot be refreshed (0 never refreshes them)
+      --indexes-file string      file listing the configured indexes (default "indexes.yaml" in the falcoctl directory)
+      --lenient-indexes          skip the indexes that cannot be loaded, e.g. a corrupt cached index, with a warning instead of failing, as long as at least one of them is loaded
+      --log-format string        Set formatting for logs (color, text, json) (default "color")
+      --log-level string         Set level for logs (info, warn, debug, trace) (default "info")
+      --no-color                 Disable colors, spinners and progress bars, as the NO_COLOR environment variable does. They are disabled as well when not attached to a tty
+      --output string            Set format for the results of the commands supporting it (text, json, yaml, annotations), messages are written to stderr when set to json or yaml (default "text")
+  -q, --quiet                    Only print errors, to stderr, and the results of the commands, silencing the other logs, spinners and progress bars
 
 `
 
@@ -75,6 +139,8 @@ an underscore "_".
 
 A reference is either a simple name or a fully qualified reference ("<registry>/<repository>"), 
 optionally followed by ":<tag>" (":latest" is assumed by default when no tag is given).
+It can also be followed by "@<constraint>", where the semver constraint (e.g. "^1.2.0", "~1.2.0"
+or ">=1.2.0 <1.5.0") selects the highest matching version among the tags of the repository.
 
 When providing just the name of the artifact, the command will search for the artifacts in 
 the configured index files, and if found, it will use the registry and repository specified 
@@ -86,8 +152,35 @@ Example - Install "latest" tag of "k8saudit-rules" artifact by relying on index
 Example - Install all updates from "k8saudit-rules" 0.5.x release series:
 	falcoctl artifact install k8saudit-rules:0.5
 
+Example - Install the highest version of "k8saudit" compatible with 0.6.0, by listing the tags of its repository:
+	falcoctl artifact install k8saudit@^0.6.0
+
+Example - Install every artifact of the configured indexes whose repository is under "falcosecurity/plugins/ruleset":
+	falcoctl artifact install 'falcosecurity/plugins/ruleset/*'
+
 Example - Install "cloudtrail" plugins using a fully qualified reference:
 	falcoctl artifact install ghcr.io/falcosecurity/plugins/ruleset/k8saudit:latest
+
+Example - Install "k8saudit-rules" only if it is signed with the given public key:
+	falcoctl artifact install k8saudit-rules --verify-signature --key cosign.pub
+
+Example - Install "cloudtrail" plugin for a different platform than the current one:
+	falcoctl artifact install cloudtrail --platform linux/arm64
+
+Example - Install "cloudtrail" plugin for armv7, when its image index has several variants of linux/arm:
+	falcoctl artifact install cloudtrail --platform linux/arm --platform-variant v7
+
+Example - Install "cloudtrail" plugin for all its platforms, e.g. in "/usr/share/falco/plugins/linux-amd64":
+	falcoctl artifact install cloudtrail --all-platforms --resolve-deps=false
+
+Example - Install exactly the digests recorded in a lockfile by a previous install:
+	falcoctl artifact install --from-lock falcoctl.lock.yaml
+
+Example - Install "k8saudit-rules" without network access, from a previously exported OCI layout:
+	falcoctl artifact install ghcr.io/falcosecurity/rules/k8saudit-rules:latest --from-dir ./oci-layout --no-verify
+
+Example - Install "k8saudit-rules" and make the running Falco reload it:
+	falcoctl artifact install k8saudit-rules --reload
 `
 
 //nolint:unused // false positive
@@ -173,6 +266,30 @@ var artifactInstallTests = Describe("install", func() {
 				"ERROR no artifacts to install, please configure artifacts or pass them as arguments to this command")
 		})
 
+		When("with --verify-signature and --no-verify", func() {
+			BeforeEach(func() {
+				configDir := GinkgoT().TempDir()
+				configFile := filepath.Join(configDir, ".config")
+				_, err := os.Create(configFile)
+				Expect(err).To(BeNil())
+				args = []string{artifactCmd, installCmd, "noregistry/testrules", "--verify-signature", "--no-verify", "--config", configFile}
+			})
+			installAssertFailedBehavior(artifactInstallUsage, "ERROR --verify-signature and --no-verify are mutually exclusive")
+		})
+
+		When("with --from-lock and artifacts", func() {
+			BeforeEach(func() {
+				configDir := GinkgoT().TempDir()
+				configFile := filepath.Join(configDir, ".config")
+				_, err := os.Create(configFile)
+				Expect(err).To(BeNil())
+				args = []string{artifactCmd, installCmd, "noregistry/testrules", "--from-lock", filepath.Join(configDir, "falcoctl.lock.yaml"),
+					"--config", configFile}
+			})
+			installAssertFailedBehavior(artifactInstallUsage,
+				"ERROR artifacts cannot be passed as arguments when installing from a lockfile with --from-lock")
+		})
+
 		When("unreachable registry", func() {
 			BeforeEach(func() {
 				configDir := GinkgoT().TempDir()
@@ -219,7 +336,7 @@ var artifactInstallTests = Describe("install", func() {
 				ref = registry + repoAndTag
 				Expect(err).To(BeNil())
 				args = []string{artifactCmd, installCmd, ref, "--plain-http",
-					"--config", configFilePath, "--allowed-types", "rulesfile"}
+					"--config", configFilePath, "--allowed-types", "rulesfile", "--strict-allowed-types"}
 			})
 
 			installAssertFailedBehavior(artifactInstallUsage, "ERROR cannot download artifact of type \"plugin\": type not permitted")
@@ -248,7 +365,7 @@ var artifactInstallTests = Describe("install", func() {
 				ref = registry + repoAndTag
 				Expect(err).To(BeNil())
 				args = []string{artifactCmd, installCmd, ref, "--plain-http",
-					"--config", configFilePath, "--allowed-types", "plugin"}
+					"--config", configFilePath, "--allowed-types", "plugin", "--strict-allowed-types"}
 			})
 
 			installAssertFailedBehavior(artifactInstallUsage, "ERROR cannot download artifact of type \"rulesfile\": type not permitted")
@@ -439,4 +556,1779 @@ var artifactInstallTests = Describe("install", func() {
 
 	})
 
+	Context("disallowed types without strict mode", func() {
+		var destDir string
+
+		BeforeEach(func() {
+			baseDir := GinkgoT().TempDir()
+			configFilePath := baseDir + "/config.yaml"
+			Expect(os.WriteFile(configFilePath, []byte("indexes: []\n"), 0o600)).To(Succeed())
+			destDir = GinkgoT().TempDir()
+
+			// push plugin
+			pusher = ocipusher.NewPusher(authn.NewClient(authn.WithCredentials(&auth.EmptyCredential)), true, nil)
+			ref = registry + repo + ":skipped"
+			config = ocipusher.WithArtifactConfig(oci.ArtifactConfig{
+				Name:    "plugin1",
+				Version: "0.0.1",
+			})
+			filePathsAndPlatforms := ocipusher.WithFilepathsAndPlatforms([]string{plugintgz}, []string{"linux/amd64"})
+			result, err := pusher.Push(ctx, oci.Plugin, ref, filePathsAndPlatforms, config)
+			Expect(err).To(BeNil())
+			Expect(result).ToNot(BeNil())
+			args = []string{artifactCmd, installCmd, ref, "--plain-http", "--platform", "linux/amd64",
+				"--config", configFilePath, "--allowed-types", "rulesfile", "--plugins-dir", destDir}
+		})
+
+		It("should skip the artifact with a warning", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(output).Should(gbytes.Say(regexp.QuoteMeta("WARN  Skipping artifact")))
+			Expect(output).Should(gbytes.Say(regexp.QuoteMeta("cannot download artifact of type \"plugin\": type not permitted")))
+			entries, err := os.ReadDir(destDir)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(entries).To(BeEmpty())
+		})
+	})
+
+	Context("annotation selector", func() {
+		var destDir string
+
+		BeforeEach(func() {
+			baseDir := GinkgoT().TempDir()
+			configFilePath := baseDir + "/config.yaml"
+			Expect(os.WriteFile(configFilePath, []byte("indexes: []\n"), 0o600)).To(Succeed())
+			destDir = GinkgoT().TempDir()
+
+			// push plugin
+			pusher = ocipusher.NewPusher(authn.NewClient(authn.WithCredentials(&auth.EmptyCredential)), true, nil)
+			ref = registry + repo + ":selected"
+			config = ocipusher.WithArtifactConfig(oci.ArtifactConfig{
+				Name:    "plugin1",
+				Version: "0.0.1",
+			})
+			filePathsAndPlatforms := ocipusher.WithFilepathsAndPlatforms([]string{plugintgz}, []string{"linux/amd64"})
+			result, err := pusher.Push(ctx, oci.Plugin, ref, filePathsAndPlatforms, config,
+				ocipusher.WithAnnotationSource("github.com/falcosecurity/plugins"))
+			Expect(err).To(BeNil())
+			Expect(result).ToNot(BeNil())
+			args = []string{artifactCmd, installCmd, ref, "--plain-http", "--platform", "linux/amd64",
+				"--config", configFilePath, "--plugins-dir", destDir, "--lock-file", baseDir + "/falcoctl.lock.yaml"}
+		})
+
+		When("the annotations match", func() {
+			BeforeEach(func() {
+				args = append(args, "--selector", v1.AnnotationSource+"=github.com/falcosecurity/plugins")
+			})
+
+			It("should install the artifact", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(filepath.Join(destDir, "libcloudtrail.so")).To(BeAnExistingFile())
+			})
+		})
+
+		When("the annotations do not match", func() {
+			BeforeEach(func() {
+				args = append(args, "--selector", v1.AnnotationSource+"!=github.com/falcosecurity/plugins")
+			})
+
+			It("should skip the artifact with a warning", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(output).Should(gbytes.Say(regexp.QuoteMeta("WARN  Skipping artifact")))
+				Expect(output).Should(gbytes.Say(regexp.QuoteMeta(
+					fmt.Sprintf("annotations do not match selector \"%s!=github.com/falcosecurity/plugins\"", v1.AnnotationSource))))
+				entries, err := os.ReadDir(destDir)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(entries).To(BeEmpty())
+			})
+		})
+
+		When("the selector is not valid", func() {
+			BeforeEach(func() {
+				args = append(args, "--selector", "stable")
+			})
+
+			It("should fail", func() {
+				Expect(err).To(MatchError(`invalid selector "stable": needs to be in key=value or key!=value format`))
+			})
+		})
+	})
+
+	Context("falco version compatibility", func() {
+		var destDir string
+
+		BeforeEach(func() {
+			baseDir := GinkgoT().TempDir()
+			configFilePath := baseDir + "/config.yaml"
+			Expect(os.WriteFile(configFilePath, []byte("indexes: []\n"), 0o600)).To(Succeed())
+			destDir = GinkgoT().TempDir()
+
+			// push plugin
+			pusher = ocipusher.NewPusher(authn.NewClient(authn.WithCredentials(&auth.EmptyCredential)), true, nil)
+			ref = registry + repo + ":compat"
+			config = ocipusher.WithArtifactConfig(oci.ArtifactConfig{
+				Name:    "plugin1",
+				Version: "0.0.1",
+			})
+			filePathsAndPlatforms := ocipusher.WithFilepathsAndPlatforms([]string{plugintgz}, []string{"linux/amd64"})
+			result, err := pusher.Push(ctx, oci.Plugin, ref, filePathsAndPlatforms, config,
+				ocipusher.WithAnnotations(map[string]string{
+					oci.FalcoMinVersionAnnotation: "0.38.0",
+					oci.FalcoMaxVersionAnnotation: "0.39.2",
+				}))
+			Expect(err).To(BeNil())
+			Expect(result).ToNot(BeNil())
+			args = []string{artifactCmd, installCmd, ref, "--plain-http", "--platform", "linux/amd64",
+				"--config", configFilePath, "--plugins-dir", destDir, "--lock-file", baseDir + "/falcoctl.lock.yaml"}
+		})
+
+		When("the falco version is within the declared range", func() {
+			BeforeEach(func() {
+				args = append(args, "--falco-version", "0.39.0")
+			})
+
+			It("should install the artifact", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(filepath.Join(destDir, "libcloudtrail.so")).To(BeAnExistingFile())
+			})
+		})
+
+		When("the falco version is older than the declared range", func() {
+			BeforeEach(func() {
+				args = append(args, "--falco-version", "0.37.1")
+			})
+
+			It("should refuse to install the artifact", func() {
+				Expect(err).To(MatchError(fmt.Sprintf("artifact %q is not compatible with Falco 0.37.1 (%s: 0.38.0), "+
+					"use --ignore-falco-version to install it anyway", ref, oci.FalcoMinVersionAnnotation)))
+				Expect(filepath.Join(destDir, "libcloudtrail.so")).ToNot(BeAnExistingFile())
+			})
+		})
+
+		When("the falco version is newer than the declared range", func() {
+			BeforeEach(func() {
+				args = append(args, "--falco-version", "0.40.0")
+			})
+
+			It("should refuse to install the artifact", func() {
+				Expect(err).To(MatchError(ContainSubstring(oci.FalcoMaxVersionAnnotation + ": 0.39.2")))
+				Expect(filepath.Join(destDir, "libcloudtrail.so")).ToNot(BeAnExistingFile())
+			})
+		})
+
+		When("with --ignore-falco-version", func() {
+			BeforeEach(func() {
+				args = append(args, "--falco-version", "0.37.1", "--ignore-falco-version")
+			})
+
+			It("should install the artifact", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(filepath.Join(destDir, "libcloudtrail.so")).To(BeAnExistingFile())
+			})
+		})
+	})
+
+	Context("environment variables", func() {
+		var envDir, flagDir string
+
+		BeforeEach(func() {
+			baseDir := GinkgoT().TempDir()
+			configFilePath := baseDir + "/config.yaml"
+			Expect(os.WriteFile(configFilePath, []byte("indexes: []\n"), 0o600)).To(Succeed())
+			envDir, flagDir = GinkgoT().TempDir(), GinkgoT().TempDir()
+			Expect(os.Setenv("FALCOCTL_PLUGINS_DIR", envDir)).To(Succeed())
+			DeferCleanup(os.Unsetenv, "FALCOCTL_PLUGINS_DIR")
+			Expect(os.Setenv("FALCOCTL_PLAIN_HTTP", "true")).To(Succeed())
+			DeferCleanup(os.Unsetenv, "FALCOCTL_PLAIN_HTTP")
+
+			// push plugin
+			pusher = ocipusher.NewPusher(authn.NewClient(authn.WithCredentials(&auth.EmptyCredential)), true, nil)
+			ref = registry + repo + ":env"
+			config = ocipusher.WithArtifactConfig(oci.ArtifactConfig{
+				Name:    "plugin1",
+				Version: "0.0.1",
+			})
+			filePathsAndPlatforms := ocipusher.WithFilepathsAndPlatforms([]string{plugintgz}, []string{"linux/amd64"})
+			_, err := pusher.Push(ctx, oci.Plugin, ref, filePathsAndPlatforms, config)
+			Expect(err).To(BeNil())
+			args = []string{artifactCmd, installCmd, ref, "--platform", "linux/amd64",
+				"--config", configFilePath, "--lock-file", baseDir + "/falcoctl.lock.yaml"}
+		})
+
+		When("the flags are not given", func() {
+			It("should take the values of the environment variables", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(filepath.Join(envDir, "libcloudtrail.so")).To(BeAnExistingFile())
+			})
+		})
+
+		When("the flags are given", func() {
+			BeforeEach(func() {
+				args = append(args, "--plugins-dir", flagDir)
+			})
+
+			It("should prefer the flags to the environment variables", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(filepath.Join(flagDir, "libcloudtrail.so")).To(BeAnExistingFile())
+				Expect(filepath.Join(envDir, "libcloudtrail.so")).ToNot(BeAnExistingFile())
+			})
+		})
+	})
+
+	Context("post-install command", func() {
+		var baseDir, marker string
+
+		hookArgs := func(allowedTypes string) []string {
+			return []string{artifactCmd, installCmd, ref, "--plain-http", "--platform", "linux/amd64",
+				"--config", baseDir + "/config.yaml", "--plugins-dir", baseDir, "--lock-file", baseDir + "/falcoctl.lock.yaml",
+				"--allowed-types", allowedTypes, "--post-install-cmd", "echo $FALCOCTL_INSTALLED_ARTIFACTS > " + marker}
+		}
+
+		BeforeEach(func() {
+			baseDir = GinkgoT().TempDir()
+			configFilePath := baseDir + "/config.yaml"
+			Expect(os.WriteFile(configFilePath, []byte("indexes: []\n"), 0o600)).To(Succeed())
+			marker = filepath.Join(baseDir, "marker")
+
+			// push plugin
+			pusher = ocipusher.NewPusher(authn.NewClient(authn.WithCredentials(&auth.EmptyCredential)), true, nil)
+			ref = registry + repo + ":hook"
+			config = ocipusher.WithArtifactConfig(oci.ArtifactConfig{
+				Name:    "plugin1",
+				Version: "0.0.1",
+			})
+			filePathsAndPlatforms := ocipusher.WithFilepathsAndPlatforms([]string{plugintgz}, []string{"linux/amd64"})
+			result, err := pusher.Push(ctx, oci.Plugin, ref, filePathsAndPlatforms, config)
+			Expect(err).To(BeNil())
+			Expect(result).ToNot(BeNil())
+		})
+
+		When("an artifact is installed", func() {
+			BeforeEach(func() {
+				args = hookArgs("plugin")
+			})
+
+			It("should run the command with the installed references", func() {
+				Expect(err).ToNot(HaveOccurred())
+				content, err := os.ReadFile(marker)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(strings.TrimSpace(string(content))).To(Equal(ref))
+			})
+		})
+
+		When("no artifact is installed", func() {
+			BeforeEach(func() {
+				args = hookArgs("rulesfile")
+			})
+
+			It("should not run the command", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(marker).ToNot(BeAnExistingFile())
+			})
+		})
+	})
+	Context("cache", func() {
+		var baseDir, digest string
+
+		cacheArgs := func(extra ...string) []string {
+			return append([]string{artifactCmd, installCmd, ref, "--plain-http", "--platform", "linux/amd64",
+				"--config", baseDir + "/config.yaml", "--plugins-dir", baseDir, "--lock-file", baseDir + "/falcoctl.lock.yaml"},
+				extra...)
+		}
+
+		cachedManifest := func() string {
+			return filepath.Join(falcoctlconfig.CacheDir, "blobs", "sha256", strings.TrimPrefix(digest, "sha256:"))
+		}
+
+		BeforeEach(func() {
+			baseDir = GinkgoT().TempDir()
+			configFilePath := baseDir + "/config.yaml"
+			Expect(os.WriteFile(configFilePath, []byte("indexes: []\n"), 0o600)).To(Succeed())
+			Expect(os.RemoveAll(falcoctlconfig.CacheDir)).To(Succeed())
+
+			// push plugin
+			pusher = ocipusher.NewPusher(authn.NewClient(authn.WithCredentials(&auth.EmptyCredential)), true, nil)
+			ref = registry + repo + ":cache"
+			config = ocipusher.WithArtifactConfig(oci.ArtifactConfig{
+				Name:    "plugin1",
+				Version: "0.0.1",
+			})
+			filePathsAndPlatforms := ocipusher.WithFilepathsAndPlatforms([]string{plugintgz}, []string{"linux/amd64"})
+			result, err := pusher.Push(ctx, oci.Plugin, ref, filePathsAndPlatforms, config)
+			Expect(err).To(BeNil())
+			Expect(result).ToNot(BeNil())
+		})
+
+		JustBeforeEach(func() {
+			lock, err := lockfile.New(baseDir + "/falcoctl.lock.yaml")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(lock.Artifacts).To(HaveLen(1))
+			digest = lock.Artifacts[0].Digest
+		})
+
+		When("the cache is enabled", func() {
+			BeforeEach(func() {
+				args = cacheArgs()
+			})
+
+			It("should store the pulled artifact in the cache", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(cachedManifest()).To(BeAnExistingFile())
+			})
+		})
+
+		When("with --no-cache", func() {
+			BeforeEach(func() {
+				args = cacheArgs("--no-cache")
+			})
+
+			It("should not store the pulled artifact in the cache", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(cachedManifest()).ToNot(BeAnExistingFile())
+			})
+		})
+	})
+	Context("destination directories preflight", func() {
+		var baseDir, pluginRef, rulesRef, missingDir string
+
+		BeforeEach(func() {
+			baseDir = GinkgoT().TempDir()
+			configFilePath := baseDir + "/config.yaml"
+			Expect(os.WriteFile(configFilePath, []byte("indexes: []\n"), 0o600)).To(Succeed())
+			missingDir = filepath.Join(baseDir, "missing")
+
+			// push a plugin and a rulesfile
+			pusher = ocipusher.NewPusher(authn.NewClient(authn.WithCredentials(&auth.EmptyCredential)), true, nil)
+			pluginRef = registry + repo + ":preflight-plugin"
+			config = ocipusher.WithArtifactConfig(oci.ArtifactConfig{
+				Name:    "plugin1",
+				Version: "0.0.1",
+			})
+			filePathsAndPlatforms := ocipusher.WithFilepathsAndPlatforms([]string{plugintgz}, []string{"linux/amd64"})
+			_, err := pusher.Push(ctx, oci.Plugin, pluginRef, filePathsAndPlatforms, config)
+			Expect(err).To(BeNil())
+			rulesRef = registry + repo + ":preflight-rules"
+			config = ocipusher.WithArtifactConfig(oci.ArtifactConfig{
+				Name:    "rules1",
+				Version: "0.0.1",
+			})
+			_, err = pusher.Push(ctx, oci.Rulesfile, rulesRef, ocipusher.WithFilepaths([]string{rulesfiletgz}), config)
+			Expect(err).To(BeNil())
+
+			args = []string{artifactCmd, installCmd, pluginRef, rulesRef, "--plain-http", "--platform", "linux/amd64",
+				"--config", configFilePath, "--plugins-dir", baseDir, "--rulesfiles-dir", missingDir,
+				"--lock-file", baseDir + "/falcoctl.lock.yaml", "--resolve-deps=false"}
+		})
+
+		It("should fail before installing any artifact", func() {
+			Expect(err).To(HaveOccurred())
+			Expect(output).Should(gbytes.Say(regexp.QuoteMeta(
+				fmt.Sprintf("ERROR cannot use directory %q as install destination: %s doesn't exists", missingDir, missingDir))))
+			Expect(filepath.Join(baseDir, "libcloudtrail.so")).ToNot(BeAnExistingFile())
+			Expect(baseDir + "/falcoctl.lock.yaml").ToNot(BeAnExistingFile())
+		})
+	})
+	Context("locally modified files", func() {
+		var baseDir, plugin string
+
+		modified := []byte("locally modified")
+
+		BeforeEach(func() {
+			baseDir = GinkgoT().TempDir()
+			configFilePath := baseDir + "/config.yaml"
+			Expect(os.WriteFile(configFilePath, []byte("indexes: []\n"), 0o600)).To(Succeed())
+			plugin = filepath.Join(baseDir, "libcloudtrail.so")
+
+			// push plugin
+			pusher = ocipusher.NewPusher(authn.NewClient(authn.WithCredentials(&auth.EmptyCredential)), true, nil)
+			ref = registry + repo + ":modified"
+			config = ocipusher.WithArtifactConfig(oci.ArtifactConfig{
+				Name:    "plugin1",
+				Version: "0.0.1",
+			})
+			filePathsAndPlatforms := ocipusher.WithFilepathsAndPlatforms([]string{plugintgz}, []string{"linux/amd64"})
+			_, err := pusher.Push(ctx, oci.Plugin, ref, filePathsAndPlatforms, config)
+			Expect(err).To(BeNil())
+
+			args = []string{artifactCmd, installCmd, ref, "--plain-http", "--platform", "linux/amd64",
+				"--config", configFilePath, "--plugins-dir", baseDir, "--lock-file", baseDir + "/falcoctl.lock.yaml"}
+
+			// install the plugin a first time and modify it.
+			rootCmd = cmd.New(ctx, opt)
+			Expect(executeRoot(args)).To(Succeed())
+			Expect(os.WriteFile(plugin, modified, 0o600)).To(Succeed())
+		})
+
+		When("without --force", func() {
+			It("should keep the modified file and write the new version next to it", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(output).Should(gbytes.Say(regexp.QuoteMeta("WARN  Keeping locally modified file, use --force to overwrite it")))
+				Expect(os.ReadFile(plugin)).To(Equal(modified))
+				Expect(plugin + ".new").To(BeAnExistingFile())
+
+				lock, err := lockfile.New(baseDir + "/falcoctl.lock.yaml")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(lock.Artifacts).To(HaveLen(1))
+				Expect(lock.Artifacts[0].Files).To(ContainElements(plugin, plugin+".new"))
+				Expect(lock.Artifacts[0].Digests).To(HaveKey(plugin))
+			})
+		})
+
+		When("with --force", func() {
+			BeforeEach(func() {
+				args = append(args, "--force")
+			})
+
+			It("should overwrite the modified file", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(output).Should(gbytes.Say(regexp.QuoteMeta("WARN  Overwriting locally modified file")))
+				Expect(os.ReadFile(plugin)).ToNot(Equal(modified))
+				Expect(plugin + ".new").ToNot(BeAnExistingFile())
+			})
+		})
+	})
+
+	Context("only newer", func() {
+		var baseDir, lockFile string
+
+		BeforeEach(func() {
+			baseDir = GinkgoT().TempDir()
+			lockFile = baseDir + "/falcoctl.lock.yaml"
+			configFilePath := baseDir + "/config.yaml"
+			Expect(os.WriteFile(configFilePath, []byte("indexes: []\n"), 0o600)).To(Succeed())
+
+			// push plugin
+			pusher = ocipusher.NewPusher(authn.NewClient(authn.WithCredentials(&auth.EmptyCredential)), true, nil)
+			ref = registry + repo + ":only-newer"
+			config = ocipusher.WithArtifactConfig(oci.ArtifactConfig{
+				Name:    "plugin1",
+				Version: "0.0.1",
+			})
+			filePathsAndPlatforms := ocipusher.WithFilepathsAndPlatforms([]string{plugintgz}, []string{"linux/amd64"})
+			_, err := pusher.Push(ctx, oci.Plugin, ref, filePathsAndPlatforms, config)
+			Expect(err).To(BeNil())
+
+			args = []string{artifactCmd, installCmd, ref, "--plain-http", "--platform", "linux/amd64", "--config", configFilePath,
+				"--plugins-dir", baseDir, "--lock-file", lockFile, "--resolve-deps=false", "--only-newer"}
+
+			// install the plugin a first time.
+			rootCmd = cmd.New(ctx, opt)
+			Expect(executeRoot(args)).To(Succeed())
+			Expect(output.Clear()).To(Succeed())
+		})
+
+		When("the digest is unchanged", func() {
+			It("should skip the artifact as up to date", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(output).Should(gbytes.Say("Artifact up to date"))
+				Expect(output).ShouldNot(gbytes.Say("Artifact successfully installed"))
+				Expect(filepath.Join(baseDir, "libcloudtrail.so")).To(BeARegularFile())
+
+				lock, err := lockfile.New(lockFile)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(lock.Artifacts).To(HaveLen(1))
+			})
+		})
+
+		When("the installed files are missing", func() {
+			BeforeEach(func() {
+				Expect(os.Remove(filepath.Join(baseDir, "libcloudtrail.so"))).To(Succeed())
+			})
+
+			It("should install the artifact again", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(output).Should(gbytes.Say("Artifact successfully installed"))
+				Expect(filepath.Join(baseDir, "libcloudtrail.so")).To(BeARegularFile())
+			})
+		})
+
+		When("the digest changed", func() {
+			BeforeEach(func() {
+				config = ocipusher.WithArtifactConfig(oci.ArtifactConfig{
+					Name:    "plugin1",
+					Version: "0.0.2",
+				})
+				filePathsAndPlatforms := ocipusher.WithFilepathsAndPlatforms([]string{plugintgz}, []string{"linux/amd64"})
+				_, err := pusher.Push(ctx, oci.Plugin, ref, filePathsAndPlatforms, config)
+				Expect(err).To(BeNil())
+			})
+
+			It("should install the newer artifact", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(output).Should(gbytes.Say("Artifact successfully installed"))
+			})
+		})
+
+		When("with --force", func() {
+			BeforeEach(func() {
+				args = append(args, "--force")
+			})
+
+			It("should install the artifact again", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(output).ShouldNot(gbytes.Say("Artifact up to date"))
+				Expect(output).Should(gbytes.Say("Artifact successfully installed"))
+			})
+		})
+	})
+
+	Context("update all", func() {
+		var baseDir, lockFile, rulesRef string
+
+		BeforeEach(func() {
+			baseDir = GinkgoT().TempDir()
+			lockFile = baseDir + "/falcoctl.lock.yaml"
+			configFilePath := baseDir + "/config.yaml"
+			Expect(os.WriteFile(configFilePath, []byte("indexes: []\n"), 0o600)).To(Succeed())
+
+			// push a plugin and a rulesfile.
+			pusher = ocipusher.NewPusher(authn.NewClient(authn.WithCredentials(&auth.EmptyCredential)), true, nil)
+			ref = registry + repo + ":update-all"
+			config = ocipusher.WithArtifactConfig(oci.ArtifactConfig{
+				Name:    "plugin1",
+				Version: "0.0.1",
+			})
+			filePathsAndPlatforms := ocipusher.WithFilepathsAndPlatforms([]string{plugintgz}, []string{"linux/amd64"})
+			_, err := pusher.Push(ctx, oci.Plugin, ref, filePathsAndPlatforms, config)
+			Expect(err).To(BeNil())
+			rulesRef = registry + "/update-all-rules:latest"
+			_, err = pusher.Push(ctx, oci.Rulesfile, rulesRef, ocipusher.WithFilepaths([]string{rulesfiletgz}))
+			Expect(err).To(BeNil())
+
+			flags := []string{"--plain-http", "--platform", "linux/amd64", "--config", configFilePath,
+				"--plugins-dir", baseDir, "--rulesfiles-dir", baseDir, "--lock-file", lockFile, "--resolve-deps=false"}
+
+			// install the artifacts a first time.
+			rootCmd = cmd.New(ctx, opt)
+			Expect(executeRoot(append([]string{artifactCmd, installCmd, ref, rulesRef}, flags...))).To(Succeed())
+			Expect(output.Clear()).To(Succeed())
+
+			args = append([]string{artifactCmd, installCmd, "--update-all"}, flags...)
+		})
+
+		When("nothing changed", func() {
+			It("should skip all the artifacts as up to date", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(output).ShouldNot(gbytes.Say("Artifact updated"))
+				Expect(output).Should(gbytes.Say("Update completed"))
+			})
+		})
+
+		When("an artifact has a newer version", func() {
+			var previous string
+
+			BeforeEach(func() {
+				lock, err := lockfile.New(lockFile)
+				Expect(err).ToNot(HaveOccurred())
+				previous = lock.Get(filepath.Base(repo)).Digest
+
+				config = ocipusher.WithArtifactConfig(oci.ArtifactConfig{
+					Name:    "plugin1",
+					Version: "0.0.2",
+				})
+				filePathsAndPlatforms := ocipusher.WithFilepathsAndPlatforms([]string{plugintgz}, []string{"linux/amd64"})
+				_, err = pusher.Push(ctx, oci.Plugin, ref, filePathsAndPlatforms, config)
+				Expect(err).To(BeNil())
+			})
+
+			It("should only install the newer one and report it", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(output).Should(gbytes.Say("Artifact updated"))
+				Expect(output).Should(gbytes.Say(previous))
+				Expect(output).Should(gbytes.Say("Update completed"))
+
+				lock, err := lockfile.New(lockFile)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(lock.Artifacts).To(HaveLen(2))
+				Expect(lock.Get(filepath.Base(repo)).Digest).ToNot(Equal(previous))
+				Expect(lock.Get(filepath.Base(repo)).Ref).To(Equal(ref))
+			})
+		})
+
+		When("artifacts are passed as arguments", func() {
+			BeforeEach(func() {
+				args = append(args, ref)
+			})
+
+			It("should fail", func() {
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("cannot be passed as arguments"))
+			})
+		})
+	})
+
+	Context("versioned layout", func() {
+		var baseDir, lockFile string
+
+		pushRulesfile := func(version string) {
+			config = ocipusher.WithArtifactConfig(oci.ArtifactConfig{
+				Name:    "rules1",
+				Version: version,
+			})
+			_, err := pusher.Push(ctx, oci.Rulesfile, ref, ocipusher.WithFilepaths([]string{rulesfiletgz}), config)
+			Expect(err).To(BeNil())
+		}
+
+		BeforeEach(func() {
+			baseDir = GinkgoT().TempDir()
+			lockFile = filepath.Join(baseDir, "falcoctl.lock.yaml")
+			configFilePath := baseDir + "/config.yaml"
+			Expect(os.WriteFile(configFilePath, []byte("indexes: []\n"), 0o600)).To(Succeed())
+
+			pusher = ocipusher.NewPusher(authn.NewClient(authn.WithCredentials(&auth.EmptyCredential)), true, nil)
+			ref = registry + repo + ":versioned"
+			pushRulesfile("0.0.1")
+
+			args = []string{artifactCmd, installCmd, ref, "--plain-http", "--config", configFilePath,
+				"--rulesfiles-dir", baseDir, "--lock-file", lockFile, "--resolve-deps=false", "--versioned-layout"}
+		})
+
+		It("should install the rulesfile in the directory of its version and link it as the current one", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(filepath.Join(baseDir, artifact, "0.0.1", "aws_cloudtrail_rules.yaml")).To(BeARegularFile())
+			Expect(os.Readlink(filepath.Join(baseDir, artifact, "current"))).To(Equal("0.0.1"))
+			Expect(filepath.Join(baseDir, artifact, "current", "aws_cloudtrail_rules.yaml")).To(BeARegularFile())
+
+			lock, err := lockfile.New(lockFile)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(lock.Artifacts).To(HaveLen(1))
+			Expect(lock.Artifacts[0].VersionedDir).To(Equal(filepath.Join(baseDir, artifact)))
+		})
+
+		When("a newer version is installed", func() {
+			BeforeEach(func() {
+				rootCmd = cmd.New(ctx, opt)
+				Expect(executeRoot(args)).To(Succeed())
+				Expect(output.Clear()).To(Succeed())
+				pushRulesfile("0.0.2")
+			})
+
+			It("should keep the previous version and link the newer one as the current one", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(filepath.Join(baseDir, artifact, "0.0.1", "aws_cloudtrail_rules.yaml")).To(BeARegularFile())
+				Expect(filepath.Join(baseDir, artifact, "0.0.2", "aws_cloudtrail_rules.yaml")).To(BeARegularFile())
+				Expect(os.Readlink(filepath.Join(baseDir, artifact, "current"))).To(Equal("0.0.2"))
+			})
+		})
+	})
+
+	Context("unknown artifact type", func() {
+		var destDir string
+
+		BeforeEach(func() {
+			baseDir := GinkgoT().TempDir()
+			configFilePath := baseDir + "/config.yaml"
+			Expect(os.WriteFile(configFilePath, []byte("indexes: []\n"), 0o600)).To(Succeed())
+			destDir = GinkgoT().TempDir()
+
+			// push an artifact with generic media types, as done by tools unaware of the falcosecurity ones.
+			ref = registry + repo + ":unknown"
+			store := memory.New()
+			layer := content.NewDescriptorFromBytes("application/octet-stream", []byte("not a falcosecurity artifact"))
+			Expect(store.Push(ctx, layer, bytes.NewReader([]byte("not a falcosecurity artifact")))).To(Succeed())
+			manifest, err := oras.PackManifest(ctx, store, oras.PackManifestVersion1_1, "application/vnd.unknown.artifact.v1",
+				oras.PackManifestOptions{Layers: []v1.Descriptor{layer}})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(store.Tag(ctx, manifest, "unknown")).To(Succeed())
+			repository, err := remote.NewRepository(registry + repo)
+			Expect(err).ToNot(HaveOccurred())
+			repository.PlainHTTP = true
+			_, err = oras.Copy(ctx, store, "unknown", repository, "unknown", oras.DefaultCopyOptions)
+			Expect(err).ToNot(HaveOccurred())
+
+			args = []string{artifactCmd, installCmd, ref, "--plain-http", "--config", configFilePath,
+				"--plugins-dir", destDir, "--rulesfiles-dir", destDir, "--assets-dir", destDir,
+				"--lock-file", baseDir + "/falcoctl.lock.yaml", "--resolve-deps=false"}
+		})
+
+		It("should fail naming the media types without writing any file", func() {
+			Expect(err).To(HaveOccurred())
+			Expect(output).Should(gbytes.Say(regexp.QuoteMeta("unknown artifact type: neither config media type " +
+				"\"application/vnd.unknown.artifact.v1\" nor layer media type \"application/octet-stream\" is a falcosecurity one")))
+			Expect(os.ReadDir(destDir)).To(BeEmpty())
+		})
+	})
+
+	Context("duplicate references", func() {
+		var baseDir string
+
+		BeforeEach(func() {
+			baseDir = GinkgoT().TempDir()
+			configFilePath := baseDir + "/config.yaml"
+			Expect(os.WriteFile(configFilePath, []byte("indexes: []\n"), 0o600)).To(Succeed())
+
+			// push plugin
+			pusher = ocipusher.NewPusher(authn.NewClient(authn.WithCredentials(&auth.EmptyCredential)), true, nil)
+			ref = registry + repo + ":dedup"
+			config = ocipusher.WithArtifactConfig(oci.ArtifactConfig{
+				Name:    "plugin1",
+				Version: "0.0.1",
+			})
+			filePathsAndPlatforms := ocipusher.WithFilepathsAndPlatforms([]string{plugintgz}, []string{"linux/amd64"})
+			pushed, err := pusher.Push(ctx, oci.Plugin, ref, filePathsAndPlatforms, config)
+			Expect(err).To(BeNil())
+			args = []string{artifactCmd, installCmd, ref, ref, registry + repo + "@" + pushed.RootDigest,
+				"--plain-http", "--platform", "linux/amd64", "--config", configFilePath, "--plugins-dir", baseDir,
+				"--lock-file", baseDir + "/falcoctl.lock.yaml", "--resolve-deps=false"}
+		})
+
+		It("should install the artifact once", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(strings.Count(string(output.Contents()), "Skipping duplicate artifact")).To(Equal(2))
+			Expect(strings.Count(string(output.Contents()), "Artifact successfully installed")).To(Equal(1))
+			Expect(filepath.Join(baseDir, "libcloudtrail.so")).To(BeARegularFile())
+
+			lock, err := lockfile.New(baseDir + "/falcoctl.lock.yaml")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(lock.Artifacts).To(HaveLen(1))
+			Expect(lock.Artifacts[0].Ref).To(Equal(ref))
+		})
+	})
+
+	Context("parallel install", func() {
+		var (
+			baseDir    string
+			jsonOutput *bytes.Buffer
+			refs       []string
+		)
+
+		BeforeEach(func() {
+			baseDir = GinkgoT().TempDir()
+			configFilePath := baseDir + "/config.yaml"
+			Expect(os.WriteFile(configFilePath, []byte("indexes: []\n"), 0o600)).To(Succeed())
+			jsonOutput = &bytes.Buffer{}
+			opt.Initialize(commonoptions.WithOutputWriter(jsonOutput))
+
+			// push a plugin and a rulesfile, installed along with two missing artifacts.
+			pusher = ocipusher.NewPusher(authn.NewClient(authn.WithCredentials(&auth.EmptyCredential)), true, nil)
+			ref = registry + repo + ":parallel"
+			config = ocipusher.WithArtifactConfig(oci.ArtifactConfig{
+				Name:    "plugin1",
+				Version: "0.0.1",
+			})
+			filePathsAndPlatforms := ocipusher.WithFilepathsAndPlatforms([]string{plugintgz}, []string{"linux/amd64"})
+			_, err := pusher.Push(ctx, oci.Plugin, ref, filePathsAndPlatforms, config)
+			Expect(err).To(BeNil())
+			rulesRef := registry + "/parallel-rules:latest"
+			_, err = pusher.Push(ctx, oci.Rulesfile, rulesRef, ocipusher.WithFilepaths([]string{rulesfiletgz}))
+			Expect(err).To(BeNil())
+
+			refs = []string{registry + "/parallel-missing-one:latest", ref, registry + "/parallel-missing-two:latest", rulesRef}
+			args = append([]string{artifactCmd, installCmd}, refs...)
+			args = append(args, "--plain-http", "--platform", "linux/amd64", "--config", configFilePath,
+				"--plugins-dir", baseDir, "--rulesfiles-dir", baseDir, "--lock-file", baseDir+"/falcoctl.lock.yaml",
+				"--resolve-deps=false", "--max-retries", "0", "--parallelism", "4", "--output", "json")
+		})
+
+		AfterEach(func() {
+			// The flag is bound to the shared options, restore its default for the other tests.
+			Expect(rootCmd.PersistentFlags().Set("output", "text")).To(Succeed())
+		})
+
+		It("should install the other artifacts and report all the failures, in the order of the arguments", func() {
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("parallel-missing-one"))
+			Expect(err.Error()).To(ContainSubstring("parallel-missing-two"))
+
+			var results []map[string]string
+			Expect(json.Unmarshal(jsonOutput.Bytes(), &results)).To(Succeed())
+			Expect(results).To(HaveLen(len(refs)))
+			for i, status := range []string{"failed", "installed", "failed", "installed"} {
+				Expect(results[i]).To(HaveKeyWithValue("ref", refs[i]))
+				Expect(results[i]).To(HaveKeyWithValue("status", status))
+			}
+			Expect(results[0]).To(HaveKey("error"))
+			Expect(results[2]).To(HaveKey("error"))
+
+			Expect(filepath.Join(baseDir, "libcloudtrail.so")).To(BeARegularFile())
+			Expect(filepath.Join(baseDir, "aws_cloudtrail_rules.yaml")).To(BeARegularFile())
+			lock, err := lockfile.New(baseDir + "/falcoctl.lock.yaml")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(lock.Artifacts).To(HaveLen(2))
+			Expect(output).Should(gbytes.Say(regexp.QuoteMeta("4/4")))
+		})
+	})
+
+	Context("install timeout", func() {
+		var baseDir string
+
+		BeforeEach(func() {
+			baseDir = GinkgoT().TempDir()
+			configFilePath := baseDir + "/config.yaml"
+			Expect(os.WriteFile(configFilePath, []byte("indexes: []\n"), 0o600)).To(Succeed())
+			args = []string{artifactCmd, installCmd, registry + repo + ":latest", "--plain-http", "--config", configFilePath,
+				"--plugins-dir", baseDir, "--lock-file", baseDir + "/falcoctl.lock.yaml", "--resolve-deps=false",
+				"--install-timeout", "1ns"}
+		})
+
+		It("should fail telling the install did not complete in time", func() {
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(HavePrefix("the install did not complete within the --install-timeout of 1ns: "))
+			Expect(errors.Is(err, context.DeadlineExceeded)).To(BeTrue())
+			Expect(filepath.Join(baseDir, "falcoctl.lock.yaml")).ToNot(BeAnExistingFile())
+		})
+	})
+
+	Context("completion", func() {
+		BeforeEach(func() {
+			baseDir := GinkgoT().TempDir()
+			configFilePath := baseDir + "/config.yaml"
+			Expect(os.WriteFile(configFilePath, []byte("indexes:\n  - name: test\n    url: http://localhost:1/index.yaml\n"),
+				0o600)).To(Succeed())
+			Expect(os.MkdirAll(filepath.Join(baseDir, "indexes"), 0o700)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(baseDir, "indexes", "test.yaml"), []byte(`- name: cloudtrail
+  type: plugin
+  registry: ghcr.io
+  repository: falcosecurity/plugins/plugin/cloudtrail
+- name: cloudtrail-rules
+  type: rulesfile
+  registry: ghcr.io
+  repository: falcosecurity/plugins/ruleset/cloudtrail
+- name: k8saudit
+  type: plugin
+  registry: ghcr.io
+  repository: falcosecurity/plugins/plugin/k8saudit
+`), 0o600)).To(Succeed())
+			args = []string{"__complete", artifactCmd, installCmd, "--config", configFilePath, "--falcoctl-dir", baseDir,
+				"cloudtrail", "cloud"}
+		})
+
+		It("should suggest the names of the indexed artifacts not given yet", func() {
+			Expect(err).To(BeNil())
+			Expect(string(output.Contents())).To(HavePrefix("cloudtrail-rules\n:4\n"))
+		})
+	})
+
+	Context("local archive", func() {
+		var baseDir, lockPath string
+
+		BeforeEach(func() {
+			baseDir = GinkgoT().TempDir()
+			lockPath = filepath.Join(baseDir, "falcoctl.lock.yaml")
+			configFilePath := baseDir + "/config.yaml"
+			Expect(os.WriteFile(configFilePath, []byte("indexes: []\n"), 0o600)).To(Succeed())
+			args = []string{artifactCmd, installCmd, rulesfiletgz, "--config", configFilePath,
+				"--rulesfiles-dir", baseDir, "--lock-file", lockPath}
+		})
+
+		It("should install the archive and record its path and digest in the lockfile", func() {
+			Expect(err).To(BeNil())
+			Expect(filepath.Join(baseDir, "aws_cloudtrail_rules.yaml")).To(BeARegularFile())
+
+			source, err := filepath.Abs(rulesfiletgz)
+			Expect(err).To(BeNil())
+			digest, err := utils.FileDigest(rulesfiletgz)
+			Expect(err).To(BeNil())
+			lock, err := lockfile.New(lockPath)
+			Expect(err).To(BeNil())
+			Expect(lock.Artifacts).To(HaveLen(1))
+			Expect(lock.Artifacts[0].Name).To(Equal("rules"))
+			Expect(lock.Artifacts[0].Ref).To(Equal(source))
+			Expect(lock.Artifacts[0].Digest).To(Equal(digest))
+			Expect(lock.Artifacts[0].Type).To(Equal(oci.Rulesfile.String()))
+		})
+	})
+
+	Context("validate", func() {
+		var baseDir, rulesDir, falcoBin, falcoArgs string
+
+		// writeFalco writes a fake falco binary recording its arguments and exiting with the given code.
+		writeFalco := func(code int) {
+			script := fmt.Sprintf("#!/bin/sh\necho \"$@\" > %s\necho \"invalid rule\"\nexit %d\n", falcoArgs, code)
+			Expect(os.WriteFile(falcoBin, []byte(script), 0o700)).To(Succeed())
+		}
+
+		BeforeEach(func() {
+			baseDir = GinkgoT().TempDir()
+			rulesDir = filepath.Join(baseDir, "rules")
+			Expect(os.Mkdir(rulesDir, 0o755)).To(Succeed())
+			falcoBin = filepath.Join(baseDir, "falco")
+			falcoArgs = filepath.Join(baseDir, "falco-args")
+			configFilePath := baseDir + "/config.yaml"
+			Expect(os.WriteFile(configFilePath, []byte("indexes: []\n"), 0o600)).To(Succeed())
+			args = []string{artifactCmd, installCmd, rulesfiletgz, "--config", configFilePath, "--rulesfiles-dir", rulesDir,
+				"--lock-file", filepath.Join(baseDir, "falcoctl.lock.yaml"), "--validate", "--falco-bin", falcoBin}
+		})
+
+		When("the rulesfile is valid", func() {
+			BeforeEach(func() {
+				writeFalco(0)
+			})
+
+			It("should validate the rulesfile and install it", func() {
+				Expect(err).To(BeNil())
+				Expect(output).Should(gbytes.Say("Rulesfiles successfully validated"))
+				Expect(filepath.Join(rulesDir, "aws_cloudtrail_rules.yaml")).To(BeARegularFile())
+				validated, err := os.ReadFile(falcoArgs)
+				Expect(err).To(BeNil())
+				Expect(string(validated)).To(HavePrefix("--validate "))
+				Expect(string(validated)).To(ContainSubstring("aws_cloudtrail_rules.yaml"))
+			})
+		})
+
+		When("the rulesfile is not valid", func() {
+			BeforeEach(func() {
+				writeFalco(1)
+			})
+
+			It("should fail without installing anything", func() {
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("did not pass the validation"))
+				Expect(err.Error()).To(ContainSubstring("invalid rule"))
+				entries, err := os.ReadDir(rulesDir)
+				Expect(err).To(BeNil())
+				Expect(entries).To(BeEmpty())
+			})
+		})
+	})
+
+	Context("path expansion", func() {
+		var home, configFilePath string
+
+		BeforeEach(func() {
+			home = GinkgoT().TempDir()
+			// Restore HOME as it was, since it is not necessarily set.
+			if prev, ok := os.LookupEnv("HOME"); ok {
+				DeferCleanup(os.Setenv, "HOME", prev)
+			} else {
+				DeferCleanup(os.Unsetenv, "HOME")
+			}
+			Expect(os.Setenv("HOME", home)).To(Succeed())
+			Expect(os.Mkdir(filepath.Join(home, "rules"), 0o755)).To(Succeed())
+			configFilePath = home + "/config.yaml"
+			Expect(os.WriteFile(configFilePath, []byte("indexes: []\n"), 0o600)).To(Succeed())
+			args = []string{artifactCmd, installCmd, rulesfiletgz, "--config", configFilePath,
+				"--lock-file", "~/falcoctl.lock.yaml"}
+		})
+
+		When("the directory starts with a tilde", func() {
+			BeforeEach(func() {
+				args = append(args, "--rulesfiles-dir", "~/rules")
+			})
+
+			It("should install in the home directory", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(filepath.Join(home, "rules", "aws_cloudtrail_rules.yaml")).To(BeARegularFile())
+				Expect(filepath.Join(home, "falcoctl.lock.yaml")).To(BeARegularFile())
+				Expect("~").ToNot(BeAnExistingFile())
+			})
+		})
+
+		When("the directory holds environment variables", func() {
+			BeforeEach(func() {
+				Expect(os.Setenv("FALCOCTL_TEST_RULES", "rules")).To(Succeed())
+				DeferCleanup(os.Unsetenv, "FALCOCTL_TEST_RULES")
+				args = append(args, "--rulesfiles-dir", "$HOME/${FALCOCTL_TEST_RULES}")
+			})
+
+			It("should expand them", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(filepath.Join(home, "rules", "aws_cloudtrail_rules.yaml")).To(BeARegularFile())
+			})
+		})
+
+		When("the directory is configured with a tilde", func() {
+			BeforeEach(func() {
+				Expect(os.WriteFile(configFilePath, []byte("indexes: []\nartifact:\n  install:\n    rulesfilesDir: ~/rules\n"),
+					0o600)).To(Succeed())
+			})
+
+			It("should install in the home directory", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(filepath.Join(home, "rules", "aws_cloudtrail_rules.yaml")).To(BeARegularFile())
+			})
+		})
+	})
+
+	Context("maximum size", func() {
+		var baseDir string
+
+		BeforeEach(func() {
+			baseDir = GinkgoT().TempDir()
+			configFilePath := baseDir + "/config.yaml"
+			Expect(os.WriteFile(configFilePath, []byte("indexes: []\n"), 0o600)).To(Succeed())
+			args = []string{artifactCmd, installCmd, rulesfiletgz, "--config", configFilePath,
+				"--rulesfiles-dir", baseDir, "--lock-file", baseDir + "/falcoctl.lock.yaml", "--max-size", "1KiB"}
+		})
+
+		It("should fail reporting the extraction limit, without installing anything", func() {
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("maximum extracted size of 1024 bytes"))
+			Expect(err.Error()).To(ContainSubstring("--max-size"))
+			Expect(filepath.Join(baseDir, "aws_cloudtrail_rules.yaml")).ToNot(BeAnExistingFile())
+		})
+	})
+
+	Context("exclude", func() {
+		var baseDir string
+
+		BeforeEach(func() {
+			baseDir = GinkgoT().TempDir()
+			configFilePath := baseDir + "/config.yaml"
+			Expect(os.WriteFile(configFilePath, []byte("indexes: []\n"), 0o600)).To(Succeed())
+
+			// push plugin
+			pusher = ocipusher.NewPusher(authn.NewClient(authn.WithCredentials(&auth.EmptyCredential)), true, nil)
+			ref = registry + repo + ":exclude"
+			config = ocipusher.WithArtifactConfig(oci.ArtifactConfig{
+				Name:    "plugin1",
+				Version: "0.0.1",
+			})
+			filePathsAndPlatforms := ocipusher.WithFilepathsAndPlatforms([]string{plugintgz}, []string{"linux/amd64"})
+			_, err := pusher.Push(ctx, oci.Plugin, ref, filePathsAndPlatforms, config)
+			Expect(err).To(BeNil())
+			args = []string{artifactCmd, installCmd, ref, "--plain-http", "--platform", "linux/amd64",
+				"--config", configFilePath, "--plugins-dir", baseDir, "--lock-file", baseDir + "/falcoctl.lock.yaml",
+				"--resolve-deps=false", "--exclude", "*.md", "--log-level", "debug"}
+		})
+
+		AfterEach(func() {
+			// The flag is bound to the shared options, restore its default for the other tests.
+			Expect(rootCmd.PersistentFlags().Set("log-level", "info")).To(Succeed())
+		})
+
+		It("should skip the matching files, logging them", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(filepath.Join(baseDir, "libcloudtrail.so")).To(BeARegularFile())
+			Expect(filepath.Join(baseDir, "README.md")).ToNot(BeAnExistingFile())
+			Expect(output).Should(gbytes.Say("Skipping excluded file"))
+
+			lock, err := lockfile.New(baseDir + "/falcoctl.lock.yaml")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(lock.Artifacts).To(HaveLen(1))
+			Expect(lock.Artifacts[0].Files).To(ConsistOf(filepath.Join(baseDir, "libcloudtrail.so")))
+		})
+	})
+
+	Context("from stdin", func() {
+		var baseDir, configFilePath string
+
+		BeforeEach(func() {
+			baseDir = GinkgoT().TempDir()
+			configFilePath = baseDir + "/config.yaml"
+			Expect(os.WriteFile(configFilePath, []byte("indexes: []\n"), 0o600)).To(Succeed())
+
+			// Pipe the archive to the standard input of the command.
+			f, err := os.Open(rulesfiletgz)
+			Expect(err).ToNot(HaveOccurred())
+			stdin := os.Stdin
+			os.Stdin = f
+			DeferCleanup(func() {
+				os.Stdin = stdin
+				Expect(f.Close()).To(Succeed())
+			})
+		})
+
+		When("the type is given", func() {
+			BeforeEach(func() {
+				args = []string{artifactCmd, installCmd, "--from-stdin", "--source-type", "rulesfile", "--config", configFilePath,
+					"--rulesfiles-dir", baseDir, "--lock-file", baseDir + "/falcoctl.lock.yaml"}
+			})
+
+			It("should extract the archive into the destination without recording it in the lockfile", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(output).Should(gbytes.Say("Extracting and installing artifact from the standard input"))
+				Expect(filepath.Join(baseDir, "aws_cloudtrail_rules.yaml")).To(BeARegularFile())
+				Expect(baseDir + "/falcoctl.lock.yaml").ToNot(BeAnExistingFile())
+			})
+		})
+
+		When("the type is not given", func() {
+			BeforeEach(func() {
+				args = []string{artifactCmd, installCmd, "--from-stdin", "--config", configFilePath,
+					"--rulesfiles-dir", baseDir, "--lock-file", baseDir + "/falcoctl.lock.yaml"}
+			})
+
+			It("should fail before reading anything", func() {
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("--from-stdin needs the type of the piped artifact, set through --source-type"))
+				Expect(filepath.Join(baseDir, "aws_cloudtrail_rules.yaml")).ToNot(BeAnExistingFile())
+			})
+		})
+
+		When("artifacts are passed as arguments", func() {
+			BeforeEach(func() {
+				args = []string{artifactCmd, installCmd, "rules", "--from-stdin", "--source-type", "rulesfile", "--config", configFilePath,
+					"--rulesfiles-dir", baseDir}
+			})
+
+			It("should fail", func() {
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("artifacts cannot be passed as arguments when installing from the standard input"))
+			})
+		})
+	})
+
+	Context("concurrent installs", func() {
+		var baseDir string
+
+		BeforeEach(func() {
+			baseDir = GinkgoT().TempDir()
+			configFilePath := baseDir + "/config.yaml"
+			Expect(os.WriteFile(configFilePath, []byte("indexes: []\n"), 0o600)).To(Succeed())
+
+			// Hold the lock as another install running on the node would.
+			unlock, err := utils.LockFile(ctx, falcoctlconfig.InstallLockFile, 0)
+			Expect(err).ToNot(HaveOccurred())
+			DeferCleanup(unlock)
+			args = []string{artifactCmd, installCmd, rulesfiletgz, "--config", configFilePath, "--rulesfiles-dir", baseDir,
+				"--lock-file", filepath.Join(baseDir, "falcoctl.lock.yaml"), "--lock-timeout", "0"}
+		})
+
+		It("should fail without installing anything while another install holds the lock", func() {
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("another install is running on the node"))
+			Expect(err.Error()).To(ContainSubstring("--lock-timeout of 0s"))
+			Expect(filepath.Join(baseDir, "aws_cloudtrail_rules.yaml")).ToNot(BeAnExistingFile())
+		})
+
+		When("the arguments are invalid", func() {
+			BeforeEach(func() {
+				args = append(args, "--update-all", "--lock-timeout", "1m")
+			})
+
+			It("should fail on the arguments without waiting for the lock", func() {
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("artifacts cannot be passed as arguments when updating all the installed ones"))
+			})
+		})
+	})
+
+	Context("from file", func() {
+		var baseDir, configFilePath, listPath string
+
+		BeforeEach(func() {
+			baseDir = GinkgoT().TempDir()
+			configFilePath = baseDir + "/config.yaml"
+			listPath = filepath.Join(baseDir, "artifacts.txt")
+			Expect(os.WriteFile(configFilePath, []byte("indexes: []\n"), 0o600)).To(Succeed())
+			Expect(os.WriteFile(listPath, []byte("# rules of the tests\n"+rulesfiletgz+"\n"), 0o600)).To(Succeed())
+		})
+
+		When("the file lists an artifact", func() {
+			BeforeEach(func() {
+				args = []string{artifactCmd, installCmd, "--from-file", listPath, "--config", configFilePath,
+					"--rulesfiles-dir", baseDir, "--lock-file", filepath.Join(baseDir, "falcoctl.lock.yaml")}
+			})
+
+			It("should install the listed artifact", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(filepath.Join(baseDir, "aws_cloudtrail_rules.yaml")).To(BeARegularFile())
+			})
+		})
+
+		When("the file does not list any artifact", func() {
+			BeforeEach(func() {
+				Expect(os.WriteFile(listPath, []byte("# nothing to install\n"), 0o600)).To(Succeed())
+				args = []string{artifactCmd, installCmd, "--from-file", listPath, "--config", configFilePath,
+					"--rulesfiles-dir", baseDir}
+			})
+
+			It("should fail", func() {
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("no artifacts to install found in artifacts file"))
+			})
+		})
+
+		When("used with --from-lock", func() {
+			BeforeEach(func() {
+				args = []string{artifactCmd, installCmd, "--from-file", listPath, "--from-lock", filepath.Join(baseDir, "falcoctl.lock.yaml"),
+					"--config", configFilePath, "--rulesfiles-dir", baseDir}
+			})
+
+			It("should fail", func() {
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("--from-file cannot be used with --from-lock, --update-all or --from-stdin"))
+			})
+		})
+	})
+
+	Context("installed files", func() {
+		var baseDir string
+
+		BeforeEach(func() {
+			baseDir = GinkgoT().TempDir()
+			configFilePath := baseDir + "/config.yaml"
+			Expect(os.WriteFile(configFilePath, []byte("indexes: []\n"), 0o600)).To(Succeed())
+			args = []string{artifactCmd, installCmd, rulesfiletgz, "--config", configFilePath,
+				"--rulesfiles-dir", baseDir, "--lock-file", baseDir + "/falcoctl.lock.yaml", "--log-level", "debug"}
+		})
+
+		AfterEach(func() {
+			// The flag is bound to the shared options, restore its default for the other tests.
+			Expect(rootCmd.PersistentFlags().Set("log-level", "info")).To(Succeed())
+		})
+
+		It("should log each installed file with the digest recorded in the lockfile", func() {
+			Expect(err).ToNot(HaveOccurred())
+			path := filepath.Join(baseDir, "aws_cloudtrail_rules.yaml")
+			digest, err := utils.FileDigest(path)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(output).Should(gbytes.Say("Installed file"))
+			Expect(output).Should(gbytes.Say(regexp.QuoteMeta(path)))
+			Expect(output).Should(gbytes.Say(regexp.QuoteMeta(digest)))
+
+			lock, err := lockfile.New(baseDir + "/falcoctl.lock.yaml")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(lock.Artifacts).To(HaveLen(1))
+			Expect(lock.Artifacts[0].Digests).To(Equal(map[string]string{path: digest}))
+		})
+	})
+
+	Context("strip components", func() {
+		var baseDir string
+
+		BeforeEach(func() {
+			baseDir = GinkgoT().TempDir()
+			configFilePath := baseDir + "/config.yaml"
+			Expect(os.WriteFile(configFilePath, []byte("indexes: []\n"), 0o600)).To(Succeed())
+
+			// Write a rulesfile archive burying its files under a top-level directory.
+			archive := filepath.Join(GinkgoT().TempDir(), "nested.tar.gz")
+			f, err := os.Create(archive)
+			Expect(err).ToNot(HaveOccurred())
+			gz := gzip.NewWriter(f)
+			tw := tar.NewWriter(gz)
+			for _, e := range []struct{ name, content string }{
+				{name: "README.md", content: "readme"},
+				{name: "rules-1.0.0/"},
+				{name: "rules-1.0.0/rules.yaml", content: "- rule: test"},
+				{name: "rules-1.0.0/nested/"},
+				{name: "rules-1.0.0/nested/other.yaml", content: "- rule: other"},
+			} {
+				header := &tar.Header{Name: e.name, Typeflag: tar.TypeReg, Mode: 0o644, Size: int64(len(e.content))}
+				if strings.HasSuffix(e.name, "/") {
+					header.Typeflag, header.Mode = tar.TypeDir, 0o755
+				}
+				Expect(tw.WriteHeader(header)).To(Succeed())
+				_, err = tw.Write([]byte(e.content))
+				Expect(err).ToNot(HaveOccurred())
+			}
+			Expect(tw.Close()).To(Succeed())
+			Expect(gz.Close()).To(Succeed())
+			Expect(f.Close()).To(Succeed())
+
+			args = []string{artifactCmd, installCmd, archive, "--config", configFilePath,
+				"--rulesfiles-dir", baseDir, "--lock-file", baseDir + "/falcoctl.lock.yaml", "--strip-components", "1"}
+		})
+
+		It("should strip the top-level directory, skipping the files with fewer components", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(filepath.Join(baseDir, "rules.yaml")).To(BeARegularFile())
+			Expect(filepath.Join(baseDir, "nested", "other.yaml")).To(BeARegularFile())
+			Expect(filepath.Join(baseDir, "rules-1.0.0")).ToNot(BeAnExistingFile())
+			Expect(filepath.Join(baseDir, "README.md")).ToNot(BeAnExistingFile())
+		})
+	})
+
+	Context("all platforms", func() {
+		var baseDir, lockFile string
+
+		BeforeEach(func() {
+			baseDir = GinkgoT().TempDir()
+			lockFile = baseDir + "/falcoctl.lock.yaml"
+			configFilePath := baseDir + "/config.yaml"
+			Expect(os.WriteFile(configFilePath, []byte("indexes: []\n"), 0o600)).To(Succeed())
+			args = []string{artifactCmd, installCmd, "--plain-http", "--all-platforms", "--config", configFilePath,
+				"--plugins-dir", baseDir, "--rulesfiles-dir", baseDir, "--lock-file", lockFile, "--resolve-deps=false"}
+			pusher = ocipusher.NewPusher(authn.NewClient(authn.WithCredentials(&auth.EmptyCredential)), true, nil)
+		})
+
+		When("the artifact has multiple platforms", func() {
+			BeforeEach(func() {
+				ref = registry + repo + ":multiarch"
+				config = ocipusher.WithArtifactConfig(oci.ArtifactConfig{
+					Name:    "plugin1",
+					Version: "0.0.1",
+				})
+				filePathsAndPlatforms := ocipusher.WithFilepathsAndPlatforms([]string{plugintgz, plugintgz},
+					[]string{"linux/amd64", "linux/arm64"})
+				_, err := pusher.Push(ctx, oci.Plugin, ref, filePathsAndPlatforms, config)
+				Expect(err).To(BeNil())
+				args = append(args, ref)
+			})
+
+			It("should install each platform in its own subdirectory, recording them in a single lockfile entry", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(filepath.Join(baseDir, "linux-amd64", "libcloudtrail.so")).To(BeARegularFile())
+				Expect(filepath.Join(baseDir, "linux-arm64", "libcloudtrail.so")).To(BeARegularFile())
+				Expect(filepath.Join(baseDir, "libcloudtrail.so")).ToNot(BeAnExistingFile())
+
+				lock, err := lockfile.New(lockFile)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(lock.Artifacts).To(HaveLen(1))
+				Expect(lock.Artifacts[0].Platform).To(Equal("linux/amd64,linux/arm64"))
+				Expect(lock.Artifacts[0].Files).To(ContainElements(filepath.Join(baseDir, "linux-amd64", "libcloudtrail.so"),
+					filepath.Join(baseDir, "linux-arm64", "libcloudtrail.so")))
+			})
+		})
+
+		When("the artifact has a single platform", func() {
+			BeforeEach(func() {
+				ref = registry + repo + ":singlearch"
+				config = ocipusher.WithArtifactConfig(oci.ArtifactConfig{
+					Name:    "rules1",
+					Version: "0.0.1",
+				})
+				_, err := pusher.Push(ctx, oci.Rulesfile, ref, ocipusher.WithFilepaths([]string{rulesfiletgz}), config)
+				Expect(err).To(BeNil())
+				args = append(args, ref)
+			})
+
+			It("should fail before installing anything", func() {
+				Expect(err).To(MatchError(fmt.Sprintf("cannot install %q for all the platforms with --all-platforms: "+
+					"it is a single-platform artifact, without an image index listing its platforms", ref)))
+				Expect(os.ReadDir(baseDir)).To(HaveLen(1))
+			})
+		})
+	})
+
+	Context("no indexes configured", func() {
+		var baseDir string
+
+		BeforeEach(func() {
+			baseDir = GinkgoT().TempDir()
+			configFilePath := baseDir + "/config.yaml"
+			Expect(os.WriteFile(configFilePath, []byte("indexes: []\n"), 0o600)).To(Succeed())
+			args = []string{artifactCmd, installCmd, registry + repo + ":latest", "cloudtrail", "k8saudit@^0.6.0",
+				"--plain-http", "--config", configFilePath, "--plugins-dir", baseDir, "--lock-file", baseDir + "/falcoctl.lock.yaml"}
+		})
+
+		It("should fail telling which artifacts cannot be resolved, before pulling anything", func() {
+			Expect(err).To(MatchError(`no indexes are configured to resolve cloudtrail, k8saudit@^0.6.0, please add an index with ` +
+				`"falcoctl index add" or pass the full references of the artifacts, e.g. "ghcr.io/falcosecurity/plugins/plugin/cloudtrail:latest"`))
+			Expect(os.ReadDir(baseDir)).To(HaveLen(1))
+		})
+	})
+
+	Context("digest pinned references", func() {
+		var (
+			baseDir string
+			pushed  *oci.RegistryResult
+		)
+
+		BeforeEach(func() {
+			baseDir = GinkgoT().TempDir()
+			configFilePath := baseDir + "/config.yaml"
+			Expect(os.WriteFile(configFilePath, []byte("indexes: []\n"), 0o600)).To(Succeed())
+
+			// push plugin
+			pusher = ocipusher.NewPusher(authn.NewClient(authn.WithCredentials(&auth.EmptyCredential)), true, nil)
+			ref = registry + repo + ":pinned"
+			config = ocipusher.WithArtifactConfig(oci.ArtifactConfig{
+				Name:    "plugin1",
+				Version: "0.0.1",
+			})
+			filePathsAndPlatforms := ocipusher.WithFilepathsAndPlatforms([]string{plugintgz}, []string{"linux/amd64"})
+			var err error
+			pushed, err = pusher.Push(ctx, oci.Plugin, ref, filePathsAndPlatforms, config)
+			Expect(err).To(BeNil())
+			args = []string{artifactCmd, installCmd, "--plain-http", "--platform", "linux/amd64",
+				"--config", configFilePath, "--plugins-dir", baseDir, "--lock-file", baseDir + "/falcoctl.lock.yaml",
+				"--resolve-deps=false"}
+		})
+
+		When("installing from a tag", func() {
+			BeforeEach(func() {
+				args = append(args, ref)
+			})
+
+			It("should warn with the digest the tag resolved to", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(output).Should(gbytes.Say(regexp.QuoteMeta("Installing from a mutable tag")))
+				Expect(output).Should(gbytes.Say(regexp.QuoteMeta(pushed.RootDigest)))
+				Expect(filepath.Join(baseDir, "libcloudtrail.so")).To(BeARegularFile())
+			})
+		})
+
+		When("installing from a tag with --require-digest", func() {
+			BeforeEach(func() {
+				args = append(args, ref, "--require-digest")
+			})
+
+			It("should fail without installing anything", func() {
+				Expect(err).To(HaveOccurred())
+				Expect(err).To(MatchError(fmt.Sprintf("--require-digest is set, but the following references are not pinned to a digest: %s",
+					ref)))
+				Expect(filepath.Join(baseDir, "libcloudtrail.so")).ToNot(BeAnExistingFile())
+			})
+		})
+
+		When("installing from a digest with --require-digest", func() {
+			BeforeEach(func() {
+				args = append(args, registry+repo+"@"+pushed.RootDigest, "--require-digest")
+			})
+
+			It("should install the artifact without warning", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(output.Contents())).ToNot(ContainSubstring("Installing from a mutable tag"))
+				Expect(filepath.Join(baseDir, "libcloudtrail.so")).To(BeARegularFile())
+			})
+		})
+	})
+
+	Context("json logs", func() {
+		var baseDir string
+
+		BeforeEach(func() {
+			baseDir = GinkgoT().TempDir()
+			configFilePath := baseDir + "/config.yaml"
+			Expect(os.WriteFile(configFilePath, []byte("indexes: []\n"), 0o600)).To(Succeed())
+
+			// push plugin
+			pusher = ocipusher.NewPusher(authn.NewClient(authn.WithCredentials(&auth.EmptyCredential)), true, nil)
+			ref = registry + repo + ":jsonlogs"
+			config = ocipusher.WithArtifactConfig(oci.ArtifactConfig{
+				Name:    "plugin1",
+				Version: "0.0.1",
+			})
+			filePathsAndPlatforms := ocipusher.WithFilepathsAndPlatforms([]string{plugintgz}, []string{"linux/amd64"})
+			_, err := pusher.Push(ctx, oci.Plugin, ref, filePathsAndPlatforms, config)
+			Expect(err).To(BeNil())
+			args = []string{artifactCmd, installCmd, ref, "--plain-http", "--platform", "linux/amd64",
+				"--config", configFilePath, "--plugins-dir", baseDir, "--lock-file", baseDir + "/falcoctl.lock.yaml",
+				"--resolve-deps=false", "--log-format", "json"}
+		})
+
+		AfterEach(func() {
+			// The flag is bound to the shared options, restore its default for the other tests.
+			Expect(rootCmd.PersistentFlags().Set("log-format", "text")).To(Succeed())
+		})
+
+		It("should log a json object per message, with the fields of the installed artifact", func() {
+			Expect(err).ToNot(HaveOccurred())
+			var installed, pulled map[string]string
+			for _, line := range strings.Split(strings.TrimSpace(string(output.Contents())), "\n") {
+				var msg map[string]interface{}
+				Expect(json.Unmarshal([]byte(line), &msg)).To(Succeed(), line)
+				Expect(msg).To(HaveKey("level"))
+				switch msg["msg"] {
+				case "Artifact successfully installed":
+					Expect(json.Unmarshal([]byte(line), &installed)).To(Succeed())
+				case "Artifact pulled":
+					Expect(json.Unmarshal([]byte(line), &pulled)).To(Succeed())
+				}
+			}
+			Expect(pulled).To(HaveKeyWithValue("level", "INFO"))
+			Expect(pulled).To(HaveKeyWithValue("ref", ref))
+			Expect(pulled).To(HaveKeyWithValue("resolvedRef", registry+repo+"@"+pulled["digest"]))
+			Expect(installed).To(HaveKeyWithValue("level", "INFO"))
+			Expect(installed).To(HaveKeyWithValue("ref", ref))
+			Expect(installed).To(HaveKeyWithValue("name", artifact))
+			Expect(installed["digest"]).To(HavePrefix("sha256:"))
+			Expect(installed).To(HaveKey("duration"))
+		})
+	})
+
+	Context("json output", func() {
+		var (
+			baseDir    string
+			jsonOutput *bytes.Buffer
+		)
+
+		BeforeEach(func() {
+			baseDir = GinkgoT().TempDir()
+			configFilePath := baseDir + "/config.yaml"
+			Expect(os.WriteFile(configFilePath, []byte("indexes: []\n"), 0o600)).To(Succeed())
+			jsonOutput = &bytes.Buffer{}
+			opt.Initialize(commonoptions.WithOutputWriter(jsonOutput))
+
+			// push plugin
+			pusher = ocipusher.NewPusher(authn.NewClient(authn.WithCredentials(&auth.EmptyCredential)), true, nil)
+			ref = registry + repo + ":json"
+			config = ocipusher.WithArtifactConfig(oci.ArtifactConfig{
+				Name:    "plugin1",
+				Version: "0.0.1",
+			})
+			filePathsAndPlatforms := ocipusher.WithFilepathsAndPlatforms([]string{plugintgz}, []string{"linux/amd64"})
+			result, err := pusher.Push(ctx, oci.Plugin, ref, filePathsAndPlatforms, config)
+			Expect(err).To(BeNil())
+			Expect(result).ToNot(BeNil())
+			args = []string{artifactCmd, installCmd, ref, registry + repo + ":missing", "--plain-http", "--platform", "linux/amd64",
+				"--config", configFilePath, "--plugins-dir", baseDir, "--lock-file", baseDir + "/falcoctl.lock.yaml",
+				"--resolve-deps=false", "--max-retries", "0", "--output", "json"}
+		})
+
+		AfterEach(func() {
+			// The flag is bound to the shared options, restore its default for the other tests.
+			Expect(rootCmd.PersistentFlags().Set("output", "text")).To(Succeed())
+		})
+
+		It("should print the result of each artifact as json", func() {
+			Expect(err).To(HaveOccurred())
+			var results []map[string]string
+			Expect(json.Unmarshal(jsonOutput.Bytes(), &results)).To(Succeed())
+			Expect(results).To(HaveLen(2))
+			Expect(results[0]).To(HaveKeyWithValue("ref", ref))
+			Expect(results[0]).To(HaveKeyWithValue("name", artifact))
+			Expect(results[0]).To(HaveKeyWithValue("type", oci.Plugin.String()))
+			Expect(results[0]).To(HaveKeyWithValue("destDir", baseDir))
+			Expect(results[0]).To(HaveKeyWithValue("status", "installed"))
+			Expect(results[0]["digest"]).To(HavePrefix("sha256:"))
+			Expect(results[1]).To(HaveKeyWithValue("status", "failed"))
+			Expect(results[1]).To(HaveKey("error"))
+			// Human messages are still written to their own writer.
+			Expect(output).Should(gbytes.Say(regexp.QuoteMeta("Artifact successfully installed")))
+			Expect(output).Should(gbytes.Say(regexp.QuoteMeta("Install progress")))
+			Expect(output).Should(gbytes.Say(regexp.QuoteMeta("2/2")))
+			Expect(output).Should(gbytes.Say(regexp.QuoteMeta("Install completed")))
+			Expect(output).Should(gbytes.Say(`installed: 1`))
+			Expect(output).Should(gbytes.Say(`failed: 1`))
+		})
+	})
+
+	Context("print plan", func() {
+		var (
+			baseDir    string
+			planOutput *bytes.Buffer
+		)
+
+		BeforeEach(func() {
+			baseDir = GinkgoT().TempDir()
+			configFilePath := baseDir + "/config.yaml"
+			Expect(os.WriteFile(configFilePath, []byte("indexes: []\n"), 0o600)).To(Succeed())
+			planOutput = &bytes.Buffer{}
+			opt.Initialize(commonoptions.WithOutputWriter(planOutput))
+
+			pusher = ocipusher.NewPusher(authn.NewClient(authn.WithCredentials(&auth.EmptyCredential)), true, nil)
+			ref = registry + repo + ":plan"
+			config = ocipusher.WithArtifactConfig(oci.ArtifactConfig{
+				Name:    "plugin1",
+				Version: "0.0.1",
+			})
+			filePathsAndPlatforms := ocipusher.WithFilepathsAndPlatforms([]string{plugintgz}, []string{"linux/amd64"})
+			result, err := pusher.Push(ctx, oci.Plugin, ref, filePathsAndPlatforms, config)
+			Expect(err).To(BeNil())
+			Expect(result).ToNot(BeNil())
+			args = []string{artifactCmd, installCmd, ref, "--plain-http", "--platform", "linux/amd64",
+				"--config", configFilePath, "--plugins-dir", baseDir, "--lock-file", baseDir + "/falcoctl.lock.yaml",
+				"--resolve-deps=false", "--max-retries", "0", "--print-plan"}
+		})
+
+		AfterEach(func() {
+			// The plan switches the shared output flag to json, restore its default for the other tests.
+			Expect(rootCmd.PersistentFlags().Set("output", "text")).To(Succeed())
+		})
+
+		It("should print only the plan as json without installing anything", func() {
+			Expect(err).ToNot(HaveOccurred())
+			var plan []map[string]string
+			Expect(json.Unmarshal(planOutput.Bytes(), &plan)).To(Succeed())
+			Expect(plan).To(HaveLen(1))
+			Expect(plan[0]).To(HaveKeyWithValue("name", artifact))
+			Expect(plan[0]).To(HaveKeyWithValue("ref", ref))
+			Expect(plan[0]).To(HaveKeyWithValue("type", oci.Plugin.String()))
+			Expect(plan[0]).To(HaveKeyWithValue("destDir", baseDir))
+			Expect(plan[0]).To(HaveKeyWithValue("action", "install"))
+			Expect(plan[0]["digest"]).To(HavePrefix("sha256:"))
+			Expect(filepath.Join(baseDir, "falcoctl.lock.yaml")).ToNot(BeAnExistingFile())
+			entries, err := os.ReadDir(baseDir)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(entries).To(HaveLen(1))
+		})
+
+		When("the type of the artifact is not allowed", func() {
+			BeforeEach(func() {
+				args = append(args, "--allowed-types", "rulesfile")
+			})
+
+			It("should plan to skip it", func() {
+				Expect(err).ToNot(HaveOccurred())
+				var plan []map[string]string
+				Expect(json.Unmarshal(planOutput.Bytes(), &plan)).To(Succeed())
+				Expect(plan).To(HaveLen(1))
+				Expect(plan[0]).To(HaveKeyWithValue("action", "skip"))
+			})
+		})
+	})
+
+	Context("provenance attestation", func() {
+		var baseDir, lockFile string
+
+		BeforeEach(func() {
+			baseDir = GinkgoT().TempDir()
+			lockFile = filepath.Join(baseDir, "falcoctl.lock.yaml")
+			configFilePath := baseDir + "/config.yaml"
+			Expect(os.WriteFile(configFilePath, []byte("indexes: []\n"), 0o600)).To(Succeed())
+			pusher = ocipusher.NewPusher(authn.NewClient(authn.WithCredentials(&auth.EmptyCredential)), true, nil)
+			config = ocipusher.WithArtifactConfig(oci.ArtifactConfig{
+				Name:    "plugin1",
+				Version: "0.0.1",
+			})
+			args = []string{artifactCmd, installCmd, "--plain-http", "--platform", "linux/amd64", "--config", configFilePath,
+				"--plugins-dir", baseDir, "--lock-file", lockFile, "--resolve-deps=false"}
+		})
+
+		When("the artifact has a SLSA provenance attestation", func() {
+			BeforeEach(func() {
+				ref = registry + "/attested:provenance"
+				filePathsAndPlatforms := ocipusher.WithFilepathsAndPlatforms([]string{plugintgz}, []string{"linux/amd64"})
+				_, err := pusher.Push(ctx, oci.Plugin, ref, filePathsAndPlatforms, config)
+				Expect(err).To(BeNil())
+				Expect(attachProvenance(ref, "https://slsa.dev/provenance/v1")).To(Succeed())
+				args = append(args, ref, "--require-attestation")
+			})
+
+			It("should write it next to the lockfile and record its path", func() {
+				Expect(err).ToNot(HaveOccurred())
+				path := filepath.Join(baseDir, "attestations", "attested.intoto.jsonl")
+				Expect(path).To(BeARegularFile())
+				content, err := os.ReadFile(path)
+				Expect(err).ToNot(HaveOccurred())
+				lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+				Expect(lines).To(HaveLen(1))
+				Expect(lines[0]).To(ContainSubstring(`"payloadType":"application/vnd.in-toto+json"`))
+
+				lock, err := lockfile.New(lockFile)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(lock.Artifacts).To(HaveLen(1))
+				Expect(lock.Artifacts[0].Provenance).To(Equal(path))
+			})
+		})
+
+		When("the artifact has no provenance attestation and it is required", func() {
+			BeforeEach(func() {
+				ref = registry + "/attested:none"
+				filePathsAndPlatforms := ocipusher.WithFilepathsAndPlatforms([]string{plugintgz}, []string{"linux/amd64"})
+				_, err := pusher.Push(ctx, oci.Plugin, ref, filePathsAndPlatforms, config)
+				Expect(err).To(BeNil())
+				Expect(attachProvenance(ref, "https://cosign.sigstore.dev/attestation/vuln/v1")).To(Succeed())
+				args = append(args, ref, "--require-attestation")
+			})
+
+			It("should fail without installing anything", func() {
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("no SLSA provenance attestation found"))
+				Expect(filepath.Join(baseDir, "libcloudtrail.so")).ToNot(BeAnExistingFile())
+				Expect(filepath.Join(baseDir, "attestations")).ToNot(BeAnExistingFile())
+			})
+		})
+
+		When("the artifact has no provenance attestation", func() {
+			BeforeEach(func() {
+				ref = registry + "/attested:optional"
+				filePathsAndPlatforms := ocipusher.WithFilepathsAndPlatforms([]string{plugintgz}, []string{"linux/amd64"})
+				_, err := pusher.Push(ctx, oci.Plugin, ref, filePathsAndPlatforms, config)
+				Expect(err).To(BeNil())
+				args = append(args, ref)
+			})
+
+			It("should install it without recording any provenance", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(filepath.Join(baseDir, "libcloudtrail.so")).To(BeARegularFile())
+				lock, err := lockfile.New(lockFile)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(lock.Artifacts).To(HaveLen(1))
+				Expect(lock.Artifacts[0].Provenance).To(BeEmpty())
+			})
+		})
+	})
+
+	Context("registry config", func() {
+		var baseDir string
+
+		BeforeEach(func() {
+			baseDir = GinkgoT().TempDir()
+			configFilePath := baseDir + "/config.yaml"
+			Expect(os.WriteFile(configFilePath, []byte("indexes: []\nregistry:\n  concurrency: 0\n  maxRetries: 1\n"), 0o600)).To(Succeed())
+			pusher = ocipusher.NewPusher(authn.NewClient(authn.WithCredentials(&auth.EmptyCredential)), true, nil)
+			config = ocipusher.WithArtifactConfig(oci.ArtifactConfig{
+				Name:    "plugin1",
+				Version: "0.0.1",
+			})
+			ref = registry + "/registryconfig:latest"
+			filePathsAndPlatforms := ocipusher.WithFilepathsAndPlatforms([]string{plugintgz}, []string{"linux/amd64"})
+			_, err := pusher.Push(ctx, oci.Plugin, ref, filePathsAndPlatforms, config)
+			Expect(err).To(BeNil())
+			args = []string{artifactCmd, installCmd, "--plain-http", "--platform", "linux/amd64", "--config", configFilePath,
+				"--plugins-dir", baseDir, "--lock-file", filepath.Join(baseDir, "falcoctl.lock.yaml"), "--resolve-deps=false", ref}
+		})
+
+		When("the flags are not set", func() {
+			It("should take the values of the config file", func() {
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("--concurrency must be greater than zero"))
+				Expect(filepath.Join(baseDir, "libcloudtrail.so")).ToNot(BeAnExistingFile())
+			})
+		})
+
+		When("the flags are set", func() {
+			BeforeEach(func() {
+				args = append(args, "--concurrency", "2")
+			})
+
+			It("should prefer them to the config file", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(filepath.Join(baseDir, "libcloudtrail.so")).To(BeARegularFile())
+			})
+		})
+	})
+
+	Context("save sbom", func() {
+		var baseDir, sbomDir, lockFile string
+
+		BeforeEach(func() {
+			baseDir = GinkgoT().TempDir()
+			sbomDir = filepath.Join(baseDir, "sboms")
+			lockFile = filepath.Join(baseDir, "falcoctl.lock.yaml")
+			configFilePath := baseDir + "/config.yaml"
+			Expect(os.WriteFile(configFilePath, []byte("indexes: []\n"), 0o600)).To(Succeed())
+			pusher = ocipusher.NewPusher(authn.NewClient(authn.WithCredentials(&auth.EmptyCredential)), true, nil)
+			config = ocipusher.WithArtifactConfig(oci.ArtifactConfig{
+				Name:    "plugin1",
+				Version: "0.0.1",
+			})
+			args = []string{artifactCmd, installCmd, "--plain-http", "--platform", "linux/amd64", "--config", configFilePath,
+				"--plugins-dir", baseDir, "--lock-file", lockFile, "--resolve-deps=false", "--save-sbom", sbomDir}
+		})
+
+		When("the artifact has an SBOM attached", func() {
+			BeforeEach(func() {
+				ref = registry + "/sbom:attached"
+				filePathsAndPlatforms := ocipusher.WithFilepathsAndPlatforms([]string{plugintgz}, []string{"linux/amd64"})
+				_, err := pusher.Push(ctx, oci.Plugin, ref, filePathsAndPlatforms, config)
+				Expect(err).To(BeNil())
+				Expect(attachSBOM(ref, oci.CycloneDXMediaType, `{"bomFormat":"CycloneDX"}`)).To(Succeed())
+				args = append(args, ref)
+			})
+
+			It("should save it and record its path", func() {
+				Expect(err).ToNot(HaveOccurred())
+				path := filepath.Join(sbomDir, "sbom.cdx.json")
+				Expect(path).To(BeARegularFile())
+				content, err := os.ReadFile(path)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(content)).To(Equal(`{"bomFormat":"CycloneDX"}`))
+
+				lock, err := lockfile.New(lockFile)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(lock.Artifacts).To(HaveLen(1))
+				Expect(lock.Artifacts[0].SBOM).To(Equal(path))
+			})
+		})
+
+		When("the artifact has no SBOM attached", func() {
+			BeforeEach(func() {
+				// Another version, so that the manifest differs from the one the SBOM has been attached to.
+				ref = registry + "/sbom:none"
+				config = ocipusher.WithArtifactConfig(oci.ArtifactConfig{
+					Name:    "plugin1",
+					Version: "0.0.2",
+				})
+				filePathsAndPlatforms := ocipusher.WithFilepathsAndPlatforms([]string{plugintgz}, []string{"linux/amd64"})
+				_, err := pusher.Push(ctx, oci.Plugin, ref, filePathsAndPlatforms, config)
+				Expect(err).To(BeNil())
+				args = append(args, ref)
+			})
+
+			It("should install it without saving any SBOM", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(filepath.Join(baseDir, "libcloudtrail.so")).To(BeARegularFile())
+				Expect(sbomDir).ToNot(BeAnExistingFile())
+				lock, err := lockfile.New(lockFile)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(lock.Artifacts).To(HaveLen(1))
+				Expect(lock.Artifacts[0].SBOM).To(BeEmpty())
+			})
+		})
+	})
 })
@@ -17,10 +17,17 @@ package list
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/spf13/cobra"
 
+	"github.com/falcosecurity/falcoctl/internal/config"
+	"github.com/falcosecurity/falcoctl/internal/utils"
+	"github.com/falcosecurity/falcoctl/pkg/lockfile"
 	"github.com/falcosecurity/falcoctl/pkg/oci"
 	"github.com/falcosecurity/falcoctl/pkg/options"
 	"github.com/falcosecurity/falcoctl/pkg/output"
@@ -29,16 +36,44 @@ import (
 // CommandName name of the command. It has to be the first word in the use line.
 const CommandName = "list"
 
+// FlagInstalled is the name of the flag to list the artifacts installed on disk.
+const FlagInstalled = "installed"
+
+// artifactEntry is an artifact found in the indexes, as printed in the structured output.
+type artifactEntry struct {
+	Index       string `json:"index"`
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Registry    string `json:"registry"`
+	Repository  string `json:"repository"`
+	Description string `json:"description,omitempty"`
+}
+
+// installedArtifact is a file found in the install directories, as printed in the structured output.
+type installedArtifact struct {
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Ref    string `json:"ref,omitempty"`
+	Digest string `json:"digest"`
+	Path   string `json:"path"`
+	// Capabilities of the plugins, as read from the plugin API functions exported by their shared library.
+	Capabilities []string `json:"capabilities,omitempty"`
+}
+
 type artifactListOptions struct {
 	*options.Common
+	*options.Directory
 	artifactType oci.ArtifactType
 	index        string
+	installed    bool
+	lockFile     string
 }
 
 // NewArtifactListCmd returns the artifact search command.
 func NewArtifactListCmd(ctx context.Context, opt *options.Common) *cobra.Command {
 	o := artifactListOptions{
-		Common: opt,
+		Common:    opt,
+		Directory: &options.Directory{},
 	}
 
 	cmd := &cobra.Command{
@@ -47,6 +82,18 @@ func NewArtifactListCmd(ctx context.Context, opt *options.Common) *cobra.Command
 		Short:                 "List all artifacts",
 		Long:                  "List all artifacts",
 		Aliases:               []string{"ls"},
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			// Override the directories flags with viper config if not set by user.
+			if err := o.Directory.OverrideFromConfig(cmd, config.ArtifactInstallRulesfilesDirKey, config.ArtifactInstallPluginsDirKey,
+				config.ArtifactFollowAssetsDirKey); err != nil {
+				return err
+			}
+
+			if err := o.Directory.Expand(); err != nil {
+				return err
+			}
+			return utils.ExpandPaths(&o.lockFile)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return o.RunArtifactList(ctx, args)
 		},
@@ -54,25 +101,126 @@ func NewArtifactListCmd(ctx context.Context, opt *options.Common) *cobra.Command
 
 	cmd.Flags().Var(&o.artifactType, "type", `Only list artifacts with a specific type. Allowed values: "rulesfile", "plugin", "asset"`)
 	cmd.Flags().StringVar(&o.index, "index", "", "Only display artifacts from a configured index")
+	cmd.Flags().BoolVar(&o.installed, FlagInstalled, false,
+		"List the artifacts installed in the rulesfiles, plugins and assets directories instead of the indexed ones")
+	cmd.Flags().StringVar(&o.lockFile, "lock-file", config.LockFile,
+		"Lockfile used to retrieve the references of the installed artifacts (only with --installed)")
+	o.Directory.AddFlags(cmd)
 
 	return cmd
 }
 
 func (o *artifactListOptions) RunArtifactList(_ context.Context, _ []string) error {
-	var data [][]string
-	for _, entry := range o.IndexCache.MergedIndexes.Entries {
-		if o.artifactType != "" && o.artifactType != oci.ArtifactType(entry.Type) {
-			continue
-		}
+	if o.installed {
+		return o.listInstalled()
+	}
 
+	var data [][]string
+	results := []artifactEntry{}
+	entries := o.IndexCache.MergedIndexes.Entries
+	if o.artifactType != "" {
+		entries = o.IndexCache.MergedIndexes.EntriesByType(o.artifactType)
+	}
+	for _, entry := range entries {
 		indexName := o.IndexCache.MergedIndexes.IndexByEntry(entry).Name
 		if o.index != "" && o.index != indexName {
 			continue
 		}
 
-		row := []string{indexName, entry.Name, entry.Type, entry.Registry, entry.Repository}
+		row := []string{indexName, entry.Name, entry.Type, entry.Registry, entry.Repository, entry.Description}
 		data = append(data, row)
+		results = append(results, artifactEntry{
+			Index: indexName, Name: entry.Name, Type: entry.Type,
+			Registry: entry.Registry, Repository: entry.Repository, Description: entry.Description,
+		})
+	}
+
+	return o.Printer.PrintResults(results, output.ArtifactSearch, data)
+}
+
+// listInstalled prints the artifacts found in the install directories.
+func (o *artifactListOptions) listInstalled() error {
+	dirs := []struct {
+		artifactType oci.ArtifactType
+		dir          string
+	}{
+		{oci.Rulesfile, o.RulesfilesDir},
+		{oci.Plugin, o.PluginsDir},
+		{oci.Asset, o.AssetsDir},
+	}
+
+	lock, err := lockfile.New(o.lockFile)
+	if err != nil {
+		return err
+	}
+
+	var data [][]string
+	results := []installedArtifact{}
+	for _, d := range dirs {
+		if o.artifactType != "" && o.artifactType != d.artifactType {
+			continue
+		}
+
+		installed, err := installedFiles(d.dir, d.artifactType, lock)
+		if err != nil {
+			return err
+		}
+		results = append(results, installed...)
+	}
+
+	for _, res := range results {
+		ref, capabilities := res.Ref, strings.Join(res.Capabilities, ",")
+		if ref == "" {
+			ref = "-"
+		}
+		if capabilities == "" {
+			capabilities = "-"
+		}
+		data = append(data, []string{res.Name, res.Type, ref, res.Digest, res.Path, capabilities})
+	}
+
+	return o.Printer.PrintResults(results, output.ArtifactInstalled, data)
+}
+
+// installedFiles returns the regular files found at the top level of dir.
+// Subdirectories are not inspected since the default assets directory lives inside the rulesfiles one.
+// A missing directory is not an error, it just means no artifacts of that type are installed.
+// Files recorded in the lockfile are reported with the name and reference of the artifact they belong to, and the
+// capabilities of the plugins are read from their shared libraries.
+func installedFiles(dir string, artifactType oci.ArtifactType, lock *lockfile.Lockfile) ([]installedArtifact, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to list installed artifacts in %q: %w", dir, err)
+	}
+
+	var installed []installedArtifact
+	for _, e := range entries {
+		if !e.Type().IsRegular() {
+			continue
+		}
+
+		path, err := filepath.Abs(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		digest, err := utils.FileDigest(path)
+		if err != nil {
+			return nil, err
+		}
+
+		res := installedArtifact{Name: e.Name(), Type: artifactType.String(), Digest: digest, Path: path}
+		if entry := lock.EntryByFile(path); entry != nil {
+			res.Name, res.Ref = entry.Name, entry.Ref
+		}
+		if artifactType == oci.Plugin {
+			res.Capabilities = pluginInfo(path)
+		}
+
+		installed = append(installed, res)
 	}
 
-	return o.Printer.PrintTable(output.ArtifactSearch, data)
+	return installed, nil
 }
@@ -0,0 +1,154 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package list_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/falcosecurity/falcoctl/cmd"
+	"github.com/falcosecurity/falcoctl/internal/utils"
+	"github.com/falcosecurity/falcoctl/pkg/lockfile"
+	commonoptions "github.com/falcosecurity/falcoctl/pkg/options"
+)
+
+var _ = Describe("list", func() {
+	const pluginRef = "ghcr.io/falcosecurity/plugins/plugin/cloudtrail:0.3.0"
+
+	var (
+		baseDir, pluginsDir, rulesfilesDir, lockFile string
+		jsonOutput                                   *bytes.Buffer
+		args                                         []string
+		err                                          error
+	)
+
+	// extract extracts the archive in dir, as installing the artifact would.
+	extract := func(archive, dir string) {
+		Expect(os.MkdirAll(dir, 0o755)).To(Succeed())
+		f, err := os.Open(archive)
+		Expect(err).ToNot(HaveOccurred())
+		defer f.Close()
+		_, err = utils.ExtractTarGz(ctx, f, dir, 0)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		baseDir = GinkgoT().TempDir()
+		pluginsDir = filepath.Join(baseDir, "plugins")
+		rulesfilesDir = filepath.Join(baseDir, "rules")
+		lockFile = filepath.Join(baseDir, "falcoctl.lock.yaml")
+		configFilePath := filepath.Join(baseDir, "config.yaml")
+		Expect(os.WriteFile(configFilePath, []byte("indexes: []\n"), 0o600)).To(Succeed())
+		jsonOutput = &bytes.Buffer{}
+		opt.Initialize(commonoptions.WithWriter(output), commonoptions.WithOutputWriter(jsonOutput))
+
+		// Install a plugin, recorded in the lockfile, and a rulesfile copied by hand.
+		extract(plugintgz, pluginsDir)
+		extract(rulesfiletgz, rulesfilesDir)
+		lock, err := lockfile.New(lockFile)
+		Expect(err).ToNot(HaveOccurred())
+		lock.Upsert(&lockfile.Entry{Name: "cloudtrail", Ref: pluginRef, Type: "plugin",
+			Files: []string{filepath.Join(pluginsDir, "libcloudtrail.so")}})
+		Expect(lock.Write(lockFile)).To(Succeed())
+
+		args = []string{"artifact", "list", "--installed", "--config", configFilePath, "--plugins-dir", pluginsDir,
+			"--rulesfiles-dir", rulesfilesDir, "--assets-dir", filepath.Join(baseDir, "assets"), "--lock-file", lockFile,
+			"--output", "json"}
+	})
+
+	JustBeforeEach(func() {
+		rootCmd = cmd.New(ctx, opt)
+		err = executeRoot(args)
+	})
+
+	AfterEach(func() {
+		Expect(output.Clear()).To(Succeed())
+		// The flag is bound to the shared options, restore its default for the other tests.
+		Expect(rootCmd.PersistentFlags().Set("output", "text")).To(Succeed())
+	})
+
+	results := func() []map[string]interface{} {
+		var res []map[string]interface{}
+		Expect(json.Unmarshal(jsonOutput.Bytes(), &res)).To(Succeed())
+		return res
+	}
+
+	Context("installed", func() {
+		It("should list the installed files with the artifacts and the plugin capabilities", func() {
+			Expect(err).ToNot(HaveOccurred())
+			res := results()
+			Expect(res).To(HaveLen(3))
+
+			Expect(res[0]).To(HaveKeyWithValue("type", "rulesfile"))
+			Expect(res[0]).To(HaveKeyWithValue("path", filepath.Join(rulesfilesDir, "aws_cloudtrail_rules.yaml")))
+			Expect(res[0]).ToNot(HaveKey("ref"))
+			Expect(res[0]).ToNot(HaveKey("capabilities"))
+
+			// The README of the plugin is not a shared library, hence it has no capabilities.
+			Expect(res[1]).To(HaveKeyWithValue("name", "README.md"))
+			Expect(res[1]).ToNot(HaveKey("capabilities"))
+
+			Expect(res[2]).To(HaveKeyWithValue("name", "cloudtrail"))
+			Expect(res[2]).To(HaveKeyWithValue("type", "plugin"))
+			Expect(res[2]).To(HaveKeyWithValue("ref", pluginRef))
+			Expect(res[2]["digest"]).To(HavePrefix("sha256:"))
+			Expect(res[2]["capabilities"]).To(ConsistOf("sourcing", "extraction"))
+		})
+
+		When("with --type", func() {
+			BeforeEach(func() {
+				args = append(args, "--type", "rulesfile")
+			})
+
+			It("should only list the artifacts of the given type", func() {
+				Expect(err).ToNot(HaveOccurred())
+				res := results()
+				Expect(res).To(HaveLen(1))
+				Expect(res[0]).To(HaveKeyWithValue("type", "rulesfile"))
+			})
+		})
+
+		When("the directories do not exist", func() {
+			BeforeEach(func() {
+				Expect(os.RemoveAll(pluginsDir)).To(Succeed())
+				Expect(os.RemoveAll(rulesfilesDir)).To(Succeed())
+			})
+
+			It("should list nothing", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(results()).To(BeEmpty())
+			})
+		})
+
+		When("printing a table", func() {
+			BeforeEach(func() {
+				args = args[:len(args)-2]
+			})
+
+			It("should print the capabilities column", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(output).Should(gbytes.Say("CAPABILITIES"))
+				Expect(output).Should(gbytes.Say("sourcing,extraction"))
+			})
+		})
+	})
+})
@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package list
+
+import (
+	"debug/elf"
+)
+
+// pluginAPISymbol is exported by the shared library of every Falco plugin.
+const pluginAPISymbol = "plugin_get_required_api_version"
+
+// pluginCapabilities maps the capabilities of the Falco plugins to the plugin API functions implementing them.
+var pluginCapabilities = []struct {
+	name    string
+	symbols []string
+}{
+	{"sourcing", []string{"plugin_open", "plugin_next_batch"}},
+	{"extraction", []string{"plugin_extract_fields"}},
+	{"parsing", []string{"plugin_parse_event"}},
+	{"async", []string{"plugin_get_async_events"}},
+	{"capture-listening", []string{"plugin_capture_open"}},
+}
+
+// pluginInfo returns the capabilities of the plugin at path, read from the plugin API functions exported by its
+// shared library. The library is not loaded, falcoctl being built without cgo, so that the info returned by the
+// plugin at runtime, e.g. its name and version, is not available. Nil is returned for files that are not plugins.
+func pluginInfo(path string) []string {
+	f, err := elf.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	symbols, err := f.DynamicSymbols()
+	if err != nil {
+		return nil
+	}
+	exported := make(map[string]bool, len(symbols))
+	for _, s := range symbols {
+		if s.Section != elf.SHN_UNDEF && elf.ST_TYPE(s.Info) == elf.STT_FUNC {
+			exported[s.Name] = true
+		}
+	}
+	if !exported[pluginAPISymbol] {
+		return nil
+	}
+
+	capabilities := []string{}
+	for _, c := range pluginCapabilities {
+		implemented := true
+		for _, s := range c.symbols {
+			implemented = implemented && exported[s]
+		}
+		if implemented {
+			capabilities = append(capabilities, c.name)
+		}
+	}
+
+	return capabilities
+}
@@ -67,7 +67,7 @@ func (o *artifactManifestOptions) RunArtifactManifest(ctx context.Context, args
 	)
 
 	// Create puller with auto login enabled.
-	if puller, err = ociutils.Puller(o.PlainHTTP, o.Printer); err != nil {
+	if puller, err = ociutils.Puller(o.Registry, o.Printer); err != nil {
 		return err
 	}
 
@@ -83,7 +83,10 @@ func (o *artifactManifestOptions) RunArtifactManifest(ctx context.Context, args
 		return fmt.Errorf("invalid platform format: %s", o.platform)
 	}
 
-	if manifest, err = puller.RawManifest(ctx, ref, tokens[0], tokens[1]); err != nil {
+	opCtx, cancel := o.OperationContext(ctx)
+	defer cancel()
+
+	if manifest, err = puller.RawManifest(opCtx, ref, tokens[0], tokens[1]); err != nil {
 		return err
 	}
 
@@ -0,0 +1,196 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pull
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/falcosecurity/falcoctl/internal/utils"
+	ocipuller "github.com/falcosecurity/falcoctl/pkg/oci/puller"
+	ociutils "github.com/falcosecurity/falcoctl/pkg/oci/utils"
+	"github.com/falcosecurity/falcoctl/pkg/options"
+)
+
+const (
+	longPull = `This command allows you to download an artifact without installing it.
+
+The artifact is resolved through the configured indexes, or used as is when an OCI reference is given, and its
+content is saved in the output directory exactly as it is stored in the registry. Nothing is written to the Falco
+directories, hence the artifact can be inspected before trusting it. The integrity of the downloaded file is always
+checked against the digest declared in the manifest.
+
+Example - Download the "k8saudit-rules" artifact in the current directory:
+	falcoctl artifact pull k8saudit-rules
+
+Example - Download the linux/arm64 version of the "k8saudit" plugin in the "downloads" directory:
+	falcoctl artifact pull ghcr.io/falcosecurity/plugins/plugin/k8saudit:latest --platform linux/arm64 --output-dir ./downloads
+
+Example - Download the armv7 version of the "k8saudit" plugin, when its image index has several variants of linux/arm:
+	falcoctl artifact pull ghcr.io/falcosecurity/plugins/plugin/k8saudit:latest --platform linux/arm --platform-variant v7
+
+Example - Download the "k8saudit-rules" artifact and extract its content in the "downloads" directory:
+	falcoctl artifact pull k8saudit-rules --output-dir ./downloads --extract
+
+Example - Extract the content of the "k8saudit-rules" artifact without its top-level directory:
+	falcoctl artifact pull k8saudit-rules --output-dir ./downloads --extract --strip-components 1
+`
+
+	// FlagOutputDir is the name of the flag to specify the directory where the artifact is saved.
+	FlagOutputDir = "output-dir"
+
+	// FlagPlatform is the name of the flag to specify the platform of the artifact.
+	FlagPlatform = "platform"
+
+	// FlagExtract is the name of the flag to extract the content of the artifact.
+	FlagExtract = "extract"
+
+	// FlagPlatformVariant is the name of the flag to specify the variant of the platform of the artifact.
+	FlagPlatformVariant = "platform-variant"
+
+	// FlagStripComponents is the name of the flag to strip leading path components from the extracted files.
+	FlagStripComponents = "strip-components"
+)
+
+type artifactPullOptions struct {
+	*options.Common
+	*options.Registry
+	outputDir       string
+	platform        string
+	extract         bool
+	stripComponents int
+	variant         string
+	os, arch        string
+}
+
+// Validate validates the options passed by the user.
+func (o *artifactPullOptions) Validate() error {
+	tokens := strings.Split(o.platform, "/")
+	if len(tokens) != 2 || tokens[0] == "" || tokens[1] == "" {
+		return fmt.Errorf("invalid platform format %q: needs to be in OS/ARCH format", o.platform)
+	}
+	o.os, o.arch = tokens[0], tokens[1]
+
+	if o.stripComponents < 0 {
+		return fmt.Errorf("--%s must not be negative", FlagStripComponents)
+	}
+	if o.stripComponents > 0 && !o.extract {
+		return fmt.Errorf("--%s needs --%s", FlagStripComponents, FlagExtract)
+	}
+
+	return utils.ExpandPaths(&o.outputDir)
+}
+
+// NewArtifactPullCmd returns the artifact pull command.
+func NewArtifactPullCmd(ctx context.Context, opt *options.Common) *cobra.Command {
+	o := artifactPullOptions{
+		Common:   opt,
+		Registry: &options.Registry{},
+	}
+
+	cmd := &cobra.Command{
+		Use:                   "pull [ref] [flags]",
+		DisableFlagsInUseLine: true,
+		Short:                 "Download an artifact without installing it",
+		Long:                  longPull,
+		Args:                  cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return o.Validate()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.RunArtifactPull(ctx, args)
+		},
+	}
+
+	o.Registry.AddFlags(cmd)
+	cmd.Flags().StringVarP(&o.outputDir, FlagOutputDir, "o", ".", "directory where the artifact is saved, created if it does not exist")
+	cmd.Flags().StringVar(&o.platform, FlagPlatform, fmt.Sprintf("%s/%s", runtime.GOOS, runtime.GOARCH),
+		"os and architecture of the artifact in OS/ARCH format")
+	cmd.Flags().StringVar(&o.variant, FlagPlatformVariant, "",
+		`variant of the platform of the artifact, e.g. "v7" for "linux/arm", preferring the manifest without variant when not given`)
+	cmd.Flags().BoolVar(&o.extract, FlagExtract, false,
+		"extract the content of the artifact in the output directory instead of keeping the downloaded archive")
+	cmd.Flags().IntVar(&o.stripComponents, FlagStripComponents, 0,
+		"strip the given number of leading path components from the extracted files, like tar does, skipping the files with fewer components")
+
+	return cmd
+}
+
+// RunArtifactPull executes the business logic for the artifact pull command.
+func (o *artifactPullOptions) RunArtifactPull(ctx context.Context, args []string) error {
+	logger := o.Printer.Logger
+
+	ref, err := o.IndexCache.ResolveReference(args[0])
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(o.outputDir, 0o750); err != nil {
+		return fmt.Errorf("cannot create output directory %q: %w", o.outputDir, err)
+	}
+
+	puller, err := ociutils.Puller(o.Registry, o.Printer, ocipuller.WithVariant(o.variant))
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Preparing to pull artifact", logger.Args("ref", ref))
+
+	opCtx, cancel := o.OperationContext(ctx)
+	result, err := puller.Pull(opCtx, ref, o.outputDir, o.os, o.arch)
+	cancel()
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(o.outputDir, result.Filename)
+	if err := utils.VerifyFileDigest(path, result.LayerDigest); err != nil {
+		return fmt.Errorf("cannot verify integrity of artifact %q: %w", ref, err)
+	}
+
+	if !o.extract {
+		logger.Info("Artifact pulled", logger.Args("ref", ref, "type", result.Type, "file", path, "digest", result.Digest))
+		return nil
+	}
+
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return err
+	}
+	files, err := utils.ExtractTarGz(ctx, f, o.outputDir, o.stripComponents)
+	f.Close()
+	if err != nil {
+		return fmt.Errorf("cannot extract %q to %q: %w", path, o.outputDir, err)
+	}
+
+	if err := os.Remove(path); err != nil {
+		return err
+	}
+
+	logger.Info("Artifact pulled and extracted", logger.Args("ref", ref, "type", result.Type, "directory", o.outputDir,
+		"digest", result.Digest))
+	for _, file := range files {
+		logger.Info("Extracted file", logger.Args("file", file))
+	}
+
+	return nil
+}
@@ -0,0 +1,281 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pull_test
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/falcosecurity/falcoctl/cmd"
+)
+
+var usage = `Usage:
+  falcoctl artifact pull [ref] [flags]
+
+Flags:
+      --anonymous                           interact with remote registries anonymously, without looking up credentials in any credential store or helper
+      --ca-cert string                      PEM encoded file with the CA certificates used, in addition to the system ones, to verify the remote registries
+      --extract                             extract the content of the artifact in the output directory instead of keeping the downloaded archive
+  -h, --help                                help for pull
+      --identity-token string               identity token exchanged for bearer tokens with the registries instead of the stored credentials, read from a file if given as @<path> or from the standard input if "-"
+      --insecure-skip-tls-verify            skip the verification of the remote registries certificates, making the connections insecure
+      --no-proxy string                     comma-separated list of hosts, domains and CIDRs reached without proxy, overrides the NO_PROXY environment variable
+  -o, --output-dir string                   directory where the artifact is saved, created if it does not exist (default ".")
+      --plain-http                          allows interacting with remote registry via plain http requests
+      --platform string                     os and architecture of the artifact in OS/ARCH format (default "linux/amd64")
+      --platform-variant string             variant of the platform of the artifact, e.g. "v7" for "linux/arm", preferring the manifest without variant when not given
+      --proxy string                        proxy URL used to connect to remote registries, overrides the HTTP_PROXY and HTTPS_PROXY environment variables
+      --registry-config string              Docker config file whose credentials and credential helpers are used when falcoctl has none for a registry, defaults to $DOCKER_CONFIG/config.json or $HOME/.docker/config.json
+      --registry-connect-timeout duration   maximum duration to establish a connection to the registry (0 means no timeout) (default 30s)
+      --registry-mirror strings             mirror registry tried, in the given order, when pulling from the registry of an artifact fails. It can be repeated multiple times
+      --registry-timeout duration           maximum duration of each registry operation, e.g. checking the connection or pulling an artifact (0 means no timeout) (default 1m0s)
+      --registry-token string               bearer token sent to the registries instead of the stored credentials, read from a file if given as @<path> or from the standard input if "-"
+      --registry-token-host strings         registry, as host[:port], the --registry-token or --identity-token is sent to, the other registries use the stored credentials. It can be repeated multiple times
+      --strip-components int                strip the given number of leading path components from the extracted files, like tar does, skipping the files with fewer components
+
+Global Flags:
+      --config string            config file to be used for falcoctl (default "/etc/falcoctl/falcoctl.yaml")
+      --falcoctl-dir string      directory where falcoctl stores the indexes and the client credentials (default "$HOME/.config/falcoctl")
+      --index-max-age duration   refresh the cached indexes older than the given age, e.g. 24h, warning if they are stale and cannot be refreshed (0 never refreshes them)
+      --indexes-file string      file listing the configured indexes (default "indexes.yaml" in the falcoctl directory)
+      --lenient-indexes          skip the indexes that cannot be loaded, e.g. a corrupt cached index, with a warning instead of failing, as long as at least one of them is loaded
+      --log-format string        Set formatting for logs (color, text, json) (default "color")
+      --log-level string         Set level for logs (info, warn, debug, trace) (default "info")
+      --no-color                 Disable colors, spinners and progress bars, as the NO_COLOR environment variable does. They are disabled as well when not attached to a tty
+      --output string            Set format for the results of the commands supporting it (text, json, yaml, annotations), messages are written to stderr when set to json or yaml (default "text")
+  -q, --quiet                    Only print errors, to stderr, and the results of the commands, silencing the other logs, spinners and progress bars
+`
+
+var help = `This command allows you to download an artifact without installing it.
+
+The artifact is resolved through the configured indexes, or used as is when an OCI reference is given, and its
+content is saved in the output directory exactly as it is stored in the registry. Nothing is written to the Falco
+directories, hence the artifact can be inspected before trusting it. The integrity of the downloaded file is always
+checked against the digest declared in the manifest.
+
+Example - Download the "k8saudit-rules" artifact in the current directory:
+	falcoctl artifact pull k8saudit-rules
+
+Example - Download the linux/arm64 version of the "k8saudit" plugin in the "downloads" directory:
+	falcoctl artifact pull ghcr.io/falcosecurity/plugins/plugin/k8saudit:latest --platform linux/arm64 --output-dir ./downloads
+
+Example - Download the armv7 version of the "k8saudit" plugin, when its image index has several variants of linux/arm:
+	falcoctl artifact pull ghcr.io/falcosecurity/plugins/plugin/k8saudit:latest --platform linux/arm --platform-variant v7
+
+Example - Download the "k8saudit-rules" artifact and extract its content in the "downloads" directory:
+	falcoctl artifact pull k8saudit-rules --output-dir ./downloads --extract
+
+Example - Extract the content of the "k8saudit-rules" artifact without its top-level directory:
+	falcoctl artifact pull k8saudit-rules --output-dir ./downloads --extract --strip-components 1
+
+Usage:
+  falcoctl artifact pull [ref] [flags]
+
+Flags:
+      --anonymous                           interact with remote registries anonymously, without looking up credentials in any credential store or helper
+      --ca-cert string                      PEM encoded file with the CA certificates used, in addition to the system ones, to verify the remote registries
+      --extract                             extract the content of the artifact in the output directory instead of keeping the downloaded archive
+  -h, --help                                help for pull
+      --identity-token string               identity token exchanged for bearer tokens with the registries instead of the stored credentials, read from a file if given as @<path> or from the standard input if "-"
+      --insecure-skip-tls-verify            skip the verification of the remote registries certificates, making the connections insecure
+      --no-proxy string                     comma-separated list of hosts, domains and CIDRs reached without proxy, overrides the NO_PROXY environment variable
+  -o, --output-dir string                   directory where the artifact is saved, created if it does not exist (default ".")
+      --plain-http                          allows interacting with remote registry via plain http requests
+      --platform string                     os and architecture of the artifact in OS/ARCH format (default "linux/amd64")
+      --platform-variant string             variant of the platform of the artifact, e.g. "v7" for "linux/arm", preferring the manifest without variant when not given
+      --proxy string                        proxy URL used to connect to remote registries, overrides the HTTP_PROXY and HTTPS_PROXY environment variables
+      --registry-config string              Docker config file whose credentials and credential helpers are used when falcoctl has none for a registry, defaults to $DOCKER_CONFIG/config.json or $HOME/.docker/config.json
+      --registry-connect-timeout duration   maximum duration to establish a connection to the registry (0 means no timeout) (default 30s)
+      --registry-mirror strings             mirror registry tried, in the given order, when pulling from the registry of an artifact fails. It can be repeated multiple times
+      --registry-timeout duration           maximum duration of each registry operation, e.g. checking the connection or pulling an artifact (0 means no timeout) (default 1m0s)
+      --registry-token string               bearer token sent to the registries instead of the stored credentials, read from a file if given as @<path> or from the standard input if "-"
+      --registry-token-host strings         registry, as host[:port], the --registry-token or --identity-token is sent to, the other registries use the stored credentials. It can be repeated multiple times
+      --strip-components int                strip the given number of leading path components from the extracted files, like tar does, skipping the files with fewer components
+
+Global Flags:
+      --config string            config file to be used for falcoctl (default "/etc/falcoctl/falcoctl.yaml")
+      --falcoctl-dir string      directory where falcoctl stores the indexes and the client credentials (default "$HOME/.config/falcoctl")
+      --index-max-age duration   refresh the cached indexes older than the given age, e.g. 24h, warning if they are stale and cannot be refreshed (0 never refreshes them)
+      --indexes-file string      file listing the configured indexes (default "indexes.yaml" in the falcoctl directory)
+      --lenient-indexes          skip the indexes that cannot be loaded, e.g. a corrupt cached index, with a warning instead of failing, as long as at least one of them is loaded
+      --log-format string        Set formatting for logs (color, text, json) (default "color")
+      --log-level string         Set level for logs (info, warn, debug, trace) (default "info")
+      --no-color                 Disable colors, spinners and progress bars, as the NO_COLOR environment variable does. They are disabled as well when not attached to a tty
+      --output string            Set format for the results of the commands supporting it (text, json, yaml, annotations), messages are written to stderr when set to json or yaml (default "text")
+  -q, --quiet                    Only print errors, to stderr, and the results of the commands, silencing the other logs, spinners and progress bars
+`
+
+var _ = Describe("Pull", func() {
+	const (
+		artifactCmd   = "artifact"
+		pullCmd       = "pull"
+		plaingHTTP    = "--plain-http"
+		configFlag    = "--config"
+		platformFlag  = "--platform"
+		outputDirFlag = "--output-dir"
+		extractFlag   = "--extract"
+	)
+
+	var (
+		err        error
+		args       []string
+		configFile string
+		outputDir  string
+	)
+
+	var assertFailedBehavior = func(usage, specificError string) {
+		It("check that fails and the usage is not printed", func() {
+			Expect(err).To(HaveOccurred())
+			Expect(output).ShouldNot(gbytes.Say(regexp.QuoteMeta(usage)))
+			Expect(output).Should(gbytes.Say(regexp.QuoteMeta(specificError)))
+		})
+	}
+
+	BeforeEach(func() {
+		tmpDir := GinkgoT().TempDir()
+		outputDir = filepath.Join(tmpDir, "downloads")
+		// Use a config without indexes, so that only the given references are resolved.
+		configFile = filepath.Join(tmpDir, "falcoctl.yaml")
+		Expect(os.WriteFile(configFile, []byte("indexes: []\n"), 0o600)).Should(Succeed())
+	})
+
+	JustBeforeEach(func() {
+		rootCmd = cmd.New(ctx, opt)
+		err = executeRoot(args)
+	})
+
+	JustAfterEach(func() {
+		err = nil
+		Expect(output.Clear()).ShouldNot(HaveOccurred())
+		args = nil
+	})
+
+	Context("help message", func() {
+		BeforeEach(func() {
+			args = []string{artifactCmd, pullCmd, "--help"}
+		})
+
+		It("should match the saved one", func() {
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(string(output.Contents())).Should(Equal(help))
+		})
+	})
+
+	Context("wrong number of arguments", func() {
+		When("number of arguments equal to 0", func() {
+			BeforeEach(func() {
+				args = []string{artifactCmd, pullCmd}
+			})
+
+			assertFailedBehavior(usage, "ERROR accepts 1 arg(s), received 0 ")
+		})
+
+		When("number of arguments equal to 2", func() {
+			BeforeEach(func() {
+				args = []string{artifactCmd, pullCmd, "arg1", "arg2", configFlag, configFile}
+			})
+
+			assertFailedBehavior(usage, "ERROR accepts 1 arg(s), received 2 ")
+		})
+	})
+
+	Context("failure", func() {
+		When("invalid platform format", func() {
+			BeforeEach(func() {
+				args = []string{artifactCmd, pullCmd, rulesRef, plaingHTTP, configFlag, configFile, platformFlag, "linux"}
+			})
+
+			assertFailedBehavior(usage, "ERROR invalid platform format \"linux\": needs to be in OS/ARCH format")
+		})
+
+		When("--strip-components without --extract", func() {
+			BeforeEach(func() {
+				args = []string{artifactCmd, pullCmd, rulesRef, plaingHTTP, configFlag, configFile, "--strip-components", "1"}
+			})
+
+			assertFailedBehavior(usage, "ERROR --strip-components needs --extract")
+		})
+
+		When("non existing repository", func() {
+			BeforeEach(func() {
+				args = []string{artifactCmd, pullCmd, localRegistryHost + "/noartifact", plaingHTTP, configFlag, configFile,
+					outputDirFlag, outputDir}
+			})
+
+			assertFailedBehavior(usage, "noartifact:latest: not found")
+		})
+
+		When("no manifest for given platform", func() {
+			BeforeEach(func() {
+				args = []string{artifactCmd, pullCmd, pluginMultiPlatformRef, plaingHTTP, configFlag, configFile,
+					outputDirFlag, outputDir, platformFlag, "linux/wrong"}
+			})
+
+			assertFailedBehavior(usage, "ERROR unable to find a manifest matching the given platform: linux/wrong")
+		})
+	})
+
+	Context("success", func() {
+		When("pulling a rulesfile", func() {
+			BeforeEach(func() {
+				args = []string{artifactCmd, pullCmd, rulesRef, plaingHTTP, configFlag, configFile, outputDirFlag, outputDir}
+			})
+
+			It("should save the archive in the output directory without extracting it", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(output).Should(gbytes.Say("Artifact pulled"))
+				Expect(output).Should(gbytes.Say(regexp.QuoteMeta(filepath.Join(outputDir, "rules.tar.gz"))))
+				Expect(output).Should(gbytes.Say("digest: sha256:"))
+				entries, err := os.ReadDir(outputDir)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(entries).Should(HaveLen(1))
+				Expect(entries[0].Name()).Should(Equal("rules.tar.gz"))
+			})
+		})
+
+		When("pulling a plugin for a given platform", func() {
+			BeforeEach(func() {
+				args = []string{artifactCmd, pullCmd, pluginMultiPlatformRef, plaingHTTP, configFlag, configFile,
+					outputDirFlag, outputDir, platformFlag, testPluginPlatform3}
+			})
+
+			It("should save the archive in the output directory", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(filepath.Join(outputDir, "plugin.tar.gz")).Should(BeARegularFile())
+			})
+		})
+
+		When("extracting the artifact", func() {
+			BeforeEach(func() {
+				args = []string{artifactCmd, pullCmd, rulesRef, plaingHTTP, configFlag, configFile, outputDirFlag, outputDir, extractFlag}
+			})
+
+			It("should extract the content and remove the archive", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(output).Should(gbytes.Say("Artifact pulled and extracted"))
+				Expect(filepath.Join(outputDir, "rules.tar.gz")).ShouldNot(BeAnExistingFile())
+				entries, err := os.ReadDir(outputDir)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(entries).ShouldNot(BeEmpty())
+			})
+		})
+	})
+})
@@ -0,0 +1,295 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package push
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2"
+	orasoci "oras.land/oras-go/v2/content/oci"
+
+	registrypush "github.com/falcosecurity/falcoctl/cmd/registry/push"
+	"github.com/falcosecurity/falcoctl/internal/utils"
+	"github.com/falcosecurity/falcoctl/pkg/oci"
+	"github.com/falcosecurity/falcoctl/pkg/oci/builder"
+	"github.com/falcosecurity/falcoctl/pkg/oci/repository"
+	ociutils "github.com/falcosecurity/falcoctl/pkg/oci/utils"
+	"github.com/falcosecurity/falcoctl/pkg/options"
+	"github.com/falcosecurity/falcoctl/pkg/output"
+)
+
+const (
+	longPush = `Build Falco "rulesfile", "plugin" or "asset" OCI artifacts from local files and push them to a remote registry
+
+The files are either tar.gz archives, stored as they are, or loose files and directories, e.g. rulesfiles, which are
+archived first. Plugins get a manifest for each platform, listed in an image index.
+
+Example - Push artifact "myplugin.tar.gz" of type "plugin" for the platform where falcoctl is running (default):
+	falcoctl artifact push --type plugin --version "1.2.3" localhost:5000/myplugin:latest myplugin.tar.gz
+
+Example - Push artifact "myplugin.tar.gz" of type "plugin" for multiple platforms:
+	falcoctl artifact push --type plugin --version "1.2.3" localhost:5000/myplugin:latest \
+		myplugin-linux-x86_64.tar.gz --platform linux/x86_64 \
+		myplugin-linux-arm64.tar.gz --platform linux/arm64
+
+Example - Push the rulesfile "myrulesfile.yaml", reading its dependencies and requirements from its content:
+	falcoctl artifact push --type rulesfile --version "0.1.2" localhost:5000/myrulesfile:latest myrulesfile.yaml
+
+Example - Push the directory of rulesfiles "rules.d" of type "rulesfile" supporting Falco 0.36.0 and later:
+	falcoctl artifact push --type rulesfile --version "0.1.2" localhost:5000/myrules:latest rules.d --requires "falco>=0.36.0"
+
+Example - Build artifact "myrulesfile.tar.gz" of type "rulesfile" in an OCI image layout, to be installed with --from-dir:
+	falcoctl artifact push --type rulesfile --version "0.1.2" localhost:5000/myrulesfile:0.1.2 myrulesfile.tar.gz --oci-layout ./layout
+`
+
+	// FlagOCILayout is the name of the flag to specify the OCI image layout where the artifact is built.
+	FlagOCILayout = "oci-layout"
+)
+
+type artifactPushOptions struct {
+	*options.Common
+	*options.Artifact
+	*options.Registry
+	ociLayout string
+}
+
+// NewArtifactPushCmd returns the artifact push command.
+func NewArtifactPushCmd(ctx context.Context, opt *options.Common) *cobra.Command {
+	o := artifactPushOptions{
+		Common:   opt,
+		Artifact: &options.Artifact{},
+		Registry: &options.Registry{},
+	}
+
+	cmd := &cobra.Command{
+		Use:                   "push hostname/repo[:tag|@digest] file [file ...] [flags]",
+		DisableFlagsInUseLine: true,
+		Short:                 "Build a Falco OCI artifact from local files and push it to a remote registry",
+		Long:                  longPush,
+		Args:                  cobra.MinimumNArgs(2),
+		// Pushing resolves no names, hence the indexes loaded by the artifact commands are not needed.
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			opt.Initialize()
+			return opt.LoadConfig(cmd)
+		},
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Artifact.Validate(); err != nil {
+				return err
+			}
+			_, err := utils.GetRegistryFromRef(args[0])
+			return err
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.RunArtifactPush(ctx, args)
+		},
+	}
+	o.Registry.AddFlags(cmd)
+	output.ExitOnErr(o.Printer, o.Artifact.AddFlags(cmd))
+	cmd.Flags().StringVar(&o.ociLayout, FlagOCILayout, "",
+		"directory of the OCI image layout where the artifact is built and tagged, instead of pushing it")
+
+	return cmd
+}
+
+// RunArtifactPush executes the business logic for the artifact push command.
+func (o *artifactPushOptions) RunArtifactPush(ctx context.Context, args []string) error {
+	ref, paths := args[0], args[1:]
+	logger := o.Printer.Logger
+
+	layers, err := o.layers(paths)
+	if err != nil {
+		return err
+	}
+	config, err := o.config(ref, paths)
+	if err != nil {
+		return err
+	}
+	annotations, err := o.AnnotationsMap()
+	if err != nil {
+		return err
+	}
+	if o.AnnotationSource != "" {
+		if annotations == nil {
+			annotations = make(map[string]string, 1)
+		}
+		annotations[v1.AnnotationSource] = o.AnnotationSource
+	}
+	buildOpts := []builder.Option{builder.WithConfig(*config), builder.WithAnnotations(annotations)}
+
+	if o.ociLayout != "" {
+		logger.Info("Building artifact in OCI layout", logger.Args("name", ref, "type", o.ArtifactType, "layout", o.ociLayout))
+		return o.buildLayout(ctx, ref, layers, buildOpts)
+	}
+
+	registry, err := utils.GetRegistryFromRef(ref)
+	if err != nil {
+		return err
+	}
+	clientOpts, err := o.Registry.ClientOptions(o.Printer)
+	if err != nil {
+		return err
+	}
+	client, err := ociutils.Client(true, clientOpts...)
+	if err != nil {
+		return fmt.Errorf("an error occurred while creating the client for registry %s: %w", registry, err)
+	}
+
+	opCtx, cancel := o.OperationContext(ctx)
+	err = ociutils.CheckConnectionForRegistry(opCtx, client, o.PlainHTTP, registry)
+	cancel()
+	var connErr *ociutils.ConnectionError
+	if errors.As(err, &connErr) && connErr.Auth {
+		return fmt.Errorf("%w, please check the credentials configured with \"falcoctl registry auth\"", err)
+	} else if err != nil {
+		return err
+	}
+
+	repo, err := repository.NewRepository(ref, repository.WithClient(client), repository.WithPlainHTTP(o.PlainHTTP))
+	if err != nil {
+		return err
+	}
+	// As with registry push, the artifact is tagged with the first tag given when the reference has none, or ":latest".
+	tags := o.Tags
+	if repo.Reference.Reference == "" {
+		if len(tags) > 0 {
+			repo.Reference.Reference, tags = tags[0], tags[1:]
+		} else {
+			repo.Reference.Reference = oci.DefaultTag
+		}
+	}
+
+	logger.Info("Preparing to push artifact", logger.Args("name", ref, "type", o.ArtifactType))
+	opCtx, cancel = o.OperationContext(ctx)
+	defer cancel()
+
+	target := oras.Target(repo)
+	if tracker := output.NewTracker(o.Printer, "Pushing"); tracker != nil {
+		target = tracker(repo)
+	}
+	desc, err := builder.Build(opCtx, target, o.ArtifactType, layers, buildOpts...)
+	if err != nil {
+		return err
+	}
+	if err := repo.Tag(opCtx, *desc, repo.Reference.Reference); err != nil {
+		return fmt.Errorf("unable to tag artifact as %q: %w", repo.Reference.Reference, err)
+	}
+	if len(tags) > 0 {
+		if _, err := oras.TagN(opCtx, repo, repo.Reference.Reference, tags, oras.DefaultTagNOptions); err != nil {
+			return fmt.Errorf("unable to tag artifact: %w", err)
+		}
+	}
+
+	logger.Info("Artifact pushed", logger.Args("name", ref, "type", o.ArtifactType, "digest", desc.Digest.String()))
+	return nil
+}
+
+// buildLayout builds the artifact in the OCI image layout, tagged with ref and with ref under each of the given tags.
+func (o *artifactPushOptions) buildLayout(ctx context.Context, ref string, layers []builder.Layer, buildOpts []builder.Option) error {
+	desc, err := builder.BuildLayout(ctx, o.ociLayout, ref, o.ArtifactType, layers, buildOpts...)
+	if err != nil {
+		return err
+	}
+
+	if len(o.Tags) > 0 {
+		store, err := orasoci.New(o.ociLayout)
+		if err != nil {
+			return fmt.Errorf("unable to open OCI layout %q: %w", o.ociLayout, err)
+		}
+		repo, err := repository.NewRepository(ref)
+		if err != nil {
+			return err
+		}
+		for _, tag := range o.Tags {
+			taggedRef := fmt.Sprintf("%s/%s:%s", repo.Reference.Registry, repo.Reference.Repository, tag)
+			if err := store.Tag(ctx, *desc, taggedRef); err != nil {
+				return fmt.Errorf("unable to tag artifact as %q in OCI layout %q: %w", taggedRef, o.ociLayout, err)
+			}
+		}
+	}
+
+	o.Printer.Logger.Info("Artifact built", o.Printer.Logger.Args("name", ref, "type", o.ArtifactType,
+		"digest", desc.Digest.String(), "layout", o.ociLayout))
+	return nil
+}
+
+// layers returns the layers of the artifact, one for each path. Plugins are built for the platform where falcoctl
+// is running when a single file is given without platform.
+func (o *artifactPushOptions) layers(paths []string) ([]builder.Layer, error) {
+	platforms := o.Platforms
+	if o.ArtifactType == oci.Plugin {
+		if len(platforms) == 0 && len(paths) == 1 {
+			platforms = []string{fmt.Sprintf("%s/%s", runtime.GOOS, runtime.GOARCH)}
+		}
+		if len(platforms) != len(paths) {
+			return nil, fmt.Errorf("%d files and %d platforms given: a platform is needed for each file of a plugin",
+				len(paths), len(platforms))
+		}
+	}
+
+	layers := make([]builder.Layer, len(paths))
+	for i, path := range paths {
+		layers[i] = builder.Layer{Path: path}
+		if o.ArtifactType == oci.Plugin {
+			layers[i].Platform = platforms[i]
+		}
+	}
+	return layers, nil
+}
+
+// config returns the config layer of the artifact. The dependencies and requirements of a single loose rulesfile
+// are read from its content when not given.
+func (o *artifactPushOptions) config(ref string, paths []string) (*oci.ArtifactConfig, error) {
+	config := &oci.ArtifactConfig{Name: o.Name, Version: o.Version}
+	if o.ArtifactType == oci.Rulesfile && len(paths) == 1 && isLooseFile(paths[0]) {
+		var err error
+		if config, err = registrypush.RulesConfigLayer(o.Printer.Logger, paths[0], o.Artifact); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := config.ParseDependencies(o.Dependencies...); err != nil {
+			return nil, err
+		}
+		if err := config.ParseRequirements(o.ConfigRequirements()...); err != nil {
+			return nil, err
+		}
+	}
+
+	if config.Name == "" {
+		// extract artifact name from ref, if not provided by the user
+		name, err := utils.NameFromRef(ref)
+		if err != nil {
+			return nil, err
+		}
+		config.Name = name
+	}
+	return config, nil
+}
+
+// isLooseFile reports whether path is a regular file which is not a tar.gz archive.
+func isLooseFile(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return false
+	}
+	return errors.Is(utils.IsTarGz(filepath.Clean(path)), utils.ErrNotTarGz)
+}
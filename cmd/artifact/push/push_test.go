@@ -0,0 +1,210 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package push_test
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	orasoci "oras.land/oras-go/v2/content/oci"
+
+	"github.com/falcosecurity/falcoctl/cmd"
+	"github.com/falcosecurity/falcoctl/pkg/oci"
+	testutils "github.com/falcosecurity/falcoctl/pkg/test"
+)
+
+var randomRepoName = func(registry, repo string) (string, string) {
+	rName := fmt.Sprintf("%s-%d", repo, rand.Int())
+	return rName, fmt.Sprintf("%s/%s", registry, rName)
+}
+
+var _ = Describe("artifact push", func() {
+	var (
+		artifactCmd = "artifact"
+		pushCmd     = "push"
+	)
+
+	JustBeforeEach(func() {
+		rootCmd = cmd.New(ctx, opt)
+		err = executeRoot(args)
+	})
+
+	JustAfterEach(func() {
+		Expect(output.Clear()).ShouldNot(HaveOccurred())
+	})
+
+	Context("help message", func() {
+		BeforeEach(func() {
+			args = []string{artifactCmd, pushCmd, "--help"}
+		})
+
+		It("should show the artifact push command in the examples and usage", func() {
+			outputMsg := string(output.Contents())
+			Expect(outputMsg).Should(ContainSubstring("falcoctl artifact push --type rulesfile"))
+			Expect(outputMsg).ShouldNot(ContainSubstring("falcoctl registry push"))
+			Expect(outputMsg).Should(ContainSubstring("falcoctl artifact push hostname/repo[:tag|@digest] file [file ...] [flags]"))
+		})
+	})
+
+	Context("failure", func() {
+		When("the platforms do not match the files of a plugin", func() {
+			BeforeEach(func() {
+				_, fullRepoName := randomRepoName(registry, "artifact-push-platforms")
+				args = []string{artifactCmd, pushCmd, fullRepoName, plugintgz, plugintgz, "--config", configFile, "--type", "plugin",
+					"--version", "1.1.1", "--platform", "linux/amd64", "--plain-http"}
+			})
+
+			It("should fail before pushing anything", func() {
+				Expect(err).Should(MatchError(ContainSubstring("2 files and 1 platforms given")))
+			})
+		})
+
+		When("a rulesfile is given more than one file", func() {
+			BeforeEach(func() {
+				_, fullRepoName := randomRepoName(registry, "artifact-push-layers")
+				args = []string{artifactCmd, pushCmd, fullRepoName, rulesfiletgz, rulesfiletgz, "--config", configFile,
+					"--type", "rulesfile", "--version", "1.1.1", "--plain-http"}
+			})
+
+			It("should fail as rulesfiles have a single layer", func() {
+				Expect(err).Should(MatchError(ContainSubstring("expecting 1 layer for rulesfile artifacts, received 2")))
+			})
+		})
+	})
+
+	Context("success", func() {
+		var repoName, fullRepoName string
+
+		When("pushing a rulesfile archive", func() {
+			BeforeEach(func() {
+				repoName, fullRepoName = randomRepoName(registry, "artifact-push-rulesfile")
+				args = []string{artifactCmd, pushCmd, fullRepoName, rulesfiletgz, "--config", configFile, "--type", "rulesfile",
+					"--version", "1.1.1", "--tag", "1.1", "--tag", "1", "--plain-http"}
+			})
+
+			It("should push the artifact tagged with the given tags", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				rulesfileData, err := testutils.FetchRulesfileFromRegistry(ctx, repoName, "1.1", orasRegistry)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(rulesfileData.Descriptor.MediaType).Should(Equal(v1.MediaTypeImageManifest))
+				Expect(output).Should(gbytes.Say(regexp.QuoteMeta(rulesfileData.Descriptor.Digest.String())))
+				Expect(rulesfileData.Layer.Config.Version).Should(Equal("1.1.1"))
+				Expect(rulesfileData.Layer.Config.Name).Should(Equal(repoName))
+				Expect(rulesfileData.Layer.Manifest.Config.MediaType).Should(Equal(oci.FalcoRulesfileConfigMediaType))
+				Expect(rulesfileData.Layer.Manifest.Layers).Should(HaveLen(1))
+				Expect(rulesfileData.Layer.Manifest.Layers[0].MediaType).Should(Equal(oci.FalcoRulesfileLayerMediaType))
+				Expect(rulesfileData.Tags).Should(ConsistOf("1.1", "1"))
+			})
+		})
+
+		When("pushing a loose rulesfile", func() {
+			BeforeEach(func() {
+				repoName, fullRepoName = randomRepoName(registry, "artifact-push-loose")
+				args = []string{artifactCmd, pushCmd, fullRepoName + ":0.1.0", rulesFileWithDepsAndReq, "--config", configFile,
+					"--type", "rulesfile", "--version", "0.1.0", "--requires", "falco>=0.36.0", "--plain-http"}
+			})
+
+			It("should archive it and read its dependencies and requirements", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				rulesfileData, err := testutils.FetchRulesfileFromRegistry(ctx, repoName, "0.1.0", orasRegistry)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(rulesfileData.Layer.Manifest.Layers[0].Annotations).Should(HaveKeyWithValue(v1.AnnotationTitle, "rules.tar.gz"))
+				Expect(rulesfileData.Layer.Config.Dependencies).ShouldNot(BeEmpty())
+				Expect(rulesfileData.Layer.Config.Requirements).ShouldNot(BeEmpty())
+				Expect(rulesfileData.Layer.Manifest.Annotations).Should(HaveKeyWithValue(oci.FalcoMinVersionAnnotation, "0.36.0"))
+			})
+		})
+
+		When("pushing a directory of rulesfiles", func() {
+			BeforeEach(func() {
+				dir := filepath.Join(GinkgoT().TempDir(), "rules.d")
+				Expect(os.MkdirAll(dir, 0o755)).To(Succeed())
+				data, err := os.ReadFile(rulesfileyaml)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(os.WriteFile(filepath.Join(dir, "first_rules.yaml"), data, 0o600)).To(Succeed())
+				Expect(os.WriteFile(filepath.Join(dir, "second_rules.yaml"), data, 0o600)).To(Succeed())
+
+				repoName, fullRepoName = randomRepoName(registry, "artifact-push-dir")
+				args = []string{artifactCmd, pushCmd, fullRepoName, dir, "--config", configFile, "--type", "rulesfile",
+					"--version", "0.1.0", "--annotation-source", "github.com/falcosecurity/rules", "--plain-http"}
+			})
+
+			It("should push the directory as a single layer", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				rulesfileData, err := testutils.FetchRulesfileFromRegistry(ctx, repoName, "latest", orasRegistry)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(rulesfileData.Layer.Manifest.Layers).Should(HaveLen(1))
+				Expect(rulesfileData.Layer.Manifest.Layers[0].Annotations).Should(HaveKeyWithValue(v1.AnnotationTitle, "rules.d.tar.gz"))
+				Expect(rulesfileData.Layer.Manifest.Annotations).Should(HaveKeyWithValue(v1.AnnotationSource, "github.com/falcosecurity/rules"))
+			})
+		})
+
+		When("pushing a plugin for several platforms", func() {
+			BeforeEach(func() {
+				repoName, fullRepoName = randomRepoName(registry, "artifact-push-plugin")
+				args = []string{artifactCmd, pushCmd, fullRepoName, plugintgz, plugintgz, "--config", configFile, "--type", "plugin",
+					"--version", "1.2.3", "--platform", "linux/amd64", "--platform", "linux/arm64", "--depends-on", "my-dep:1.0.0",
+					"--plain-http"}
+			})
+
+			It("should push an image index with a manifest for each platform", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				pluginData, err := testutils.FetchPluginFromRegistry(ctx, repoName, "latest", orasRegistry)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(pluginData.Descriptor.MediaType).Should(Equal(v1.MediaTypeImageIndex))
+				Expect(pluginData.Platforms).Should(HaveLen(2))
+				Expect(pluginData.Platforms).Should(HaveKey("linux/amd64"))
+				Expect(pluginData.Platforms).Should(HaveKey("linux/arm64"))
+				for _, layer := range pluginData.Platforms {
+					Expect(layer.Config.Version).Should(Equal("1.2.3"))
+					Expect(layer.Config.Dependencies).Should(HaveLen(1))
+					Expect(layer.Manifest.Layers[0].MediaType).Should(Equal(oci.FalcoPluginLayerMediaType))
+				}
+			})
+		})
+
+		When("building an OCI image layout", func() {
+			var layoutDir string
+
+			BeforeEach(func() {
+				layoutDir = filepath.Join(GinkgoT().TempDir(), "layout")
+				fullRepoName = "ghcr.io/falcosecurity/rules/my-rules:0.1.0"
+				args = []string{artifactCmd, pushCmd, fullRepoName, rulesfiletgz, "--config", configFile, "--type", "rulesfile",
+					"--version", "0.1.0", "--tag", "latest", "--oci-layout", layoutDir}
+			})
+
+			It("should build and tag the artifact in the layout without pushing it", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				store, err := orasoci.NewFromFS(ctx, os.DirFS(layoutDir))
+				Expect(err).ShouldNot(HaveOccurred())
+				desc, err := store.Resolve(ctx, fullRepoName)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(desc.MediaType).Should(Equal(v1.MediaTypeImageManifest))
+				latest, err := store.Resolve(ctx, "ghcr.io/falcosecurity/rules/my-rules:latest")
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(latest.Digest).Should(Equal(desc.Digest))
+				Expect(output).Should(gbytes.Say(regexp.QuoteMeta(desc.Digest.String())))
+			})
+		})
+	})
+})
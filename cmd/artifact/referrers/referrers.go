@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package referrers
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	ociutils "github.com/falcosecurity/falcoctl/pkg/oci/utils"
+	"github.com/falcosecurity/falcoctl/pkg/options"
+	"github.com/falcosecurity/falcoctl/pkg/output"
+)
+
+const (
+	longReferrers = `List the artifacts attached to a given artifact, e.g. its signatures, SBOMs or attestations.
+
+The attached artifacts are the manifests having the given artifact as subject. They are discovered through the
+referrers API of the registry, falling back to the referrers tag schema for the registries not supporting it, and
+they are listed grouped by artifact type. If no tag or digest is given, "latest" is used.
+
+Example - List the artifacts attached to the "k8saudit-rules" rulesfile:
+	falcoctl artifact referrers k8saudit-rules
+
+Example - List only the SBOMs attached to a given version of the "k8saudit" plugin:
+	falcoctl artifact referrers ghcr.io/falcosecurity/plugins/plugin/k8saudit:0.7.0 --artifact-type application/spdx+json
+`
+
+	// FlagArtifactType is the name of the flag to only list the attached artifacts of a given type.
+	FlagArtifactType = "artifact-type"
+)
+
+type artifactReferrersOptions struct {
+	*options.Common
+	*options.Registry
+	artifactType string
+}
+
+// NewArtifactReferrersCmd returns the artifact referrers command.
+func NewArtifactReferrersCmd(ctx context.Context, opt *options.Common) *cobra.Command {
+	o := artifactReferrersOptions{
+		Common:   opt,
+		Registry: &options.Registry{},
+	}
+
+	cmd := &cobra.Command{
+		Use:                   "referrers ref [flags]",
+		DisableFlagsInUseLine: true,
+		Short:                 "List the artifacts attached to a given artifact",
+		Long:                  longReferrers,
+		Args:                  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.RunArtifactReferrers(ctx, args)
+		},
+	}
+
+	o.Registry.AddFlags(cmd)
+	cmd.Flags().StringVar(&o.artifactType, FlagArtifactType, "",
+		"only list the attached artifacts of the given type, e.g. application/spdx+json")
+
+	return cmd
+}
+
+// artifactReferrer is an artifact attached to another one, as printed in the structured output.
+type artifactReferrer struct {
+	ArtifactType string            `json:"artifactType"`
+	Digest       string            `json:"digest"`
+	MediaType    string            `json:"mediaType"`
+	Size         int64             `json:"size"`
+	Annotations  map[string]string `json:"annotations,omitempty"`
+}
+
+// RunArtifactReferrers lists the artifacts attached to the given artifact.
+func (o *artifactReferrersOptions) RunArtifactReferrers(ctx context.Context, args []string) error {
+	logger := o.Printer.Logger
+
+	puller, err := ociutils.Puller(o.Registry, o.Printer)
+	if err != nil {
+		return err
+	}
+
+	ref, err := o.IndexCache.ResolveReference(args[0])
+	if err != nil {
+		return err
+	}
+
+	opCtx, cancel := o.OperationContext(ctx)
+	defer cancel()
+
+	referrers, err := puller.Referrers(opCtx, ref, o.artifactType)
+	if err != nil {
+		return err
+	}
+
+	var data [][]string
+	results := []artifactReferrer{}
+	for _, desc := range referrers {
+		data = append(data, []string{desc.ArtifactType, desc.Digest.String(), desc.MediaType, strconv.FormatInt(desc.Size, 10)})
+		results = append(results, artifactReferrer{
+			ArtifactType: desc.ArtifactType,
+			Digest:       desc.Digest.String(),
+			MediaType:    desc.MediaType,
+			Size:         desc.Size,
+			Annotations:  desc.Annotations,
+		})
+	}
+
+	// Print the table header + data only if there is data, the structured output is always printed.
+	if len(data) > 0 || o.Printer.StructuredOutput() {
+		return o.Printer.PrintResults(results, output.ArtifactReferrers, data)
+	}
+
+	logger.Info("No artifact attached", logger.Args("ref", ref))
+	return nil
+}
@@ -0,0 +1,263 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remove
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/falcosecurity/falcoctl/internal/config"
+	"github.com/falcosecurity/falcoctl/internal/utils"
+	"github.com/falcosecurity/falcoctl/pkg/lockfile"
+	"github.com/falcosecurity/falcoctl/pkg/options"
+)
+
+const (
+	longRemove = `This command removes one or more installed artifacts, given their names.
+
+The files written while installing an artifact are recorded in the lockfile, together with the artifact name:
+exactly those files are removed, and the artifact entry is removed from the lockfile. Directories are removed only
+if empty, while files also installed by other artifacts are kept.
+When an artifact is not recorded in the lockfile, the rulesfile with the same name, optionally followed by the
+".yaml" or ".yml" extension, is removed from the rulesfiles directory.
+The rulesfiles installed with --versioned-layout are removed with all their versions, i.e. the whole
+<rulesfiles-dir>/<name> directory holding them and the "current" symlink.
+
+Example - Remove the "k8saudit-rules" and "k8saudit" artifacts:
+	falcoctl artifact remove k8saudit-rules k8saudit
+
+Example - Print the files that would be removed, without removing anything:
+	falcoctl artifact remove k8saudit-rules --dry-run
+`
+
+	// FlagLockFile is the name of the flag to specify the lockfile recording the installed artifacts.
+	FlagLockFile = "lock-file"
+
+	// FlagDryRun is the name of the flag to only print the files that would be removed.
+	FlagDryRun = "dry-run"
+)
+
+// rulesfileExtensions are the extensions tried when looking up a rulesfile by name.
+var rulesfileExtensions = []string{"", ".yaml", ".yml"}
+
+// currentLink is the symlink to the version in use of a rulesfile installed with the versioned layout of the
+// install command, in the directory holding all its versions.
+const currentLink = "current"
+
+type artifactRemoveOptions struct {
+	*options.Common
+	rulesfilesDir string
+	lockFile      string
+	dryRun        bool
+}
+
+// NewArtifactRemoveCmd returns the artifact remove command.
+func NewArtifactRemoveCmd(ctx context.Context, opt *options.Common) *cobra.Command {
+	o := artifactRemoveOptions{
+		Common: opt,
+	}
+
+	cmd := &cobra.Command{
+		Use:                   "remove name1 [name2 ...] [flags]",
+		DisableFlagsInUseLine: true,
+		Short:                 "Remove installed artifacts",
+		Long:                  longRemove,
+		Args:                  cobra.MinimumNArgs(1),
+		Aliases:               []string{"uninstall", "rm"},
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			// Override "rulesfiles-dir" flag with viper config if not set by user.
+			f := cmd.Flags().Lookup(options.FlagRulesFilesDir)
+			if f == nil {
+				// should never happen
+				return fmt.Errorf("unable to retrieve flag %q", options.FlagRulesFilesDir)
+			} else if !f.Changed && viper.IsSet(config.ArtifactInstallRulesfilesDirKey) {
+				val := viper.Get(config.ArtifactInstallRulesfilesDirKey)
+				if err := cmd.Flags().Set(f.Name, fmt.Sprintf("%v", val)); err != nil {
+					return fmt.Errorf("unable to overwrite %q flag: %w", options.FlagRulesFilesDir, err)
+				}
+			}
+
+			return utils.ExpandPaths(&o.rulesfilesDir, &o.lockFile)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.RunArtifactRemove(ctx, args)
+		},
+	}
+
+	cmd.Flags().StringVar(&o.rulesfilesDir, options.FlagRulesFilesDir, config.RulesfilesDir,
+		"directory where the rulesfiles not recorded in the lockfile are looked up")
+	cmd.Flags().StringVar(&o.lockFile, FlagLockFile, config.LockFile,
+		"path of the lockfile where the files of the installed artifacts are recorded")
+	cmd.Flags().BoolVar(&o.dryRun, FlagDryRun, false,
+		"print the files that would be removed without removing anything")
+
+	return cmd
+}
+
+// RunArtifactRemove executes the business logic for the artifact remove command.
+func (o *artifactRemoveOptions) RunArtifactRemove(_ context.Context, args []string) error {
+	logger := o.Printer.Logger
+
+	lock, err := lockfile.New(o.lockFile)
+	if err != nil {
+		return fmt.Errorf("unable to read lockfile %q: %w", o.lockFile, err)
+	}
+
+	lockChanged := false
+	for _, name := range args {
+		if dir := o.versionedDirOf(name, lock); dir != "" {
+			if err := o.removeVersionedDir(name, dir); err != nil {
+				return err
+			}
+			if !o.dryRun && lock.Remove(name) {
+				lockChanged = true
+			}
+			continue
+		}
+
+		files, err := o.filesOf(name, lock)
+		if err != nil {
+			return err
+		}
+
+		if o.dryRun {
+			for _, path := range files {
+				logger.Info("File would be removed", logger.Args("artifact", name, "path", path))
+			}
+			continue
+		}
+
+		logger.Info("Removing artifact", logger.Args("name", name))
+		if err := removeFiles(files); err != nil {
+			return fmt.Errorf("unable to remove artifact %q: %w", name, err)
+		}
+		if lock.Remove(name) {
+			lockChanged = true
+		}
+		logger.Info("Artifact successfully removed", logger.Args("name", name, "files", len(files)))
+	}
+
+	if lockChanged {
+		if err := lock.Write(o.lockFile); err != nil {
+			return fmt.Errorf("unable to update lockfile %q: %w", o.lockFile, err)
+		}
+	}
+
+	return nil
+}
+
+// versionedDirOf returns the directory holding the versions of the artifact with the given name when installed
+// with the versioned layout: the one recorded in the lockfile or, for the artifacts not recorded there, the
+// directory of the rulesfiles directory with the same name holding the current symlink.
+func (o *artifactRemoveOptions) versionedDirOf(name string, lock *lockfile.Lockfile) string {
+	if entry := lock.Get(name); entry != nil {
+		return entry.VersionedDir
+	}
+
+	dir := filepath.Join(o.rulesfilesDir, name)
+	if info, err := os.Lstat(filepath.Join(dir, currentLink)); err == nil && info.Mode()&os.ModeSymlink != 0 {
+		return dir
+	}
+	return ""
+}
+
+// removeVersionedDir removes the directory holding all the versions of the artifact with the given name.
+func (o *artifactRemoveOptions) removeVersionedDir(name, dir string) error {
+	logger := o.Printer.Logger
+
+	if o.dryRun {
+		logger.Info("Directory would be removed with all the versions", logger.Args("artifact", name, "path", dir))
+		return nil
+	}
+
+	logger.Info("Removing artifact", logger.Args("name", name))
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("unable to remove artifact %q: %w", name, err)
+	}
+	logger.Info("Artifact successfully removed", logger.Args("name", name, "directory", dir))
+
+	return nil
+}
+
+// filesOf returns the files to be removed for the artifact with the given name: those recorded in the lockfile and
+// not shared with other artifacts or, for the artifacts not recorded there, the rulesfile with the same name.
+func (o *artifactRemoveOptions) filesOf(name string, lock *lockfile.Lockfile) ([]string, error) {
+	if entry := lock.Get(name); entry != nil && len(entry.Files) > 0 {
+		var files []string
+		for _, path := range entry.Files {
+			if owner := otherOwner(lock, entry, path); owner != "" {
+				o.Printer.Logger.Debug("Keeping file shared with another artifact", o.Printer.Logger.Args("path", path, "artifact", owner))
+				continue
+			}
+			files = append(files, path)
+		}
+		return files, nil
+	}
+
+	for _, ext := range rulesfileExtensions {
+		path := filepath.Join(o.rulesfilesDir, name+ext)
+		if info, err := os.Stat(path); err == nil && info.Mode().IsRegular() {
+			return []string{path}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("artifact %q is neither recorded in lockfile %q nor found in %q", name, o.lockFile, o.rulesfilesDir)
+}
+
+// otherOwner returns the name of another artifact that installed the file at path, if any.
+func otherOwner(lock *lockfile.Lockfile, entry *lockfile.Entry, path string) string {
+	for _, e := range lock.Artifacts {
+		if e == entry {
+			continue
+		}
+		for _, f := range e.Files {
+			if f == path {
+				return e.Name
+			}
+		}
+	}
+	return ""
+}
+
+// removeFiles removes the given files, ignoring those already missing. Since parent directories precede their
+// content, paths are removed in reverse order and directories only if empty.
+func removeFiles(paths []string) error {
+	for i := len(paths) - 1; i >= 0; i-- {
+		info, err := os.Lstat(paths[i])
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			if entries, err := os.ReadDir(paths[i]); err != nil || len(entries) > 0 {
+				continue
+			}
+		}
+		if err := os.Remove(paths[i]); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,194 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remove_test
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/falcosecurity/falcoctl/cmd"
+	"github.com/falcosecurity/falcoctl/pkg/lockfile"
+)
+
+var _ = Describe("remove", func() {
+	const (
+		artifactCmd = "artifact"
+		removeCmd   = "remove"
+	)
+
+	var (
+		pluginsDir    string
+		rulesfilesDir string
+		lockFile      string
+		pluginFiles   []string
+	)
+
+	// Each test gets its own root command and runs it.
+	// The err variable is asserted by each test.
+	JustBeforeEach(func() {
+		rootCmd = cmd.New(ctx, opt)
+		err = executeRoot(args)
+	})
+
+	JustAfterEach(func() {
+		Expect(output.Clear()).ShouldNot(HaveOccurred())
+	})
+
+	BeforeEach(func() {
+		baseDir := GinkgoT().TempDir()
+		pluginsDir = filepath.Join(baseDir, "plugins")
+		rulesfilesDir = filepath.Join(baseDir, "rules")
+		lockFile = filepath.Join(baseDir, "falcoctl.lock.yaml")
+
+		// Simulate the install of a plugin, sharing its README with another plugin, and of a rulesfile.
+		pluginFiles = []string{filepath.Join(pluginsDir, "docs"), filepath.Join(pluginsDir, "docs", "cloudtrail.md"),
+			filepath.Join(pluginsDir, "libcloudtrail.so"), filepath.Join(pluginsDir, "README.md")}
+		Expect(os.MkdirAll(filepath.Join(pluginsDir, "docs"), 0o755)).Should(Succeed())
+		Expect(os.MkdirAll(rulesfilesDir, 0o755)).Should(Succeed())
+		for _, path := range append(pluginFiles[1:], filepath.Join(rulesfilesDir, "custom_rules.yaml")) {
+			Expect(os.WriteFile(path, []byte("content"), 0o600)).Should(Succeed())
+		}
+
+		lock := &lockfile.Lockfile{}
+		lock.Upsert(&lockfile.Entry{Name: "cloudtrail", Files: pluginFiles})
+		lock.Upsert(&lockfile.Entry{Name: "k8saudit", Files: []string{filepath.Join(pluginsDir, "README.md")}})
+		Expect(lock.Write(lockFile)).Should(Succeed())
+	})
+
+	removeArgs := func(extra ...string) []string {
+		return append([]string{artifactCmd, removeCmd, "--config", configFile, "--lock-file", lockFile,
+			"--rulesfiles-dir", rulesfilesDir}, extra...)
+	}
+
+	When("the artifact is recorded in the lockfile", func() {
+		BeforeEach(func() {
+			args = removeArgs("cloudtrail")
+		})
+
+		It("should remove its files, keeping the shared ones, and its lockfile entry", func() {
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(filepath.Join(pluginsDir, "libcloudtrail.so")).ShouldNot(BeAnExistingFile())
+			Expect(filepath.Join(pluginsDir, "docs")).ShouldNot(BeAnExistingFile())
+			Expect(filepath.Join(pluginsDir, "README.md")).Should(BeARegularFile())
+
+			lock, err := lockfile.New(lockFile)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(lock.Get("cloudtrail")).Should(BeNil())
+			Expect(lock.Get("k8saudit")).ShouldNot(BeNil())
+		})
+	})
+
+	When("with --dry-run", func() {
+		BeforeEach(func() {
+			args = removeArgs("cloudtrail", "--dry-run")
+		})
+
+		It("should print the files without removing anything", func() {
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(output).Should(gbytes.Say(regexp.QuoteMeta("File would be removed")))
+			for _, path := range pluginFiles {
+				Expect(path).Should(BeAnExistingFile())
+			}
+
+			lock, err := lockfile.New(lockFile)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(lock.Get("cloudtrail")).ShouldNot(BeNil())
+		})
+	})
+
+	When("the artifact is a rulesfile not recorded in the lockfile", func() {
+		BeforeEach(func() {
+			args = removeArgs("custom_rules")
+		})
+
+		It("should remove the rulesfile with the same name", func() {
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(filepath.Join(rulesfilesDir, "custom_rules.yaml")).ShouldNot(BeAnExistingFile())
+		})
+	})
+
+	When("the artifact is a rulesfile installed with the versioned layout", func() {
+		var versionedDir string
+
+		BeforeEach(func() {
+			versionedDir = filepath.Join(rulesfilesDir, "k8saudit-rules")
+			current := filepath.Join(versionedDir, "0.2.0", "k8saudit_rules.yaml")
+			for _, version := range []string{"0.1.0", "0.2.0"} {
+				Expect(os.MkdirAll(filepath.Join(versionedDir, version), 0o755)).Should(Succeed())
+				Expect(os.WriteFile(filepath.Join(versionedDir, version, "k8saudit_rules.yaml"), []byte("content"), 0o600)).Should(Succeed())
+			}
+			Expect(os.Symlink("0.2.0", filepath.Join(versionedDir, "current"))).Should(Succeed())
+
+			lock, err := lockfile.New(lockFile)
+			Expect(err).ShouldNot(HaveOccurred())
+			lock.Upsert(&lockfile.Entry{Name: "k8saudit-rules", Files: []string{current}, VersionedDir: versionedDir})
+			Expect(lock.Write(lockFile)).Should(Succeed())
+			args = removeArgs("k8saudit-rules")
+		})
+
+		It("should remove all its versions and its lockfile entry", func() {
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(versionedDir).ShouldNot(BeAnExistingFile())
+			Expect(rulesfilesDir).Should(BeADirectory())
+
+			lock, err := lockfile.New(lockFile)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(lock.Get("k8saudit-rules")).Should(BeNil())
+		})
+
+		When("with --dry-run", func() {
+			BeforeEach(func() {
+				args = removeArgs("k8saudit-rules", "--dry-run")
+			})
+
+			It("should print the directory without removing anything", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(output).Should(gbytes.Say(regexp.QuoteMeta("Directory would be removed with all the versions")))
+				Expect(versionedDir).Should(BeADirectory())
+			})
+		})
+
+		When("the artifact is not recorded in the lockfile", func() {
+			BeforeEach(func() {
+				lock, err := lockfile.New(lockFile)
+				Expect(err).ShouldNot(HaveOccurred())
+				lock.Remove("k8saudit-rules")
+				Expect(lock.Write(lockFile)).Should(Succeed())
+			})
+
+			It("should remove the directory holding the current symlink", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(versionedDir).ShouldNot(BeAnExistingFile())
+			})
+		})
+	})
+
+	When("the artifact is not installed", func() {
+		BeforeEach(func() {
+			args = removeArgs("missing")
+		})
+
+		It("should fail", func() {
+			Expect(err).Should(HaveOccurred())
+			Expect(output).Should(gbytes.Say(regexp.QuoteMeta(`ERROR artifact "missing" is neither recorded in lockfile`)))
+		})
+	})
+})
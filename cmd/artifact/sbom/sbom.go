@@ -0,0 +1,115 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/falcosecurity/falcoctl/internal/utils"
+	ociutils "github.com/falcosecurity/falcoctl/pkg/oci/utils"
+	"github.com/falcosecurity/falcoctl/pkg/options"
+)
+
+const (
+	longSbom = `Fetch the SBOM attached to a given artifact.
+
+The SBOM is the SPDX or CycloneDX document attached to the artifact as a referrer, as listed by the
+"artifact referrers" command, an SPDX one being preferred when both are attached. It is printed to stdout, or
+written to the file given through the --output flag. The command fails if the artifact has no SBOM attached.
+If no tag or digest is given, "latest" is used.
+
+Example - Save the SBOM of the "k8saudit" plugin:
+	falcoctl artifact sbom k8saudit --output k8saudit.spdx.json
+`
+
+	// FlagOutput is the name of the flag to specify the file where the SBOM is written.
+	FlagOutput = "output"
+)
+
+type artifactSbomOptions struct {
+	*options.Common
+	*options.Registry
+	output string
+}
+
+// NewArtifactSbomCmd returns the artifact sbom command.
+func NewArtifactSbomCmd(ctx context.Context, opt *options.Common) *cobra.Command {
+	o := artifactSbomOptions{
+		Common:   opt,
+		Registry: &options.Registry{},
+	}
+
+	cmd := &cobra.Command{
+		Use:                   "sbom ref [flags]",
+		DisableFlagsInUseLine: true,
+		Short:                 "Fetch the SBOM attached to a given artifact",
+		Long:                  longSbom,
+		Args:                  cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return utils.ExpandPaths(&o.output)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.RunArtifactSbom(ctx, args)
+		},
+	}
+
+	o.Registry.AddFlags(cmd)
+	cmd.Flags().StringVarP(&o.output, FlagOutput, "o", "", "file where the SBOM is written. If not set, it is printed to stdout")
+
+	return cmd
+}
+
+// RunArtifactSbom fetches the SBOM attached to the given artifact.
+func (o *artifactSbomOptions) RunArtifactSbom(ctx context.Context, args []string) error {
+	logger := o.Printer.Logger
+
+	puller, err := ociutils.Puller(o.Registry, o.Printer)
+	if err != nil {
+		return err
+	}
+
+	ref, err := o.IndexCache.ResolveReference(args[0])
+	if err != nil {
+		return err
+	}
+
+	opCtx, cancel := o.OperationContext(ctx)
+	defer cancel()
+
+	sbom, err := puller.SBOM(opCtx, ref)
+	if err != nil {
+		return err
+	}
+	if sbom == nil {
+		return fmt.Errorf("no SBOM attached to %q", ref)
+	}
+
+	if o.output == "" {
+		o.Printer.DefaultText.Println(string(sbom.Content))
+		return nil
+	}
+
+	if err := os.WriteFile(o.output, sbom.Content, 0o644); err != nil {
+		return fmt.Errorf("unable to write SBOM %q: %w", o.output, err)
+	}
+
+	logger.Info("SBOM saved", logger.Args("ref", ref, "type", sbom.ArtifactType, "path", o.output))
+	return nil
+}
@@ -30,8 +30,27 @@ const (
 	defaultMinScore = 0.65
 	// CommandName name of the command. It has to be the first word in the use line.
 	CommandName = "search"
+
+	longSearch = `Search an artifact by keywords across all the configured indexes.
+
+An artifact matches when its name is similar enough to one of the keywords, see --min-score,
+or when one of the keywords is part of its name, description or keywords, ignoring the case.
+
+Example - Search the artifacts related to Kubernetes:
+	falcoctl artifact search kubernetes
+`
 )
 
+// artifactEntry is an artifact found in the indexes, as printed in the structured output.
+type artifactEntry struct {
+	Index       string `json:"index"`
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Registry    string `json:"registry"`
+	Repository  string `json:"repository"`
+	Description string `json:"description,omitempty"`
+}
+
 type artifactSearchOptions struct {
 	*options.Common
 	minScore     float64
@@ -56,7 +75,7 @@ func NewArtifactSearchCmd(ctx context.Context, opt *options.Common) *cobra.Comma
 		Use:                   fmt.Sprintf("%s [keyword1 [keyword2 ...]] [flags]", CommandName),
 		DisableFlagsInUseLine: true,
 		Short:                 "Search an artifact by keywords",
-		Long:                  "Search an artifact by keywords",
+		Long:                  longSearch,
 		Args:                  cobra.MinimumNArgs(1),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			return o.Validate()
@@ -78,14 +97,19 @@ func (o *artifactSearchOptions) RunArtifactSearch(_ context.Context, args []stri
 	resultEntries := o.IndexCache.MergedIndexes.SearchByKeywords(o.minScore, args...)
 
 	var data [][]string
+	results := []artifactEntry{}
 	for _, entry := range resultEntries {
 		if o.artifactType != "" && o.artifactType != oci.ArtifactType(entry.Type) {
 			continue
 		}
 		indexName := o.IndexCache.MergedIndexes.IndexByEntry(entry).Name
-		row := []string{indexName, entry.Name, entry.Type, entry.Registry, entry.Repository}
+		row := []string{indexName, entry.Name, entry.Type, entry.Registry, entry.Repository, entry.Description}
 		data = append(data, row)
+		results = append(results, artifactEntry{
+			Index: indexName, Name: entry.Name, Type: entry.Type,
+			Registry: entry.Registry, Repository: entry.Repository, Description: entry.Description,
+		})
 	}
 
-	return o.Printer.PrintTable(output.ArtifactSearch, data)
+	return o.Printer.PrintResults(results, output.ArtifactSearch, data)
 }
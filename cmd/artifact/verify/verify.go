@@ -0,0 +1,216 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/falcosecurity/falcoctl/internal/config"
+	"github.com/falcosecurity/falcoctl/internal/utils"
+	"github.com/falcosecurity/falcoctl/pkg/lockfile"
+	ocipuller "github.com/falcosecurity/falcoctl/pkg/oci/puller"
+	ociutils "github.com/falcosecurity/falcoctl/pkg/oci/utils"
+	"github.com/falcosecurity/falcoctl/pkg/options"
+	"github.com/falcosecurity/falcoctl/pkg/output"
+)
+
+const (
+	longVerify = `This command verifies that the installed artifacts still match what has been installed.
+
+The files of each artifact recorded in the lockfile are hashed again and compared with the digests recorded
+when installing it. Each file is reported as "ok", "modified", "missing" or "unverified", the latter when no
+digest has been recorded for it, e.g. by an older version of falcoctl. When no name is given, all the artifacts
+recorded in the lockfile are verified.
+With --remote, the digest each reference currently points to in the registry is also compared with the
+installed one, reporting the artifacts whose tag has moved as "outdated".
+The command fails if any file is modified or missing, or any artifact is outdated.
+
+Example - Verify all the installed artifacts:
+	falcoctl artifact verify
+
+Example - Verify the "k8saudit-rules" artifact, also against the registry:
+	falcoctl artifact verify k8saudit-rules --remote
+`
+
+	// FlagLockFile is the name of the flag to specify the lockfile recording the installed artifacts.
+	FlagLockFile = "lock-file"
+
+	// FlagRemote is the name of the flag to also compare the installed digests with the registry ones.
+	FlagRemote = "remote"
+)
+
+const (
+	statusOK         = "ok"
+	statusModified   = "modified"
+	statusMissing    = "missing"
+	statusUnverified = "unverified"
+	statusOutdated   = "outdated"
+)
+
+// verifyResult is the result of the verification of a file, or of a reference with --remote.
+type verifyResult struct {
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	Status   string `json:"status"`
+	Expected string `json:"expected,omitempty"`
+	Actual   string `json:"actual,omitempty"`
+}
+
+type artifactVerifyOptions struct {
+	*options.Common
+	*options.Registry
+	lockFile string
+	remote   bool
+}
+
+// NewArtifactVerifyCmd returns the artifact verify command.
+func NewArtifactVerifyCmd(ctx context.Context, opt *options.Common) *cobra.Command {
+	o := artifactVerifyOptions{
+		Common:   opt,
+		Registry: &options.Registry{},
+	}
+
+	cmd := &cobra.Command{
+		Use:                   "verify [name1 [name2 ...]] [flags]",
+		DisableFlagsInUseLine: true,
+		Short:                 "Verify the installed artifacts against the lockfile",
+		Long:                  longVerify,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return utils.ExpandPaths(&o.lockFile)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.RunArtifactVerify(ctx, args)
+		},
+	}
+
+	o.Registry.AddFlags(cmd)
+	cmd.Flags().StringVar(&o.lockFile, FlagLockFile, config.LockFile,
+		"path of the lockfile where the files of the installed artifacts are recorded")
+	cmd.Flags().BoolVar(&o.remote, FlagRemote, false,
+		"also compare the installed digests with the ones the references currently point to in the registry")
+
+	return cmd
+}
+
+// RunArtifactVerify executes the business logic for the artifact verify command.
+func (o *artifactVerifyOptions) RunArtifactVerify(ctx context.Context, args []string) error {
+	lock, err := lockfile.New(o.lockFile)
+	if err != nil {
+		return fmt.Errorf("unable to read lockfile %q: %w", o.lockFile, err)
+	}
+
+	entries := lock.Artifacts
+	if len(args) > 0 {
+		entries = nil
+		for _, name := range args {
+			entry := lock.Get(name)
+			if entry == nil {
+				return fmt.Errorf("artifact %q is not recorded in lockfile %q", name, o.lockFile)
+			}
+			entries = append(entries, entry)
+		}
+	}
+
+	var puller *ocipuller.Puller
+	if o.remote {
+		if puller, err = ociutils.Puller(o.Registry, o.Printer); err != nil {
+			return err
+		}
+	}
+
+	var results []verifyResult
+	for _, entry := range entries {
+		res, err := verifyFiles(entry)
+		if err != nil {
+			return err
+		}
+		results = append(results, res...)
+
+		if puller != nil {
+			opCtx, cancel := o.OperationContext(ctx)
+			desc, err := puller.Descriptor(opCtx, entry.Ref)
+			cancel()
+			if err != nil {
+				return fmt.Errorf("unable to resolve %q: %w", entry.Ref, err)
+			}
+			res := verifyResult{Name: entry.Name, Path: entry.Ref, Status: statusOK}
+			if actual := desc.Digest.String(); actual != entry.Digest {
+				res.Status, res.Expected, res.Actual = statusOutdated, entry.Digest, actual
+			}
+			results = append(results, res)
+		}
+	}
+
+	drifted := 0
+	data := make([][]string, 0, len(results))
+	for _, res := range results {
+		data = append(data, []string{res.Name, res.Path, res.Status})
+		if res.Status != statusOK && res.Status != statusUnverified {
+			drifted++
+		}
+	}
+	if err := o.Printer.PrintResults(results, output.ArtifactVerify, data); err != nil {
+		return err
+	}
+
+	if drifted > 0 {
+		return fmt.Errorf("%d of the verified files and references do not match lockfile %q", drifted, o.lockFile)
+	}
+	return nil
+}
+
+// verifyFiles hashes the regular files of the given artifact and compares them with the recorded digests.
+// Directories are not reported.
+func verifyFiles(entry *lockfile.Entry) ([]verifyResult, error) {
+	var results []verifyResult
+	for _, path := range entry.Files {
+		res := verifyResult{Name: entry.Name, Path: path, Expected: entry.Digests[path]}
+
+		info, err := os.Lstat(path)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			res.Status = statusMissing
+			results = append(results, res)
+			continue
+		case err != nil:
+			return nil, err
+		case !info.Mode().IsRegular():
+			continue
+		}
+
+		if res.Expected == "" {
+			res.Status = statusUnverified
+			results = append(results, res)
+			continue
+		}
+
+		if res.Actual, err = utils.FileDigest(path); err != nil {
+			return nil, fmt.Errorf("unable to compute the digest of %q: %w", path, err)
+		}
+		if res.Actual == res.Expected {
+			res.Status, res.Expected, res.Actual = statusOK, "", ""
+		} else {
+			res.Status = statusModified
+		}
+		results = append(results, res)
+	}
+	return results, nil
+}
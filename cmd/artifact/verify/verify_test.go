@@ -0,0 +1,128 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify_test
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/falcosecurity/falcoctl/cmd"
+	"github.com/falcosecurity/falcoctl/internal/utils"
+	"github.com/falcosecurity/falcoctl/pkg/lockfile"
+)
+
+var _ = Describe("verify", func() {
+	const (
+		artifactCmd = "artifact"
+		verifyCmd   = "verify"
+	)
+
+	var (
+		pluginsDir string
+		lockFile   string
+		plugin     string
+		readme     string
+	)
+
+	// Each test gets its own root command and runs it.
+	// The err variable is asserted by each test.
+	JustBeforeEach(func() {
+		rootCmd = cmd.New(ctx, opt)
+		err = executeRoot(args)
+	})
+
+	JustAfterEach(func() {
+		Expect(output.Clear()).ShouldNot(HaveOccurred())
+	})
+
+	BeforeEach(func() {
+		baseDir := GinkgoT().TempDir()
+		pluginsDir = filepath.Join(baseDir, "plugins")
+		lockFile = filepath.Join(baseDir, "falcoctl.lock.yaml")
+		plugin = filepath.Join(pluginsDir, "libcloudtrail.so")
+		readme = filepath.Join(pluginsDir, "README.md")
+
+		// Simulate the install of a plugin, whose README has been recorded without digest.
+		Expect(os.MkdirAll(pluginsDir, 0o755)).Should(Succeed())
+		for _, path := range []string{plugin, readme} {
+			Expect(os.WriteFile(path, []byte("content"), 0o600)).Should(Succeed())
+		}
+		digest, err := utils.FileDigest(plugin)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		lock := &lockfile.Lockfile{}
+		lock.Upsert(&lockfile.Entry{Name: "cloudtrail", Files: []string{pluginsDir, plugin, readme},
+			Digests: map[string]string{plugin: digest}})
+		Expect(lock.Write(lockFile)).Should(Succeed())
+	})
+
+	verifyArgs := func(extra ...string) []string {
+		return append([]string{artifactCmd, verifyCmd, "--config", configFile, "--lock-file", lockFile}, extra...)
+	}
+
+	When("the installed files are unchanged", func() {
+		BeforeEach(func() {
+			args = verifyArgs()
+		})
+
+		It("should report them as ok", func() {
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(output).Should(gbytes.Say(regexp.QuoteMeta(plugin) + `\s+ok`))
+			Expect(output).Should(gbytes.Say(regexp.QuoteMeta(readme) + `\s+unverified`))
+		})
+	})
+
+	When("an installed file is modified", func() {
+		BeforeEach(func() {
+			Expect(os.WriteFile(plugin, []byte("modified"), 0o600)).Should(Succeed())
+			args = verifyArgs("cloudtrail")
+		})
+
+		It("should report the drift and fail", func() {
+			Expect(err).Should(HaveOccurred())
+			Expect(output).Should(gbytes.Say(regexp.QuoteMeta(plugin) + `\s+modified`))
+			Expect(output).Should(gbytes.Say(regexp.QuoteMeta("ERROR 1 of the verified files and references do not match lockfile")))
+		})
+	})
+
+	When("an installed file is missing", func() {
+		BeforeEach(func() {
+			Expect(os.Remove(plugin)).Should(Succeed())
+			args = verifyArgs()
+		})
+
+		It("should report the drift and fail", func() {
+			Expect(err).Should(HaveOccurred())
+			Expect(output).Should(gbytes.Say(regexp.QuoteMeta(plugin) + `\s+missing`))
+		})
+	})
+
+	When("the artifact is not recorded in the lockfile", func() {
+		BeforeEach(func() {
+			args = verifyArgs("k8saudit")
+		})
+
+		It("should fail", func() {
+			Expect(err).Should(HaveOccurred())
+			Expect(output).Should(gbytes.Say(regexp.QuoteMeta(`ERROR artifact "k8saudit" is not recorded in lockfile`)))
+		})
+	})
+})
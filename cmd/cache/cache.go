@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"github.com/falcosecurity/falcoctl/cmd/cache/clean"
+	"github.com/falcosecurity/falcoctl/cmd/cache/prune"
+	commonoptions "github.com/falcosecurity/falcoctl/pkg/options"
+)
+
+// NewCacheCmd returns the cache command.
+func NewCacheCmd(ctx context.Context, opt *commonoptions.Common) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "cache",
+		DisableFlagsInUseLine: true,
+		Short:                 "Manage the cache of pulled artifacts",
+		Long:                  "Manage the cache where the manifests and layers pulled from the registries are kept, keyed by digest",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			opt.Initialize()
+			return opt.LoadConfig(cmd)
+		},
+	}
+
+	cmd.AddCommand(clean.NewCacheCleanCmd(ctx, opt))
+	cmd.AddCommand(prune.NewCachePruneCmd(ctx, opt))
+
+	return cmd
+}
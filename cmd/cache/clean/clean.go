@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clean
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"github.com/falcosecurity/falcoctl/internal/config"
+	ocicache "github.com/falcosecurity/falcoctl/pkg/oci/cache"
+	"github.com/falcosecurity/falcoctl/pkg/options"
+)
+
+type cacheCleanOptions struct {
+	*options.Common
+}
+
+// NewCacheCleanCmd returns the cache clean command.
+func NewCacheCleanCmd(ctx context.Context, opt *options.Common) *cobra.Command {
+	o := cacheCleanOptions{
+		Common: opt,
+	}
+
+	cmd := &cobra.Command{
+		Use:                   "clean [flags]",
+		DisableFlagsInUseLine: true,
+		Short:                 "Remove all the contents of the cache",
+		Long:                  "Remove all the manifests and layers kept in the cache of pulled artifacts",
+		Args:                  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return o.RunCacheClean(ctx)
+		},
+	}
+
+	return cmd
+}
+
+// RunCacheClean executes the business logic for the cache clean command.
+func (o *cacheCleanOptions) RunCacheClean(ctx context.Context) error {
+	logger := o.Printer.Logger
+
+	cache, err := ocicache.New(ctx, config.CacheDir)
+	if err != nil {
+		return err
+	}
+
+	logger.Debug("Removing cache contents", logger.Args("path", cache.Dir()))
+	if err := cache.Clean(); err != nil {
+		return err
+	}
+
+	logger.Info("Cache successfully cleaned", logger.Args("path", cache.Dir()))
+
+	return nil
+}
@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prune
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/falcosecurity/falcoctl/internal/config"
+	ocicache "github.com/falcosecurity/falcoctl/pkg/oci/cache"
+	"github.com/falcosecurity/falcoctl/pkg/options"
+)
+
+const (
+	// FlagOlderThan is the name of the flag to specify how long the contents must have not been used to be pruned.
+	FlagOlderThan = "older-than"
+
+	// DefaultOlderThan is the default for the FlagOlderThan flag.
+	DefaultOlderThan = 30 * 24 * time.Hour
+)
+
+type cachePruneOptions struct {
+	*options.Common
+	olderThan time.Duration
+}
+
+// NewCachePruneCmd returns the cache prune command.
+func NewCachePruneCmd(ctx context.Context, opt *options.Common) *cobra.Command {
+	o := cachePruneOptions{
+		Common: opt,
+	}
+
+	cmd := &cobra.Command{
+		Use:                   "prune [flags]",
+		DisableFlagsInUseLine: true,
+		Short:                 "Remove the contents of the cache not used recently",
+		Long:                  "Remove the manifests and layers kept in the cache of pulled artifacts that have not been used for a while",
+		Args:                  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return o.RunCachePrune(ctx)
+		},
+	}
+
+	cmd.Flags().DurationVar(&o.olderThan, FlagOlderThan, DefaultOlderThan,
+		"remove the contents that have not been used for at least the given time")
+
+	return cmd
+}
+
+// RunCachePrune executes the business logic for the cache prune command.
+func (o *cachePruneOptions) RunCachePrune(ctx context.Context) error {
+	logger := o.Printer.Logger
+
+	if o.olderThan < 0 {
+		return fmt.Errorf("--%s must not be negative", FlagOlderThan)
+	}
+
+	cache, err := ocicache.New(ctx, config.CacheDir)
+	if err != nil {
+		return err
+	}
+
+	logger.Debug("Pruning cache contents", logger.Args("path", cache.Dir(), "older than", o.olderThan.String()))
+	removed, freed, err := cache.Prune(ctx, time.Now().Add(-o.olderThan))
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Cache successfully pruned", logger.Args("removed blobs", removed, "freed bytes", freed))
+
+	return nil
+}
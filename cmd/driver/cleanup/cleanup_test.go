@@ -37,12 +37,17 @@ Flags:
 
 Global Flags:
       --config string          config file to be used for falcoctl (default "/etc/falcoctl/falcoctl.yaml")
+      --falcoctl-dir string    directory where falcoctl stores the indexes and the client credentials (default "$HOME/.config/falcoctl")
       --host-root string       Driver host root to be used. (default "/")
+      --indexes-file string    file listing the configured indexes (default "indexes.yaml" in the falcoctl directory)
       --kernelrelease string   Specify the kernel release for which to download/build the driver in the same format used by 'uname -r' (e.g. '6.1.0-10-cloud-amd64')
       --kernelversion string   Specify the kernel version for which to download/build the driver in the same format used by 'uname -v' (e.g. '#1 SMP PREEMPT_DYNAMIC Debian 6.1.38-2 (2023-07-27)')
       --log-format string      Set formatting for logs (color, text, json) (default "color")
       --log-level string       Set level for logs (info, warn, debug, trace) (default "info")
       --name string            Driver name to be used. (default "falco")
+      --no-color               Disable colors, spinners and progress bars, as the NO_COLOR environment variable does. They are disabled as well when not attached to a tty
+      --output string          Set format for the results of the commands supporting it (text, json, yaml, annotations), messages are written to stderr when set to json or yaml (default "text")
+  -q, --quiet                  Only print errors, to stderr, and the results of the commands, silencing the other logs, spinners and progress bars
       --repo strings           Driver repo to be used. (default [https://download.falco.org/driver])
       --type strings           Driver types allowed in descending priority order (ebpf, kmod, modern_ebpf) (default [modern_ebpf,ebpf,kmod])
       --version string         Driver version to be used.
@@ -59,7 +59,7 @@ func NewDriverCmd(ctx context.Context, opt *options.Common) *cobra.Command {
 ** This command is in preview and under development. **`,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 			opt.Initialize()
-			if err := config.Load(opt.ConfigFile); err != nil {
+			if err := opt.LoadConfig(cmd); err != nil {
 				return err
 			}
 
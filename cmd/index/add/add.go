@@ -23,6 +23,7 @@ import (
 
 	"github.com/falcosecurity/falcoctl/internal/config"
 	"github.com/falcosecurity/falcoctl/pkg/index/cache"
+	indexConf "github.com/falcosecurity/falcoctl/pkg/index/config"
 	"github.com/falcosecurity/falcoctl/pkg/options"
 )
 
@@ -63,6 +64,11 @@ func (o *IndexAddOptions) RunIndexAdd(ctx context.Context, args []string) error
 		backend = args[2]
 	}
 
+	// Names identify the indexes, hence the same name cannot be reused for a different URL.
+	if err = checkUniqueName(name, url); err != nil {
+		return err
+	}
+
 	logger.Debug("Creating in-memory cache using", logger.Args("indexes file", config.IndexesFile, "indexes directory", config.IndexesDir))
 	indexCache, err := cache.New(ctx, config.IndexesFile, config.IndexesDir)
 	if err != nil {
@@ -93,3 +99,27 @@ func (o *IndexAddOptions) RunIndexAdd(ctx context.Context, args []string) error
 
 	return nil
 }
+
+// checkUniqueName returns an error if an index with the given name, but a different URL, is already configured
+// or present in the indexes file.
+func checkUniqueName(name, url string) error {
+	configured, err := config.Indexes()
+	if err != nil {
+		return fmt.Errorf("unable to get indexes from configuration: %w", err)
+	}
+	for _, idx := range configured {
+		if idx.Name == name && idx.URL != url {
+			return fmt.Errorf("index %q already exists with URL %q, please remove it first or choose a different name", name, idx.URL)
+		}
+	}
+
+	indexConfig, err := indexConf.New(config.IndexesFile)
+	if err != nil {
+		return err
+	}
+	if entry := indexConfig.Get(name); entry != nil && entry.URL != url {
+		return fmt.Errorf("index %q already exists with URL %q, please remove it first or choose a different name", name, entry.URL)
+	}
+
+	return nil
+}
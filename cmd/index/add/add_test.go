@@ -16,6 +16,7 @@
 package add_test
 
 import (
+	"os"
 	"regexp"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -33,9 +34,14 @@ Flags:
 -h, --help   help for add
 
 Global Flags:
-      --config string       config file to be used for falcoctl (default "/etc/falcoctl/falcoctl.yaml")
-      --log-format string   Set formatting for logs (color, text, json) (default "color")
-      --log-level string    Set level for logs (info, warn, debug, trace) (default "info")
+      --config string         config file to be used for falcoctl (default "/etc/falcoctl/falcoctl.yaml")
+      --falcoctl-dir string   directory where falcoctl stores the indexes and the client credentials (default "$HOME/.config/falcoctl")
+      --indexes-file string   file listing the configured indexes (default "indexes.yaml" in the falcoctl directory)
+      --log-format string     Set formatting for logs (color, text, json) (default "color")
+      --log-level string      Set level for logs (info, warn, debug, trace) (default "info")
+      --no-color              Disable colors, spinners and progress bars, as the NO_COLOR environment variable does. They are disabled as well when not attached to a tty
+      --output string         Set format for the results of the commands supporting it (text, json, yaml, annotations), messages are written to stderr when set to json or yaml (default "text")
+  -q, --quiet                 Only print errors, to stderr, and the results of the commands, silencing the other logs, spinners and progress bars
 `
 
 //nolint:lll // no need to check for line length.
@@ -48,9 +54,14 @@ Flags:
   -h, --help   help for add
 
 Global Flags:
-      --config string       config file to be used for falcoctl (default "/etc/falcoctl/falcoctl.yaml")
-      --log-format string   Set formatting for logs (color, text, json) (default "color")
-      --log-level string    Set level for logs (info, warn, debug, trace) (default "info")
+      --config string         config file to be used for falcoctl (default "/etc/falcoctl/falcoctl.yaml")
+      --falcoctl-dir string   directory where falcoctl stores the indexes and the client credentials (default "$HOME/.config/falcoctl")
+      --indexes-file string   file listing the configured indexes (default "indexes.yaml" in the falcoctl directory)
+      --log-format string     Set formatting for logs (color, text, json) (default "color")
+      --log-level string      Set level for logs (info, warn, debug, trace) (default "info")
+      --no-color              Disable colors, spinners and progress bars, as the NO_COLOR environment variable does. They are disabled as well when not attached to a tty
+      --output string         Set format for the results of the commands supporting it (text, json, yaml, annotations), messages are written to stderr when set to json or yaml (default "text")
+  -q, --quiet                 Only print errors, to stderr, and the results of the commands, silencing the other logs, spinners and progress bars
 `
 
 var addAssertFailedBehavior = func(usage, specificError string) {
@@ -116,6 +127,17 @@ var indexAddTests = Describe("add", func() {
 			addAssertFailedBehavior(indexAddUsage, "ERROR unable to add index: unable to fetch index \"testName\" "+
 				"with URL \"http://noindex\": unsupported index backend type: notabackend")
 		})
+
+		When("with a name already used for a different URL", func() {
+			BeforeEach(func() {
+				configFilePath := GinkgoT().TempDir() + "/config.yaml"
+				Expect(os.WriteFile(configFilePath, []byte("indexes:\n  - name: "+indexName+"\n    url: http://already/index.yaml\n"),
+					0o600)).To(Succeed())
+				args = []string{indexCmd, addCmd, "--config", configFilePath, indexName, "http://other/index.yaml"}
+			})
+			addAssertFailedBehavior(indexAddUsage, "ERROR index \"testName\" already exists with URL \"http://already/index.yaml\", "+
+				"please remove it first or choose a different name")
+		})
 	})
 
 })
@@ -24,7 +24,6 @@ import (
 	"github.com/falcosecurity/falcoctl/cmd/index/list"
 	"github.com/falcosecurity/falcoctl/cmd/index/remove"
 	"github.com/falcosecurity/falcoctl/cmd/index/update"
-	"github.com/falcosecurity/falcoctl/internal/config"
 	commonoptions "github.com/falcosecurity/falcoctl/pkg/options"
 )
 
@@ -37,7 +36,7 @@ func NewIndexCmd(ctx context.Context, opt *commonoptions.Common) *cobra.Command
 		Long:                  "Interact with index",
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 			opt.Initialize()
-			return config.Load(opt.ConfigFile)
+			return opt.LoadConfig(cmd)
 		},
 	}
 
@@ -26,6 +26,14 @@ import (
 	"github.com/falcosecurity/falcoctl/pkg/output"
 )
 
+// indexEntry is a configured index, as printed in the structured output.
+type indexEntry struct {
+	Name    string `json:"name"`
+	URL     string `json:"url"`
+	Added   string `json:"added"`
+	Updated string `json:"updated"`
+}
+
 type indexListOptions struct {
 	*options.Common
 }
@@ -58,10 +66,12 @@ func (o *indexListOptions) RunIndexList() error {
 	}
 
 	var data [][]string
+	results := []indexEntry{}
 	for _, conf := range indexConfig.Configs {
 		newEntry := []string{conf.Name, conf.URL, conf.AddedTimestamp, conf.UpdatedTimestamp}
 		data = append(data, newEntry)
+		results = append(results, indexEntry{Name: conf.Name, URL: conf.URL, Added: conf.AddedTimestamp, Updated: conf.UpdatedTimestamp})
 	}
 
-	return o.Printer.PrintTable(output.IndexList, data)
+	return o.Printer.PrintResults(results, output.IndexList, data)
 }
@@ -18,16 +18,19 @@ package update
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/falcosecurity/falcoctl/internal/config"
 	"github.com/falcosecurity/falcoctl/pkg/index/cache"
+	indexConf "github.com/falcosecurity/falcoctl/pkg/index/config"
 	"github.com/falcosecurity/falcoctl/pkg/options"
 )
 
 type indexUpdateOptions struct {
 	*options.Common
+	maxAge time.Duration
 }
 
 // NewIndexUpdateCmd returns the index update command.
@@ -40,13 +43,18 @@ func NewIndexUpdateCmd(ctx context.Context, opt *options.Common) *cobra.Command
 		Use:                   "update [INDEX1 [INDEX2 ...]] [flags]",
 		DisableFlagsInUseLine: true,
 		Short:                 "Update an existing index",
-		Long:                  "Update an existing index",
-		Args:                  cobra.MinimumNArgs(1),
+		Long: `Update the given indexes, or all the configured ones if none is given, by downloading their latest content.
+Indexes served by HTTP/S backends are downloaded only if changed since the last update, according to their ETag and Last-Modified headers.
+With --index-max-age only the indexes whose cached file is older than the given age are updated`,
+		Args: cobra.ArbitraryArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return o.RunIndexUpdate(ctx, args)
 		},
 	}
 
+	cmd.Flags().DurationVar(&o.maxAge, "index-max-age", 0,
+		"update only the indexes whose cached file is older than the given age, e.g. 24h (0 updates all of them)")
+
 	return cmd
 }
 
@@ -59,11 +67,36 @@ func (o *indexUpdateOptions) RunIndexUpdate(ctx context.Context, args []string)
 		return fmt.Errorf("unable to create index cache: %w", err)
 	}
 
+	if len(args) == 0 {
+		indexConfig, err := indexConf.New(config.IndexesFile)
+		if err != nil {
+			return err
+		}
+		for _, entry := range indexConfig.Configs {
+			args = append(args, entry.Name)
+		}
+		if len(args) == 0 {
+			logger.Info("No indexes to update")
+			return nil
+		}
+	}
+
 	for _, arg := range args {
+		if o.maxAge > 0 {
+			if age, err := indexCache.IndexAge(arg); err == nil && age <= o.maxAge {
+				logger.Info("Index file not older than --index-max-age, skipping it",
+					logger.Args("name", arg, "age", age.Round(time.Second).String()))
+				continue
+			}
+		}
 		logger.Info("Updating index file", logger.Args("name", arg))
-		if err := indexCache.Update(ctx, arg); err != nil {
+		updated, err := indexCache.Update(ctx, arg)
+		if err != nil {
 			return fmt.Errorf("an error occurred while updating index %q: %w", arg, err)
 		}
+		if !updated {
+			logger.Info("Index file already up to date", logger.Args("name", arg))
+		}
 	}
 
 	logger.Debug("Writing cache to disk")
@@ -0,0 +1,97 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package add
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/falcosecurity/falcoctl/internal/config"
+	"github.com/falcosecurity/falcoctl/internal/keys"
+	"github.com/falcosecurity/falcoctl/pkg/options"
+)
+
+const (
+	longAdd = `Trust a public key to verify the signatures of the artifacts.
+
+The PEM encoded public key read from the given file is stored under the given name in the falcoctl directory, so that
+it can be referenced by name with --key, e.g. when installing artifacts with --verify-signature, or with the "key" field
+of the signature of an index entry. Names are made of letters, digits, '.', '-' and '_'.
+
+Example - Trust the key used to sign the artifacts of your organization:
+	falcoctl key add my-org ./cosign.pub
+
+Example - Install an artifact verifying its signature with the trusted key:
+	falcoctl artifact install my-rules --verify-signature --key my-org
+`
+
+	// FlagForce is the name of the flag to replace the trusted key with the same name.
+	FlagForce = "force"
+)
+
+type keyAddOptions struct {
+	*options.Common
+	force bool
+}
+
+// NewKeyAddCmd returns the key add command.
+func NewKeyAddCmd(_ context.Context, opt *options.Common) *cobra.Command {
+	o := keyAddOptions{
+		Common: opt,
+	}
+
+	cmd := &cobra.Command{
+		Use:                   "add name pubkey [flags]",
+		DisableFlagsInUseLine: true,
+		Short:                 "Trust a public key to verify the signatures of the artifacts",
+		Long:                  longAdd,
+		Args:                  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.RunKeyAdd(args[0], args[1])
+		},
+	}
+
+	cmd.Flags().BoolVar(&o.force, FlagForce, false, "replace the trusted key with the same name, if any")
+
+	return cmd
+}
+
+// RunKeyAdd executes the business logic for the key add command.
+func (o *keyAddOptions) RunKeyAdd(name, pubKey string) error {
+	logger := o.Printer.Logger
+
+	pemBytes, err := os.ReadFile(filepath.Clean(pubKey))
+	if err != nil {
+		return fmt.Errorf("cannot read public key: %w", err)
+	}
+
+	key, err := keys.Add(config.KeysDir, name, pemBytes, o.force)
+	if errors.Is(err, keys.ErrKeyExists) {
+		return fmt.Errorf("%w, use --%s to replace it", err, FlagForce)
+	} else if err != nil {
+		return err
+	}
+
+	logger.Info("Key successfully added", logger.Args("name", key.Name, "algorithm", key.Algorithm,
+		"fingerprint", key.Fingerprint, "path", key.Path))
+
+	return nil
+}
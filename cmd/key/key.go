@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package key
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"github.com/falcosecurity/falcoctl/cmd/key/add"
+	"github.com/falcosecurity/falcoctl/cmd/key/list"
+	"github.com/falcosecurity/falcoctl/cmd/key/remove"
+	commonoptions "github.com/falcosecurity/falcoctl/pkg/options"
+)
+
+// NewKeyCmd returns the key command.
+func NewKeyCmd(ctx context.Context, opt *commonoptions.Common) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "key",
+		DisableFlagsInUseLine: true,
+		Short:                 "Manage the keys trusted to verify the signatures of the artifacts",
+		Long: "Manage the public keys trusted to verify the signatures of the artifacts, stored by name in the falcoctl " +
+			"directory, so that they can be referenced by name with --key",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			opt.Initialize()
+			return opt.LoadConfig(cmd)
+		},
+	}
+
+	cmd.AddCommand(add.NewKeyAddCmd(ctx, opt))
+	cmd.AddCommand(list.NewKeyListCmd(ctx, opt))
+	cmd.AddCommand(remove.NewKeyRemoveCmd(ctx, opt))
+
+	return cmd
+}
@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package list
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"github.com/falcosecurity/falcoctl/internal/config"
+	"github.com/falcosecurity/falcoctl/internal/keys"
+	"github.com/falcosecurity/falcoctl/pkg/options"
+	"github.com/falcosecurity/falcoctl/pkg/output"
+)
+
+// trustedKey is a trusted public key, as printed in the structured output.
+type trustedKey struct {
+	Name        string `json:"name"`
+	Algorithm   string `json:"algorithm"`
+	Fingerprint string `json:"fingerprint"`
+	Path        string `json:"path"`
+}
+
+type keyListOptions struct {
+	*options.Common
+}
+
+// NewKeyListCmd returns the key list command.
+func NewKeyListCmd(_ context.Context, opt *options.Common) *cobra.Command {
+	o := keyListOptions{
+		Common: opt,
+	}
+
+	cmd := &cobra.Command{
+		Use:                   "list [flags]",
+		DisableFlagsInUseLine: true,
+		Short:                 "List the trusted public keys",
+		Long:                  "List the public keys trusted to verify the signatures of the artifacts, with their algorithm and fingerprint",
+		Args:                  cobra.ExactArgs(0),
+		Aliases:               []string{"ls"},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return o.RunKeyList()
+		},
+	}
+
+	return cmd
+}
+
+// RunKeyList executes the business logic for the key list command.
+func (o *keyListOptions) RunKeyList() error {
+	trusted, err := keys.List(config.KeysDir)
+	if err != nil {
+		return err
+	}
+
+	var data [][]string
+	results := []trustedKey{}
+	for _, key := range trusted {
+		data = append(data, []string{key.Name, key.Algorithm, key.Fingerprint})
+		results = append(results, trustedKey{Name: key.Name, Algorithm: key.Algorithm, Fingerprint: key.Fingerprint, Path: key.Path})
+	}
+
+	return o.Printer.PrintResults(results, output.TrustedKeys, data)
+}
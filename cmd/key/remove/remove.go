@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remove
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"github.com/falcosecurity/falcoctl/internal/config"
+	"github.com/falcosecurity/falcoctl/internal/keys"
+	"github.com/falcosecurity/falcoctl/pkg/options"
+)
+
+type keyRemoveOptions struct {
+	*options.Common
+}
+
+// NewKeyRemoveCmd returns the key remove command.
+func NewKeyRemoveCmd(_ context.Context, opt *options.Common) *cobra.Command {
+	o := keyRemoveOptions{
+		Common: opt,
+	}
+
+	cmd := &cobra.Command{
+		Use:                   "remove [KEY1 [KEY2 ...]] [flags]",
+		DisableFlagsInUseLine: true,
+		Short:                 "Stop trusting public keys",
+		Long:                  "Remove public keys, given by name, from the keys trusted to verify the signatures of the artifacts",
+		Args:                  cobra.MinimumNArgs(1),
+		Aliases:               []string{"rm"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.RunKeyRemove(args)
+		},
+	}
+
+	return cmd
+}
+
+// RunKeyRemove executes the business logic for the key remove command.
+func (o *keyRemoveOptions) RunKeyRemove(names []string) error {
+	logger := o.Printer.Logger
+
+	for _, name := range names {
+		if err := keys.Remove(config.KeysDir, name); err != nil {
+			return err
+		}
+		logger.Info("Key successfully removed", logger.Args("name", name))
+	}
+
+	return nil
+}
@@ -66,7 +66,11 @@ func (o *loginOptions) RunBasic(ctx context.Context, args []string) error {
 	client := authn.NewClient()
 
 	// create credential store
-	credentialStore, err := credentials.NewStore(config.RegistryCredentialConfPath(), credentials.StoreOptions{
+	helpers, err := config.RegistryCredentialHelpers()
+	if err != nil {
+		return fmt.Errorf("unable to retrieve the configured credential helpers: %w", err)
+	}
+	credentialStore, err := authn.NewStore(config.RegistryCredentialConfPath(), helpers, credentials.StoreOptions{
 		AllowPlaintextPut: true,
 	})
 	if err != nil {
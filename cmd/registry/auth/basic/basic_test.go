@@ -53,9 +53,11 @@ Flags:
   -h, --help   help for basic
 
 Global Flags:
-      --config string     config file to be used for falcoctl (default "/etc/falcoctl/falcoctl.yaml")
-      --disable-styling   Disable output styling such as spinners, progress bars and colors. Styling is automatically disabled if not attacched to a tty (default false)
-  -v, --verbose           Enable verbose logs (default false)
+      --config string         config file to be used for falcoctl (default "/etc/falcoctl/falcoctl.yaml")
+      --disable-styling       Disable output styling such as spinners, progress bars and colors. Styling is automatically disabled if not attacched to a tty (default false)
+      --falcoctl-dir string   directory where falcoctl stores the indexes and the client credentials (default "$HOME/.config/falcoctl")
+      --indexes-file string   file listing the configured indexes (default "indexes.yaml" in the falcoctl directory)
+  -v, --verbose               Enable verbose logs (default false)
 `
 
 //nolint:unused // false positive
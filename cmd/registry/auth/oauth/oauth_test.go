@@ -64,9 +64,14 @@ Flags:
       --token-url string       token URL used to get access and refresh tokens
 
 Global Flags:
-      --config string     config file to be used for falcoctl (default "/etc/falcoctl/falcoctl.yaml")
-      --log-format string   Set formatting for logs (color, text, json) (default "color")
-      --log-level string    Set level for logs (info, warn, debug, trace) (default "info")
+      --config string         config file to be used for falcoctl (default "/etc/falcoctl/falcoctl.yaml")
+      --falcoctl-dir string   directory where falcoctl stores the indexes and the client credentials (default "$HOME/.config/falcoctl")
+      --indexes-file string   file listing the configured indexes (default "indexes.yaml" in the falcoctl directory)
+      --log-format string     Set formatting for logs (color, text, json) (default "color")
+      --log-level string      Set level for logs (info, warn, debug, trace) (default "info")
+      --no-color              Disable colors, spinners and progress bars, as the NO_COLOR environment variable does. They are disabled as well when not attached to a tty
+      --output string         Set format for the results of the commands supporting it (text, json, yaml, annotations), messages are written to stderr when set to json or yaml (default "text")
+  -q, --quiet                 Only print errors, to stderr, and the results of the commands, silencing the other logs, spinners and progress bars
 
 `
 
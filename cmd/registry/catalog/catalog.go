@@ -0,0 +1,147 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package catalog
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/falcosecurity/falcoctl/pkg/oci/registry"
+	ociutils "github.com/falcosecurity/falcoctl/pkg/oci/utils"
+	"github.com/falcosecurity/falcoctl/pkg/options"
+	"github.com/falcosecurity/falcoctl/pkg/output"
+)
+
+const (
+	longCatalog = `List the repositories of an OCI registry.
+
+The repositories are listed through the catalog endpoint of the OCI distribution API, following every page
+of results, which helps finding the artifacts that are not in any configured index. Registries may restrict
+or disable the catalog endpoint, e.g. to the repositories the credentials in use have access to.
+
+Example - List the repositories of a registry:
+	falcoctl registry catalog localhost:5000
+
+Example - List the repositories sorted after "falcosecurity/plugins", requesting pages of 50 repositories:
+	falcoctl registry catalog localhost:5000 --last falcosecurity/plugins --page-size 50
+`
+
+	// FlagPageSize is the name of the flag to specify the number of repositories requested for each page.
+	FlagPageSize = "page-size"
+
+	// FlagLast is the name of the flag to specify the repository after which the listing starts.
+	FlagLast = "last"
+)
+
+type catalogOptions struct {
+	*options.Common
+	*options.Registry
+	pageSize int
+	last     string
+}
+
+// Validate validates the options passed by the user.
+func (o *catalogOptions) Validate() error {
+	if o.pageSize < 0 {
+		return fmt.Errorf("--%s must not be negative", FlagPageSize)
+	}
+	return nil
+}
+
+// NewCatalogCmd returns the catalog command.
+func NewCatalogCmd(ctx context.Context, opt *options.Common) *cobra.Command {
+	o := catalogOptions{
+		Common:   opt,
+		Registry: &options.Registry{},
+	}
+
+	cmd := &cobra.Command{
+		Use:                   "catalog hostname [flags]",
+		DisableFlagsInUseLine: true,
+		Short:                 "List the repositories of an OCI registry",
+		Long:                  longCatalog,
+		Args:                  cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return o.Validate()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.RunCatalog(ctx, args)
+		},
+	}
+
+	o.Registry.AddFlags(cmd)
+	cmd.Flags().IntVar(&o.pageSize, FlagPageSize, 0,
+		"number of repositories requested to the registry for each page of results (0 leaves it to the registry)")
+	cmd.Flags().StringVar(&o.last, FlagLast, "",
+		"list only the repositories sorted after the given one, e.g. the last one of a previous listing")
+
+	return cmd
+}
+
+// repositories are the repositories of a registry, as printed in the structured output.
+type repositories struct {
+	Registry     string   `json:"registry"`
+	Repositories []string `json:"repositories"`
+}
+
+// RunCatalog executes the business logic for the catalog command.
+func (o *catalogOptions) RunCatalog(ctx context.Context, args []string) error {
+	logger := o.Printer.Logger
+
+	clientOpts, err := o.ClientOptions(o.Printer)
+	if err != nil {
+		return err
+	}
+	client, err := ociutils.Client(true, clientOpts...)
+	if err != nil {
+		return err
+	}
+
+	reg, err := registry.NewRegistry(args[0],
+		registry.WithClient(client),
+		registry.WithPlainHTTP(o.PlainHTTP),
+		registry.WithRepositoryListPageSize(o.pageSize))
+	if err != nil {
+		return err
+	}
+
+	opCtx, cancel := o.OperationContext(ctx)
+	defer cancel()
+	repos, err := reg.Catalog(opCtx, o.last)
+	if err != nil {
+		return err
+	}
+
+	result := repositories{Registry: args[0], Repositories: repos}
+	if result.Repositories == nil {
+		result.Repositories = []string{}
+	}
+
+	// The table is printed only if there are repositories, the structured output is always printed.
+	if len(repos) == 0 && !o.Printer.StructuredOutput() {
+		logger.Info("No repositories found", logger.Args("registry", args[0]))
+		return nil
+	}
+
+	data := make([][]string, 0, len(repos))
+	for _, repo := range repos {
+		data = append(data, []string{repo})
+	}
+
+	return o.Printer.PrintResults(result, output.RegistryCatalog, data)
+}
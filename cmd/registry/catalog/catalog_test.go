@@ -0,0 +1,124 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package catalog_test
+
+import (
+	"regexp"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"oras.land/oras-go/v2/registry/remote/auth"
+
+	"github.com/falcosecurity/falcoctl/cmd"
+	"github.com/falcosecurity/falcoctl/pkg/oci"
+	"github.com/falcosecurity/falcoctl/pkg/oci/authn"
+	ocipusher "github.com/falcosecurity/falcoctl/pkg/oci/pusher"
+)
+
+var _ = Describe("catalog", func() {
+	const (
+		registryCmd = "registry"
+		catalogCmd  = "catalog"
+	)
+
+	// Each test gets its own root command and runs it.
+	// The err variable is asserted by each test.
+	JustBeforeEach(func() {
+		rootCmd = cmd.New(ctx, opt)
+		err = executeRoot(args)
+	})
+
+	JustAfterEach(func() {
+		Expect(output.Clear()).ShouldNot(HaveOccurred())
+	})
+
+	Context("with repositories", func() {
+		BeforeEach(func() {
+			pusher := ocipusher.NewPusher(authn.NewClient(authn.WithCredentials(&auth.EmptyCredential)), true, nil)
+			for _, repo := range []string{"/catalog/rules-a", "/catalog/rules-b", "/catalog/rules-c"} {
+				_, err := pusher.Push(ctx, oci.Rulesfile, registry+repo+":0.1.0",
+					ocipusher.WithFilepaths([]string{rulesfiletgz}),
+					ocipusher.WithArtifactConfig(oci.ArtifactConfig{Name: "rules", Version: "0.1.0"}))
+				Expect(err).ToNot(HaveOccurred())
+			}
+		})
+
+		When("listing every repository", func() {
+			BeforeEach(func() {
+				args = []string{registryCmd, catalogCmd, registry, "--plain-http", "--config", configFile}
+			})
+
+			It("should print all of them", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(output).Should(gbytes.Say("REPOSITORY"))
+				Expect(output).Should(gbytes.Say("catalog/rules-a"))
+				Expect(output).Should(gbytes.Say("catalog/rules-b"))
+				Expect(output).Should(gbytes.Say("catalog/rules-c"))
+			})
+		})
+
+		When("following pages of a single repository", func() {
+			BeforeEach(func() {
+				args = []string{registryCmd, catalogCmd, registry, "--plain-http", "--config", configFile, "--page-size", "1"}
+			})
+
+			It("should print all of them", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(output).Should(gbytes.Say("catalog/rules-a"))
+				Expect(output).Should(gbytes.Say("catalog/rules-b"))
+				Expect(output).Should(gbytes.Say("catalog/rules-c"))
+			})
+		})
+
+		When("starting after a repository", func() {
+			BeforeEach(func() {
+				args = []string{registryCmd, catalogCmd, registry, "--plain-http", "--config", configFile, "--last", "catalog/rules-a"}
+			})
+
+			It("should print only the repositories sorted after it", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(output).ShouldNot(gbytes.Say("catalog/rules-a"))
+				Expect(string(output.Contents())).To(ContainSubstring("catalog/rules-b"))
+				Expect(string(output.Contents())).To(ContainSubstring("catalog/rules-c"))
+			})
+		})
+	})
+
+	Context("failure", func() {
+		When("the page size is negative", func() {
+			BeforeEach(func() {
+				args = []string{registryCmd, catalogCmd, registry, "--plain-http", "--config", configFile, "--page-size", "-1"}
+			})
+
+			It("should fail", func() {
+				Expect(err).To(HaveOccurred())
+				Expect(output).Should(gbytes.Say(regexp.QuoteMeta("--page-size must not be negative")))
+			})
+		})
+
+		When("the registry is unreachable", func() {
+			BeforeEach(func() {
+				args = []string{registryCmd, catalogCmd, "localhost:1", "--plain-http", "--config", configFile}
+			})
+
+			It("should fail", func() {
+				Expect(err).To(HaveOccurred())
+				Expect(output).Should(gbytes.Say(regexp.QuoteMeta(`unable to list the repositories of registry "localhost:1"`)))
+			})
+		})
+	})
+})
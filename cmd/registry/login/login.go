@@ -0,0 +1,155 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package login
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	credentials "github.com/oras-project/oras-credentials-go"
+	"github.com/spf13/cobra"
+
+	"github.com/falcosecurity/falcoctl/internal/config"
+	"github.com/falcosecurity/falcoctl/internal/login/basic"
+	"github.com/falcosecurity/falcoctl/internal/utils"
+	"github.com/falcosecurity/falcoctl/pkg/oci/authn"
+	"github.com/falcosecurity/falcoctl/pkg/oci/registry"
+	"github.com/falcosecurity/falcoctl/pkg/options"
+)
+
+const (
+	longLogin = `Login to an OCI registry.
+
+The credentials are validated against the registry and then stored in the falcoctl credential store,
+from where they are used by the commands interacting with the registry.
+
+Example - Login interactively, username and password are prompted:
+	falcoctl registry login ghcr.io
+
+Example - Login reading the password from the standard input:
+	echo $TOKEN | falcoctl registry login ghcr.io --username myuser --password-stdin
+
+Example - Login to a local registry without TLS:
+	falcoctl registry login localhost:5000 --username myuser --plain-http
+`
+
+	// FlagUsername is the name of the flag to specify the username.
+	FlagUsername = "username"
+	// FlagPasswordStdin is the name of the flag to read the password from the standard input.
+	FlagPasswordStdin = "password-stdin"
+)
+
+type loginOptions struct {
+	*options.Common
+	*options.Registry
+	username      string
+	passwordStdin bool
+}
+
+// NewLoginCmd returns the login command.
+func NewLoginCmd(ctx context.Context, opt *options.Common) *cobra.Command {
+	o := loginOptions{
+		Common:   opt,
+		Registry: &options.Registry{},
+	}
+
+	cmd := &cobra.Command{
+		Use:                   "login [hostname]",
+		DisableFlagsInUseLine: true,
+		Short:                 "Login to an OCI registry",
+		Long:                  longLogin,
+		Args:                  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.RunLogin(ctx, cmd.InOrStdin(), args)
+		},
+	}
+
+	o.Registry.AddFlags(cmd)
+	cmd.Flags().StringVarP(&o.username, FlagUsername, "u", "", "username used to login, prompted if not given")
+	cmd.Flags().BoolVar(&o.passwordStdin, FlagPasswordStdin, false, "read the password from the standard input")
+
+	return cmd
+}
+
+// RunLogin executes the business logic for the login command.
+func (o *loginOptions) RunLogin(ctx context.Context, stdin io.Reader, args []string) error {
+	reg := args[0]
+	logger := o.Printer.Logger
+
+	if o.Anonymous {
+		return fmt.Errorf("cannot login anonymously, remove --anonymous")
+	}
+
+	user, password, err := o.credentials(stdin)
+	if err != nil {
+		return err
+	}
+
+	clientOpts, err := o.ClientOptions(o.Printer)
+	if err != nil {
+		return err
+	}
+	client := authn.NewClient(clientOpts...)
+
+	helpers, err := config.RegistryCredentialHelpers()
+	if err != nil {
+		return fmt.Errorf("unable to retrieve the configured credential helpers: %w", err)
+	}
+	credentialStore, err := authn.NewStore(config.RegistryCredentialConfPath(), helpers, credentials.StoreOptions{
+		AllowPlaintextPut: true,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to create new store: %w", err)
+	}
+
+	opCtx, cancel := o.OperationContext(ctx)
+	defer cancel()
+
+	if err := basic.Login(opCtx, client, credentialStore, reg, user, password, registry.WithPlainHTTP(o.PlainHTTP)); err != nil {
+		return err
+	}
+	logger.Debug("Credentials added", logger.Args("credential store", config.RegistryCredentialConfPath()))
+	logger.Info("Login succeeded", logger.Args("registry", reg, "user", user))
+
+	return nil
+}
+
+// credentials returns the username and password, prompting for the missing ones.
+func (o *loginOptions) credentials(stdin io.Reader) (user, password string, err error) {
+	if o.passwordStdin {
+		if o.username == "" {
+			return "", "", fmt.Errorf("--%s is required when using --%s", FlagUsername, FlagPasswordStdin)
+		}
+		content, err := io.ReadAll(stdin)
+		if err != nil {
+			return "", "", fmt.Errorf("unable to read password from standard input: %w", err)
+		}
+		password = strings.TrimSuffix(strings.TrimSuffix(string(content), "\n"), "\r")
+		if password == "" {
+			return "", "", fmt.Errorf("empty password read from standard input")
+		}
+		return o.username, password, nil
+	}
+
+	if o.username == "" {
+		return utils.GetCredentials(o.Printer)
+	}
+
+	password, err = utils.GetPassword(o.Printer)
+	return o.username, password, err
+}
@@ -0,0 +1,131 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package login_test
+
+import (
+	"os"
+	"regexp"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/falcosecurity/falcoctl/cmd"
+)
+
+//nolint:unused // false positive
+var registryLoginHelp = `Login to an OCI registry.
+
+The credentials are validated against the registry and then stored in the falcoctl credential store,
+from where they are used by the commands interacting with the registry.`
+
+//nolint:unused // false positive
+var registryLoginAssertFailedBehavior = func(specificError string) {
+	It("check that fails", func() {
+		Expect(err).To(HaveOccurred())
+		Expect(output).Should(gbytes.Say(regexp.QuoteMeta(specificError)))
+	})
+}
+
+//nolint:unused // false positive
+var registryLoginTests = Describe("login", func() {
+	const (
+		// Used as flags for all the test cases.
+		registryCmd = "registry"
+		loginCmd    = "login"
+		logoutCmd   = "logout"
+	)
+
+	// Each test gets its own root command and runs it.
+	// The err variable is asserted by each test.
+	JustBeforeEach(func() {
+		rootCmd = cmd.New(ctx, opt)
+		err = executeRoot(args)
+	})
+
+	JustAfterEach(func() {
+		Expect(output.Clear()).ShouldNot(HaveOccurred())
+		stdin = ""
+	})
+
+	Context("help message", func() {
+		BeforeEach(func() {
+			args = []string{registryCmd, loginCmd, "--help"}
+		})
+
+		It("should match the saved one", func() {
+			Expect(output).Should(gbytes.Say(regexp.QuoteMeta(registryLoginHelp)))
+		})
+	})
+
+	Context("failure", func() {
+		When("without hostname", func() {
+			BeforeEach(func() {
+				args = []string{registryCmd, loginCmd, "--config", configFile}
+			})
+			registryLoginAssertFailedBehavior("ERROR accepts 1 arg(s), received 0")
+		})
+
+		When("with --password-stdin and without --username", func() {
+			BeforeEach(func() {
+				stdin = testPassword
+				args = []string{registryCmd, loginCmd, registry, "--password-stdin", "--plain-http", "--config", configFile}
+			})
+			registryLoginAssertFailedBehavior("ERROR --username is required when using --password-stdin")
+		})
+
+		When("with wrong credentials", func() {
+			BeforeEach(func() {
+				stdin = "wrong\n"
+				args = []string{registryCmd, loginCmd, registry, "--username", testUsername, "--password-stdin",
+					"--plain-http", "--config", configFile}
+			})
+			registryLoginAssertFailedBehavior("ERROR unable to connect to registry")
+
+			It("does not store the credentials", func() {
+				_, err := os.Stat(credsFile)
+				Expect(os.IsNotExist(err)).To(BeTrue())
+			})
+		})
+	})
+
+	Context("success", func() {
+		BeforeEach(func() {
+			stdin = testPassword + "\n"
+			args = []string{registryCmd, loginCmd, registry, "--username", testUsername, "--password-stdin",
+				"--plain-http", "--config", configFile}
+		})
+
+		It("stores the credentials and removes them on logout", func() {
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(output).Should(gbytes.Say("Login succeeded"))
+			content, err := os.ReadFile(credsFile)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(string(content)).Should(ContainSubstring(registry))
+
+			rootCmd = cmd.New(ctx, opt)
+			Expect(executeRoot([]string{registryCmd, logoutCmd, registry, "--config", configFile})).Should(Succeed())
+			Expect(output).Should(gbytes.Say("Logout succeeded"))
+			content, err = os.ReadFile(credsFile)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(string(content)).ShouldNot(ContainSubstring(registry))
+
+			rootCmd = cmd.New(ctx, opt)
+			Expect(executeRoot([]string{registryCmd, logoutCmd, registry, "--config", configFile})).Should(Succeed())
+			Expect(output).Should(gbytes.Say("Not logged in"))
+		})
+	})
+})
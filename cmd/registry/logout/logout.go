@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logout
+
+import (
+	"context"
+	"fmt"
+
+	credentials "github.com/oras-project/oras-credentials-go"
+	"github.com/spf13/cobra"
+
+	"github.com/falcosecurity/falcoctl/internal/config"
+	"github.com/falcosecurity/falcoctl/internal/login/basic"
+	"github.com/falcosecurity/falcoctl/pkg/oci/authn"
+	"github.com/falcosecurity/falcoctl/pkg/options"
+)
+
+type logoutOptions struct {
+	*options.Common
+}
+
+// NewLogoutCmd returns the logout command.
+func NewLogoutCmd(ctx context.Context, opt *options.Common) *cobra.Command {
+	o := logoutOptions{
+		Common: opt,
+	}
+
+	cmd := &cobra.Command{
+		Use:                   "logout [hostname]",
+		DisableFlagsInUseLine: true,
+		Short:                 "Logout from an OCI registry",
+		Long:                  "Logout from an OCI registry removing its credentials from the falcoctl credential store",
+		Args:                  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.RunLogout(ctx, args)
+		},
+	}
+
+	return cmd
+}
+
+// RunLogout executes the business logic for the logout command.
+func (o *logoutOptions) RunLogout(ctx context.Context, args []string) error {
+	reg := args[0]
+	logger := o.Printer.Logger
+
+	helpers, err := config.RegistryCredentialHelpers()
+	if err != nil {
+		return fmt.Errorf("unable to retrieve the configured credential helpers: %w", err)
+	}
+	credentialStore, err := authn.NewStore(config.RegistryCredentialConfPath(), helpers, credentials.StoreOptions{})
+	if err != nil {
+		return fmt.Errorf("unable to create new store: %w", err)
+	}
+
+	removed, err := basic.Logout(ctx, credentialStore, reg)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		logger.Warn("Not logged in, no credentials found", logger.Args("registry", reg))
+		return nil
+	}
+	logger.Debug("Credentials removed", logger.Args("credential store", config.RegistryCredentialConfPath()))
+	logger.Info("Logout succeeded", logger.Args("registry", reg))
+
+	return nil
+}
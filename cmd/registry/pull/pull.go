@@ -17,6 +17,7 @@ package pull
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"runtime"
 
@@ -58,7 +59,10 @@ type pullOptions struct {
 }
 
 func (o *pullOptions) Validate() error {
-	return o.Artifact.Validate()
+	if err := o.Artifact.Validate(); err != nil {
+		return err
+	}
+	return utils.ExpandPaths(&o.destDir)
 }
 
 // NewPullCmd returns the pull command.
@@ -110,13 +114,18 @@ func (o *pullOptions) RunPull(ctx context.Context, args []string) error {
 		return err
 	}
 
-	puller, err := ociutils.Puller(o.PlainHTTP, o.Printer)
+	puller, err := ociutils.Puller(o.Registry, o.Printer)
 	if err != nil {
 		return fmt.Errorf("an error occurred while creating the puller for registry %s: %w", registry, err)
 	}
 
-	err = ociutils.CheckConnectionForRegistry(ctx, puller.Client, o.PlainHTTP, registry)
-	if err != nil {
+	opCtx, cancel := o.OperationContext(ctx)
+	err = ociutils.CheckConnectionForRegistry(opCtx, puller.Client, o.PlainHTTP, registry)
+	cancel()
+	var connErr *ociutils.ConnectionError
+	if errors.As(err, &connErr) && connErr.Auth {
+		return fmt.Errorf("%w, please check the credentials configured with \"falcoctl registry auth\"", err)
+	} else if err != nil {
 		return err
 	}
 
@@ -133,7 +142,10 @@ func (o *pullOptions) RunPull(ctx context.Context, args []string) error {
 		os, arch = o.OSArch(0)
 	}
 
-	res, err := puller.Pull(ctx, ref, o.destDir, os, arch)
+	opCtx, cancel = o.OperationContext(ctx)
+	defer cancel()
+
+	res, err := puller.Pull(opCtx, ref, o.destDir, os, arch)
 	if err != nil {
 		return err
 	}
@@ -17,6 +17,8 @@ package pull_test
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -39,15 +41,29 @@ var registryPullUsage = `Usage:
   falcoctl registry pull hostname/repo[:tag|@digest] [flags]
 
 Flags:
-  -o, --dest-dir string        destination dir where to save the artifacts(default: current directory)
-  -h, --help                   help for pull
-      --plain-http             allows interacting with remote registry via plain http requests
-      --platform stringArray   os and architecture of the artifact in OS/ARCH format (only for plugins artifacts)
+      --anonymous                           interact with remote registries anonymously, without looking up credentials in any credential store or helper
+      --ca-cert string                      PEM encoded file with the CA certificates used, in addition to the system ones, to verify the remote registries
+  -o, --dest-dir string                     destination dir where to save the artifacts(default: current directory)
+  -h, --help                                help for pull
+      --identity-token string               identity token exchanged for bearer tokens with the registries instead of the stored credentials, read from a file if given as @<path> or from the standard input if "-"
+      --insecure-skip-tls-verify            skip the verification of the remote registries certificates, making the connections insecure
+      --no-proxy string                     comma-separated list of hosts, domains and CIDRs reached without proxy, overrides the NO_PROXY environment variable
+      --plain-http                          allows interacting with remote registry via plain http requests
+      --platform stringArray                os and architecture of the artifact in OS/ARCH format (only for plugins artifacts)
+      --proxy string                        proxy URL used to connect to remote registries, overrides the HTTP_PROXY and HTTPS_PROXY environment variables
+      --registry-config string              Docker config file whose credentials and credential helpers are used when falcoctl has none for a registry, defaults to $DOCKER_CONFIG/config.json or $HOME/.docker/config.json
+      --registry-connect-timeout duration   maximum duration to establish a connection to the registry (0 means no timeout) (default 30s)
+      --registry-mirror strings             mirror registry tried, in the given order, when pulling from the registry of an artifact fails. It can be repeated multiple times
+      --registry-timeout duration           maximum duration of each registry operation, e.g. checking the connection or pulling an artifact (0 means no timeout) (default 1m0s)
+      --registry-token string               bearer token sent to the registries instead of the stored credentials, read from a file if given as @<path> or from the standard input if "-"
+      --registry-token-host strings         registry, as host[:port], the --registry-token or --identity-token is sent to, the other registries use the stored credentials. It can be repeated multiple times
 
 Global Flags:
-      --config string     config file to be used for falcoctl (default "/etc/falcoctl/falcoctl.yaml")
-      --disable-styling   Disable output styling such as spinners, progress bars and colors. Styling is automatically disabled if not attacched to a tty (default false)
-  -v, --verbose           Enable verbose logs (default false)
+      --config string         config file to be used for falcoctl (default "/etc/falcoctl/falcoctl.yaml")
+      --disable-styling       Disable output styling such as spinners, progress bars and colors. Styling is automatically disabled if not attacched to a tty (default false)
+      --falcoctl-dir string   directory where falcoctl stores the indexes and the client credentials (default "$HOME/.config/falcoctl")
+      --indexes-file string   file listing the configured indexes (default "indexes.yaml" in the falcoctl directory)
+  -v, --verbose               Enable verbose logs (default false)
 
 `
 
@@ -158,6 +174,23 @@ var registryPullTests = Describe("pull", func() {
 			pullAssertFailedBehavior(registryPullUsage, "ERROR unable to connect to remote registry")
 		})
 
+		When("unresponsive registry", func() {
+			BeforeEach(func() {
+				configDir := GinkgoT().TempDir()
+				configFile := filepath.Join(configDir, ".config")
+				_, err := os.Create(configFile)
+				Expect(err).To(BeNil())
+				// The registry accepts connections but never answers.
+				hanging := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					<-r.Context().Done()
+				}))
+				DeferCleanup(hanging.Close)
+				args = []string{registryCmd, pullCmd, strings.TrimPrefix(hanging.URL, "http://") + "/testrules:latest",
+					"--plain-http", "--registry-timeout", "500ms", "--config", configFile}
+			})
+			pullAssertFailedBehavior(registryPullUsage, "context deadline exceeded")
+		})
+
 		When("invalid repository", func() {
 			newReg := registry + "/wrong:latest"
 			BeforeEach(func() {
@@ -319,7 +352,8 @@ var registryPullTests = Describe("pull", func() {
 					"--platform", "linux/unknown", "--config", configFile}
 			})
 
-			pullAssertFailedBehavior(registryPullUsage, "not found: no matching manifest was found in the manifest list")
+			pullAssertFailedBehavior(registryPullUsage, "ERROR unable to find a manifest matching the given platform: linux/unknown "+
+				"(available platforms: "+testPluginPlatform1+")")
 		})
 
 	})
@@ -132,13 +132,18 @@ func (o *pushOptions) runPush(ctx context.Context, args []string) error {
 		return err
 	}
 
-	pusher, err := ociutils.Pusher(o.PlainHTTP, o.Printer)
+	pusher, err := ociutils.Pusher(o.Registry, o.Printer)
 	if err != nil {
 		return fmt.Errorf("an error occurred while creating the pusher for registry %s: %w", registry, err)
 	}
 
-	err = ociutils.CheckConnectionForRegistry(ctx, pusher.Client, o.PlainHTTP, registry)
-	if err != nil {
+	opCtx, cancel := o.OperationContext(ctx)
+	err = ociutils.CheckConnectionForRegistry(opCtx, pusher.Client, o.PlainHTTP, registry)
+	cancel()
+	var connErr *ociutils.ConnectionError
+	if errors.As(err, &connErr) && connErr.Auth {
+		return fmt.Errorf("%w, please check the credentials configured with \"falcoctl registry auth\"", err)
+	} else if err != nil {
 		return err
 	}
 
@@ -166,7 +171,7 @@ func (o *pushOptions) runPush(ctx context.Context, args []string) error {
 			continue
 		} else {
 			if o.ArtifactType == oci.Rulesfile {
-				if config, err = rulesConfigLayer(o.Printer.Logger, p, o.Artifact); err != nil {
+				if config, err = RulesConfigLayer(o.Printer.Logger, p, o.Artifact); err != nil {
 					return err
 				}
 			}
@@ -188,13 +193,19 @@ func (o *pushOptions) runPush(ctx context.Context, args []string) error {
 	if err := config.ParseDependencies(o.Dependencies...); err != nil {
 		return err
 	}
-	if err := config.ParseRequirements(o.Requirements...); err != nil {
+	if err := config.ParseRequirements(o.ConfigRequirements()...); err != nil {
+		return err
+	}
+
+	annotations, err := o.AnnotationsMap()
+	if err != nil {
 		return err
 	}
 
 	opts := ocipusher.Options{
 		ocipusher.WithTags(o.Tags...),
 		ocipusher.WithAnnotationSource(o.AnnotationSource),
+		ocipusher.WithAnnotations(annotations),
 		ocipusher.WithArtifactConfig(*config),
 	}
 
@@ -207,7 +218,10 @@ func (o *pushOptions) runPush(ctx context.Context, args []string) error {
 		opts = append(opts, ocipusher.WithFilepaths(paths))
 	}
 
-	res, err := pusher.Push(ctx, o.ArtifactType, ref, opts...)
+	opCtx, cancel = o.OperationContext(ctx)
+	defer cancel()
+
+	res, err := pusher.Push(opCtx, o.ArtifactType, ref, opts...)
 	if err != nil {
 		return err
 	}
@@ -226,7 +240,9 @@ const (
 	engineRequirementKey = "engine_version_semver"
 )
 
-func rulesConfigLayer(logger *pterm.Logger, filePath string, artifactOptions *options.Artifact) (*oci.ArtifactConfig, error) {
+// RulesConfigLayer returns the config layer of the rulesfile at filePath, holding the name, version, dependencies and
+// requirements given with the artifact options, or the ones declared in the rulesfile when not given.
+func RulesConfigLayer(logger *pterm.Logger, filePath string, artifactOptions *options.Artifact) (*oci.ArtifactConfig, error) {
 	var data []map[string]interface{}
 
 	// Setup OCI artifact configuration
@@ -284,9 +300,9 @@ func rulesConfigLayer(logger *pterm.Logger, filePath string, artifactOptions *op
 
 	// Parse the requirements.
 	// Check if the user has provided any.
-	if len(artifactOptions.Requirements) != 0 {
+	if requirements := artifactOptions.ConfigRequirements(); len(requirements) != 0 {
 		logger.Info("Requirements provided by user")
-		if err = config.ParseRequirements(artifactOptions.Requirements...); err != nil {
+		if err = config.ParseRequirements(requirements...); err != nil {
 			return nil, err
 		}
 	} else {
@@ -213,5 +213,34 @@ var _ = Describe("pushing plugins", func() {
 				platformAMD64,
 			})
 		})
+
+		When("one platform, with Falco version requirements", func() {
+			BeforeEach(func() {
+				repoName, fullRepoName = randomRulesRepoName(registry, pluginsRepoBaseName)
+				pluginOne = plugintgz
+				args = []string{registryCmd, pushCmd, fullRepoName, pluginOne, "--type", "plugin", "--platform",
+					platformAMD64, "--version", version, "--config", configFile,
+					"--plain-http", "--depends-on", "my-test:4.3.2", "--requires", requirement, "--requires", "falco>=0.36,<=0.38.1",
+					"--annotation", "stability=stable", "--tag", pushedTags[0], "--tag", pushedTags[1], "--tag", pushedTags[2],
+					"--name", artifactNameInConfigLayer}
+			})
+			// We expect the Falco versions in the manifest annotations and not in the config layer requirements.
+			AssertSuccessBehaviour([]oci.ArtifactDependency{{
+				Name:         "my-test",
+				Version:      "4.3.2",
+				Alternatives: nil,
+			}}, []oci.ArtifactRequirement{
+				{
+					Name:    "plugin_api_version",
+					Version: "3.2.1",
+				},
+			}, map[string]string{
+				oci.FalcoMinVersionAnnotation: "0.36.0",
+				oci.FalcoMaxVersionAnnotation: "0.38.1",
+				"stability":                   "stable",
+			}, []string{
+				platformAMD64,
+			})
+		})
 	})
 })
@@ -31,21 +31,39 @@ var registryPushUsage = `Usage:
   falcoctl registry push hostname/repo[:tag|@digest] file [flags]
 
 Flags:
-      --annotation-source string   set annotation source for the artifact
-  -d, --depends-on stringArray     set an artifact dependency (can be specified multiple times). Example: "--depends-on my-plugin:1.2.3"
-  -h, --help                       help for push
-      --name string                set the unique name of the artifact (if not set, the name is extracted from the reference)
-      --plain-http                 allows interacting with remote registry via plain http requests
-      --platform stringArray       os and architecture of the artifact in OS/ARCH format (only for plugins artifacts)
-  -r, --requires stringArray       set an artifact requirement (can be specified multiple times). Example: "--requires plugin_api_version:1.2.3"
-  -t, --tag stringArray            additional artifact tag. Can be repeated multiple times
-      --type ArtifactType          type of artifact to be pushed. Allowed values: "rulesfile", "plugin", "asset" (default )
-      --version string             set the version of the artifact
+      --annotation stringArray              set an annotation of the artifact manifest (can be specified multiple times). Example: "--annotation io.falcosecurity.falco.version.min=0.38.0"
+      --annotation-source string            set annotation source for the artifact
+      --anonymous                           interact with remote registries anonymously, without looking up credentials in any credential store or helper
+      --ca-cert string                      PEM encoded file with the CA certificates used, in addition to the system ones, to verify the remote registries
+  -d, --depends-on stringArray              set an artifact dependency (can be specified multiple times). Example: "--depends-on my-plugin:1.2.3"
+  -h, --help                                help for push
+      --identity-token string               identity token exchanged for bearer tokens with the registries instead of the stored credentials, read from a file if given as @<path> or from the standard input if "-"
+      --insecure-skip-tls-verify            skip the verification of the remote registries certificates, making the connections insecure
+      --name string                         set the unique name of the artifact (if not set, the name is extracted from the reference)
+      --no-proxy string                     comma-separated list of hosts, domains and CIDRs reached without proxy, overrides the NO_PROXY environment variable
+      --plain-http                          allows interacting with remote registry via plain http requests
+      --platform stringArray                os and architecture of the artifact in OS/ARCH format (only for plugins artifacts)
+      --proxy string                        proxy URL used to connect to remote registries, overrides the HTTP_PROXY and HTTPS_PROXY environment variables
+      --registry-config string              Docker config file whose credentials and credential helpers are used when falcoctl has none for a registry, defaults to $DOCKER_CONFIG/config.json or $HOME/.docker/config.json
+      --registry-connect-timeout duration   maximum duration to establish a connection to the registry (0 means no timeout) (default 30s)
+      --registry-mirror strings             mirror registry tried, in the given order, when pulling from the registry of an artifact fails. It can be repeated multiple times
+      --registry-timeout duration           maximum duration of each registry operation, e.g. checking the connection or pulling an artifact (0 means no timeout) (default 1m0s)
+      --registry-token string               bearer token sent to the registries instead of the stored credentials, read from a file if given as @<path> or from the standard input if "-"
+      --registry-token-host strings         registry, as host[:port], the --registry-token or --identity-token is sent to, the other registries use the stored credentials. It can be repeated multiple times
+  -r, --requires stringArray                set an artifact requirement (can be specified multiple times). Example: "--requires plugin_api_version:1.2.3", or "--requires falco>=0.36.0" for the supported Falco versions
+  -t, --tag stringArray                     additional artifact tag. Can be repeated multiple times
+      --type ArtifactType                   type of artifact to be pushed. Allowed values: "rulesfile", "plugin", "asset" (default )
+      --version string                      set the version of the artifact
 
 Global Flags:
-      --config string       config file to be used for falcoctl (default "/etc/falcoctl/falcoctl.yaml")
-      --log-format string   Set formatting for logs (color, text, json) (default "color")
-      --log-level string    Set level for logs (info, warn, debug, trace) (default "info")
+      --config string         config file to be used for falcoctl (default "/etc/falcoctl/falcoctl.yaml")
+      --falcoctl-dir string   directory where falcoctl stores the indexes and the client credentials (default "$HOME/.config/falcoctl")
+      --indexes-file string   file listing the configured indexes (default "indexes.yaml" in the falcoctl directory)
+      --log-format string     Set formatting for logs (color, text, json) (default "color")
+      --log-level string      Set level for logs (info, warn, debug, trace) (default "info")
+      --no-color              Disable colors, spinners and progress bars, as the NO_COLOR environment variable does. They are disabled as well when not attached to a tty
+      --output string         Set format for the results of the commands supporting it (text, json, yaml, annotations), messages are written to stderr when set to json or yaml (default "text")
+  -q, --quiet                 Only print errors, to stderr, and the results of the commands, silencing the other logs, spinners and progress bars
 `
 
 //nolint:lll,unused // no need to check for line length.
@@ -85,21 +103,39 @@ Usage:
   falcoctl registry push hostname/repo[:tag|@digest] file [flags]
 
 Flags:
-      --annotation-source string   set annotation source for the artifact
-  -d, --depends-on stringArray     set an artifact dependency (can be specified multiple times). Example: "--depends-on my-plugin:1.2.3"
-  -h, --help                       help for push
-      --name string                set the unique name of the artifact (if not set, the name is extracted from the reference)
-      --plain-http                 allows interacting with remote registry via plain http requests
-      --platform stringArray       os and architecture of the artifact in OS/ARCH format (only for plugins artifacts)
-  -r, --requires stringArray       set an artifact requirement (can be specified multiple times). Example: "--requires plugin_api_version:1.2.3"
-  -t, --tag stringArray            additional artifact tag. Can be repeated multiple times
-      --type ArtifactType          type of artifact to be pushed. Allowed values: "rulesfile", "plugin", "asset"
-      --version string             set the version of the artifact
+      --annotation stringArray              set an annotation of the artifact manifest (can be specified multiple times). Example: "--annotation io.falcosecurity.falco.version.min=0.38.0"
+      --annotation-source string            set annotation source for the artifact
+      --anonymous                           interact with remote registries anonymously, without looking up credentials in any credential store or helper
+      --ca-cert string                      PEM encoded file with the CA certificates used, in addition to the system ones, to verify the remote registries
+  -d, --depends-on stringArray              set an artifact dependency (can be specified multiple times). Example: "--depends-on my-plugin:1.2.3"
+  -h, --help                                help for push
+      --identity-token string               identity token exchanged for bearer tokens with the registries instead of the stored credentials, read from a file if given as @<path> or from the standard input if "-"
+      --insecure-skip-tls-verify            skip the verification of the remote registries certificates, making the connections insecure
+      --name string                         set the unique name of the artifact (if not set, the name is extracted from the reference)
+      --no-proxy string                     comma-separated list of hosts, domains and CIDRs reached without proxy, overrides the NO_PROXY environment variable
+      --plain-http                          allows interacting with remote registry via plain http requests
+      --platform stringArray                os and architecture of the artifact in OS/ARCH format (only for plugins artifacts)
+      --proxy string                        proxy URL used to connect to remote registries, overrides the HTTP_PROXY and HTTPS_PROXY environment variables
+      --registry-config string              Docker config file whose credentials and credential helpers are used when falcoctl has none for a registry, defaults to $DOCKER_CONFIG/config.json or $HOME/.docker/config.json
+      --registry-connect-timeout duration   maximum duration to establish a connection to the registry (0 means no timeout) (default 30s)
+      --registry-mirror strings             mirror registry tried, in the given order, when pulling from the registry of an artifact fails. It can be repeated multiple times
+      --registry-timeout duration           maximum duration of each registry operation, e.g. checking the connection or pulling an artifact (0 means no timeout) (default 1m0s)
+      --registry-token string               bearer token sent to the registries instead of the stored credentials, read from a file if given as @<path> or from the standard input if "-"
+      --registry-token-host strings         registry, as host[:port], the --registry-token or --identity-token is sent to, the other registries use the stored credentials. It can be repeated multiple times
+  -r, --requires stringArray                set an artifact requirement (can be specified multiple times). Example: "--requires plugin_api_version:1.2.3", or "--requires falco>=0.36.0" for the supported Falco versions
+  -t, --tag stringArray                     additional artifact tag. Can be repeated multiple times
+      --type ArtifactType                   type of artifact to be pushed. Allowed values: "rulesfile", "plugin", "asset"
+      --version string                      set the version of the artifact
 
 Global Flags:
-      --config string       config file to be used for falcoctl (default "/etc/falcoctl/falcoctl.yaml")
-      --log-format string   Set formatting for logs (color, text, json) (default "color")
-      --log-level string    Set level for logs (info, warn, debug, trace) (default "info")
+      --config string         config file to be used for falcoctl (default "/etc/falcoctl/falcoctl.yaml")
+      --falcoctl-dir string   directory where falcoctl stores the indexes and the client credentials (default "$HOME/.config/falcoctl")
+      --indexes-file string   file listing the configured indexes (default "indexes.yaml" in the falcoctl directory)
+      --log-format string     Set formatting for logs (color, text, json) (default "color")
+      --log-level string      Set level for logs (info, warn, debug, trace) (default "info")
+      --no-color              Disable colors, spinners and progress bars, as the NO_COLOR environment variable does. They are disabled as well when not attached to a tty
+      --output string         Set format for the results of the commands supporting it (text, json, yaml, annotations), messages are written to stderr when set to json or yaml (default "text")
+  -q, --quiet                 Only print errors, to stderr, and the results of the commands, silencing the other logs, spinners and progress bars
 `
 
 var pushAssertFailedBehavior = func(usage, specificError string) {
@@ -21,9 +21,12 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/falcosecurity/falcoctl/cmd/registry/auth"
+	"github.com/falcosecurity/falcoctl/cmd/registry/catalog"
+	"github.com/falcosecurity/falcoctl/cmd/registry/login"
+	"github.com/falcosecurity/falcoctl/cmd/registry/logout"
 	"github.com/falcosecurity/falcoctl/cmd/registry/pull"
 	"github.com/falcosecurity/falcoctl/cmd/registry/push"
-	"github.com/falcosecurity/falcoctl/internal/config"
+	"github.com/falcosecurity/falcoctl/cmd/registry/tags"
 	commonoptions "github.com/falcosecurity/falcoctl/pkg/options"
 )
 
@@ -38,13 +41,17 @@ func NewRegistryCmd(ctx context.Context, opt *commonoptions.Common) *cobra.Comma
 			// Initialize the options.
 			opt.Initialize()
 			// Load configuration from ENV variables and/or config file.
-			return config.Load(opt.ConfigFile)
+			return opt.LoadConfig(cmd)
 		},
 	}
 
 	cmd.AddCommand(auth.NewAuthCmd(ctx, opt))
+	cmd.AddCommand(login.NewLoginCmd(ctx, opt))
+	cmd.AddCommand(logout.NewLogoutCmd(ctx, opt))
 	cmd.AddCommand(push.NewPushCmd(ctx, opt))
 	cmd.AddCommand(pull.NewPullCmd(ctx, opt))
+	cmd.AddCommand(catalog.NewCatalogCmd(ctx, opt))
+	cmd.AddCommand(tags.NewTagsCmd(ctx, opt))
 
 	return cmd
 }
@@ -0,0 +1,154 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tags
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2/registry"
+
+	"github.com/falcosecurity/falcoctl/pkg/oci/repository"
+	ociutils "github.com/falcosecurity/falcoctl/pkg/oci/utils"
+	"github.com/falcosecurity/falcoctl/pkg/options"
+	"github.com/falcosecurity/falcoctl/pkg/output"
+)
+
+const (
+	longTags = `List the tags of a repository of an OCI registry.
+
+A reference is a fully qualified reference ("<registry>/<repository>"), whose tag or digest, if any, is ignored.
+The tags are listed through the tag list endpoint of the OCI distribution API, following every page of results.
+
+Example - List the tags of the "myplugin" repository:
+	falcoctl registry tags localhost:5000/myplugin
+
+Example - List the tags sorted after "0.1.0", requesting pages of 50 tags:
+	falcoctl registry tags localhost:5000/myplugin --last 0.1.0 --page-size 50
+`
+
+	// FlagPageSize is the name of the flag to specify the number of tags requested for each page.
+	FlagPageSize = "page-size"
+
+	// FlagLast is the name of the flag to specify the tag after which the listing starts.
+	FlagLast = "last"
+)
+
+type tagsOptions struct {
+	*options.Common
+	*options.Registry
+	pageSize int
+	last     string
+}
+
+// Validate validates the options passed by the user.
+func (o *tagsOptions) Validate() error {
+	if o.pageSize < 0 {
+		return fmt.Errorf("--%s must not be negative", FlagPageSize)
+	}
+	return nil
+}
+
+// NewTagsCmd returns the tags command.
+func NewTagsCmd(ctx context.Context, opt *options.Common) *cobra.Command {
+	o := tagsOptions{
+		Common:   opt,
+		Registry: &options.Registry{},
+	}
+
+	cmd := &cobra.Command{
+		Use:                   "tags hostname/repo [flags]",
+		DisableFlagsInUseLine: true,
+		Short:                 "List the tags of a repository of an OCI registry",
+		Long:                  longTags,
+		Args:                  cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return o.Validate()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.RunTags(ctx, args)
+		},
+	}
+
+	o.Registry.AddFlags(cmd)
+	cmd.Flags().IntVar(&o.pageSize, FlagPageSize, 0,
+		"number of tags requested to the registry for each page of results (0 leaves it to the registry)")
+	cmd.Flags().StringVar(&o.last, FlagLast, "",
+		"list only the tags sorted after the given one, e.g. the last one of a previous listing")
+
+	return cmd
+}
+
+// repositoryTags are the tags of a repository, as printed in the structured output.
+type repositoryTags struct {
+	Repository string   `json:"repository"`
+	Tags       []string `json:"tags"`
+}
+
+// RunTags executes the business logic for the tags command.
+func (o *tagsOptions) RunTags(ctx context.Context, args []string) error {
+	logger := o.Printer.Logger
+
+	parsedRef, err := registry.ParseReference(args[0])
+	if err != nil {
+		return fmt.Errorf("unable to parse reference %q: %w", args[0], err)
+	}
+	parsedRef.Reference = ""
+	ref := parsedRef.String()
+
+	clientOpts, err := o.ClientOptions(o.Printer)
+	if err != nil {
+		return err
+	}
+	client, err := ociutils.Client(true, clientOpts...)
+	if err != nil {
+		return err
+	}
+
+	repo, err := repository.NewRepository(ref,
+		repository.WithClient(client),
+		repository.WithPlainHTTP(o.PlainHTTP),
+		repository.WithTagListPageSize(o.pageSize))
+	if err != nil {
+		return err
+	}
+
+	opCtx, cancel := o.OperationContext(ctx)
+	defer cancel()
+	tags, err := repo.TagsAfter(opCtx, o.last)
+	if err != nil {
+		return fmt.Errorf("unable to list the tags of %q: %w", ref, err)
+	}
+
+	result := repositoryTags{Repository: ref, Tags: tags}
+	if result.Tags == nil {
+		result.Tags = []string{}
+	}
+
+	// The table is printed only if there are tags, the structured output is always printed.
+	if len(tags) == 0 && !o.Printer.StructuredOutput() {
+		logger.Info("No tags found", logger.Args("repository", ref))
+		return nil
+	}
+
+	data := make([][]string, 0, len(tags))
+	for _, tag := range tags {
+		data = append(data, []string{tag})
+	}
+
+	return o.Printer.PrintResults(result, output.RegistryTags, data)
+}
@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tags_test
+
+import (
+	"regexp"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"oras.land/oras-go/v2/registry/remote/auth"
+
+	"github.com/falcosecurity/falcoctl/cmd"
+	"github.com/falcosecurity/falcoctl/pkg/oci"
+	"github.com/falcosecurity/falcoctl/pkg/oci/authn"
+	ocipusher "github.com/falcosecurity/falcoctl/pkg/oci/pusher"
+)
+
+var _ = Describe("tags", func() {
+	const (
+		registryCmd = "registry"
+		tagsCmd     = "tags"
+		repo        = "/tags/rules"
+	)
+
+	// Each test gets its own root command and runs it.
+	// The err variable is asserted by each test.
+	JustBeforeEach(func() {
+		rootCmd = cmd.New(ctx, opt)
+		err = executeRoot(args)
+	})
+
+	JustAfterEach(func() {
+		Expect(output.Clear()).ShouldNot(HaveOccurred())
+	})
+
+	Context("with tags", func() {
+		BeforeEach(func() {
+			pusher := ocipusher.NewPusher(authn.NewClient(authn.WithCredentials(&auth.EmptyCredential)), true, nil)
+			_, err := pusher.Push(ctx, oci.Rulesfile, registry+repo+":0.1.0",
+				ocipusher.WithFilepaths([]string{rulesfiletgz}),
+				ocipusher.WithTags("0.1.0", "0.2.0", "latest"),
+				ocipusher.WithArtifactConfig(oci.ArtifactConfig{Name: "rules", Version: "0.1.0"}))
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		When("listing every tag", func() {
+			BeforeEach(func() {
+				args = []string{registryCmd, tagsCmd, registry + repo, "--plain-http", "--config", configFile}
+			})
+
+			It("should print all of them", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(output).Should(gbytes.Say("TAG"))
+				Expect(output).Should(gbytes.Say("0.1.0"))
+				Expect(output).Should(gbytes.Say("0.2.0"))
+				Expect(output).Should(gbytes.Say("latest"))
+			})
+		})
+
+		When("following pages of a single tag, with a tag in the reference", func() {
+			BeforeEach(func() {
+				args = []string{registryCmd, tagsCmd, registry + repo + ":latest", "--plain-http", "--config", configFile,
+					"--page-size", "1"}
+			})
+
+			It("should print all of them", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(output).Should(gbytes.Say("0.1.0"))
+				Expect(output).Should(gbytes.Say("0.2.0"))
+				Expect(output).Should(gbytes.Say("latest"))
+			})
+		})
+
+		When("starting after a tag", func() {
+			BeforeEach(func() {
+				args = []string{registryCmd, tagsCmd, registry + repo, "--plain-http", "--config", configFile, "--last", "0.1.0"}
+			})
+
+			It("should print only the tags sorted after it", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(output.Contents())).ToNot(ContainSubstring("0.1.0"))
+				Expect(string(output.Contents())).To(ContainSubstring("0.2.0"))
+				Expect(string(output.Contents())).To(ContainSubstring("latest"))
+			})
+		})
+	})
+
+	Context("failure", func() {
+		When("the reference is not fully qualified", func() {
+			BeforeEach(func() {
+				args = []string{registryCmd, tagsCmd, "rules", "--plain-http", "--config", configFile}
+			})
+
+			It("should fail", func() {
+				Expect(err).To(HaveOccurred())
+				Expect(output).Should(gbytes.Say(regexp.QuoteMeta(`unable to parse reference "rules"`)))
+			})
+		})
+
+		When("the repository does not exist", func() {
+			BeforeEach(func() {
+				args = []string{registryCmd, tagsCmd, registry + "/tags/missing", "--plain-http", "--config", configFile}
+			})
+
+			It("should fail", func() {
+				Expect(err).To(HaveOccurred())
+				Expect(output).Should(gbytes.Say(regexp.QuoteMeta(`unable to list the tags of "` + registry + `/tags/missing"`)))
+			})
+		})
+	})
+})
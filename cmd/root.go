@@ -21,8 +21,10 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/falcosecurity/falcoctl/cmd/artifact"
+	"github.com/falcosecurity/falcoctl/cmd/cache"
 	"github.com/falcosecurity/falcoctl/cmd/driver"
 	"github.com/falcosecurity/falcoctl/cmd/index"
+	"github.com/falcosecurity/falcoctl/cmd/key"
 	"github.com/falcosecurity/falcoctl/cmd/registry"
 	"github.com/falcosecurity/falcoctl/cmd/tls"
 	"github.com/falcosecurity/falcoctl/cmd/version"
@@ -68,6 +70,8 @@ func New(ctx context.Context, opt *options.Common) *cobra.Command {
 	rootCmd.AddCommand(version.NewVersionCmd(opt))
 	rootCmd.AddCommand(registry.NewRegistryCmd(ctx, opt))
 	rootCmd.AddCommand(index.NewIndexCmd(ctx, opt))
+	rootCmd.AddCommand(key.NewKeyCmd(ctx, opt))
+	rootCmd.AddCommand(cache.NewCacheCmd(ctx, opt))
 	rootCmd.AddCommand(artifact.NewArtifactCmd(ctx, opt))
 	rootCmd.AddCommand(driver.NewDriverCmd(ctx, opt))
 
@@ -43,19 +43,26 @@ Usage:
 
 Available Commands:
   artifact    Interact with Falco artifacts
+  cache       Manage the cache of pulled artifacts
   completion  Generate the autocompletion script for the specified shell
   driver      [Preview] Interact with falcosecurity driver
   help        Help about any command
   index       Interact with index
+  key         Manage the keys trusted to verify the signatures of the artifacts
   registry    Interact with OCI registries
   tls         Generate and install TLS material for Falco
   version     Print the falcoctl version information
 
 Flags:
-      --config string       config file to be used for falcoctl (default "/etc/falcoctl/falcoctl.yaml")
-  -h, --help                help for falcoctl
-      --log-format string   Set formatting for logs (color, text, json) (default "color")
-      --log-level string    Set level for logs (info, warn, debug, trace) (default "info")
+      --config string         config file to be used for falcoctl (default "/etc/falcoctl/falcoctl.yaml")
+      --falcoctl-dir string   directory where falcoctl stores the indexes and the client credentials (default "$HOME/.config/falcoctl")
+  -h, --help                  help for falcoctl
+      --indexes-file string   file listing the configured indexes (default "indexes.yaml" in the falcoctl directory)
+      --log-format string     Set formatting for logs (color, text, json) (default "color")
+      --log-level string      Set level for logs (info, warn, debug, trace) (default "info")
+      --no-color              Disable colors, spinners and progress bars, as the NO_COLOR environment variable does. They are disabled as well when not attached to a tty
+      --output string         Set format for the results of the commands supporting it (text, json, yaml, annotations), messages are written to stderr when set to json or yaml (default "text")
+  -q, --quiet                 Only print errors, to stderr, and the results of the commands, silencing the other logs, spinners and progress bars
 
 Use "falcoctl [command] --help" for more information about a command.
 `
@@ -75,18 +82,25 @@ Usage:
 
 Available Commands:
   artifact    Interact with Falco artifacts
+  cache       Manage the cache of pulled artifacts
   completion  Generate the autocompletion script for the specified shell
   help        Help about any command
   index       Interact with index
+  key         Manage the keys trusted to verify the signatures of the artifacts
   registry    Interact with OCI registries
   tls         Generate and install TLS material for Falco
   version     Print the falcoctl version information
 
 Flags:
-      --config string       config file to be used for falcoctl (default "/etc/falcoctl/falcoctl.yaml")
-  -h, --help                help for falcoctl
-      --log-format string   Set formatting for logs (color, text, json) (default "color")
-      --log-level string    Set level for logs (info, warn, debug, trace) (default "info")
+      --config string         config file to be used for falcoctl (default "/etc/falcoctl/falcoctl.yaml")
+      --falcoctl-dir string   directory where falcoctl stores the indexes and the client credentials (default "$HOME/.config/falcoctl")
+  -h, --help                  help for falcoctl
+      --indexes-file string   file listing the configured indexes (default "indexes.yaml" in the falcoctl directory)
+      --log-format string     Set formatting for logs (color, text, json) (default "color")
+      --log-level string      Set level for logs (info, warn, debug, trace) (default "info")
+      --no-color              Disable colors, spinners and progress bars, as the NO_COLOR environment variable does. They are disabled as well when not attached to a tty
+      --output string         Set format for the results of the commands supporting it (text, json, yaml, annotations), messages are written to stderr when set to json or yaml (default "text")
+  -q, --quiet                 Only print errors, to stderr, and the results of the commands, silencing the other logs, spinners and progress bars
 
 Use "falcoctl [command] --help" for more information about a command.
 `
@@ -45,6 +45,16 @@ var (
 	IndexesDir string
 	// ClientCredentialsFile name of the file where oauth client credentials are stored. It lives under FalcoctlPath.
 	ClientCredentialsFile string
+	// KeysDir is where the public keys trusted to verify the signatures are stored, by name. It lives under FalcoctlPath.
+	KeysDir string
+	// InstallLockFile is the file locked by the installs, so that the ones running at the same time on the node are
+	// serialized. It lives under FalcoctlPath.
+	InstallLockFile string
+	// LockFile is the default path of the lockfile recording the installed artifacts. It lives under FalcoctlPath.
+	LockFile string
+	// CacheDir is where the manifests and layers pulled from the registries are cached. It lives under the
+	// user cache directory, e.g. $XDG_CACHE_HOME on Linux.
+	CacheDir string
 	// DefaultIndex is the default index for the falcosecurity organization.
 	DefaultIndex Index
 	// DefaultRegistryCredentialConfPath is the default path for the credential store configuration file.
@@ -52,6 +62,9 @@ var (
 	// DefaultDriver is the default config for the falcosecurity organization.
 	DefaultDriver Driver
 
+	// defaultFalcoctlPath is the value of FalcoctlPath when it is not relocated with SetFalcoctlPath.
+	defaultFalcoctlPath string
+
 	// Useful regexps for parsing.
 
 	// SemicolonSeparatedRegexp is a regexp matching semi-colon separated values, without trailing separator.
@@ -82,12 +95,43 @@ const (
 	// RegistryCredentialConfigKey is the Viper key for the credentials store path configuration.
 	//#nosec G101 -- false positive
 	RegistryCredentialConfigKey = "registry.creds.config"
+	// RegistryCredentialHelpersKey is the Viper key for the credential helpers used for each registry.
+	RegistryCredentialHelpersKey = "registry.creds.helpers"
 	// RegistryAuthOauthKey is the Viper key for OAuth authentication configuration.
 	RegistryAuthOauthKey = "registry.auth.oauth"
 	// RegistryAuthBasicKey is the Viper key for basic authentication configuration.
 	RegistryAuthBasicKey = "registry.auth.basic"
 	// RegistryAuthGcpKey is the Viper key for gcp authentication configuration.
 	RegistryAuthGcpKey = "registry.auth.gcp"
+	// RegistryMirrorsKey is the Viper key for the mirror registries configuration.
+	RegistryMirrorsKey = "registry.mirrors"
+	// RegistryPlainHTTPKey is the Viper key for the plain http configuration of the registries.
+	RegistryPlainHTTPKey = "registry.plainHTTP"
+	// RegistryProxyKey is the Viper key for the proxy used to connect to the registries.
+	RegistryProxyKey = "registry.proxy"
+	// RegistryNoProxyKey is the Viper key for the hosts reached without proxy.
+	RegistryNoProxyKey = "registry.noProxy"
+	// RegistryTimeoutKey is the Viper key for the maximum duration of each registry operation.
+	RegistryTimeoutKey = "registry.timeout"
+	// RegistryConnectTimeoutKey is the Viper key for the maximum duration to establish a connection to the registries.
+	RegistryConnectTimeoutKey = "registry.connectTimeout"
+	// RegistryCACertKey is the Viper key for the CA certificates used to verify the registries.
+	RegistryCACertKey = "registry.caCert"
+	// RegistryInsecureSkipTLSVerifyKey is the Viper key to skip the verification of the registries certificates.
+	RegistryInsecureSkipTLSVerifyKey = "registry.insecureSkipTLSVerify"
+	// RegistryAnonymousKey is the Viper key to interact with the registries anonymously.
+	RegistryAnonymousKey = "registry.anonymous"
+	// RegistryMaxRetriesKey is the Viper key for how many times failed registry requests are retried.
+	RegistryMaxRetriesKey = "registry.maxRetries"
+	// RegistryRetryBackoffKey is the Viper key for the initial wait time between the retries of registry requests.
+	RegistryRetryBackoffKey = "registry.retryBackoff"
+	// RegistryConcurrencyKey is the Viper key for how many chunks of a layer are downloaded in parallel.
+	RegistryConcurrencyKey = "registry.concurrency"
+
+	// LogLevelKey is the Viper key for the log level.
+	LogLevelKey = "log.level"
+	// LogFormatKey is the Viper key for the log format.
+	LogFormatKey = "log.format"
 
 	// IndexesKey is the Viper key for indexes configuration.
 	IndexesKey = "indexes"
@@ -198,10 +242,14 @@ type Driver struct {
 
 func init() {
 	ConfigDir = filepath.Join(homedir.Get(), ".config")
-	FalcoctlPath = filepath.Join(ConfigDir, "falcoctl")
-	IndexesFile = filepath.Join(FalcoctlPath, "indexes.yaml")
-	IndexesDir = filepath.Join(FalcoctlPath, "indexes")
-	ClientCredentialsFile = filepath.Join(FalcoctlPath, "clientcredentials.json")
+	defaultFalcoctlPath = filepath.Join(ConfigDir, "falcoctl")
+	SetFalcoctlPath("", "")
+	LockFile = filepath.Join(FalcoctlPath, "falcoctl.lock.yaml")
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		cacheDir = filepath.Join(homedir.Get(), ".cache")
+	}
+	CacheDir = filepath.Join(cacheDir, "falcoctl")
 	DefaultIndex = Index{
 		Name: "falcosecurity",
 		URL:  "https://falcosecurity.github.io/falcoctl/index.yaml",
@@ -215,6 +263,40 @@ func init() {
 	}
 }
 
+// SetFalcoctlPath relocates the files falcoctl stores under FalcoctlPath, i.e. IndexesFile, IndexesDir,
+// ClientCredentialsFile and KeysDir, to the given directory. The indexes file is set to indexesFile, when not empty,
+// instead of the one in the directory. Empty values restore the defaults.
+func SetFalcoctlPath(path, indexesFile string) {
+	if path == "" {
+		path = defaultFalcoctlPath
+	}
+	FalcoctlPath = path
+	IndexesFile = filepath.Join(FalcoctlPath, "indexes.yaml")
+	if indexesFile != "" {
+		IndexesFile = indexesFile
+	}
+	IndexesDir = filepath.Join(FalcoctlPath, "indexes")
+	ClientCredentialsFile = filepath.Join(FalcoctlPath, "clientcredentials.json")
+	KeysDir = filepath.Join(FalcoctlPath, "keys")
+	InstallLockFile = filepath.Join(FalcoctlPath, "install.lock")
+}
+
+// DiscoverConfigFile returns the config file used when none is given: the user one, i.e.
+// $XDG_CONFIG_HOME/falcoctl/falcoctl.yaml or ~/.config/falcoctl/falcoctl.yaml, if it exists, else ConfigPath.
+func DiscoverConfigFile() string {
+	configDir := os.Getenv("XDG_CONFIG_HOME")
+	if configDir == "" {
+		configDir = ConfigDir
+	}
+
+	userConfig := filepath.Join(configDir, "falcoctl", "falcoctl.yaml")
+	if info, err := os.Stat(userConfig); err == nil && !info.IsDir() {
+		return userConfig
+	}
+
+	return ConfigPath
+}
+
 // Load is used to load the config file.
 func Load(path string) error {
 	// we keep these for consistency, but not actually used
@@ -348,6 +430,42 @@ func RegistryCredentialConfPath() string {
 	return viper.GetString(RegistryCredentialConfigKey)
 }
 
+// RegistryCredentialHelpers retrieves the credential helpers of the config file, mapping each registry to the
+// suffix of its helper program, e.g. "internal" for "docker-credential-internal".
+// When passed as env it should be in the following format: "registry,helper;registry1,helper1".
+func RegistryCredentialHelpers() (map[string]string, error) {
+	raw, ok := viper.Get(RegistryCredentialHelpersKey).(string)
+	if !ok {
+		return viper.GetStringMapString(RegistryCredentialHelpersKey), nil
+	}
+
+	if !SemicolonSeparatedRegexp.MatchString(raw) {
+		return nil, fmt.Errorf("env variable not correctly set, should match %q, got %q", SemicolonSeparatedRegexp.String(), raw)
+	}
+	helpers := make(map[string]string)
+	for _, token := range strings.Split(raw, ";") {
+		values := strings.Split(token, ",")
+		if len(values) != 2 || values[0] == "" || values[1] == "" {
+			return nil, fmt.Errorf("not valid token %q, should be in the \"registry,helper\" format", token)
+		}
+		helpers[values[0]] = values[1]
+	}
+	return helpers, nil
+}
+
+// RegistryMirrors retrieves the mirror registries of the config file.
+func RegistryMirrors() ([]string, error) {
+	// manage registry.mirrors as ";" separated list.
+	mirrors := viper.GetStringSlice(RegistryMirrorsKey)
+	if len(mirrors) == 1 { // in this case it might come from the env
+		if !SemicolonSeparatedRegexp.MatchString(mirrors[0]) {
+			return mirrors, fmt.Errorf("env variable not correctly set, should match %q, got %q", SemicolonSeparatedRegexp.String(), mirrors[0])
+		}
+		mirrors = strings.Split(mirrors[0], ";")
+	}
+	return mirrors, nil
+}
+
 // BasicAuths retrieves the basicAuths section of the config file.
 func BasicAuths() ([]BasicAuth, error) {
 	var auths []BasicAuth
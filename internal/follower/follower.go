@@ -34,12 +34,13 @@ import (
 	"oras.land/oras-go/v2/registry"
 
 	"github.com/falcosecurity/falcoctl/internal/config"
-	"github.com/falcosecurity/falcoctl/internal/signature"
 	"github.com/falcosecurity/falcoctl/internal/utils"
 	"github.com/falcosecurity/falcoctl/pkg/index/index"
 	"github.com/falcosecurity/falcoctl/pkg/oci"
+	"github.com/falcosecurity/falcoctl/pkg/oci/authn"
 	ocipuller "github.com/falcosecurity/falcoctl/pkg/oci/puller"
 	ociutils "github.com/falcosecurity/falcoctl/pkg/oci/utils"
+	"github.com/falcosecurity/falcoctl/pkg/oci/verify"
 	"github.com/falcosecurity/falcoctl/pkg/output"
 )
 
@@ -75,6 +76,12 @@ type Config struct {
 	ArtifactReference string
 	// PlainHTTP is set to true if all registry interaction must be in plain http.
 	PlainHTTP bool
+	// ClientOptions are additional options, e.g. the proxy settings, for the client used to reach the registry.
+	ClientOptions []func(*authn.Options)
+	// Mirrors are the registries tried, in order, when pulling from the registry of the artifact fails.
+	Mirrors []string
+	// RegistryTimeout bounds each interaction with the registry, zero means no timeout.
+	RegistryTimeout time.Duration
 	// TmpDir directory where to save temporary files.
 	TmpDir string
 	// FalcoVersions is a struct containing all the required Falco versions that this follower
@@ -84,6 +91,11 @@ type Config struct {
 	AllowedTypes oci.ArtifactTypeSlice
 	// Signature has the data needed for signature checking
 	Signature *index.Signature
+	// RetryBackoff is the delay before checking again after a failed check, doubled at each consecutive failure
+	// and never longer than the resync time. Zero means waiting for the resync time.
+	RetryBackoff time.Duration
+	// PostInstall, if set, is called once a new version of the artifact has been installed, e.g. to reload Falco.
+	PostInstall func(ctx context.Context) error
 }
 
 var (
@@ -104,12 +116,12 @@ func New(ref string, printer *output.Printer, conf *Config) (*Follower, error) {
 	}
 	tag := parsedRef.Reference
 
-	client, err := ociutils.Client(false)
+	client, err := ociutils.Client(false, conf.ClientOptions...)
 	if err != nil {
 		return nil, err
 	}
 
-	puller := ocipuller.NewPuller(client, conf.PlainHTTP, nil)
+	puller := ocipuller.NewPuller(client, conf.PlainHTTP, nil, ocipuller.WithMirrors(conf.Mirrors...))
 	if err != nil {
 		return nil, err
 	}
@@ -134,11 +146,18 @@ func New(ref string, printer *output.Printer, conf *Config) (*Follower, error) {
 // Follow starts a goroutine that periodically checks for updates for the configured artifact.
 func (f *Follower) Follow(ctx context.Context) {
 	// At start up time of the follower we sync immediately without waiting the resync time.
-	f.follow(ctx)
+	failures := 0
+	if err := f.follow(ctx); err != nil {
+		failures++
+	}
 
 	for {
 		now := time.Now()
-		next := f.Resync.Next(now)
+		wait := f.Resync.Next(now).Sub(now)
+		if retry := f.retryDelay(failures); failures > 0 && retry > 0 && retry < wait {
+			f.logger.Info("Check failed, retrying", f.logger.Args("followerName", f.ref, "failures", failures, "retryIn", retry.String()))
+			wait = retry
+		}
 		select {
 		case <-f.CloseChan:
 			f.cleanUp()
@@ -146,20 +165,45 @@ func (f *Follower) Follow(ctx context.Context) {
 			// Notify that the follower is done.
 			f.WaitGroup.Done()
 			return
-		case <-time.After(next.Sub(now)):
+		case <-time.After(wait):
 			// Start following the artifact.
-			f.follow(ctx)
+			if err := f.follow(ctx); err != nil {
+				failures++
+			} else {
+				failures = 0
+			}
+		}
+	}
+}
+
+// retryDelay returns the delay before checking again after the given number of consecutive failed checks,
+// i.e. RetryBackoff doubled at each failure after the first one.
+func (f *Follower) retryDelay(failures int) time.Duration {
+	if failures <= 0 || f.RetryBackoff <= 0 {
+		return 0
+	}
+
+	delay := f.RetryBackoff
+	for i := 1; i < failures; i++ {
+		// Once past a day the delay is certainly longer than any sensible resync time, stop doubling.
+		if delay > 24*time.Hour {
+			break
 		}
+		delay *= 2
 	}
+	return delay
 }
 
-func (f *Follower) follow(ctx context.Context) {
+// follow checks for a new version of the artifact and installs it, returning an error if the check failed.
+func (f *Follower) follow(ctx context.Context) error {
 	// First thing get the descriptor from remote repo.
 	f.logger.Debug("Fetching descriptor from remote repository...", f.logger.Args("followerName", f.ref))
-	desc, err := f.Descriptor(ctx, f.ref)
+	opCtx, cancel := f.operationContext(ctx)
+	desc, err := f.Descriptor(opCtx, f.ref)
+	cancel()
 	if err != nil {
 		f.logger.Debug(fmt.Sprintf("an error occurred while fetching descriptor from remote repository: %v", err))
-		return
+		return err
 	}
 	f.logger.Debug("Descriptor correctly fetched", f.logger.Args("followerName", f.ref))
 
@@ -167,7 +211,7 @@ func (f *Follower) follow(ctx context.Context) {
 	// TODO(alacuku): check that the file also exists to cover the case when someone has removed the file.
 	if desc.Digest.String() == f.currentDigest {
 		f.logger.Debug("Nothing to do, artifact already up to date.", f.logger.Args("followerName", f.ref))
-		return
+		return err
 	}
 
 	f.logger.Info("Found new artifact version", f.logger.Args("followerName", f.ref, "tag", f.tag))
@@ -176,13 +220,13 @@ func (f *Follower) follow(ctx context.Context) {
 	artifactConfig, err := f.ArtifactConfig(ctx, f.ref, runtime.GOOS, runtime.GOARCH)
 	if err != nil {
 		f.logger.Error("Unable to pull config layer", f.logger.Args("followerName", f.ref, "reason", err.Error()))
-		return
+		return err
 	}
 
 	err = f.checkRequirements(artifactConfig)
 	if err != nil {
 		f.logger.Error("Unmet requirements", f.logger.Args("followerName", f.ref, "reason", err.Error()))
-		return
+		return err
 	}
 
 	f.logger.Debug("Pulling artifact", f.logger.Args("followerName", f.ref))
@@ -190,7 +234,7 @@ func (f *Follower) follow(ctx context.Context) {
 	filePaths, res, err := f.pull(ctx)
 	if err != nil {
 		f.logger.Error("Unable to pull artifact", f.logger.Args("followerName", f.ref, "reason", err.Error()))
-		return
+		return err
 	}
 	f.logger.Debug("Artifact correctly pulled", f.logger.Args("followerName", f.ref))
 
@@ -200,7 +244,7 @@ func (f *Follower) follow(ctx context.Context) {
 	err = utils.ExistsAndIsWritable(dstDir)
 	if err != nil {
 		f.logger.Error("Invalid destination", f.logger.Args("followerName", f.ref, "directory", dstDir, "reason", err.Error()))
-		return
+		return err
 	}
 
 	// Install the artifacts if necessary.
@@ -213,14 +257,14 @@ func (f *Follower) follow(ctx context.Context) {
 		exists, err := utils.FileExists(dstPath)
 		if err != nil {
 			f.logger.Error("Unable to check existence for file", f.logger.Args("followerName", f.ref, "fileName", baseName, "reason", err.Error()))
-			return
+			return err
 		}
 
 		if !exists {
 			f.logger.Debug("Moving file", f.logger.Args("followerName", f.ref, "fileName", baseName, "destDirectory", dstDir))
 			if err = utils.Move(path, dstPath); err != nil {
 				f.logger.Error("Unable to move file", f.logger.Args("followerName", f.ref, "fileName", baseName, "destDirectory", dstDir, "reason", err.Error()))
-				return
+				return err
 			}
 			f.logger.Debug("File correctly installed", f.logger.Args("followerName", f.ref, "path", path))
 			// It's done, move to the next file.
@@ -232,14 +276,14 @@ func (f *Follower) follow(ctx context.Context) {
 		eq, err := equal([]string{path, dstPath})
 		if err != nil {
 			f.logger.Error("Unable to compare files", f.logger.Args("followerName", f.ref, "newFile", path, "existingFile", dstPath, "reason", err.Error()))
-			return
+			return err
 		}
 
 		if !eq {
 			f.logger.Debug(fmt.Sprintf("Overwriting file %q with file %q", dstPath, path), f.logger.Args("followerName", f.ref))
 			if err = utils.Move(path, dstPath); err != nil {
 				f.logger.Error("Unable to overwrite file", f.logger.Args("followerName", f.ref, "existingFile", dstPath, "reason", err.Error()))
-				return
+				return err
 			}
 		} else {
 			f.logger.Debug("The two file are equal, nothing to be done")
@@ -249,23 +293,38 @@ func (f *Follower) follow(ctx context.Context) {
 	f.logger.Info("Artifact correctly installed",
 		f.logger.Args("followerName", f.ref, "artifactName", f.ref, "type", res.Type, "digest", res.Digest, "directory", dstDir))
 	f.currentDigest = desc.Digest.String()
+
+	if f.PostInstall != nil {
+		if err := f.PostInstall(ctx); err != nil {
+			// The artifact is installed anyway, hence it is not checked again until a new version is found.
+			f.logger.Error("Post-install hook failed", f.logger.Args("followerName", f.ref, "reason", err.Error()))
+		}
+	}
+	return nil
 }
 
 // pull downloads, extracts, and installs the artifact.
 func (f *Follower) pull(ctx context.Context) (filePaths []string, res *oci.RegistryResult, err error) {
+	opCtx, cancel := f.operationContext(ctx)
+	defer cancel()
+
 	f.logger.Debug("Check if pulling an allowed type of artifact", f.logger.Args("followerName", f.ref))
-	if err := f.Puller.CheckAllowedType(ctx, f.ref, runtime.GOOS, runtime.GOARCH, f.Config.AllowedTypes.Types); err != nil {
+	if err := f.Puller.CheckAllowedType(opCtx, f.ref, runtime.GOOS, runtime.GOARCH, f.Config.AllowedTypes.Types); err != nil {
 		return nil, nil, err
 	}
 
 	// Pull the artifact from the repository.
 	f.logger.Debug("Pulling artifact %q", f.logger.Args("followerName", f.ref, "artifactName", f.ref))
-	res, err = f.Pull(ctx, f.ref, f.tmpDir, runtime.GOOS, runtime.GOARCH)
+	res, err = f.Pull(opCtx, f.ref, f.tmpDir, runtime.GOOS, runtime.GOARCH)
 	if err != nil {
 		return filePaths, res, fmt.Errorf("unable to pull artifact %q: %w", f.ref, err)
 	}
+	if res.Ref != f.ref {
+		f.logger.Info("Artifact pulled from mirror", f.logger.Args("followerName", f.ref, "registry", res.Registry))
+	}
 
-	repo, err := utils.RepositoryFromRef(f.ref)
+	// The signature is verified against the repository that served the artifact.
+	repo, err := utils.RepositoryFromRef(res.Ref)
 	if err != nil {
 		return filePaths, res, err
 	}
@@ -275,7 +334,7 @@ func (f *Follower) pull(ctx context.Context) (filePaths []string, res *oci.Regis
 	// Verify the signature if needed
 	if f.Config.Signature != nil {
 		f.logger.Debug("Verifying signature", f.logger.Args("followerName", f.ref, "digest", digestRef))
-		err = signature.Verify(ctx, digestRef, f.Config.Signature)
+		err = verify.Signature(opCtx, digestRef, f.Config.Signature)
 		if err != nil {
 			return filePaths, res, fmt.Errorf("could not verify signature for %s: %w", res.RootDigest, err)
 		}
@@ -306,6 +365,14 @@ func (f *Follower) pull(ctx context.Context) (filePaths []string, res *oci.Regis
 }
 
 // destinationDir returns the dir where to save the artifact.
+// operationContext returns a context bounded by the registry timeout, if any.
+func (f *Follower) operationContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if f.Config.RegistryTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, f.Config.RegistryTimeout)
+}
+
 func (f *Follower) destinationDir(res *oci.RegistryResult) string {
 	var dir string
 	switch res.Type {
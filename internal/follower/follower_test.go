@@ -18,6 +18,7 @@ package follower
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/pterm/pterm"
 	"github.com/stretchr/testify/assert"
@@ -135,3 +136,17 @@ func TestCheckRequirements(t *testing.T) {
 		})
 	}
 }
+
+func TestRetryDelay(t *testing.T) {
+	f := &Follower{Config: &Config{RetryBackoff: 30 * time.Second}}
+
+	assert.Equal(t, time.Duration(0), f.retryDelay(0))
+	assert.Equal(t, 30*time.Second, f.retryDelay(1))
+	assert.Equal(t, time.Minute, f.retryDelay(2))
+	assert.Equal(t, 4*time.Minute, f.retryDelay(4))
+	// The delay stops growing once past a day, whatever the number of failures.
+	assert.Less(t, f.retryDelay(1000), 49*time.Hour)
+
+	f.RetryBackoff = 0
+	assert.Equal(t, time.Duration(0), f.retryDelay(3))
+}
@@ -0,0 +1,194 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keys
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+)
+
+// Extension is the extension of the files holding the trusted keys, named after the keys.
+const Extension = ".pub"
+
+var (
+	// ErrKeyExists is returned by Add when a key with the same name is already trusted.
+	ErrKeyExists = errors.New("trusted key already exists")
+	// ErrKeyNotFound is returned by Remove when no key with the given name is trusted.
+	ErrKeyNotFound = errors.New("trusted key not found")
+
+	nameRegexp = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`)
+)
+
+// Key is a public key trusted to verify the signatures of the artifacts.
+type Key struct {
+	// Name of the key, as given when adding it.
+	Name string
+	// Algorithm of the key, e.g. "ECDSA P-256" or "RSA 4096".
+	Algorithm string
+	// Fingerprint is the digest of the DER encoding of the key.
+	Fingerprint string
+	// Path of the PEM encoded key.
+	Path string
+}
+
+// ValidateName returns an error if the name cannot be used for a trusted key. Names are made of letters, digits,
+// dots, dashes and underscores, and do not start with a dot, a dash or an underscore.
+func ValidateName(name string) error {
+	if !nameRegexp.MatchString(name) {
+		return fmt.Errorf("invalid key name %q: it must be made of letters, digits, '.', '-' and '_', starting with a letter or a digit", name)
+	}
+	return nil
+}
+
+// Path returns the path of the file holding the trusted key with the given name in dir.
+func Path(dir, name string) string {
+	return filepath.Join(dir, name+Extension)
+}
+
+// Add stores the PEM encoded public key in dir under the given name, replacing the key with the same name only when
+// force is set, and returns the stored key.
+func Add(dir, name string, pemBytes []byte, force bool) (*Key, error) {
+	if err := ValidateName(name); err != nil {
+		return nil, err
+	}
+	key, err := parse(name, pemBytes)
+	if err != nil {
+		return nil, err
+	}
+	key.Path = Path(dir, name)
+
+	if _, err := os.Stat(key.Path); err == nil && !force {
+		return nil, fmt.Errorf("%w: %q", ErrKeyExists, name)
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("cannot create directory %q: %w", dir, err)
+	}
+	if err := os.WriteFile(key.Path, pemBytes, 0o600); err != nil {
+		return nil, fmt.Errorf("cannot write key %q: %w", name, err)
+	}
+	return key, nil
+}
+
+// List returns the keys trusted in dir, sorted by name. A missing directory means that no key is trusted.
+func List(dir string) ([]*Key, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var keys []*Key
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), Extension)
+		if !ok || entry.IsDir() || ValidateName(name) != nil {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		pemBytes, err := os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return nil, err
+		}
+		key, err := parse(name, pemBytes)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read key %q: %w", path, err)
+		}
+		key.Path = path
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Name < keys[j].Name })
+	return keys, nil
+}
+
+// Remove deletes the trusted key with the given name from dir.
+func Remove(dir, name string) error {
+	if err := ValidateName(name); err != nil {
+		return err
+	}
+	if err := os.Remove(Path(dir, name)); errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%w: %q", ErrKeyNotFound, name)
+	} else if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Resolve returns the path of the trusted key named keyRef in dir, if any, else keyRef itself, e.g. the path of a
+// key file or a KMS URI. A trusted key takes precedence over a file with the same name in the working directory,
+// which can still be given as a path, e.g. "./name".
+func Resolve(dir, keyRef string) string {
+	if keyRef == "" || ValidateName(keyRef) != nil {
+		return keyRef
+	}
+	if path := Path(dir, keyRef); fileExists(path) {
+		return path
+	}
+	return keyRef
+}
+
+// fileExists reports whether path is an existing regular file.
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular()
+}
+
+// parse returns the key with the given name held by the PEM encoded public key.
+func parse(name string, pemBytes []byte) (*Key, error) {
+	pub, err := cryptoutils.UnmarshalPEMToPublicKey(pemBytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key %q: %w", name, err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key %q: %w", name, err)
+	}
+	sum := sha256.Sum256(der)
+
+	return &Key{
+		Name:        name,
+		Algorithm:   algorithm(pub),
+		Fingerprint: "sha256:" + hex.EncodeToString(sum[:]),
+	}, nil
+}
+
+// algorithm describes the algorithm of the public key.
+func algorithm(pub crypto.PublicKey) string {
+	switch k := pub.(type) {
+	case *ecdsa.PublicKey:
+		return "ECDSA " + k.Curve.Params().Name
+	case *rsa.PublicKey:
+		return fmt.Sprintf("RSA %d", k.N.BitLen())
+	case ed25519.PublicKey:
+		return "Ed25519"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,120 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keys
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPublicKeyPEM(t *testing.T) []byte {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	pemBytes, err := cryptoutils.MarshalPublicKeyToPEM(priv.Public())
+	require.NoError(t, err)
+	return pemBytes
+}
+
+func TestAddListRemove(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "keys")
+	pemBytes := newPublicKeyPEM(t)
+
+	key, err := Add(dir, "my-org", pemBytes, false)
+	require.NoError(t, err)
+	assert.Equal(t, "my-org", key.Name)
+	assert.Equal(t, "ECDSA P-256", key.Algorithm)
+	assert.Regexp(t, `^sha256:[0-9a-f]{64}$`, key.Fingerprint)
+	assert.Equal(t, filepath.Join(dir, "my-org.pub"), key.Path)
+	content, err := os.ReadFile(key.Path)
+	require.NoError(t, err)
+	assert.Equal(t, pemBytes, content)
+
+	// A key with the same name is only replaced when forced.
+	other := newPublicKeyPEM(t)
+	_, err = Add(dir, "my-org", other, false)
+	assert.ErrorIs(t, err, ErrKeyExists)
+	replaced, err := Add(dir, "my-org", other, true)
+	require.NoError(t, err)
+	assert.NotEqual(t, key.Fingerprint, replaced.Fingerprint)
+
+	_, err = Add(dir, "another", pemBytes, false)
+	require.NoError(t, err)
+	list, err := List(dir)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, "another", list[0].Name)
+	assert.Equal(t, key.Fingerprint, list[0].Fingerprint)
+	assert.Equal(t, *replaced, *list[1])
+
+	require.NoError(t, Remove(dir, "my-org"))
+	assert.ErrorIs(t, Remove(dir, "my-org"), ErrKeyNotFound)
+	list, err = List(dir)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, "another", list[0].Name)
+}
+
+func TestAddInvalid(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := Add(dir, "../escape", newPublicKeyPEM(t), false)
+	assert.ErrorContains(t, err, `invalid key name "../escape"`)
+
+	_, err = Add(dir, "garbage", []byte("not a key"), false)
+	assert.ErrorContains(t, err, `invalid public key "garbage"`)
+	assert.NoFileExists(t, Path(dir, "garbage"))
+}
+
+func TestListMissingDir(t *testing.T) {
+	list, err := List(filepath.Join(t.TempDir(), "missing"))
+	assert.NoError(t, err)
+	assert.Empty(t, list)
+}
+
+func TestResolve(t *testing.T) {
+	dir := t.TempDir()
+	_, err := Add(dir, "my-org", newPublicKeyPEM(t), false)
+	require.NoError(t, err)
+
+	// Trusted keys are resolved by name, anything else is left as is.
+	assert.Equal(t, Path(dir, "my-org"), Resolve(dir, "my-org"))
+	assert.Equal(t, "unknown", Resolve(dir, "unknown"))
+	assert.Equal(t, "./cosign.pub", Resolve(dir, "./cosign.pub"))
+	assert.Equal(t, "awskms:///alias/my-key", Resolve(dir, "awskms:///alias/my-key"))
+	assert.Equal(t, "", Resolve(dir, ""))
+
+	// The trusted key takes precedence over a file with the same name, which is given as a path instead.
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(t.TempDir()))
+	t.Cleanup(func() {
+		_ = os.Chdir(wd)
+	})
+	require.NoError(t, os.WriteFile("my-org", []byte("key"), 0o600))
+	assert.Equal(t, Path(dir, "my-org"), Resolve(dir, "my-org"))
+	assert.Equal(t, "./my-org", Resolve(dir, "./my-org"))
+	// Files are still used when no trusted key has their name.
+	require.NoError(t, os.WriteFile("cosign.pub", []byte("key"), 0o600))
+	assert.Equal(t, "cosign.pub", Resolve(dir, "cosign.pub"))
+}
@@ -26,7 +26,9 @@ import (
 )
 
 // Login checks if passed credentials are correct and stores them.
-func Login(ctx context.Context, client *auth.Client, credStore credentials.Store, reg, username, password string) error {
+// Additional options, e.g. registry.WithPlainHTTP, are used for the registry the credentials are checked against.
+func Login(ctx context.Context, client *auth.Client, credStore credentials.Store, reg, username, password string,
+	opts ...func(*registry.Registry)) error {
 	cred := auth.Credential{
 		Username: username,
 		Password: password,
@@ -34,7 +36,7 @@ func Login(ctx context.Context, client *auth.Client, credStore credentials.Store
 
 	client.Credential = auth.StaticCredential(reg, cred)
 
-	r, err := registry.NewRegistry(reg, registry.WithClient(client))
+	r, err := registry.NewRegistry(reg, append([]func(*registry.Registry){registry.WithClient(client)}, opts...)...)
 	if err != nil {
 		return err
 	}
@@ -49,3 +51,20 @@ func Login(ctx context.Context, client *auth.Client, credStore credentials.Store
 	}
 	return nil
 }
+
+// Logout removes the credentials stored for the given registry.
+// It returns false if no credentials were stored for the registry.
+func Logout(ctx context.Context, credStore credentials.Store, reg string) (bool, error) {
+	cred, err := credStore.Get(ctx, reg)
+	if err != nil {
+		return false, fmt.Errorf("unable to retrieve credentials from credential store: %w", err)
+	}
+	if cred == auth.EmptyCredential {
+		return false, nil
+	}
+
+	if err := credStore.Delete(ctx, reg); err != nil {
+		return false, fmt.Errorf("unable to remove credentials from credential store: %w", err)
+	}
+	return true, nil
+}
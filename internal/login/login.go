@@ -26,20 +26,24 @@ import (
 	"github.com/falcosecurity/falcoctl/internal/login/basic"
 	"github.com/falcosecurity/falcoctl/internal/login/gcp"
 	"github.com/falcosecurity/falcoctl/internal/login/oauth"
+	"github.com/falcosecurity/falcoctl/pkg/oci/registry"
 )
 
 // PerformAuthsFromConfig logins to the specified registries and stores credentials in local stores.
-func PerformAuthsFromConfig(ctx context.Context, client *auth.Client, credStore credentials.Store, registries []string) error {
+// Additional options, e.g. registry.WithPlainHTTP, are used for the registries the basic auth credentials are checked against.
+func PerformAuthsFromConfig(ctx context.Context, client *auth.Client, credStore credentials.Store, registries []string,
+	opts ...func(*registry.Registry)) error {
 	registrySet := make(map[string]bool)
 	for _, reg := range registries {
 		registrySet[reg] = true
 	}
 
-	return PerformAuthsFromConfigWithMap(ctx, client, credStore, registrySet)
+	return PerformAuthsFromConfigWithMap(ctx, client, credStore, registrySet, opts...)
 }
 
 // PerformAuthsFromConfigWithMap logins to the specified registry set and stores credentials in local stores.
-func PerformAuthsFromConfigWithMap(ctx context.Context, client *auth.Client, credStore credentials.Store, registrySet map[string]bool) error {
+func PerformAuthsFromConfigWithMap(ctx context.Context, client *auth.Client, credStore credentials.Store, registrySet map[string]bool,
+	opts ...func(*registry.Registry)) error {
 	// Perform authentications using basic auth.
 	basicAuths, err := config.BasicAuths()
 	if err != nil {
@@ -48,7 +52,7 @@ func PerformAuthsFromConfigWithMap(ctx context.Context, client *auth.Client, cre
 
 	// skip basic auth login if we do not have a credentials.Store
 	if credStore != nil {
-		if err := PerformBasicAuthsLogin(ctx, client, credStore, basicAuths, registrySet); err != nil {
+		if err := PerformBasicAuthsLogin(ctx, client, credStore, basicAuths, registrySet, opts...); err != nil {
 			return err
 		}
 	}
@@ -75,10 +79,11 @@ func PerformAuthsFromConfigWithMap(ctx context.Context, client *auth.Client, cre
 // PerformBasicAuthsLogin logins to the registries using basic auth and stores the credentials in a local store.
 func PerformBasicAuthsLogin(
 	ctx context.Context, client *auth.Client, credStore credentials.Store, auths []config.BasicAuth, registrySet map[string]bool,
+	opts ...func(*registry.Registry),
 ) error {
 	for _, basicAuth := range auths {
 		if _, exists := registrySet[basicAuth.Registry]; exists {
-			if err := basic.Login(ctx, client, credStore, basicAuth.Registry, basicAuth.User, basicAuth.Password); err != nil {
+			if err := basic.Login(ctx, client, credStore, basicAuth.Registry, basicAuth.User, basicAuth.Password, opts...); err != nil {
 				return err
 			}
 		}
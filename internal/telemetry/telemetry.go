@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// EndpointEnv is the environment variable enabling the export of traces and metrics to the given OTLP endpoint.
+	// The other OTEL_EXPORTER_OTLP_* variables, e.g. the headers, are honored as well.
+	EndpointEnv = "OTEL_EXPORTER_OTLP_ENDPOINT"
+
+	instrumentationName = "github.com/falcosecurity/falcoctl"
+	serviceName         = "falcoctl"
+)
+
+// enabled is set once the providers exporting to the OTLP endpoint are installed.
+var enabled atomic.Bool
+
+// Setup installs the global tracer and meter providers exporting to the OTLP endpoint over HTTP, when EndpointEnv
+// is set. The returned function flushes and stops them, and must be called before exiting. When EndpointEnv is not
+// set, the no-op global providers are kept so that the instrumentation has no cost.
+func Setup(ctx context.Context) (func(context.Context) error, error) {
+	noop := func(context.Context) error { return nil }
+	if os.Getenv(EndpointEnv) == "" {
+		return noop, nil
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithAttributes(attribute.String("service.name", serviceName)),
+		resource.WithTelemetrySDK(),
+		resource.WithFromEnv())
+	if err != nil {
+		return noop, fmt.Errorf("unable to create telemetry resource: %w", err)
+	}
+
+	traceExporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return noop, fmt.Errorf("unable to create traces exporter: %w", err)
+	}
+	metricExporter, err := otlpmetrichttp.New(ctx)
+	if err != nil {
+		return noop, fmt.Errorf("unable to create metrics exporter: %w", err)
+	}
+
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithBatcher(traceExporter), sdktrace.WithResource(res))
+	meterProvider := sdkmetric.NewMeterProvider(
+		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(metricExporter)), sdkmetric.WithResource(res))
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetMeterProvider(meterProvider)
+	enabled.Store(true)
+
+	return func(ctx context.Context) error {
+		return errors.Join(tracerProvider.Shutdown(ctx), meterProvider.Shutdown(ctx))
+	}, nil
+}
+
+// StartSpan starts a span with the given name and attributes, child of the span in ctx if any.
+// When telemetry is disabled, the span found in ctx, a no-op one if none, is returned as is.
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if !enabled.Load() {
+		return ctx, trace.SpanFromContext(ctx)
+	}
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// EndSpan records err, if any, on the span and ends it.
+func EndSpan(span trace.Span, err error) {
+	if !enabled.Load() {
+		return
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Count adds one to the counter with the given name and attributes, when telemetry is enabled.
+func Count(ctx context.Context, name, description string, attrs ...attribute.KeyValue) {
+	if !enabled.Load() {
+		return
+	}
+	counter, err := otel.Meter(instrumentationName).Int64Counter(name, metric.WithDescription(description))
+	if err != nil {
+		otel.Handle(err)
+		return
+	}
+	counter.Add(ctx, 1, metric.WithAttributes(attrs...))
+}
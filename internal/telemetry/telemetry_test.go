@@ -0,0 +1,41 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetupDisabled(t *testing.T) {
+	t.Setenv(EndpointEnv, "")
+
+	shutdown, err := Setup(context.Background())
+	require.NoError(t, err)
+	assert.False(t, enabled.Load())
+
+	ctx := context.Background()
+	spanCtx, span := StartSpan(ctx, "test")
+	assert.Equal(t, ctx, spanCtx)
+	assert.False(t, span.IsRecording())
+	EndSpan(span, nil)
+	Count(ctx, "test", "test counter")
+
+	assert.NoError(t, shutdown(ctx))
+}
@@ -135,3 +135,61 @@ func copyToTarGz(path string, tw *tar.Writer, info fs.FileInfo) error {
 
 	return nil
 }
+
+// CreateTarArchive writes to dst an uncompressed tar archive holding the content of srcDir.
+// Entries are named relative to srcDir, without any leading "./".
+func CreateTarArchive(srcDir, dst string) (err error) {
+	outFile, err := os.Create(filepath.Clean(dst))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if errDefer := outFile.Close(); err == nil {
+			err = errDefer
+		}
+	}()
+
+	tw := tar.NewWriter(outFile)
+	defer func() {
+		if errDefer := tw.Close(); err == nil {
+			err = errDefer
+		}
+	}()
+
+	return filepath.Walk(srcDir, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		name, err := filepath.Rel(srcDir, path)
+		if err != nil {
+			return err
+		}
+		if name == "." {
+			return nil
+		}
+
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(name)
+
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		f, err := os.Open(filepath.Clean(path))
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		_, err = io.CopyN(tw, f, info.Size())
+		return err
+	})
+}
@@ -120,6 +120,47 @@ func TestCreateTarGzArchiveDir(t *testing.T) {
 	}
 }
 
+func TestCreateTarArchive(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "blobs", "sha256"), 0o755); err != nil {
+		t.Fatalf(err.Error())
+	}
+	for _, name := range []string{"index.json", filepath.Join("blobs", "sha256", "abc")} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(name), 0o600); err != nil {
+			t.Fatalf(err.Error())
+		}
+	}
+
+	dst := filepath.Join(t.TempDir(), "layout.tar")
+	if err := CreateTarArchive(dir, dst); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	file, err := os.Open(dst)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	defer file.Close()
+
+	tarReader := tar.NewReader(file)
+	var paths []string
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		paths = append(paths, header.Name)
+	}
+
+	expected := []string{"blobs", "blobs/sha256", "blobs/sha256/abc", "index.json"}
+	if fmt.Sprint(paths) != fmt.Sprint(expected) {
+		t.Fatalf("expected entries %v, got %v", expected, paths)
+	}
+}
+
 func listHeaders(gzipStream io.Reader) ([]string, error) {
 	uncompressedStream, err := gzip.NewReader(gzipStream)
 	if err != nil {
@@ -35,12 +35,21 @@ func GetCredentials(p *output.Printer) (username, password string, err error) {
 		return "", "", err
 	}
 
+	password, err = GetPassword(p)
+	if err != nil {
+		return "", "", err
+	}
+
+	return strings.TrimSpace(username), password, nil
+}
+
+// GetPassword is used to retrieve the password from standard input, without echoing it.
+func GetPassword(p *output.Printer) (string, error) {
 	p.Logger.Info("Enter password: ")
 	bytePassword, err := term.ReadPassword(int(os.Stdin.Fd()))
 	if err != nil {
-		return "", "", err
+		return "", err
 	}
 
-	password = string(bytePassword)
-	return strings.TrimSpace(username), strings.TrimSpace(password), nil
+	return strings.TrimSpace(string(bytePassword)), nil
 }
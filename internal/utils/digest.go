@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// sha256Algorithm is the only digest algorithm used by falcoctl artifacts.
+const sha256Algorithm = "sha256"
+
+// VerifyFileDigest computes the sha256 digest of the file pointed by filePath
+// and compares it against the expected one, in the "sha256:<hex>" format.
+func VerifyFileDigest(filePath, expected string) error {
+	algorithm, encoded, ok := strings.Cut(expected, ":")
+	if !ok || encoded == "" {
+		return fmt.Errorf("invalid digest %q", expected)
+	}
+	if algorithm != sha256Algorithm {
+		return fmt.Errorf("unsupported digest algorithm %q", algorithm)
+	}
+
+	actual, err := FileDigest(filePath)
+	if err != nil {
+		return err
+	}
+
+	if actual != expected {
+		return fmt.Errorf("digest mismatch for %q: expected %s, got %s", filePath, expected, actual)
+	}
+
+	return nil
+}
+
+// FileDigest computes the sha256 digest of the file pointed by filePath, in the "sha256:<hex>" format.
+func FileDigest(filePath string) (string, error) {
+	f, err := os.Open(filepath.Clean(filePath))
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("unable to compute digest of %q: %w", filePath, err)
+	}
+
+	return sha256Algorithm + ":" + hex.EncodeToString(h.Sum(nil)), nil
+}
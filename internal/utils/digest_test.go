@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyFileDigest(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "artifact.tar.gz")
+	require.NoError(t, os.WriteFile(filePath, []byte("falco"), 0o600))
+
+	// sha256 of "falco".
+	const expected = "sha256:f5bed22b9f4bed888f77f06c03a5d6aaef691682aa2820f6158919427f905194"
+
+	tests := []struct {
+		name    string
+		digest  string
+		wantErr string
+	}{
+		{name: "matching digest", digest: expected},
+		{name: "mismatching digest", digest: "sha256:0000", wantErr: "digest mismatch"},
+		{name: "unsupported algorithm", digest: "sha512:abcd", wantErr: "unsupported digest algorithm"},
+		{name: "invalid digest", digest: "abcd", wantErr: "invalid digest"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyFileDigest(filePath, tt.digest)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
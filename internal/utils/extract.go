@@ -17,31 +17,251 @@ package utils
 
 import (
 	"archive/tar"
+	"bufio"
+	"bytes"
 	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 
+	"github.com/klauspost/compress/zstd"
+	"go.opentelemetry.io/otel/attribute"
 	"golang.org/x/net/context"
+
+	"github.com/falcosecurity/falcoctl/internal/telemetry"
+)
+
+const (
+	// DefaultMaxExtractedFiles is the default maximum number of entries extracted from an archive.
+	DefaultMaxExtractedFiles = 10000
+	// DefaultMaxExtractedSize is the default maximum number of bytes extracted from an archive.
+	DefaultMaxExtractedSize int64 = 1 << 30
+	// DefaultExtractModeMask is the default mask applied to the mode of the extracted entries.
+	// It preserves the permission bits, e.g. the executable ones, and strips the setuid, setgid and sticky bits.
+	DefaultExtractModeMask = os.ModePerm
 )
 
+// ErrMaxExtractedSize is returned by ExtractTarGz when the regular files of an archive add up to more bytes than
+// allowed by WithMaxSize.
+var ErrMaxExtractedSize = errors.New("maximum extracted size")
+
 type link struct {
 	Name string
 	Path string
 }
 
+type extractOptions struct {
+	maxFiles int
+	maxSize  int64
+	modeMask os.FileMode
+	exclude  []string
+	excluded func(name string)
+	written  func(path, digest string)
+}
+
+// ExtractOption customizes the behavior of ExtractTarGz.
+type ExtractOption func(*extractOptions)
+
+// WithMaxFiles sets the maximum number of entries that can be extracted from the archive, 0 means no limit.
+func WithMaxFiles(maxFiles int) ExtractOption {
+	return func(o *extractOptions) {
+		o.maxFiles = maxFiles
+	}
+}
+
+// WithMaxSize sets the maximum number of bytes that can be extracted from the archive, 0 means no limit.
+func WithMaxSize(maxSize int64) ExtractOption {
+	return func(o *extractOptions) {
+		o.maxSize = maxSize
+	}
+}
+
+// WithModeMask sets the mask applied to the mode of the extracted entries. For example,
+// DefaultExtractModeMask|os.ModeSetuid|os.ModeSetgid|os.ModeSticky keeps the special bits found in the archive.
+func WithModeMask(mask os.FileMode) ExtractOption {
+	return func(o *extractOptions) {
+		o.modeMask = mask
+	}
+}
+
+// WithExclude skips the entries matching any of the given glob patterns, see path.Match, calling excluded, when not
+// nil, with the name of each of them. A pattern matches the path of an entry in the archive, after the leading path
+// components have been stripped, its base name or any of its parent directories, whose content is skipped as a whole.
+func WithExclude(patterns []string, excluded func(name string)) ExtractOption {
+	return func(o *extractOptions) {
+		o.exclude = patterns
+		o.excluded = excluded
+	}
+}
+
+// WithWritten calls written with the path and the digest, in the format returned by FileDigest, of each regular file
+// and hard link written in destDir. The digests are computed while the files are written, without reading them again.
+func WithWritten(written func(path, digest string)) ExtractOption {
+	return func(o *extractOptions) {
+		o.written = written
+	}
+}
+
+// ValidateExcludePatterns returns an error if any of the patterns given to WithExclude is malformed.
+func ValidateExcludePatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid exclude pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// isExcluded returns true if the cleaned path of an entry, its base name or any of its parent directories matches
+// one of the patterns.
+func isExcluded(patterns []string, name string) bool {
+	if len(patterns) == 0 {
+		return false
+	}
+	name = strings.TrimPrefix(filepath.ToSlash(filepath.Clean(name)), "/")
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, path.Base(name)); ok {
+			return true
+		}
+		for p := name; p != "." && p != "/"; p = path.Dir(p) {
+			if ok, _ := path.Match(pattern, p); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // ExtractTarGz extracts a *.tar.gz compressed archive and moves its content to destDir.
 // Returns a slice containing the full path of the extracted files.
-func ExtractTarGz(ctx context.Context, gzipStream io.Reader, destDir string, stripPathComponents int) ([]string, error) {
+// Despite its name, the compression is detected from the magic bytes of the stream: gzip and zstd compressed tar
+// archives are supported, as well as uncompressed ones, and any other format is rejected.
+// Entries, hard links and symlinks resolving outside destDir are rejected, leading slashes are stripped.
+// The number of entries and the extracted size are bounded, see WithMaxFiles and WithMaxSize.
+// Entries can be skipped with WithExclude.
+// The first stripPathComponents components are stripped from the entry names, like GNU tar --strip-components does:
+// entries with fewer components are skipped.
+// Regular files get the mode found in the archive, filtered by DefaultExtractModeMask unless WithModeMask is given.
+// The extraction is traced, with the number of bytes read and of entries extracted, when telemetry is enabled.
+func ExtractTarGz(ctx context.Context, gzipStream io.Reader, destDir string, stripPathComponents int,
+	opts ...ExtractOption) ([]string, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ExtractTarGz", attribute.String("destDir", destDir))
+	counter := &countingReader{Reader: gzipStream}
+	files, err := extractTarGz(ctx, counter, destDir, stripPathComponents, opts...)
+	span.SetAttributes(attribute.Int64("bytes", counter.n), attribute.Int("files", len(files)))
+	telemetry.EndSpan(span, err)
+	return files, err
+}
+
+// ExtractedSize returns the number of bytes the regular files of a compressed tar archive, as accepted by
+// ExtractTarGz, add up to once extracted. Only the headers of the entries are parsed, although the whole stream is
+// decompressed to reach them. The excluded entries are counted as well, hence the size is an upper bound.
+func ExtractedSize(r io.Reader) (int64, error) {
+	tarStream, closeFn, err := decompress(r)
+	if err != nil {
+		return 0, err
+	}
+	defer closeFn()
+
+	var size int64
+	tarReader := tar.NewReader(tarStream)
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			return size, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		if header.Typeflag == tar.TypeReg && header.Size > 0 {
+			size += header.Size
+		}
+	}
+}
+
+var (
+	// gzipMagic are the first bytes of a gzip stream.
+	gzipMagic = []byte{0x1f, 0x8b}
+	// zstdMagic are the first bytes of a zstd frame.
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+	// tarMagic is found in the header of the first entry of POSIX and GNU tar archives.
+	tarMagic = []byte("ustar")
+)
+
+// tarMagicOffset is the offset of tarMagic in a tar header.
+const tarMagicOffset = 257
+
+// decompress returns the uncompressed tar stream read from r, detecting whether it is compressed with gzip or zstd
+// from its magic bytes. The returned function releases the resources of the decompressor.
+func decompress(r io.Reader) (io.Reader, func(), error) {
+	br := bufio.NewReader(r)
+	// Short streams are fine, the tar reader reports them.
+	head, err := br.Peek(tarMagicOffset + len(tarMagic))
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, nil, err
+	}
+
+	switch {
+	case bytes.HasPrefix(head, gzipMagic):
+		gzr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, nil, err
+		}
+		return gzr, func() { _ = gzr.Close() }, nil
+	case bytes.HasPrefix(head, zstdMagic):
+		zr, err := zstd.NewReader(br)
+		if err != nil {
+			return nil, nil, err
+		}
+		return zr, zr.Close, nil
+	case len(head) > tarMagicOffset && bytes.HasPrefix(head[tarMagicOffset:], tarMagic),
+		// An empty archive only holds the zeroed end-of-archive blocks.
+		len(head) > 0 && bytes.Count(head, []byte{0}) == len(head):
+		return br, func() {}, nil
+	default:
+		return nil, nil, errors.New("unsupported archive format: expected a tar archive, either uncompressed or " +
+			"compressed with gzip or zstd")
+	}
+}
+
+// countingReader counts the bytes read through it.
+type countingReader struct {
+	io.Reader
+	n int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.n += int64(n)
+	return n, err
+}
+
+func extractTarGz(ctx context.Context, gzipStream io.Reader, destDir string, stripPathComponents int,
+	opts ...ExtractOption) ([]string, error) {
 	var (
 		files    []string
 		links    []link
 		symlinks []link
+		size     int64
 		err      error
 	)
+	// digests of the files written, by path, only filled when asked for with WithWritten.
+	digests := make(map[string]string)
+
+	o := extractOptions{
+		maxFiles: DefaultMaxExtractedFiles,
+		maxSize:  DefaultMaxExtractedSize,
+		modeMask: DefaultExtractModeMask,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
 
 	// We need an absolute path
 	destDir, err = filepath.Abs(destDir)
@@ -49,10 +269,11 @@ func ExtractTarGz(ctx context.Context, gzipStream io.Reader, destDir string, str
 		return nil, err
 	}
 
-	uncompressedStream, err := gzip.NewReader(gzipStream)
+	uncompressedStream, closeStream, err := decompress(gzipStream)
 	if err != nil {
 		return nil, err
 	}
+	defer closeStream()
 
 	tarReader := tar.NewReader(uncompressedStream)
 	for {
@@ -69,36 +290,59 @@ func ExtractTarGz(ctx context.Context, gzipStream io.Reader, destDir string, str
 		if err != nil {
 			return nil, err
 		}
-		if strings.Contains(header.Name, "..") {
-			return nil, fmt.Errorf("not allowed relative path in tar archive")
+		if escapes(header.Name) {
+			return nil, fmt.Errorf("not allowed path %q in tar archive: it escapes the destination directory", header.Name)
 		}
 
 		path := header.Name
 		if stripPathComponents > 0 {
 			path = stripComponents(path, stripPathComponents)
 		}
-		if path == "" {
+		// The archive root, e.g. "./", maps to destDir itself.
+		if path == "" || filepath.Clean(path) == "." {
+			continue
+		}
+		if isExcluded(o.exclude, path) {
+			if o.excluded != nil {
+				o.excluded(header.Name)
+			}
 			continue
 		}
 
 		if path, err = safeConcat(destDir, filepath.Clean(path)); err != nil {
-			// Skip paths that would escape destDir
-			continue
+			return nil, err
 		}
-		info := header.FileInfo()
+		mode := header.FileInfo().Mode() & o.modeMask
 		files = append(files, path)
+		if o.maxFiles > 0 && len(files) > o.maxFiles {
+			return nil, fmt.Errorf("tar archive contains more than %d entries", o.maxFiles)
+		}
 
 		switch header.Typeflag {
 		case tar.TypeDir:
-			if err = os.MkdirAll(path, info.Mode()); err != nil {
+			if err = os.MkdirAll(path, mode.Perm()); err != nil {
 				return nil, err
 			}
 		case tar.TypeReg:
-			outFile, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, info.Mode())
+			size += header.Size
+			if o.maxSize > 0 && size > o.maxSize {
+				return nil, fmt.Errorf("tar archive exceeds the %w of %d bytes", ErrMaxExtractedSize, o.maxSize)
+			}
+			outFile, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, mode.Perm())
 			if err != nil {
 				return nil, err
 			}
-			if written, err := io.CopyN(outFile, tarReader, header.Size); err != nil {
+			// Set the mode explicitly, since the umask applies on creation and existing files keep their mode.
+			if err = outFile.Chmod(mode); err != nil {
+				return nil, err
+			}
+			// The content is only hashed when the digests are asked for.
+			h := sha256.New()
+			var w io.Writer = outFile
+			if o.written != nil {
+				w = io.MultiWriter(outFile, h)
+			}
+			if written, err := io.CopyN(w, tarReader, header.Size); err != nil {
 				return nil, err
 			} else if written != header.Size {
 				return nil, io.ErrShortWrite
@@ -106,6 +350,10 @@ func ExtractTarGz(ctx context.Context, gzipStream io.Reader, destDir string, str
 			if err = outFile.Close(); err != nil {
 				return nil, err
 			}
+			if o.written != nil {
+				digests[path] = sha256Algorithm + ":" + hex.EncodeToString(h.Sum(nil))
+				o.written(path, digests[path])
+			}
 		case tar.TypeLink:
 			name := header.Linkname
 			if stripPathComponents > 0 {
@@ -114,16 +362,59 @@ func ExtractTarGz(ctx context.Context, gzipStream io.Reader, destDir string, str
 			if name == "" {
 				continue
 			}
+			// The target has not been extracted, hence there is nothing to link to.
+			if isExcluded(o.exclude, name) {
+				if o.excluded != nil {
+					o.excluded(header.Name)
+				}
+				continue
+			}
 
-			name = filepath.Join(destDir, filepath.Clean(name))
+			if escapes(name) {
+				return nil, fmt.Errorf("not allowed hard link %q to %q in tar archive: it escapes the destination directory",
+					header.Name, header.Linkname)
+			}
+			if name, err = safeConcat(destDir, filepath.Clean(name)); err != nil {
+				return nil, err
+			}
 			links = append(links, link{Path: path, Name: name})
 		case tar.TypeSymlink:
-			symlinks = append(symlinks, link{Path: path, Name: header.Linkname})
+			// Symlinks are resolved relative to the directory containing them.
+			target := header.Linkname
+			if filepath.IsAbs(target) || !isWithin(destDir, filepath.Join(filepath.Dir(path), target)) {
+				return nil, fmt.Errorf("not allowed symlink %q to %q in tar archive: it points outside the destination directory",
+					header.Name, header.Linkname)
+			}
+			symlinks = append(symlinks, link{Path: path, Name: target})
 		default:
 			return nil, fmt.Errorf("extractTarGz: uknown type: %b in %s", header.Typeflag, header.Name)
 		}
 	}
 
+	// The symlinks are checked lexically above, which only holds if no path goes through another symlink: a chain
+	// such as "dir -> ." followed by "dir/l -> .." would otherwise escape destDir once the links are created.
+	extracted := make(map[string]bool, len(symlinks))
+	for i := range symlinks {
+		extracted[symlinks[i].Path] = true
+	}
+	for _, path := range files {
+		if rel, err := filepath.Rel(destDir, path); err != nil || throughSymlink(extracted, destDir, rel) {
+			return nil, fmt.Errorf("not allowed path %q in tar archive: it goes through a symlink", path)
+		}
+	}
+	for i := range links {
+		if rel, err := filepath.Rel(destDir, links[i].Name); err != nil || throughSymlink(extracted, destDir, rel) {
+			return nil, fmt.Errorf("not allowed hard link %q to %q in tar archive: it goes through a symlink",
+				links[i].Path, links[i].Name)
+		}
+	}
+	for i := range symlinks {
+		if throughSymlink(extracted, filepath.Dir(symlinks[i].Path), symlinks[i].Name) {
+			return nil, fmt.Errorf("not allowed symlink %q to %q in tar archive: it goes through a symlink",
+				symlinks[i].Path, symlinks[i].Name)
+		}
+	}
+
 	// Now we make another pass creating the links
 	for i := range links {
 		select {
@@ -134,6 +425,11 @@ func ExtractTarGz(ctx context.Context, gzipStream io.Reader, destDir string, str
 		if err = os.Link(links[i].Name, links[i].Path); err != nil {
 			return nil, err
 		}
+		// Hard links share the content, hence the digest, of their target.
+		if digest, ok := digests[links[i].Name]; ok && o.written != nil {
+			digests[links[i].Path] = digest
+			o.written(links[i].Path, digest)
+		}
 	}
 
 	for i := range symlinks {
@@ -149,26 +445,58 @@ func ExtractTarGz(ctx context.Context, gzipStream io.Reader, destDir string, str
 	return files, nil
 }
 
-func stripComponents(headerName string, stripComponents int) string {
-	if stripComponents == 0 {
+// stripComponents removes the first n leading components from the name of a tar entry, as GNU tar does with
+// --strip-components. Entries with n components or less are dropped by returning an empty name.
+func stripComponents(headerName string, n int) string {
+	if n <= 0 {
 		return headerName
 	}
-	names := strings.Split(headerName, string(filepath.Separator))
-	if len(names) < stripComponents {
-		return headerName
+	// Tar entries always use forward slashes; empty components, e.g. from "a//b" or a trailing slash, don't count.
+	names := strings.FieldsFunc(headerName, func(r rune) bool { return r == '/' })
+	if len(names) <= n {
+		return ""
 	}
-	return filepath.Clean(strings.Join(names[stripComponents:], string(filepath.Separator)))
+	return filepath.Clean(filepath.Join(names[n:]...))
 }
 
 // safeConcat concatenates destDir and name
 // but returns an error  if the resulting path points outside 'destDir'.
 func safeConcat(destDir, name string) (string, error) {
 	res := filepath.Join(destDir, name)
+	if !isWithin(destDir, res) || res == filepath.Clean(destDir) {
+		return res, fmt.Errorf("unsafe path concatenation: '%s' with '%s'", destDir, name)
+	}
+	return res, nil
+}
+
+// isWithin reports whether path is destDir or is contained in it.
+func isWithin(destDir, path string) bool {
+	destDir = filepath.Clean(destDir)
+	path = filepath.Clean(path)
+	if path == destDir {
+		return true
+	}
 	if !strings.HasSuffix(destDir, string(os.PathSeparator)) {
 		destDir += string(os.PathSeparator)
 	}
-	if !strings.HasPrefix(res, destDir) {
-		return res, fmt.Errorf("unsafe path concatenation: '%s' with '%s'", destDir, name)
+	return strings.HasPrefix(path, destDir)
+}
+
+// throughSymlink reports whether resolving the relative name from dir goes through one of the symlinks, by path,
+// before reaching its last component. The name is walked without cleaning it, so that ".." after a symlink counts.
+func throughSymlink(symlinks map[string]bool, dir, name string) bool {
+	names := strings.FieldsFunc(name, func(r rune) bool { return r == filepath.Separator })
+	for i := 0; i < len(names)-1; i++ {
+		dir = filepath.Join(dir, names[i])
+		if symlinks[dir] {
+			return true
+		}
 	}
-	return res, nil
+	return false
+}
+
+// escapes reports whether the relative archive path name climbs above the archive root.
+func escapes(name string) bool {
+	name = filepath.Clean(name)
+	return name == ".." || strings.HasPrefix(name, ".."+string(filepath.Separator))
 }
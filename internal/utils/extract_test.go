@@ -17,6 +17,7 @@ package utils
 
 import (
 	"archive/tar"
+	"bytes"
 	"compress/gzip"
 	"io"
 	"os"
@@ -24,6 +25,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/klauspost/compress/zstd"
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/net/context"
 )
@@ -208,3 +210,431 @@ func TestExtractTarGzStripComponents(t *testing.T) {
 		assert.Contains(t, list, path)
 	}
 }
+
+type tarEntry struct {
+	name     string
+	typeflag byte
+	linkname string
+	content  string
+	mode     int64
+}
+
+// createCraftedTarball writes a tarball containing exactly the given entries, without touching the filesystem.
+func createCraftedTarball(t *testing.T, entries []tarEntry) string {
+	tarballFilePath := filepath.Join(t.TempDir(), "crafted.tgz")
+	file, err := os.Create(tarballFilePath)
+	assert.NoError(t, err)
+	defer file.Close()
+
+	gzipWriter := gzip.NewWriter(file)
+	defer gzipWriter.Close()
+
+	tarWriter := tar.NewWriter(gzipWriter)
+	defer tarWriter.Close()
+
+	for _, e := range entries {
+		header := &tar.Header{
+			Name:     e.name,
+			Typeflag: e.typeflag,
+			Linkname: e.linkname,
+			Mode:     0o644,
+			Size:     int64(len(e.content)),
+		}
+		if e.typeflag == tar.TypeDir {
+			header.Mode = 0o755
+		}
+		if e.mode != 0 {
+			header.Mode = e.mode
+		}
+		assert.NoError(t, tarWriter.WriteHeader(header))
+		_, err := tarWriter.Write([]byte(e.content))
+		assert.NoError(t, err)
+	}
+
+	return tarballFilePath
+}
+
+func TestExtractTarGzMalicious(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []tarEntry
+		opts    []ExtractOption
+		errMsg  string
+	}{
+		{
+			name:    "parent directory traversal",
+			entries: []tarEntry{{name: "../../etc/passwd", typeflag: tar.TypeReg, content: "evil"}},
+			errMsg:  "escapes the destination directory",
+		},
+		{
+			name:    "traversal hidden in the middle of the path",
+			entries: []tarEntry{{name: "foo/../../evil", typeflag: tar.TypeReg, content: "evil"}},
+			errMsg:  "escapes the destination directory",
+		},
+		{
+			name:    "absolute symlink",
+			entries: []tarEntry{{name: "passwd", typeflag: tar.TypeSymlink, linkname: "/etc/passwd"}},
+			errMsg:  "points outside the destination directory",
+		},
+		{
+			name: "relative symlink escaping the destination",
+			entries: []tarEntry{
+				{name: "foo/", typeflag: tar.TypeDir},
+				{name: "foo/link", typeflag: tar.TypeSymlink, linkname: "../../outside"},
+			},
+			errMsg: "points outside the destination directory",
+		},
+		{
+			name: "chained symlinks escaping the destination",
+			entries: []tarEntry{
+				{name: "dir", typeflag: tar.TypeSymlink, linkname: "."},
+				{name: "dir/l", typeflag: tar.TypeSymlink, linkname: ".."},
+			},
+			errMsg: "goes through a symlink",
+		},
+		{
+			name: "symlink target through another symlink",
+			entries: []tarEntry{
+				{name: "sub/", typeflag: tar.TypeDir},
+				{name: "l", typeflag: tar.TypeSymlink, linkname: "sub/b/../.."},
+				{name: "sub/b", typeflag: tar.TypeSymlink, linkname: "."},
+			},
+			errMsg: "goes through a symlink",
+		},
+		{
+			name:    "hard link escaping the destination",
+			entries: []tarEntry{{name: "link", typeflag: tar.TypeLink, linkname: "../outside"}},
+			errMsg:  "escapes the destination directory",
+		},
+		{
+			name: "too many entries",
+			entries: []tarEntry{
+				{name: "a.txt", typeflag: tar.TypeReg},
+				{name: "b.txt", typeflag: tar.TypeReg},
+				{name: "c.txt", typeflag: tar.TypeReg},
+			},
+			opts:   []ExtractOption{WithMaxFiles(2)},
+			errMsg: "more than 2 entries",
+		},
+		{
+			name:    "too big",
+			entries: []tarEntry{{name: "big.txt", typeflag: tar.TypeReg, content: strings.Repeat("a", 20)}},
+			opts:    []ExtractOption{WithMaxSize(10)},
+			errMsg:  "maximum extracted size of 10 bytes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parentDir := t.TempDir()
+			destDir := filepath.Join(parentDir, "dest")
+			assert.NoError(t, os.Mkdir(destDir, 0o750))
+
+			f, err := os.Open(createCraftedTarball(t, tt.entries))
+			assert.NoError(t, err)
+			defer f.Close()
+
+			_, err = ExtractTarGz(context.TODO(), f, destDir, 0, tt.opts...)
+			assert.ErrorContains(t, err, tt.errMsg)
+
+			// Nothing must have been written outside destDir.
+			entries, err := os.ReadDir(parentDir)
+			assert.NoError(t, err)
+			assert.Len(t, entries, 1)
+		})
+	}
+}
+
+func TestExtractTarGzMaxSize(t *testing.T) {
+	// The limit applies to the files altogether, each of them being smaller than it.
+	entries := []tarEntry{
+		{name: "a.txt", typeflag: tar.TypeReg, content: strings.Repeat("a", 6)},
+		{name: "b.txt", typeflag: tar.TypeReg, content: strings.Repeat("b", 6)},
+	}
+
+	f, err := os.Open(createCraftedTarball(t, entries))
+	assert.NoError(t, err)
+	defer f.Close()
+	_, err = ExtractTarGz(context.TODO(), f, t.TempDir(), 0, WithMaxSize(10))
+	assert.ErrorIs(t, err, ErrMaxExtractedSize)
+
+	f, err = os.Open(createCraftedTarball(t, entries))
+	assert.NoError(t, err)
+	defer f.Close()
+	files, err := ExtractTarGz(context.TODO(), f, t.TempDir(), 0, WithMaxSize(12))
+	assert.NoError(t, err)
+	assert.Len(t, files, 2)
+}
+
+func TestExtractedSize(t *testing.T) {
+	entries := []tarEntry{
+		{name: "foo/", typeflag: tar.TypeDir},
+		{name: "foo/a.txt", typeflag: tar.TypeReg, content: strings.Repeat("a", 6)},
+		{name: "foo/link", typeflag: tar.TypeSymlink, linkname: "a.txt"},
+		{name: "b.txt", typeflag: tar.TypeReg, content: strings.Repeat("b", 10)},
+	}
+
+	f, err := os.Open(createCraftedTarball(t, entries))
+	assert.NoError(t, err)
+	defer f.Close()
+	size, err := ExtractedSize(f)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(16), size)
+
+	_, err = ExtractedSize(strings.NewReader("not an archive"))
+	assert.Error(t, err)
+}
+
+func TestExtractTarGzSanitized(t *testing.T) {
+	destDir := t.TempDir()
+	f, err := os.Open(createCraftedTarball(t, []tarEntry{
+		{name: "./", typeflag: tar.TypeDir},
+		{name: "/abs/", typeflag: tar.TypeDir},
+		{name: "/abs/file.txt", typeflag: tar.TypeReg, content: "abs"},
+		{name: "foo..bar.txt", typeflag: tar.TypeReg, content: "dots"},
+		{name: "dir/", typeflag: tar.TypeDir},
+		{name: "dir/link", typeflag: tar.TypeSymlink, linkname: "../foo..bar.txt"},
+		{name: "hardlink", typeflag: tar.TypeLink, linkname: "foo..bar.txt"},
+	}))
+	assert.NoError(t, err)
+	defer f.Close()
+
+	list, err := ExtractTarGz(context.TODO(), f, destDir, 0)
+	assert.NoError(t, err)
+
+	// Leading slashes are stripped, hence absolute paths end up in destDir.
+	assert.Contains(t, list, filepath.Join(destDir, "abs", "file.txt"))
+	assert.FileExists(t, filepath.Join(destDir, "abs", "file.txt"))
+
+	// Links pointing inside destDir are allowed.
+	content, err := os.ReadFile(filepath.Join(destDir, "dir", "link"))
+	assert.NoError(t, err)
+	assert.Equal(t, "dots", string(content))
+	content, err = os.ReadFile(filepath.Join(destDir, "hardlink"))
+	assert.NoError(t, err)
+	assert.Equal(t, "dots", string(content))
+}
+
+func TestExtractTarGzModes(t *testing.T) {
+	entries := []tarEntry{
+		{name: "plugin.so", typeflag: tar.TypeReg, mode: 0o755},
+		{name: "helper", typeflag: tar.TypeReg, mode: 0o4755},
+		{name: "rules.yaml", typeflag: tar.TypeReg, mode: 0o644},
+	}
+
+	t.Run("default strips special bits", func(t *testing.T) {
+		destDir := t.TempDir()
+		f, err := os.Open(createCraftedTarball(t, entries))
+		assert.NoError(t, err)
+		defer f.Close()
+
+		_, err = ExtractTarGz(context.TODO(), f, destDir, 0)
+		assert.NoError(t, err)
+
+		expected := map[string]os.FileMode{
+			"plugin.so":  0o755,
+			"helper":     0o755,
+			"rules.yaml": 0o644,
+		}
+		for name, mode := range expected {
+			info, err := os.Stat(filepath.Join(destDir, name))
+			assert.NoError(t, err)
+			assert.Equal(t, mode, info.Mode(), name)
+		}
+	})
+
+	t.Run("custom mask keeps the setuid bit", func(t *testing.T) {
+		destDir := t.TempDir()
+		f, err := os.Open(createCraftedTarball(t, entries))
+		assert.NoError(t, err)
+		defer f.Close()
+
+		_, err = ExtractTarGz(context.TODO(), f, destDir, 0, WithModeMask(DefaultExtractModeMask|os.ModeSetuid))
+		assert.NoError(t, err)
+
+		info, err := os.Stat(filepath.Join(destDir, "helper"))
+		assert.NoError(t, err)
+		assert.Equal(t, 0o755|os.ModeSetuid, info.Mode())
+	})
+}
+
+func TestExtractTarGzCompressions(t *testing.T) {
+	var tarball bytes.Buffer
+	tarWriter := tar.NewWriter(&tarball)
+	for _, e := range []tarEntry{
+		{name: "dir/", typeflag: tar.TypeDir},
+		{name: "dir/file.txt", typeflag: tar.TypeReg, content: "content"},
+	} {
+		mode := int64(0o644)
+		if e.typeflag == tar.TypeDir {
+			mode = 0o755
+		}
+		assert.NoError(t, tarWriter.WriteHeader(&tar.Header{Name: e.name, Typeflag: e.typeflag, Mode: mode, Size: int64(len(e.content))}))
+		_, err := tarWriter.Write([]byte(e.content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, tarWriter.Close())
+
+	var gzipped bytes.Buffer
+	gzipWriter := gzip.NewWriter(&gzipped)
+	_, err := gzipWriter.Write(tarball.Bytes())
+	assert.NoError(t, err)
+	assert.NoError(t, gzipWriter.Close())
+
+	var zstdCompressed bytes.Buffer
+	zstdWriter, err := zstd.NewWriter(&zstdCompressed)
+	assert.NoError(t, err)
+	_, err = zstdWriter.Write(tarball.Bytes())
+	assert.NoError(t, err)
+	assert.NoError(t, zstdWriter.Close())
+
+	tests := []struct {
+		name    string
+		archive []byte
+	}{
+		{"gzip", gzipped.Bytes()},
+		{"zstd", zstdCompressed.Bytes()},
+		{"uncompressed", tarball.Bytes()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			destDir := t.TempDir()
+			list, err := ExtractTarGz(context.TODO(), bytes.NewReader(tt.archive), destDir, 0)
+			assert.NoError(t, err)
+			assert.ElementsMatch(t, []string{filepath.Join(destDir, "dir"), filepath.Join(destDir, "dir", "file.txt")}, list)
+			content, err := os.ReadFile(filepath.Join(destDir, "dir", "file.txt"))
+			assert.NoError(t, err)
+			assert.Equal(t, "content", string(content))
+		})
+	}
+
+	t.Run("empty uncompressed", func(t *testing.T) {
+		var empty bytes.Buffer
+		assert.NoError(t, tar.NewWriter(&empty).Close())
+		list, err := ExtractTarGz(context.TODO(), &empty, t.TempDir(), 0)
+		assert.NoError(t, err)
+		assert.Empty(t, list)
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		// bzip2 magic bytes.
+		_, err := ExtractTarGz(context.TODO(), strings.NewReader("BZh91AY&SY"+strings.Repeat("x", 512)), t.TempDir(), 0)
+		assert.EqualError(t, err, "unsupported archive format: expected a tar archive, either uncompressed or compressed with gzip or zstd")
+	})
+}
+
+func TestExtractTarGzExclude(t *testing.T) {
+	destDir := t.TempDir()
+	f, err := os.Open(createCraftedTarball(t, []tarEntry{
+		{name: "rules.yaml", typeflag: tar.TypeReg, content: "rules"},
+		{name: "rules.example.yaml", typeflag: tar.TypeReg, content: "example"},
+		{name: "examples/", typeflag: tar.TypeDir},
+		{name: "examples/config.yaml", typeflag: tar.TypeReg, content: "config"},
+		{name: "nested/dir/", typeflag: tar.TypeDir},
+		{name: "nested/dir/other.example.yaml", typeflag: tar.TypeReg, content: "example"},
+		{name: "hardlink", typeflag: tar.TypeLink, linkname: "rules.example.yaml"},
+	}))
+	assert.NoError(t, err)
+	defer f.Close()
+
+	var excluded []string
+	list, err := ExtractTarGz(context.TODO(), f, destDir, 0, WithExclude([]string{"*.example.yaml", "examples", "hardlink"},
+		func(name string) {
+			excluded = append(excluded, name)
+		}))
+	assert.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{
+		filepath.Join(destDir, "rules.yaml"),
+		filepath.Join(destDir, "nested", "dir"),
+	}, list)
+	assert.ElementsMatch(t, []string{"rules.example.yaml", "examples/", "examples/config.yaml",
+		"nested/dir/other.example.yaml", "hardlink"}, excluded)
+	assert.NoFileExists(t, filepath.Join(destDir, "rules.example.yaml"))
+	assert.NoDirExists(t, filepath.Join(destDir, "examples"))
+	assert.NoFileExists(t, filepath.Join(destDir, "hardlink"))
+}
+
+func TestExtractTarGzStripComponentsShortEntries(t *testing.T) {
+	destDir := t.TempDir()
+	f, err := os.Open(createCraftedTarball(t, []tarEntry{
+		{name: "./", typeflag: tar.TypeDir},
+		{name: "./README.md", typeflag: tar.TypeReg, content: "readme"},
+		{name: "./rules-1.0.0/", typeflag: tar.TypeDir},
+		{name: "./rules-1.0.0/rules.yaml", typeflag: tar.TypeReg, content: "rules"},
+		{name: "./rules-1.0.0/nested/", typeflag: tar.TypeDir},
+		{name: "./rules-1.0.0/nested/other.yaml", typeflag: tar.TypeReg, content: "other"},
+		{name: "./rules-1.0.0/hardlink", typeflag: tar.TypeLink, linkname: "./rules-1.0.0/rules.yaml"},
+		{name: "./short-link", typeflag: tar.TypeLink, linkname: "./README.md"},
+	}))
+	assert.NoError(t, err)
+	defer f.Close()
+
+	list, err := ExtractTarGz(context.TODO(), f, destDir, 2)
+	assert.NoError(t, err)
+
+	// Entries with 2 components or less, e.g. "./README.md" and the top-level directory, are skipped.
+	assert.ElementsMatch(t, []string{
+		filepath.Join(destDir, "rules.yaml"),
+		filepath.Join(destDir, "nested"),
+		filepath.Join(destDir, "nested", "other.yaml"),
+		filepath.Join(destDir, "hardlink"),
+	}, list)
+	assert.NoFileExists(t, filepath.Join(destDir, "README.md"))
+	assert.NoFileExists(t, filepath.Join(destDir, "short-link"))
+	content, err := os.ReadFile(filepath.Join(destDir, "hardlink"))
+	assert.NoError(t, err)
+	assert.Equal(t, "rules", string(content))
+}
+
+func TestStripComponents(t *testing.T) {
+	tests := []struct {
+		name     string
+		n        int
+		expected string
+	}{
+		{name: "dir/file.yaml", n: 0, expected: "dir/file.yaml"},
+		{name: "dir/file.yaml", n: 1, expected: "file.yaml"},
+		{name: "dir/nested/file.yaml", n: 2, expected: "file.yaml"},
+		{name: "./dir/file.yaml", n: 1, expected: filepath.Join("dir", "file.yaml")},
+		{name: "/dir//nested/", n: 1, expected: "nested"},
+		{name: "dir/", n: 1, expected: ""},
+		{name: "file.yaml", n: 1, expected: ""},
+		{name: "dir/file.yaml", n: 3, expected: ""},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, stripComponents(tt.name, tt.n), "stripping %d components from %q", tt.n, tt.name)
+	}
+}
+
+func TestExtractTarGzWritten(t *testing.T) {
+	destDir := t.TempDir()
+	f, err := os.Open(createCraftedTarball(t, []tarEntry{
+		{name: "dir/", typeflag: tar.TypeDir},
+		{name: "dir/rules.yaml", typeflag: tar.TypeReg, content: "rules"},
+		{name: "hardlink", typeflag: tar.TypeLink, linkname: "dir/rules.yaml"},
+		{name: "symlink", typeflag: tar.TypeSymlink, linkname: "dir/rules.yaml"},
+	}))
+	assert.NoError(t, err)
+	defer f.Close()
+
+	written := make(map[string]string)
+	_, err = ExtractTarGz(context.TODO(), f, destDir, 0, WithWritten(func(path, digest string) {
+		written[path] = digest
+	}))
+	assert.NoError(t, err)
+
+	// Only the regular files and the hard links are reported, with the digest of their content.
+	digest, err := FileDigest(filepath.Join(destDir, "dir", "rules.yaml"))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		filepath.Join(destDir, "dir", "rules.yaml"): digest,
+		filepath.Join(destDir, "hardlink"):          digest,
+	}, written)
+}
+
+func TestValidateExcludePatterns(t *testing.T) {
+	assert.NoError(t, ValidateExcludePatterns([]string{"*.yaml", "examples/*", "rules-?.yaml"}))
+	assert.ErrorContains(t, ValidateExcludePatterns([]string{"*.yaml", "[examples"}), `invalid exclude pattern "[examples"`)
+}
@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// lockPollInterval is the time waited between attempts to acquire a lock held by another process.
+const lockPollInterval = 100 * time.Millisecond
+
+// ErrLocked is returned by LockFile when the lock is still held by another process once the timeout expires.
+var ErrLocked = errors.New("lock held by another process")
+
+// LockFile acquires an exclusive advisory lock on the file at path, created if missing, waiting up to timeout
+// for the process holding it to release it. A zero timeout fails right away when the lock is held. The returned
+// function releases the lock, which is released as well when the process exits.
+func LockFile(ctx context.Context, path string, timeout time.Duration) (func() error, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return nil, fmt.Errorf("unable to create directory of lock file %q: %w", path, err)
+	}
+	f, err := os.OpenFile(filepath.Clean(path), os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open lock file %q: %w", path, err)
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		locked, err := tryLock(f)
+		if err != nil {
+			_ = f.Close()
+			return nil, fmt.Errorf("unable to lock file %q: %w", path, err)
+		}
+		if locked {
+			// Closing the file releases the lock.
+			return f.Close, nil
+		}
+
+		if !time.Now().Before(deadline) {
+			_ = f.Close()
+			return nil, ErrLocked
+		}
+		select {
+		case <-ctx.Done():
+			_ = f.Close()
+			return nil, ctx.Err()
+		case <-time.After(min(lockPollInterval, time.Until(deadline))):
+		}
+	}
+}
@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLockFile(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "falcoctl", "install.lock")
+
+	unlock, err := LockFile(ctx, path, 0)
+	require.NoError(t, err)
+	assert.FileExists(t, path)
+
+	_, err = LockFile(ctx, path, 0)
+	assert.ErrorIs(t, err, ErrLocked)
+
+	_, err = LockFile(ctx, path, 3*lockPollInterval)
+	assert.ErrorIs(t, err, ErrLocked)
+
+	// The lock is acquired once released by its holder, while waiting.
+	go func(unlock func() error) {
+		time.Sleep(lockPollInterval)
+		_ = unlock()
+	}(unlock)
+	release, err := LockFile(ctx, path, time.Minute)
+	require.NoError(t, err)
+
+	canceledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = LockFile(canceledCtx, path, time.Minute)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	require.NoError(t, release())
+	unlock, err = LockFile(ctx, path, 0)
+	require.NoError(t, err)
+	require.NoError(t, unlock())
+}
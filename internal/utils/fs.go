@@ -18,8 +18,11 @@ package utils
 import (
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
+	"syscall"
 )
 
 // Move moves oldPath file to to newPath file. It works also on different file system types.
@@ -41,6 +44,34 @@ func Move(oldPath, newPath string) error {
 	return nil
 }
 
+// ExpandPath expands the environment variables, in the $VAR or ${VAR} form, found in path, and then a leading "~"
+// to the home directory of the current user, as a shell does. Undefined variables expand to the empty string, while
+// the "~user" form is left as is.
+func ExpandPath(path string) (string, error) {
+	path = os.ExpandEnv(path)
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("unable to expand %q: %w", path, err)
+	}
+	return home + path[1:], nil
+}
+
+// ExpandPaths expands in place each of the given paths, see ExpandPath.
+func ExpandPaths(paths ...*string) error {
+	for _, path := range paths {
+		expanded, err := ExpandPath(*path)
+		if err != nil {
+			return err
+		}
+		*path = expanded
+	}
+	return nil
+}
+
 // ExistsAndIsWritable checks if the directory specified by the path exists and is writable.
 func ExistsAndIsWritable(path string) error {
 	info, err := os.Stat(path)
@@ -62,8 +93,211 @@ func ExistsAndIsWritable(path string) error {
 			return err
 		}
 	} else {
-		return fmt.Errorf("%s is not writable", path)
+		return fmt.Errorf("%s is not writable: %w", path, err)
 	}
 
 	return nil
 }
+
+// MoveTree moves the given paths, all found under srcDir, to the same relative paths under dstDir and returns
+// the new absolute paths. Parent directories must precede their content, as in the list returned by ExtractTarGz.
+// Directories are created in dstDir, while files and symlinks atomically replace the existing ones.
+// When srcDir and dstDir are on different file systems, each file is first copied next to its destination.
+// If one of the paths cannot be moved, the ones already moved are rolled back: the replaced files are restored
+// and the new files and directories removed, so that dstDir is left as it was.
+func MoveTree(srcDir, dstDir string, paths []string) ([]string, error) {
+	srcDir, err := filepath.Abs(srcDir)
+	if err != nil {
+		return nil, err
+	}
+	if dstDir, err = filepath.Abs(dstDir); err != nil {
+		return nil, err
+	}
+
+	var undo []movedPath
+	committed := false
+	defer func() {
+		if !committed {
+			rollback(undo)
+			return
+		}
+		for _, u := range undo {
+			if u.backup != "" {
+				_ = os.Remove(u.backup)
+			}
+		}
+	}()
+
+	moved := make([]string, 0, len(paths))
+	for _, path := range paths {
+		rel, err := filepath.Rel(srcDir, path)
+		if err != nil {
+			return nil, err
+		}
+		dst := filepath.Join(dstDir, rel)
+
+		info, err := os.Lstat(path)
+		if err != nil {
+			return nil, err
+		}
+
+		u, err := prepareMove(dst)
+		if err != nil {
+			return nil, err
+		}
+		undo = append(undo, u)
+
+		if info.IsDir() {
+			if err := os.MkdirAll(dst, info.Mode().Perm()); err != nil {
+				return nil, err
+			}
+		} else if err := moveFile(path, dst, info); err != nil {
+			return nil, err
+		}
+		moved = append(moved, dst)
+	}
+
+	committed = true
+	return moved, nil
+}
+
+// movedPath records what MoveTree needs to undo the move of a path.
+type movedPath struct {
+	dst string
+	// backup is a copy of the file replaced by dst, if any.
+	backup string
+	// created is set when dst did not exist before the move.
+	created bool
+}
+
+// prepareMove backs up the file at dst, if any, before it gets replaced.
+func prepareMove(dst string) (movedPath, error) {
+	u := movedPath{dst: dst}
+	info, err := os.Lstat(dst)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		u.created = true
+	case err != nil:
+		return u, err
+	case !info.IsDir():
+		if u.backup, err = backupFile(dst, info); err != nil {
+			return u, fmt.Errorf("cannot back up %q: %w", dst, err)
+		}
+	}
+	return u, nil
+}
+
+// backupFile copies the file or symlink at path next to it and returns the path of the copy. A hard link is used
+// when supported by the file system, leaving path untouched until it is replaced.
+func backupFile(path string, info os.FileInfo) (string, error) {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".falcoctl-backup-")
+	if err != nil {
+		return "", err
+	}
+	backup := tmp.Name()
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+	if err := os.Remove(backup); err != nil {
+		return "", err
+	}
+	if err := os.Link(path, backup); err == nil {
+		return backup, nil
+	}
+
+	// Hard links are not supported, copy the file instead.
+	if info.Mode()&os.ModeSymlink != 0 {
+		target, err := os.Readlink(path)
+		if err != nil {
+			return "", err
+		}
+		return backup, os.Symlink(target, backup)
+	}
+	f, err := os.OpenFile(filepath.Clean(backup), os.O_CREATE|os.O_EXCL|os.O_WRONLY, info.Mode().Perm())
+	if err != nil {
+		return "", err
+	}
+	if err := copyContent(f, path); err != nil {
+		f.Close()
+		_ = os.Remove(backup)
+		return "", err
+	}
+	return backup, f.Close()
+}
+
+// rollback undoes the moves in reverse order. Directories are removed only if left empty.
+func rollback(undo []movedPath) {
+	for i := len(undo) - 1; i >= 0; i-- {
+		switch u := undo[i]; {
+		case u.backup != "":
+			_ = os.Rename(u.backup, u.dst)
+		case u.created:
+			_ = os.Remove(u.dst)
+		}
+	}
+}
+
+// moveFile renames src to dst, falling back to copyReplace when they are on different file systems.
+func moveFile(src, dst string, info os.FileInfo) error {
+	err := os.Rename(src, dst)
+	if errors.Is(err, syscall.EXDEV) {
+		return copyReplace(src, dst, info)
+	}
+	return err
+}
+
+// copyReplace copies the file or symlink src to a temporary file in the directory of dst, then renames it to dst,
+// so that dst is replaced atomically even if src is on another file system. src is removed on success.
+func copyReplace(src, dst string, info os.FileInfo) error {
+	tmp, err := os.CreateTemp(filepath.Dir(dst), "."+filepath.Base(dst)+".falcoctl-")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+	defer os.Remove(tmpName)
+
+	if info.Mode()&os.ModeSymlink != 0 {
+		if err := tmp.Close(); err != nil {
+			return err
+		}
+		target, err := os.Readlink(src)
+		if err != nil {
+			return err
+		}
+		if err := os.Remove(tmpName); err != nil {
+			return err
+		}
+		if err := os.Symlink(target, tmpName); err != nil {
+			return err
+		}
+	} else {
+		if err := copyContent(tmp, src); err != nil {
+			tmp.Close()
+			return err
+		}
+		if err := tmp.Chmod(info.Mode()); err != nil {
+			tmp.Close()
+			return err
+		}
+		if err := tmp.Close(); err != nil {
+			return err
+		}
+	}
+
+	if err := os.Rename(tmpName, dst); err != nil {
+		return err
+	}
+
+	return os.Remove(src)
+}
+
+func copyContent(dst io.Writer, src string) error {
+	f, err := os.Open(filepath.Clean(src))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(dst, f)
+	return err
+}
@@ -0,0 +1,194 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMoveTree(t *testing.T) {
+	srcDir := filepath.Join(t.TempDir(), "staging")
+	dstDir := t.TempDir()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(srcDir, "sub"), 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(srcDir, "rules.yaml"), []byte("new"), 0o640))
+	require.NoError(t, os.WriteFile(filepath.Join(srcDir, "sub", "plugin.so"), []byte("plugin"), 0o750))
+	require.NoError(t, os.Symlink("rules.yaml", filepath.Join(srcDir, "link.yaml")))
+	// The existing file is replaced.
+	require.NoError(t, os.WriteFile(filepath.Join(dstDir, "rules.yaml"), []byte("old"), 0o600))
+
+	paths := []string{
+		filepath.Join(srcDir, "rules.yaml"),
+		filepath.Join(srcDir, "sub"),
+		filepath.Join(srcDir, "sub", "plugin.so"),
+		filepath.Join(srcDir, "link.yaml"),
+	}
+	moved, err := MoveTree(srcDir, dstDir, paths)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dstDir, "rules.yaml"),
+		filepath.Join(dstDir, "sub"),
+		filepath.Join(dstDir, "sub", "plugin.so"),
+		filepath.Join(dstDir, "link.yaml"),
+	}, moved)
+
+	data, err := os.ReadFile(filepath.Join(dstDir, "rules.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "new", string(data))
+
+	info, err := os.Stat(filepath.Join(dstDir, "sub", "plugin.so"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o750), info.Mode().Perm())
+
+	target, err := os.Readlink(filepath.Join(dstDir, "link.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "rules.yaml", target)
+
+	_, err = os.Stat(filepath.Join(srcDir, "rules.yaml"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	// The backup of the replaced file is removed.
+	entries, err := os.ReadDir(dstDir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 3)
+}
+
+func TestMoveTreeRollback(t *testing.T) {
+	srcDir := filepath.Join(t.TempDir(), "staging")
+	dstDir := t.TempDir()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(srcDir, "sub"), 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(srcDir, "rules.yaml"), []byte("new"), 0o640))
+	require.NoError(t, os.WriteFile(filepath.Join(srcDir, "sub", "plugin.so"), []byte("plugin"), 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(dstDir, "rules.yaml"), []byte("old"), 0o600))
+
+	// The last path does not exist, hence it cannot be moved.
+	paths := []string{
+		filepath.Join(srcDir, "rules.yaml"),
+		filepath.Join(srcDir, "sub"),
+		filepath.Join(srcDir, "sub", "plugin.so"),
+		filepath.Join(srcDir, "missing.yaml"),
+	}
+	_, err := MoveTree(srcDir, dstDir, paths)
+	require.Error(t, err)
+
+	data, err := os.ReadFile(filepath.Join(dstDir, "rules.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "old", string(data))
+
+	entries, err := os.ReadDir(dstDir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestCopyReplace(t *testing.T) {
+	srcDir := t.TempDir()
+	dstDir := t.TempDir()
+
+	src := filepath.Join(srcDir, "plugin.so")
+	dst := filepath.Join(dstDir, "plugin.so")
+	require.NoError(t, os.WriteFile(src, []byte("new"), 0o600))
+	require.NoError(t, os.Chmod(src, 0o755))
+	require.NoError(t, os.WriteFile(dst, []byte("old"), 0o600))
+
+	info, err := os.Lstat(src)
+	require.NoError(t, err)
+	require.NoError(t, copyReplace(src, dst, info))
+
+	data, err := os.ReadFile(dst)
+	require.NoError(t, err)
+	assert.Equal(t, "new", string(data))
+	info, err = os.Stat(dst)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o755), info.Mode().Perm())
+	_, err = os.Stat(src)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	link := filepath.Join(srcDir, "link")
+	require.NoError(t, os.Symlink("plugin.so", link))
+	info, err = os.Lstat(link)
+	require.NoError(t, err)
+	require.NoError(t, copyReplace(link, filepath.Join(dstDir, "link"), info))
+	target, err := os.Readlink(filepath.Join(dstDir, "link"))
+	require.NoError(t, err)
+	assert.Equal(t, "plugin.so", target)
+
+	// No temporary file is left behind.
+	entries, err := os.ReadDir(dstDir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+}
+
+func TestExpandPath(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("FALCOCTL_TEST_DIR", "/opt/falco")
+	t.Setenv("FALCOCTL_TEST_TILDE", "~")
+
+	tests := []struct {
+		name     string
+		path     string
+		expected string
+	}{
+		{name: "plain path", path: "/usr/share/falco/plugins", expected: "/usr/share/falco/plugins"},
+		{name: "relative path", path: "plugins", expected: "plugins"},
+		{name: "tilde alone", path: "~", expected: home},
+		{name: "tilde prefix", path: "~/falco/plugins", expected: filepath.Join(home, "falco", "plugins")},
+		{name: "tilde not leading", path: "/tmp/~/plugins", expected: "/tmp/~/plugins"},
+		{name: "tilde user", path: "~falco/plugins", expected: "~falco/plugins"},
+		{name: "home variable", path: "$HOME/falco/plugins", expected: filepath.Join(home, "falco", "plugins")},
+		{name: "braced variable", path: "${FALCOCTL_TEST_DIR}/plugins", expected: "/opt/falco/plugins"},
+		{name: "variable expanding to tilde", path: "$FALCOCTL_TEST_TILDE/plugins", expected: filepath.Join(home, "plugins")},
+		{name: "undefined variable", path: "/opt/$FALCOCTL_TEST_UNDEFINED/plugins", expected: "/opt//plugins"},
+		{name: "empty path", path: "", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expanded, err := ExpandPath(tt.path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, expanded)
+		})
+	}
+}
+
+func TestExpandPaths(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	rules, plugins := "~/rules", "$HOME/plugins"
+	require.NoError(t, ExpandPaths(&rules, &plugins))
+	assert.Equal(t, filepath.Join(home, "rules"), rules)
+	assert.Equal(t, filepath.Join(home, "plugins"), plugins)
+}
+
+func TestAvailableSpace(t *testing.T) {
+	available, err := AvailableSpace(t.TempDir())
+	if errors.Is(err, errors.ErrUnsupported) {
+		t.Skip("available space not supported on this platform")
+	}
+	require.NoError(t, err)
+	assert.Positive(t, available)
+
+	_, err = AvailableSpace(filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+}
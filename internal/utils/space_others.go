@@ -0,0 +1,28 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !linux
+
+package utils
+
+import (
+	"errors"
+)
+
+// AvailableSpace returns the number of bytes available to unprivileged users on the filesystem holding path.
+// It is only supported on Linux, errors.ErrUnsupported being returned elsewhere.
+func AvailableSpace(_ string) (int64, error) {
+	return 0, errors.ErrUnsupported
+}
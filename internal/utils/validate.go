@@ -17,17 +17,46 @@ package utils
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 )
 
-// GetRegistryFromRef extracts the registry from a ref string.
+// registryHostRegexp matches a registry host, i.e. a domain name or an IPv4 address, or an IPv6 address between
+// brackets, optionally followed by a port.
+var registryHostRegexp = regexp.MustCompile(
+	`^(\[[0-9a-fA-F:.]+\]|[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?(\.[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?)*)(:[0-9]+)?$`)
+
+// dockerHubAliases are the hosts of Docker Hub, normalized to the registry name used by the credential stores.
+var dockerHubAliases = map[string]bool{
+	"index.docker.io":      true,
+	"registry-1.docker.io": true,
+}
+
+// GetRegistryFromRef extracts the registry from a ref string, i.e. its first path component, e.g. "localhost:5000"
+// for "localhost:5000/foo/bar@sha256:<digest>". As for the clients interacting with the registries, the first
+// component is always the registry, there is no implicit Docker Hub registry, while the Docker Hub hosts are
+// normalized to "docker.io". It fails when the ref has no repository, contains a URL scheme or starts with an
+// invalid host, so that the registry is never mistaken for another host, e.g. when looking for its credentials.
 func GetRegistryFromRef(ref string) (string, error) {
 	index := strings.Index(ref, "/")
 	if index <= 0 {
 		return "", fmt.Errorf("cannot extract registry name from ref %q", ref)
 	}
+	reg, repo := ref[:index], ref[index+1:]
 
-	return ref[0:index], nil
+	switch {
+	case strings.Contains(ref, "://"):
+		return "", fmt.Errorf("cannot extract registry name from ref %q: references must not contain a URL scheme", ref)
+	case !registryHostRegexp.MatchString(reg):
+		return "", fmt.Errorf("cannot extract registry name from ref %q: invalid registry host %q", ref, reg)
+	case repo == "" || strings.HasPrefix(repo, ":") || strings.HasPrefix(repo, "@") || strings.HasPrefix(repo, "/"):
+		return "", fmt.Errorf("cannot extract registry name from ref %q: missing repository", ref)
+	}
+
+	if dockerHubAliases[strings.ToLower(reg)] {
+		return "docker.io", nil
+	}
+	return reg, nil
 }
 
 // RepositoryFromRef extracts the registry+repository from a ref string.
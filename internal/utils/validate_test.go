@@ -17,6 +17,56 @@ package utils
 
 import "testing"
 
+func TestGetRegistryFromRef(t *testing.T) {
+	const digest = "sha256:67df5990affad0d8f0b13c6e611733f3b5725029135368207ed0e4d58341b5d7"
+	tests := []struct {
+		name    string
+		ref     string
+		want    string
+		wantErr bool
+	}{
+		{"reg_repo", "ghcr.io/falcosecurity/rules", "ghcr.io", false},
+		{"reg_repo_tag", "ghcr.io/falcosecurity/rules/my_rule:0.1.0", "ghcr.io", false},
+		{"reg_repo_hash", "ghcr.io/falcosecurity/rules/my_rule@" + digest, "ghcr.io", false},
+		{"reg_repo_tag_hash", "ghcr.io/falcosecurity/rules/my_rule:0.1.0@" + digest, "ghcr.io", false},
+		{"reg_port_repo", "localhost:5000/foo", "localhost:5000", false},
+		{"reg_port_repo_tag", "localhost:5000/foo:latest", "localhost:5000", false},
+		{"reg_port_repo_hash", "localhost:5000/foo@" + digest, "localhost:5000", false},
+		{"reg_port_nested_repo", "registry.example.com:8443/a/b/c/d:1.0.0", "registry.example.com:8443", false},
+		{"ipv4_port", "127.0.0.1:5000/foo/bar:1.0.0", "127.0.0.1:5000", false},
+		{"ipv6_port", "[::1]:5000/foo/bar:1.0.0", "[::1]:5000", false},
+		{"no_domain", "noregistry/testrules", "noregistry", false},
+		{"docker_hub", "docker.io/falcosecurity/rules:latest", "docker.io", false},
+		{"docker_hub_index", "index.docker.io/falcosecurity/rules:latest", "docker.io", false},
+		{"docker_hub_registry", "registry-1.docker.io/falcosecurity/rules:latest", "docker.io", false},
+		{"name_only", "my_rule", "", true},
+		{"name_tag", "my_rule:0.1.0", "", true},
+		{"reg_only", "localhost:5000", "", true},
+		{"reg_hash", "ghcr.io@" + digest, "", true},
+		{"reg_slash", "ghcr.io/", "", true},
+		{"reg_tag_only", "ghcr.io/:latest", "", true},
+		{"reg_hash_only", "ghcr.io/@" + digest, "", true},
+		{"reg_double_slash", "ghcr.io//foo", "", true},
+		{"leading_slash", "/foo/bar", "", true},
+		{"scheme", "https://ghcr.io/falcosecurity/rules", "", true},
+		{"hash_in_host", "ghcr.io@" + digest + "/foo", "", true},
+		{"invalid_port", "localhost:port/foo", "", true},
+		{"empty", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetRegistryFromRef(tt.ref)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetRegistryFromRef() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("GetRegistryFromRef() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestNameFromRef(t *testing.T) {
 	tests := []struct {
 		name    string
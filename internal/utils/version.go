@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/blang/semver"
+)
+
+// shorthandConstraint matches the caret and tilde constraints, which are not understood by semver.ParseRange.
+var shorthandConstraint = regexp.MustCompile(`[\^~]\s*v?[0-9][^\s|]*`)
+
+// SplitVersionConstraint splits a name in the "name@constraint" format. Digests, in the "name@algorithm:hex"
+// format, are not considered constraints. The returned constraint is empty when name does not have one.
+func SplitVersionConstraint(name string) (base, constraint string) {
+	i := strings.LastIndex(name, "@")
+	if i < 0 || strings.Contains(name[i+1:], ":") {
+		return name, ""
+	}
+	return name[:i], name[i+1:]
+}
+
+// ParseVersionConstraint parses a semver constraint. Besides the syntax of semver.ParseRange, e.g. ">=1.2.0 <2.0.0",
+// it accepts the caret constraints, e.g. "^1.2.0" matching any version >=1.2.0 <2.0.0, and the tilde ones,
+// e.g. "~1.2.0" matching any version >=1.2.0 <1.3.0.
+func ParseVersionConstraint(constraint string) (semver.Range, error) {
+	var expandErr error
+	expanded := shorthandConstraint.ReplaceAllStringFunc(constraint, func(term string) string {
+		lower, upper, err := shorthandBounds(term)
+		if err != nil {
+			expandErr = err
+			return term
+		}
+		return fmt.Sprintf(">=%s <%s", lower, upper)
+	})
+	if expandErr != nil {
+		return nil, fmt.Errorf("invalid version constraint %q: %w", constraint, expandErr)
+	}
+
+	rng, err := semver.ParseRange(expanded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version constraint %q: %w", constraint, err)
+	}
+
+	return rng, nil
+}
+
+// shorthandBounds returns the bounds of a caret or tilde constraint. The caret allows changes that do not modify
+// the left-most non-zero component, while the tilde allows patch-level changes only.
+func shorthandBounds(term string) (lower, upper semver.Version, err error) {
+	operator, version := term[0], strings.TrimSpace(term[1:])
+	if lower, err = semver.ParseTolerant(version); err != nil {
+		return lower, upper, err
+	}
+
+	switch {
+	case operator == '~':
+		upper = semver.Version{Major: lower.Major, Minor: lower.Minor + 1}
+	case lower.Major > 0:
+		upper = semver.Version{Major: lower.Major + 1}
+	case lower.Minor > 0:
+		upper = semver.Version{Minor: lower.Minor + 1}
+	default:
+		upper = semver.Version{Patch: lower.Patch + 1}
+	}
+
+	return lower, upper, nil
+}
+
+// HighestMatchingVersion returns the tag holding the highest semver version matching the constraint. Tags that are
+// not semver versions, e.g. "latest", are ignored, as well as pre-releases unless the constraint mentions one.
+// A leading "v" is allowed in tags.
+func HighestMatchingVersion(tags []string, constraint string) (string, error) {
+	rng, err := ParseVersionConstraint(constraint)
+	if err != nil {
+		return "", err
+	}
+	allowPre := strings.Contains(constraint, "-")
+
+	var (
+		bestTag string
+		best    semver.Version
+	)
+	for _, tag := range tags {
+		ver, err := semver.Parse(strings.TrimPrefix(tag, "v"))
+		if err != nil {
+			continue
+		}
+		if len(ver.Pre) > 0 && !allowPre {
+			continue
+		}
+		if rng(ver) && (bestTag == "" || ver.GT(best)) {
+			bestTag, best = tag, ver
+		}
+	}
+
+	if bestTag == "" {
+		return "", fmt.Errorf("no version matching %q among the available tags: %s", constraint, strings.Join(tags, ", "))
+	}
+
+	return bestTag, nil
+}
@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitVersionConstraint(t *testing.T) {
+	tests := []struct {
+		name           string
+		wantBase       string
+		wantConstraint string
+	}{
+		{name: "k8saudit", wantBase: "k8saudit"},
+		{name: "k8saudit:0.6.0", wantBase: "k8saudit:0.6.0"},
+		{name: "k8saudit@^0.6.0", wantBase: "k8saudit", wantConstraint: "^0.6.0"},
+		{name: "ghcr.io/falcosecurity/plugins/plugin/k8saudit@>=0.6.0 <0.8.0",
+			wantBase: "ghcr.io/falcosecurity/plugins/plugin/k8saudit", wantConstraint: ">=0.6.0 <0.8.0"},
+		{name: "k8saudit@sha256:f5bed22b9f4bed888f77f06c03a5d6aaef691682aa2820f6158919427f905194",
+			wantBase: "k8saudit@sha256:f5bed22b9f4bed888f77f06c03a5d6aaef691682aa2820f6158919427f905194"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base, constraint := SplitVersionConstraint(tt.name)
+			assert.Equal(t, tt.wantBase, base)
+			assert.Equal(t, tt.wantConstraint, constraint)
+		})
+	}
+}
+
+func TestHighestMatchingVersion(t *testing.T) {
+	tags := []string{"latest", "0", "0.1.0", "0.1.5", "0.2.0", "1.0.0", "1.2.0", "1.2.7", "v1.4.1", "1.5.0-rc1", "2.0.0"}
+
+	tests := []struct {
+		constraint string
+		want       string
+		wantErr    string
+	}{
+		{constraint: "^1.2.0", want: "v1.4.1"},
+		{constraint: "^0.1.0", want: "0.1.5"},
+		{constraint: "^0.0.1", wantErr: "no version matching"},
+		{constraint: "~1.2.0", want: "1.2.7"},
+		{constraint: "~1.2", want: "1.2.7"},
+		{constraint: ">=1.0.0 <1.3.0", want: "1.2.7"},
+		{constraint: "^0.1.0 || ^2.0.0", want: "2.0.0"},
+		{constraint: "1.0.0", want: "1.0.0"},
+		{constraint: ">=1.5.0-rc0 <2.0.0", want: "1.5.0-rc1"},
+		{constraint: ">=3.0.0", wantErr: "no version matching \">=3.0.0\""},
+		{constraint: "^foo", wantErr: "invalid version constraint"},
+		{constraint: "not-a-constraint", wantErr: "invalid version constraint"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			got, err := HighestMatchingVersion(tags, tt.constraint)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
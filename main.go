@@ -21,11 +21,16 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/falcosecurity/falcoctl/cmd"
+	"github.com/falcosecurity/falcoctl/internal/telemetry"
 	"github.com/falcosecurity/falcoctl/pkg/options"
 )
 
+// telemetryShutdownTimeout bounds the time spent flushing the telemetry before exiting.
+const telemetryShutdownTimeout = 5 * time.Second
+
 func main() {
 	// Set up the root cmd.
 	opt := options.NewOptions()
@@ -47,11 +52,26 @@ func main() {
 		stop()
 	}()
 
+	// Export traces and metrics when an OTLP endpoint is configured.
+	shutdownTelemetry, err := telemetry.Setup(ctx)
+	if err != nil {
+		opt.Printer.Logger.Warn("Unable to set up telemetry", opt.Printer.Logger.Args("reason", err))
+	}
+
 	// Create root command
 	rootCmd := cmd.New(ctx, opt)
 
 	// Execute the command.
-	if err := cmd.Execute(rootCmd, opt); err != nil {
+	err = cmd.Execute(rootCmd, opt)
+
+	// Flush the telemetry, even if the command has been interrupted.
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), telemetryShutdownTimeout)
+	if shutdownErr := shutdownTelemetry(shutdownCtx); shutdownErr != nil {
+		opt.Printer.Logger.Warn("Unable to flush telemetry", opt.Printer.Logger.Args("reason", shutdownErr))
+	}
+	cancel()
+
+	if err != nil {
 		os.Exit(1)
 	}
 	os.Exit(0)
@@ -0,0 +1,230 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package install
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"time"
+
+	"github.com/falcosecurity/falcoctl/internal/config"
+	"github.com/falcosecurity/falcoctl/internal/utils"
+	"github.com/falcosecurity/falcoctl/pkg/index/index"
+	"github.com/falcosecurity/falcoctl/pkg/oci"
+	ocipuller "github.com/falcosecurity/falcoctl/pkg/oci/puller"
+	"github.com/falcosecurity/falcoctl/pkg/options"
+)
+
+// Options are the options of the installs performed by an Installer. They mirror the flags of the install command,
+// whose defaults are set by NewOptions.
+type Options struct {
+	// Common holds the printer the progress is reported to and the index cache the artifact names are resolved
+	// through, both required, as set by options.Common.Initialize with options.WithIndexCache.
+	*options.Common
+	// Registry holds the settings used to reach the registries. It defaults to the zero Registry options.
+	*options.Registry
+	// Directory holds the directories the artifacts are installed to, by type. It is required.
+	*options.Directory
+	// AllowedTypes are the types of the artifacts that can be installed. All types are allowed when empty.
+	AllowedTypes oci.ArtifactTypeSlice
+	// StrictTypes makes artifacts whose type is not allowed fail, instead of being skipped.
+	StrictTypes bool
+	// ResolveDeps makes the dependencies of the artifacts installed as well.
+	ResolveDeps bool
+	// NoVerify disables the verification of the signatures.
+	NoVerify bool
+	// SkipDigestCheck disables the verification of the digests of the pulled artifacts, for debugging only.
+	SkipDigestCheck bool
+	// VerifySignature makes the artifacts without signature fail, and Signature is used for the artifacts not
+	// having one in the indexes, when set.
+	VerifySignature bool
+	Signature       index.CosignSignature
+	// Platform is the "OS/ARCH" platform of the plugins installed.
+	Platform string
+	// PlatformVariant is the variant of the platform of the plugins installed, e.g. "v7" for "linux/arm".
+	PlatformVariant string
+	// AllPlatforms installs the artifacts for every platform of their image index, each in a subdirectory named
+	// after it, instead of Platform.
+	AllPlatforms bool
+	// LockFile is the lockfile the installed artifacts and files are recorded in. No lockfile is written when empty.
+	LockFile string
+	// FromLock is the lockfile to install the recorded artifacts from, in place of the given references.
+	FromLock string
+	// FromFile is a file listing artifacts to install after the given references, see --from-file.
+	FromFile string
+	// FromStdin installs the tar archive read from Stdin, of type SourceType, in place of the given references.
+	FromStdin bool
+	Stdin     io.Reader
+	// FromDir and FromTar install the artifacts from an OCI image layout, or a tar archive of it, instead of pulling
+	// them from the registries.
+	FromDir string
+	FromTar string
+	// NoCache always downloads the artifacts, without reading or storing them in the local cache.
+	NoCache bool
+	// Parallelism is the number of artifacts installed at the same time, the number of CPUs by default.
+	Parallelism int
+	// Concurrency is the number of chunks of a layer downloaded in parallel through range requests.
+	Concurrency int
+	// MaxRetries is the number of times a request to the registry is retried on transient errors, waiting
+	// RetryBackoff before the first retry and doubling it at each attempt.
+	MaxRetries   int
+	RetryBackoff time.Duration
+	// DestDirMapping is a YAML file mapping artifact names or types to the directories they are installed to,
+	// taking precedence over Directory, see --dest-dir-mapping.
+	DestDirMapping string
+	// Selectors are the key=value or key!=value selectors the manifest annotations of the installed artifacts must
+	// match, the other artifacts being skipped.
+	Selectors []string
+	// Exclude are the glob patterns of the files of the artifacts not installed, see --exclude.
+	Exclude []string
+	// StripComponents is the number of leading path components stripped from the files of the artifacts, see
+	// --strip-components.
+	StripComponents int
+	// FalcoVersion is the version of Falco the artifacts must be compatible with. It is detected running FalcoBin
+	// when empty, while IgnoreFalcoVersion disables the check.
+	FalcoVersion       string
+	IgnoreFalcoVersion bool
+	// RequireDigest makes the artifacts whose references are not pinned to a digest fail.
+	RequireDigest bool
+	// RequireAttestation makes the artifacts without a SLSA provenance attestation fail.
+	RequireAttestation bool
+	// Force overwrites the files modified since a previous install, and installs again up to date artifacts.
+	Force bool
+	// OnlyNewer skips the artifacts whose digest matches the one recorded in LockFile.
+	OnlyNewer bool
+	// UpdateAll installs again the artifacts recorded in LockFile from the references they track, in place of the
+	// given references, skipping the ones whose digest did not change, see --update-all.
+	UpdateAll bool
+	// DryRun only resolves the artifacts, returning the planned results without pulling or writing anything.
+	DryRun bool
+	// VersionedLayout installs each rulesfile in a directory per version, see --versioned-layout.
+	VersionedLayout bool
+	// Validate runs FalcoBin with --validate on the rulesfiles before installing them, see --validate.
+	Validate bool
+	// FalcoBin is the falco binary used to validate the rulesfiles and detect the Falco version.
+	FalcoBin string
+	// SaveSBOM is the directory where the SBOMs attached to the artifacts are saved, see --save-sbom. No SBOM is
+	// saved when empty.
+	SaveSBOM string
+	// Reload sends SIGHUP to the running falco processes once at least one artifact has been installed, and
+	// PostInstallCmd is then run through the shell, see --post-install-cmd.
+	Reload         bool
+	PostInstallCmd string
+	// MaxSize bounds the size of each artifact, in bytes. No limit is applied when zero.
+	MaxSize options.ByteSize
+	// Timeout bounds the whole install. No timeout is applied when zero.
+	Timeout time.Duration
+	// LockTimeout bounds the time waited for the other installs running on the node to complete. The install
+	// fails right away when another one is running when zero.
+	LockTimeout time.Duration
+	// SourceType is the type of the artifacts installed from HTTP(S) URLs, local archives or Stdin.
+	SourceType oci.ArtifactType
+}
+
+// NewOptions returns the options of the install command, set to the defaults of its flags, with the given common
+// options.
+func NewOptions(common *options.Common) *Options {
+	return &Options{
+		Common:   common,
+		Registry: &options.Registry{},
+		Directory: &options.Directory{
+			RulesfilesDir: config.RulesfilesDir,
+			PluginsDir:    config.PluginsDir,
+			AssetsDir:     config.AssetsDir,
+		},
+		ResolveDeps:  true,
+		Platform:     fmt.Sprintf("%s/%s", runtime.GOOS, runtime.GOARCH),
+		LockFile:     config.LockFile,
+		Parallelism:  runtime.NumCPU(),
+		Concurrency:  1,
+		MaxRetries:   ocipuller.DefaultMaxRetries,
+		RetryBackoff: ocipuller.DefaultRetryBackoff,
+		FalcoBin:     defaultFalcoBin,
+		MaxSize:      options.ByteSize(utils.DefaultMaxExtractedSize),
+		LockTimeout:  DefaultLockTimeout,
+		SourceType:   oci.Rulesfile,
+	}
+}
+
+// New returns an Installer installing the artifacts according to opts, once validated. The paths of opts are
+// expanded, see utils.ExpandPath.
+func New(opts *Options) (*Installer, error) {
+	if opts.Common == nil || opts.Printer == nil || opts.IndexCache == nil {
+		return nil, errors.New("the options of the install need a printer and an index cache")
+	}
+	if opts.Directory == nil {
+		return nil, errors.New("the options of the install need the directories to install the artifacts to")
+	}
+	if opts.Registry == nil {
+		opts.Registry = &options.Registry{}
+	}
+
+	o := &Installer{Options: opts}
+	if err := o.validateOptions(); err != nil {
+		return nil, err
+	}
+	if err := o.Directory.Expand(); err != nil {
+		return nil, err
+	}
+	if err := utils.ExpandPaths(&o.LockFile, &o.FromLock, &o.FromDir, &o.FromTar, &o.FromFile, &o.SaveSBOM); err != nil {
+		return nil, err
+	}
+
+	return o, nil
+}
+
+// Artifacts installs the given artifacts, as the install command does, and returns the outcome of each of them.
+// See Installer.Install.
+func Artifacts(ctx context.Context, opts *Options, refs ...string) ([]*Result, error) {
+	o, err := New(opts)
+	if err != nil {
+		return nil, err
+	}
+	return o.Install(ctx, refs...)
+}
+
+// Install installs the given artifacts and returns the outcome of each of them. The references are either names
+// resolved through the configured indexes, fully qualified references, HTTP(S) URLs or local archives, followed by
+// the ones listed in FromFile. Results are returned, alongside the errors, even when some of the artifacts could not
+// be installed, while no results are returned when failing before installing anything.
+func (o *Installer) Install(ctx context.Context, refs ...string) ([]*Result, error) {
+	// The artifacts listed in the file are installed after the ones passed as arguments.
+	if o.FromFile != "" {
+		listed, err := loadArtifactsFile(o.FromFile)
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, listed...)
+	}
+
+	if len(refs) == 0 && o.FromLock == "" && !o.UpdateAll && !o.FromStdin {
+		return nil, errors.New("no artifacts to install")
+	}
+
+	return o.install(ctx, refs)
+}
+
+// PrintSummary logs how many artifacts have been installed, skipped and failed, or updated with UpdateAll.
+func (o *Installer) PrintSummary(results []*Result) {
+	if o.UpdateAll {
+		o.printUpdateSummary(results)
+		return
+	}
+	o.printInstallSummary(results)
+}
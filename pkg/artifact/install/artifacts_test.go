@@ -0,0 +1,230 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package install_test
+
+import (
+	"crypto/ecdsa"
+	"path/filepath"
+	"runtime"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"oras.land/oras-go/v2/registry/remote/auth"
+
+	"github.com/falcosecurity/falcoctl/pkg/artifact/install"
+	"github.com/falcosecurity/falcoctl/pkg/index/cache"
+	"github.com/falcosecurity/falcoctl/pkg/index/index"
+	"github.com/falcosecurity/falcoctl/pkg/lockfile"
+	"github.com/falcosecurity/falcoctl/pkg/oci"
+	"github.com/falcosecurity/falcoctl/pkg/oci/authn"
+	ocipuller "github.com/falcosecurity/falcoctl/pkg/oci/puller"
+	ocipusher "github.com/falcosecurity/falcoctl/pkg/oci/pusher"
+	commonoptions "github.com/falcosecurity/falcoctl/pkg/options"
+	testutils "github.com/falcosecurity/falcoctl/pkg/test"
+)
+
+var _ = Describe("NewOptions func", func() {
+	It("should return the defaults of the install command", func() {
+		opts := install.NewOptions(commonoptions.NewOptions())
+		Expect(opts.ResolveDeps).To(BeTrue())
+		Expect(opts.Parallelism).To(Equal(runtime.NumCPU()))
+		Expect(opts.Concurrency).To(Equal(1))
+		Expect(opts.MaxRetries).To(Equal(ocipuller.DefaultMaxRetries))
+		Expect(opts.RetryBackoff).To(Equal(ocipuller.DefaultRetryBackoff))
+		Expect(opts.LockTimeout).To(Equal(install.DefaultLockTimeout))
+		Expect(opts.SourceType).To(Equal(oci.Rulesfile))
+		Expect(opts.Directory).ToNot(BeNil())
+		Expect(opts.Registry).ToNot(BeNil())
+	})
+})
+
+var _ = Describe("Artifacts func", func() {
+	var (
+		baseDir string
+		ref     string
+		opts    *install.Options
+		results []*install.Result
+		err     error
+	)
+
+	BeforeEach(func() {
+		baseDir = GinkgoT().TempDir()
+
+		pusher := ocipusher.NewPusher(authn.NewClient(authn.WithCredentials(&auth.EmptyCredential)), true, nil)
+		ref = registry + "/library-install/plugin:0.0.1"
+		config := ocipusher.WithArtifactConfig(oci.ArtifactConfig{Name: "plugin1", Version: "0.0.1"})
+		filePathsAndPlatforms := ocipusher.WithFilepathsAndPlatforms([]string{plugintgz}, []string{"linux/amd64"})
+		_, err := pusher.Push(ctx, oci.Plugin, ref, filePathsAndPlatforms, config)
+		Expect(err).ToNot(HaveOccurred())
+
+		indexCache, err := cache.NewFromConfig(ctx, "", "", nil)
+		Expect(err).ToNot(HaveOccurred())
+		common := commonoptions.NewOptions()
+		common.Initialize(commonoptions.WithWriter(output), commonoptions.WithIndexCache(indexCache))
+
+		opts = install.NewOptions(common)
+		opts.Registry = &commonoptions.Registry{PlainHTTP: true}
+		opts.Directory = &commonoptions.Directory{PluginsDir: baseDir}
+		opts.Platform = "linux/amd64"
+		opts.NoVerify = true
+		opts.IgnoreFalcoVersion = true
+		opts.LockFile = filepath.Join(baseDir, "falcoctl.lock.yaml")
+	})
+
+	JustBeforeEach(func() {
+		results, err = install.Artifacts(ctx, opts, ref)
+	})
+
+	AfterEach(func() {
+		Expect(output.Clear()).To(Succeed())
+	})
+
+	When("installing a plugin", func() {
+		It("should install it and return its result", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(results).To(HaveLen(1))
+			Expect(results[0].Ref).To(Equal(ref))
+			Expect(results[0].Status).To(Equal(install.StatusInstalled))
+			Expect(results[0].Type).To(Equal(oci.Plugin.String()))
+			Expect(results[0].DestDir).To(Equal(baseDir))
+			Expect(results[0].Digest).ToNot(BeEmpty())
+			Expect(filepath.Join(baseDir, "libcloudtrail.so")).To(BeARegularFile())
+
+			lock, err := lockfile.New(opts.LockFile)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(lock.Artifacts).To(HaveLen(1))
+		})
+	})
+
+	When("in dry-run mode", func() {
+		BeforeEach(func() {
+			opts.DryRun = true
+		})
+
+		It("should return the planned result without installing anything", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(results).To(HaveLen(1))
+			Expect(results[0].Status).To(Equal(install.StatusPlanned))
+			Expect(results[0].DestDir).To(Equal(baseDir))
+			Expect(filepath.Join(baseDir, "libcloudtrail.so")).ToNot(BeAnExistingFile())
+			Expect(output).ShouldNot(gbytes.Say("Artifact successfully installed"))
+		})
+	})
+
+	When("the type is not allowed", func() {
+		BeforeEach(func() {
+			opts.AllowedTypes = oci.ArtifactTypeSlice{Types: []oci.ArtifactType{oci.Rulesfile}}
+		})
+
+		It("should return the artifact as skipped", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(results).To(HaveLen(1))
+			Expect(results[0].Status).To(Equal(install.StatusSkipped))
+			Expect(filepath.Join(baseDir, "libcloudtrail.so")).ToNot(BeAnExistingFile())
+		})
+	})
+
+	When("the annotations do not match the selectors", func() {
+		BeforeEach(func() {
+			opts.Selectors = []string{"org.falcosecurity.channel=stable"}
+		})
+
+		It("should return the artifact as skipped", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(results).To(HaveLen(1))
+			Expect(results[0].Status).To(Equal(install.StatusSkipped))
+			Expect(results[0].Error).To(ContainSubstring("annotations do not match selector"))
+			Expect(filepath.Join(baseDir, "libcloudtrail.so")).ToNot(BeAnExistingFile())
+		})
+	})
+
+	When("the files are excluded", func() {
+		BeforeEach(func() {
+			opts.Exclude = []string{"*.so"}
+		})
+
+		It("should install the artifact without the excluded files", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(results).To(HaveLen(1))
+			Expect(results[0].Status).To(Equal(install.StatusInstalled))
+			Expect(filepath.Join(baseDir, "libcloudtrail.so")).ToNot(BeAnExistingFile())
+		})
+	})
+
+	Context("requiring the signature", func() {
+		var key *ecdsa.PrivateKey
+
+		BeforeEach(func() {
+			var pubKey string
+			key, pubKey, err = testutils.GenerateCosignKey(baseDir, "cosign.pub")
+			Expect(err).ToNot(HaveOccurred())
+			opts.NoVerify = false
+			opts.VerifySignature = true
+			opts.Signature = index.CosignSignature{KeyRef: pubKey, IgnoreTlog: true}
+		})
+
+		When("the artifact is signed with the key", func() {
+			BeforeEach(func() {
+				Expect(testutils.CosignSign(ctx, ref, "", key)).To(Succeed())
+			})
+
+			It("should verify the signature and install it", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(results).To(HaveLen(1))
+				Expect(results[0].Status).To(Equal(install.StatusInstalled))
+				Expect(filepath.Join(baseDir, "libcloudtrail.so")).To(BeARegularFile())
+			})
+		})
+
+		When("the artifact is signed with another key", func() {
+			BeforeEach(func() {
+				other, _, err := testutils.GenerateCosignKey(baseDir, "other.pub")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(testutils.CosignSign(ctx, ref, "", other)).To(Succeed())
+			})
+
+			It("should fail without installing it", func() {
+				Expect(err).To(MatchError(ContainSubstring("error while verifying signature")))
+				Expect(results).To(HaveLen(1))
+				Expect(results[0].Status).To(Equal(install.StatusFailed))
+				Expect(filepath.Join(baseDir, "libcloudtrail.so")).ToNot(BeAnExistingFile())
+			})
+		})
+	})
+
+	When("the options are invalid", func() {
+		BeforeEach(func() {
+			opts.StripComponents = -1
+		})
+
+		It("should fail without results", func() {
+			Expect(err).To(MatchError(ContainSubstring("--strip-components must not be negative")))
+			Expect(results).To(BeNil())
+		})
+	})
+
+	When("the directories are missing", func() {
+		BeforeEach(func() {
+			opts.Directory = nil
+		})
+
+		It("should fail without results", func() {
+			Expect(err).To(MatchError(ContainSubstring("directories")))
+			Expect(results).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package install
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/falcosecurity/falcoctl/internal/utils"
+	"github.com/falcosecurity/falcoctl/pkg/lockfile"
+	"github.com/falcosecurity/falcoctl/pkg/oci"
+	ocipuller "github.com/falcosecurity/falcoctl/pkg/oci/puller"
+)
+
+// attestationsDir is the directory, next to the lockfile, where the provenance attestations are written.
+const attestationsDir = "attestations"
+
+// fetchProvenance retrieves the SLSA provenance attestations of the pulled artifact from the repository that served
+// it. Failing to retrieve them, or finding none, is only an error when attestations are required.
+func (o *Installer) fetchProvenance(ctx context.Context, puller *ocipuller.Puller, ref string,
+	result *oci.RegistryResult) ([]ocipuller.Attestation, error) {
+	logger := o.Printer.Logger
+
+	repo, err := utils.RepositoryFromRef(result.Ref)
+	if err != nil {
+		return nil, err
+	}
+	digestRef := fmt.Sprintf("%s@%s", repo, result.RootDigest)
+
+	attestations, err := puller.Attestations(ctx, digestRef)
+	if err != nil {
+		if o.RequireAttestation {
+			return nil, fmt.Errorf("cannot fetch the provenance attestations of %q: %w", ref, err)
+		}
+		logger.Warn("Unable to fetch provenance attestations", logger.Args("ref", ref, "reason", err.Error()))
+		return nil, nil
+	}
+
+	var provenance []ocipuller.Attestation
+	for i := range attestations {
+		if attestations[i].IsSLSAProvenance() {
+			provenance = append(provenance, attestations[i])
+		}
+	}
+
+	if len(provenance) == 0 {
+		if o.RequireAttestation {
+			return nil, fmt.Errorf("no SLSA provenance attestation found for %q, required by --%s", digestRef, FlagRequireAttestation)
+		}
+		logger.Debug("No provenance attestation found", logger.Args("ref", ref, "digest", digestRef))
+		return nil, nil
+	}
+
+	logger.Info("Provenance attestation found", logger.Args("ref", ref, "predicateType", provenance[0].PredicateType))
+	return provenance, nil
+}
+
+// writeProvenance writes the provenance attestations of the named artifact in the attestations directory next to
+// the lockfile, one DSSE envelope per line as in the in-toto JSON Lines format, and returns the path of the file.
+// Nothing is written when there are no attestations.
+func (o *Installer) writeProvenance(name string, provenance []ocipuller.Attestation) (string, error) {
+	if len(provenance) == 0 {
+		return "", nil
+	}
+
+	var buf bytes.Buffer
+	for i := range provenance {
+		if err := json.Compact(&buf, provenance[i].Envelope); err != nil {
+			return "", fmt.Errorf("unable to encode the provenance attestation of %q: %w", name, err)
+		}
+		buf.WriteByte('\n')
+	}
+
+	dir := filepath.Join(filepath.Dir(o.LockFile), attestationsDir)
+	path := filepath.Join(dir, name+".intoto.jsonl")
+	if err := writeFileAtomically(path, buf.Bytes()); err != nil {
+		return "", fmt.Errorf("unable to write provenance attestations %q: %w", path, err)
+	}
+	return path, nil
+}
+
+// writeFileAtomically writes data to path through a temporary file renamed in place, creating its directory if
+// needed, since the platforms of an artifact installed with --all-platforms share the file.
+func writeFileAtomically(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, lockfile.DefaultDirPermissions); err != nil {
+		return fmt.Errorf("cannot create directory %q: %w", dir, err)
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+"-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(lockfile.DefaultFilePermissions); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
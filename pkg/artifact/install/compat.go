@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package install
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+
+	"github.com/blang/semver"
+
+	"github.com/falcosecurity/falcoctl/pkg/oci"
+)
+
+// falcoVersionRegexp matches the Falco version in the output of "falco --version".
+var falcoVersionRegexp = regexp.MustCompile(`Falco version:\s*(\S+)`)
+
+// falcoVersion returns the version of Falco the artifacts are installed for: the one given with --falco-version or,
+// when not given, the one of the falco binary given with --falco-bin. The detection runs only once.
+func (o *Installer) falcoVersion(ctx context.Context) (string, error) {
+	if o.FalcoVersion != "" {
+		return o.FalcoVersion, nil
+	}
+
+	o.detectFalcoVersion.Do(func() {
+		//nolint:gosec // the binary is explicitly given by the user
+		out, err := exec.CommandContext(ctx, o.FalcoBin, "--version").Output()
+		if err != nil {
+			o.detectedFalcoVer.err = fmt.Errorf("unable to run \"falco --version\": %w", err)
+			return
+		}
+		m := falcoVersionRegexp.FindSubmatch(out)
+		if m == nil {
+			o.detectedFalcoVer.err = fmt.Errorf("unable to find the Falco version in the output of \"falco --version\"")
+			return
+		}
+		o.detectedFalcoVer.version = string(m[1])
+	})
+
+	return o.detectedFalcoVer.version, o.detectedFalcoVer.err
+}
+
+// checkFalcoVersion makes sure that the Falco version is within the range declared by the artifact annotations, if
+// any. When the Falco version cannot be detected, the check is skipped with a warning.
+func (o *Installer) checkFalcoVersion(ctx context.Context, ref string, annotations map[string]string) error {
+	logger := o.Printer.Logger
+	minVer, maxVer := annotations[oci.FalcoMinVersionAnnotation], annotations[oci.FalcoMaxVersionAnnotation]
+	if minVer == "" && maxVer == "" {
+		return nil
+	}
+
+	falcoVer, err := o.falcoVersion(ctx)
+	if err != nil {
+		logger.Warn(fmt.Sprintf("Unable to detect the Falco version, use --%s to give it", FlagFalcoVersion),
+			logger.Args("ref", ref, "reason", err.Error()))
+		return nil
+	}
+	falco, err := semver.ParseTolerant(falcoVer)
+	if err != nil {
+		return fmt.Errorf("invalid Falco version %q: %w", falcoVer, err)
+	}
+
+	for _, bound := range []struct {
+		annotation, version string
+		excludes            func(v semver.Version) bool
+	}{
+		{oci.FalcoMinVersionAnnotation, minVer, falco.LT},
+		{oci.FalcoMaxVersionAnnotation, maxVer, falco.GT},
+	} {
+		if bound.version == "" {
+			continue
+		}
+		v, err := semver.ParseTolerant(bound.version)
+		if err != nil {
+			return fmt.Errorf("invalid %s annotation %q in artifact %q: %w", bound.annotation, bound.version, ref, err)
+		}
+		if bound.excludes(v) {
+			return fmt.Errorf("artifact %q is not compatible with Falco %s (%s: %s), use --%s to install it anyway",
+				ref, falcoVer, bound.annotation, bound.version, FlagIgnoreFalcoVersion)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,162 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package install
+
+const (
+	// FlagAllowedTypes is the name of the flag to specify allowed artifact types.
+	FlagAllowedTypes = "allowed-types"
+
+	// FlagStrictAllowedTypes is the name of the flag to fail, instead of skipping them, on artifacts whose type is not allowed.
+	FlagStrictAllowedTypes = "strict-allowed-types"
+
+	// FlagResolveDeps is the name of the flag to enable artifact dependencies resolution.
+	FlagResolveDeps = "resolve-deps"
+
+	// FlagNoVerify is the name of the flag to disable signature verification.
+	FlagNoVerify = "no-verify"
+
+	// FlagParallelism is the name of the flag to specify how many artifacts can be installed concurrently.
+	FlagParallelism = "parallelism"
+
+	// FlagSkipDigestCheck is the name of the flag to disable the digest verification of pulled artifacts.
+	FlagSkipDigestCheck = "skip-digest-check"
+
+	// FlagVerifySignature is the name of the flag to make signature verification mandatory.
+	FlagVerifySignature = "verify-signature"
+
+	// FlagKey is the name of the flag to specify the public key or KMS URI used to verify signatures.
+	FlagKey = "key"
+
+	// FlagCertificateIdentity is the name of the flag to specify the identity for keyless signature verification.
+	FlagCertificateIdentity = "certificate-identity"
+
+	// FlagCertificateIdentityRegexp is the name of the flag to specify the identity regexp for keyless signature verification.
+	FlagCertificateIdentityRegexp = "certificate-identity-regexp"
+
+	// FlagCertificateOidcIssuer is the name of the flag to specify the OIDC issuer for keyless signature verification.
+	FlagCertificateOidcIssuer = "certificate-oidc-issuer"
+
+	// FlagCertificateOidcIssuerRegexp is the name of the flag to specify the OIDC issuer regexp for keyless signature verification.
+	FlagCertificateOidcIssuerRegexp = "certificate-oidc-issuer-regexp"
+
+	// FlagDryRun is the name of the flag to print the install plan without pulling or writing anything.
+	FlagDryRun = "dry-run"
+
+	// FlagPrintPlan is the name of the flag to print the install plan as a JSON or YAML document, without pulling or writing anything.
+	FlagPrintPlan = "print-plan"
+
+	// FlagPlatform is the name of the flag to specify the platform of the artifacts to install.
+	FlagPlatform = "platform"
+
+	// FlagLockFile is the name of the flag to specify where the lockfile is written.
+	FlagLockFile = "lock-file"
+
+	// FlagFromLock is the name of the flag to install the artifacts recorded in a lockfile.
+	FlagFromLock = "from-lock"
+
+	// FlagMaxRetries is the name of the flag to specify how many times failed registry requests are retried.
+	FlagMaxRetries = "max-retries"
+
+	// FlagRetryBackoff is the name of the flag to specify the initial wait time between retries.
+	FlagRetryBackoff = "retry-backoff"
+
+	// FlagFromDir is the name of the flag to install artifacts from an OCI image layout directory.
+	FlagFromDir = "from-dir"
+
+	// FlagFromTar is the name of the flag to install artifacts from a tar archive of an OCI image layout.
+	FlagFromTar = "from-tar"
+
+	// FlagDestDirMapping is the name of the flag to specify the file mapping artifacts to their destination directories.
+	FlagDestDirMapping = "dest-dir-mapping"
+
+	// FlagReload is the name of the flag to notify the running Falco processes once artifacts are installed.
+	FlagReload = "reload"
+
+	// FlagPostInstallCmd is the name of the flag to specify the command run once artifacts are installed.
+	FlagPostInstallCmd = "post-install-cmd"
+
+	// FlagNoCache is the name of the flag to disable the cache of the pulled manifests and layers.
+	FlagNoCache = "no-cache"
+
+	// FlagConcurrency is the name of the flag to specify how many chunks of a layer are downloaded in parallel.
+	FlagConcurrency = "concurrency"
+
+	// FlagForce is the name of the flag to overwrite the installed files modified locally.
+	FlagForce = "force"
+
+	// FlagUpdateAll is the name of the flag to install the newer versions of the artifacts recorded in the lockfile.
+	FlagUpdateAll = "update-all"
+
+	// FlagOnlyNewer is the name of the flag to skip the artifacts already installed with the same digest.
+	FlagOnlyNewer = "only-newer"
+
+	// FlagSelector is the name of the flag to specify the annotations the installed artifacts must match.
+	FlagSelector = "selector"
+
+	// FlagFalcoVersion is the name of the flag to specify the Falco version the artifacts must be compatible with.
+	FlagFalcoVersion = "falco-version"
+
+	// FlagIgnoreFalcoVersion is the name of the flag to install artifacts not compatible with the Falco version.
+	FlagIgnoreFalcoVersion = "ignore-falco-version"
+
+	// FlagRequireDigest is the name of the flag to only install artifacts whose references are pinned to a digest.
+	FlagRequireDigest = "require-digest"
+
+	// FlagAllPlatforms is the name of the flag to install artifacts for all the platforms they are available for.
+	FlagAllPlatforms = "all-platforms"
+
+	// FlagPlatformVariant is the name of the flag to specify the variant of the platform of the plugins to install.
+	FlagPlatformVariant = "platform-variant"
+
+	// FlagExclude is the name of the flag to skip the files of the artifacts matching a glob pattern.
+	FlagExclude = "exclude"
+
+	// FlagStripComponents is the name of the flag to strip leading path components from the files of the artifacts.
+	FlagStripComponents = "strip-components"
+
+	// FlagInstallTimeout is the name of the flag to bound the duration of the whole install.
+	FlagInstallTimeout = "install-timeout"
+
+	// FlagSourceType is the name of the flag to set the type of the artifacts installed from URLs or local archives.
+	FlagSourceType = "source-type"
+
+	// FlagFromStdin is the name of the flag to install the archive read from the standard input.
+	FlagFromStdin = "from-stdin"
+
+	// FlagLockTimeout is the name of the flag to specify how long to wait for the other installs running on the node.
+	FlagLockTimeout = "lock-timeout"
+
+	// FlagFromFile is the name of the flag to specify the file listing the artifacts to install.
+	FlagFromFile = "from-file"
+
+	// FlagRequireAttestation is the name of the flag to refuse to install artifacts without a SLSA provenance attestation.
+	FlagRequireAttestation = "require-attestation"
+
+	// FlagVersionedLayout is the name of the flag to install each rulesfile in a directory per version, next to a symlink to the current one.
+	FlagVersionedLayout = "versioned-layout"
+
+	// FlagValidate is the name of the flag to validate the rulesfiles with Falco before installing them.
+	FlagValidate = "validate"
+
+	// FlagFalcoBin is the name of the flag to specify the falco binary used to detect the version and validate the rulesfiles.
+	FlagFalcoBin = "falco-bin"
+
+	// FlagSaveSBOM is the name of the flag to specify the directory where the SBOMs of the installed artifacts are saved.
+	FlagSaveSBOM = "save-sbom"
+
+	// FlagMaxSize is the name of the flag to bound the bytes downloaded and extracted for each artifact.
+	FlagMaxSize = "max-size"
+)
@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package install
+
+import (
+	"context"
+
+	"oras.land/oras-go/v2/registry"
+
+	ocipuller "github.com/falcosecurity/falcoctl/pkg/oci/puller"
+)
+
+// dedupRefs drops the references pointing to an artifact already referenced before them, so that each artifact is
+// pulled and installed once. References are compared once normalized, and those of the same repository by the
+// digest they resolve to, e.g. a tag and the digest it points to. References whose digest cannot be resolved are
+// kept, leaving the install to report the error.
+func (o *Installer) dedupRefs(ctx context.Context, puller *ocipuller.Puller, refs []string) []string {
+	logger := o.Printer.Logger
+
+	var (
+		normalized = make([]string, len(refs))
+		repos      = make([]string, len(refs))
+		repoCount  = make(map[string]int)
+	)
+	for i, ref := range refs {
+		normalized[i] = ref
+		if parsedRef, err := registry.ParseReference(ref); err == nil {
+			normalized[i] = parsedRef.String()
+			repos[i] = parsedRef.Registry + "/" + parsedRef.Repository
+			repoCount[repos[i]]++
+		}
+	}
+
+	var (
+		deduped []string
+		// seen maps the keys of the kept references, i.e. their normalized form and their digest, to them.
+		seen = make(map[string]string)
+	)
+	for i, ref := range refs {
+		keys := []string{normalized[i]}
+		// Digests are only resolved when the repository is referenced more than once.
+		if repos[i] != "" && repoCount[repos[i]] > 1 {
+			opCtx, cancel := o.OperationContext(ctx)
+			if desc, err := puller.Descriptor(opCtx, normalized[i]); err == nil {
+				keys = append(keys, repos[i]+"@"+desc.Digest.String())
+			}
+			cancel()
+		}
+
+		duplicate := ""
+		for _, key := range keys {
+			if kept, ok := seen[key]; ok {
+				duplicate = kept
+				break
+			}
+		}
+		if duplicate != "" {
+			logger.Info("Skipping duplicate artifact", logger.Args("ref", ref, "duplicateOf", duplicate))
+			continue
+		}
+
+		for _, key := range keys {
+			seen[key] = ref
+		}
+		deduped = append(deduped, ref)
+	}
+
+	return deduped
+}
@@ -52,8 +52,28 @@ func copyDepsMap(in depsMapType) (out depsMapType) {
 	return
 }
 
+// DepNode is a node of the dependency tree built while resolving dependencies.
+type DepNode struct {
+	// Name is the name of the artifact, as found in its config layer.
+	Name string
+	// Ref is the reference the artifact is resolved to.
+	Ref string
+	// Cycle marks a dependency on an artifact already found on the path from the root, whose
+	// dependencies are not expanded again.
+	Cycle bool
+	// Dependencies are the nodes of the artifacts this one depends on.
+	Dependencies []*DepNode
+}
+
 // ResolveDeps resolves dependencies to a list of references.
 func ResolveDeps(resolver artifactConfigResolver, inRefs ...string) (outRefs []string, err error) {
+	outRefs, _, err = ResolveDepsTree(resolver, inRefs...)
+	return outRefs, err
+}
+
+// ResolveDepsTree resolves dependencies to a list of references, also returning the tree of the resolved
+// dependencies with a root for each of the input references.
+func ResolveDepsTree(resolver artifactConfigResolver, inRefs ...string) (outRefs []string, tree []*DepNode, err error) {
 	depMap := make(depsMapType)
 	// configMap is used to avoid getting a remote config layer more than once
 	configMap := make(map[string]*oci.ArtifactConfig)
@@ -98,21 +118,23 @@ func ResolveDeps(resolver artifactConfigResolver, inRefs ...string) (outRefs []s
 	}
 
 	// Prepare initial map from user inputs
+	rootNames := make([]string, 0, len(inRefs))
 	for _, ref := range inRefs {
 		config, err := retrieveConfig(ref)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		name := config.Name
 
 		// todo: shall we shadow?
 		if info, ok := depMap[name]; ok {
-			return nil, fmt.Errorf(`cannot provide multiple references for %q: %q, %q`, name, info.ref, ref)
+			return nil, nil, fmt.Errorf(`cannot provide multiple references for %q: %q, %q`, name, info.ref, ref)
 		}
 
 		if err := upsertMap(ref); err != nil {
-			return nil, err
+			return nil, nil, err
 		}
+		rootNames = append(rootNames, name)
 	}
 
 	for {
@@ -128,12 +150,12 @@ func ResolveDeps(resolver artifactConfigResolver, inRefs ...string) (outRefs []s
 				if existing, ok := depMap[required.Name]; ok {
 					requiredVer, err := semver.Parse(required.Version)
 					if err != nil {
-						return nil, fmt.Errorf(`invalid artifact config: version %q is not semver compatible`, required.Version)
+						return nil, nil, fmt.Errorf(`invalid artifact config: version %q is not semver compatible`, required.Version)
 					}
 
 					// Is the existing dep compatible?
 					if existing.ver.Major != requiredVer.Major {
-						return nil, fmt.Errorf(
+						return nil, nil, fmt.Errorf(
 							`%w: %s depends on %s:%s but an incompatible version %s:%s is required by other artifacts`,
 							ErrCannotSatisfyDependencies, name, required.Name, required.Version, required.Name, existing.ver.String(),
 						)
@@ -157,12 +179,12 @@ func ResolveDeps(resolver artifactConfigResolver, inRefs ...string) (outRefs []s
 
 					alternativeVer, err := semver.Parse(alternative.Version)
 					if err != nil {
-						return nil, fmt.Errorf(`invalid artifact config: version %q is not semver compatible`, required.Version)
+						return nil, nil, fmt.Errorf(`invalid artifact config: version %q is not semver compatible`, required.Version)
 					}
 
 					// Is the alternative specified by the user compatible?
 					if existing.ver.Major != alternativeVer.Major {
-						return nil, fmt.Errorf(
+						return nil, nil, fmt.Errorf(
 							`%w: %s depends on %s:%s but an incompatible version %s:%s is required by other artifacts`,
 							ErrCannotSatisfyDependencies, name, required.Name, required.Version, required.Name, existing.ver.String(),
 						)
@@ -170,7 +192,7 @@ func ResolveDeps(resolver artifactConfigResolver, inRefs ...string) (outRefs []s
 
 					if alternativeVer.Compare(*existing.ver) > 0 {
 						if err := upsertMap(alternative.Name + ":" + alternativeVer.String()); err != nil {
-							return nil, err
+							return nil, nil, err
 						}
 					}
 
@@ -182,7 +204,7 @@ func ResolveDeps(resolver artifactConfigResolver, inRefs ...string) (outRefs []s
 
 				// dep to be added or bumped
 				if err := upsertMap(required.Name + ":" + required.Version); err != nil {
-					return nil, err
+					return nil, nil, err
 				}
 				allOk = false
 			}
@@ -195,7 +217,43 @@ func ResolveDeps(resolver artifactConfigResolver, inRefs ...string) (outRefs []s
 			for _, info := range depMap {
 				outRefs = append(outRefs, info.ref)
 			}
-			return
+			for _, name := range rootNames {
+				tree = append(tree, depMap.node(name, map[string]bool{}))
+			}
+			return outRefs, tree, nil
 		}
 	}
 }
+
+// node builds the dependency tree rooted at the artifact with the given name, once all the dependencies
+// have been resolved. Each dependency points to the artifact chosen for it, which may be one of its alternatives.
+// The path holds the names of the ancestors, so that cycles are reported instead of being walked forever.
+func (m depsMapType) node(name string, path map[string]bool) *DepNode {
+	info := m[name]
+	n := &DepNode{Name: name, Ref: info.ref}
+	if path[name] {
+		n.Cycle = true
+		return n
+	}
+
+	path[name] = true
+	defer delete(path, name)
+
+	for _, required := range info.config.Dependencies {
+		depName := required.Name
+		if _, ok := m[depName]; !ok {
+			for _, alternative := range required.Alternatives {
+				if _, ok := m[alternative.Name]; ok {
+					depName = alternative.Name
+					break
+				}
+			}
+		}
+		if _, ok := m[depName]; !ok {
+			continue
+		}
+		n.Dependencies = append(n.Dependencies, m.node(depName, path))
+	}
+
+	return n
+}
@@ -238,3 +238,61 @@ func TestResolveDeps(t *testing.T) {
 		}
 	}
 }
+
+func TestResolveDepsTree(t *testing.T) {
+	configs := map[string]oci.ArtifactConfig{
+		"rules:1.0.0": {
+			Name:    "rules",
+			Version: "1.0.0",
+			Dependencies: []oci.ArtifactDependency{
+				{Name: "plugin", Version: "1.2.3"},
+				{Name: "missing", Version: "1.0.0", Alternatives: []oci.Dependency{{Name: "alt", Version: "2.0.0"}}},
+			},
+		},
+		"plugin:1.2.3": {
+			Name:         "plugin",
+			Version:      "1.2.3",
+			Dependencies: []oci.ArtifactDependency{{Name: "rules", Version: "1.0.0"}},
+		},
+		"alt:2.0.0": {Name: "alt", Version: "2.0.0"},
+	}
+	resolver := artifactConfigResolver(func(ref string) (*oci.RegistryResult, error) {
+		config, ok := configs[ref]
+		if !ok {
+			return nil, errors.New("not found")
+		}
+		return &oci.RegistryResult{Config: config}, nil
+	})
+
+	// The alternative is requested explicitly, so that it is chosen in place of the missing dependency.
+	outRef, tree, err := ResolveDepsTree(resolver, "rules:1.0.0", "alt:2.0.0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sort.Strings(outRef)
+	if strings.Join(outRef, ",") != "alt:2.0.0,plugin:1.2.3,rules:1.0.0" {
+		t.Fatalf("dependencies not correctly resolved: got %v", outRef)
+	}
+
+	if len(tree) != 2 || tree[0].Name != "rules" || tree[1].Name != "alt" {
+		t.Fatalf("expected a root for each input reference, got %+v", tree)
+	}
+
+	root := tree[0]
+	if len(root.Dependencies) != 2 {
+		t.Fatalf("expected 2 dependencies for %q, got %d", root.Name, len(root.Dependencies))
+	}
+
+	plugin := root.Dependencies[0]
+	if plugin.Name != "plugin" || plugin.Ref != "plugin:1.2.3" || plugin.Cycle {
+		t.Fatalf("unexpected node for plugin: %+v", plugin)
+	}
+	if len(plugin.Dependencies) != 1 || !plugin.Dependencies[0].Cycle || plugin.Dependencies[0].Name != "rules" {
+		t.Fatalf("expected the dependency of plugin on rules to be reported as a cycle, got %+v", plugin.Dependencies)
+	}
+
+	if alt := root.Dependencies[1]; alt.Name != "alt" || len(alt.Dependencies) != 0 {
+		t.Fatalf("expected the alternative to be used for the missing dependency, got %+v", alt)
+	}
+}
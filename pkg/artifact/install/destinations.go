@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package install
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/falcosecurity/falcoctl/internal/utils"
+	"github.com/falcosecurity/falcoctl/pkg/oci"
+	ocipuller "github.com/falcosecurity/falcoctl/pkg/oci/puller"
+)
+
+// loadDestDirMapping reads the YAML file mapping artifact names or types to the directories where
+// the artifacts are installed, e.g.:
+//
+//	k8saudit-rules: /etc/falco/rules.d
+//	plugin: /opt/falco/plugins
+func loadDestDirMapping(path string) (map[string]string, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read destination directories mapping: %w", err)
+	}
+
+	var mapping map[string]string
+	if err := yaml.Unmarshal(data, &mapping); err != nil {
+		return nil, fmt.Errorf("unable to parse destination directories mapping %q: %w", path, err)
+	}
+
+	for key, dir := range mapping {
+		if key == "" || dir == "" {
+			return nil, fmt.Errorf("invalid entry %q: %q in destination directories mapping %q: name and directory must not be empty",
+				key, dir, path)
+		}
+	}
+
+	return mapping, nil
+}
+
+// destDir returns the directory where the artifact with the given name and type is installed. The destination
+// directories mapping is looked up by name first and then by type, before falling back to the directory flags.
+func (o *Installer) destDir(name string, artifactType oci.ArtifactType) (string, error) {
+	if dir, ok := o.destDirs[name]; ok {
+		return dir, nil
+	}
+	if dir, ok := o.destDirs[artifactType.String()]; ok {
+		return dir, nil
+	}
+
+	var dir string
+	switch artifactType {
+	case oci.Plugin:
+		dir = o.PluginsDir
+	case oci.Rulesfile:
+		dir = o.RulesfilesDir
+	case oci.Asset:
+		dir = o.AssetsDir
+	default:
+		return "", fmt.Errorf("unrecognized result type %q while pulling artifact", artifactType)
+	}
+
+	// An empty directory would extract the artifact in the current working directory.
+	if dir == "" {
+		return "", fmt.Errorf("no directory where to install %s %q", artifactType, name)
+	}
+	return dir, nil
+}
+
+// checkDestDirs makes sure that the directories where the given artifacts are going to be installed exist and are
+// writable, so that the install fails before pulling any of them. Only the manifests are fetched, to know the types
+// of the artifacts: those whose type cannot be retrieved or is not allowed are left to the install to report.
+func (o *Installer) checkDestDirs(ctx context.Context, puller *ocipuller.Puller, refs []string) error {
+	checked := make(map[string]bool)
+	for _, ref := range refs {
+		opCtx, cancel := o.OperationContext(ctx)
+		platformOS, platformArch := o.queryPlatform()
+		artifactType, err := puller.ArtifactType(opCtx, ref, platformOS, platformArch)
+		cancel()
+		if err != nil || !o.isAllowedType(artifactType) {
+			continue
+		}
+
+		name, err := utils.NameFromRef(ref)
+		if err != nil {
+			return err
+		}
+		dir, err := o.destDir(name, artifactType)
+		if err != nil || checked[dir] {
+			continue
+		}
+		checked[dir] = true
+
+		if err := utils.ExistsAndIsWritable(dir); err != nil {
+			if errors.Is(err, fs.ErrPermission) {
+				return fmt.Errorf("cannot use directory %q as install destination: %w, try running with sudo or choose a different directory",
+					dir, err)
+			}
+			return fmt.Errorf("cannot use directory %q as install destination: %w", dir, err)
+		}
+	}
+
+	return nil
+}
+
+// isAllowedType returns true if artifacts of the given type can be installed.
+func (o *Installer) isAllowedType(artifactType oci.ArtifactType) bool {
+	if len(o.AllowedTypes.Types) == 0 {
+		return true
+	}
+	for _, t := range o.AllowedTypes.Types {
+		if t == artifactType {
+			return true
+		}
+	}
+	return false
+}
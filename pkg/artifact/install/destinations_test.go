@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package install
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/falcosecurity/falcoctl/pkg/oci"
+	"github.com/falcosecurity/falcoctl/pkg/options"
+)
+
+func TestLoadDestDirMapping(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("unable to write %q: %v", path, err)
+		}
+		return path
+	}
+
+	mapping, err := loadDestDirMapping(write("valid.yaml", "k8saudit-rules: /etc/falco/rules.d\nplugin: /opt/plugins\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(mapping) != 2 || mapping["k8saudit-rules"] != "/etc/falco/rules.d" || mapping["plugin"] != "/opt/plugins" {
+		t.Fatalf("unexpected mapping: %v", mapping)
+	}
+
+	for name, content := range map[string]string{
+		"empty-dir.yaml": "k8saudit-rules: \"\"\n",
+		"not-a-map.yaml": "- k8saudit-rules\n",
+	} {
+		if _, err := loadDestDirMapping(write(name, content)); err == nil {
+			t.Fatalf("expected an error for %q", name)
+		}
+	}
+
+	if _, err := loadDestDirMapping(filepath.Join(dir, "missing.yaml")); err == nil ||
+		!strings.Contains(err.Error(), "unable to read destination directories mapping") {
+		t.Fatalf("unexpected error for a missing file: %v", err)
+	}
+}
+
+func TestDestDir(t *testing.T) {
+	o := &Installer{
+		Options: &Options{Directory: &options.Directory{
+			RulesfilesDir: "/etc/falco",
+			PluginsDir:    "/usr/share/falco/plugins",
+			AssetsDir:     "/etc/falco/assets",
+		}},
+		destDirs: map[string]string{
+			"k8saudit-rules": "/etc/falco/rules.d",
+			"rulesfile":      "/etc/falco/other.d",
+		},
+	}
+
+	tests := []struct {
+		name         string
+		artifactType oci.ArtifactType
+		want         string
+	}{
+		{name: "k8saudit-rules", artifactType: oci.Rulesfile, want: "/etc/falco/rules.d"},
+		{name: "cloudtrail-rules", artifactType: oci.Rulesfile, want: "/etc/falco/other.d"},
+		{name: "k8saudit", artifactType: oci.Plugin, want: "/usr/share/falco/plugins"},
+		{name: "asset", artifactType: oci.Asset, want: "/etc/falco/assets"},
+	}
+
+	for _, tt := range tests {
+		got, err := o.destDir(tt.name, tt.artifactType)
+		if err != nil {
+			t.Fatalf("unexpected error for %q: %v", tt.name, err)
+		}
+		if got != tt.want {
+			t.Fatalf("unexpected destination for %q: got %q, expected %q", tt.name, got, tt.want)
+		}
+	}
+
+	if _, err := o.destDir("unknown", oci.ArtifactType("unknown")); err == nil {
+		t.Fatalf("expected an error for an unknown type")
+	}
+
+	o.AssetsDir = ""
+	if _, err := o.destDir("asset", oci.Asset); err == nil {
+		t.Fatalf("expected an error for an empty directory")
+	}
+}
@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package install
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/falcosecurity/falcoctl/internal/utils"
+)
+
+// loadArtifactsFile reads the artifacts listed in the given file, with the same syntax as the arguments of
+// the command, e.g.:
+//
+//	# rules of the k8saudit plugin
+//	k8saudit-rules:0.7.0
+//	k8saudit, ghcr.io/falcosecurity/plugins/plugin/json:latest
+//	cloudtrail@>=0.9.0 <0.11.0
+//
+// Artifacts are separated by new lines, commas or spaces, and the text following a # is ignored. Since version
+// constraints may hold spaces, an artifact with a constraint spans up to the next comma or the end of the line.
+func loadArtifactsFile(path string) ([]string, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read artifacts file: %w", err)
+	}
+	defer f.Close()
+
+	var refs []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		for _, field := range strings.Split(line, ",") {
+			field = strings.TrimSpace(field)
+			if _, constraint := utils.SplitVersionConstraint(field); constraint != "" {
+				refs = append(refs, field)
+				continue
+			}
+			refs = append(refs, strings.Fields(field)...)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read artifacts file %q: %w", path, err)
+	}
+
+	if len(refs) == 0 {
+		return nil, fmt.Errorf("no artifacts to install found in artifacts file %q", path)
+	}
+	return refs, nil
+}
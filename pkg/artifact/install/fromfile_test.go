@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package install
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadArtifactsFile(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("unable to write %q: %v", path, err)
+		}
+		return path
+	}
+
+	refs, err := loadArtifactsFile(write("artifacts.txt", `# rules of the k8saudit plugin
+k8saudit-rules:0.7.0
+
+k8saudit, json   # plugins
+ghcr.io/falcosecurity/rules/cloudtrail-rules@sha256:0123,cloudtrail@>=0.9.0 <0.11.0
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{
+		"k8saudit-rules:0.7.0",
+		"k8saudit",
+		"json",
+		"ghcr.io/falcosecurity/rules/cloudtrail-rules@sha256:0123",
+		"cloudtrail@>=0.9.0 <0.11.0",
+	}
+	if !reflect.DeepEqual(refs, want) {
+		t.Fatalf("unexpected artifacts %v, want %v", refs, want)
+	}
+
+	if _, err := loadArtifactsFile(write("empty.txt", "# nothing to install\n\n")); err == nil ||
+		!strings.Contains(err.Error(), "no artifacts to install") {
+		t.Fatalf("unexpected error for a file without artifacts: %v", err)
+	}
+
+	if _, err := loadArtifactsFile(filepath.Join(dir, "missing.txt")); err == nil ||
+		!strings.Contains(err.Error(), "unable to read artifacts file") {
+		t.Fatalf("unexpected error for a missing file: %v", err)
+	}
+}
@@ -0,0 +1,130 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package install
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/pterm/pterm"
+
+	"github.com/falcosecurity/falcoctl/pkg/lockfile"
+)
+
+const (
+	// falcoProcessName is the name of the processes notified by --reload.
+	falcoProcessName = "falco"
+	// InstalledArtifactsEnv is the environment variable listing the installed artifacts to the post-install command.
+	InstalledArtifactsEnv = "FALCOCTL_INSTALLED_ARTIFACTS"
+)
+
+// procRoot is the mount point of the proc filesystem, overridden in tests.
+var procRoot = "/proc"
+
+// runPostInstallHooks notifies Falco and runs the post-install command, once at least one artifact has been installed.
+func (o *Installer) runPostInstallHooks(ctx context.Context, entries []*lockfile.Entry) error {
+	logger := o.Printer.Logger
+	var errs []error
+
+	if o.Reload {
+		if err := ReloadFalco(logger); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if o.PostInstallCmd != "" {
+		refs := make([]string, len(entries))
+		for i, entry := range entries {
+			refs[i] = entry.Ref
+		}
+
+		logger.Info("Running post-install command", logger.Args("command", o.PostInstallCmd))
+		//nolint:gosec // the command is explicitly given by the user
+		cmd := exec.CommandContext(ctx, "/bin/sh", "-c", o.PostInstallCmd)
+		cmd.Env = append(os.Environ(), InstalledArtifactsEnv+"="+strings.Join(refs, " "))
+		if out, err := cmd.CombinedOutput(); err != nil {
+			errs = append(errs, fmt.Errorf("post-install command %q failed: %w: %s", o.PostInstallCmd, err, strings.TrimSpace(string(out))))
+		} else if len(out) > 0 {
+			logger.Debug("Post-install command output", logger.Args("output", strings.TrimSpace(string(out))))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// ReloadFalco sends SIGHUP to the running falco processes, on which Falco restarts itself, thus loading the new
+// rules and plugins. It fails if no falco process is running or if any of them cannot be notified.
+func ReloadFalco(logger *pterm.Logger) error {
+	pids, err := findProcesses(falcoProcessName)
+	if err != nil {
+		return fmt.Errorf("unable to reload falco: %w", err)
+	}
+
+	var errs []error
+	for _, pid := range pids {
+		if err := signalProcess(pid, syscall.SIGHUP); err != nil {
+			errs = append(errs, fmt.Errorf("unable to send SIGHUP to falco process %d: %w", pid, err))
+			continue
+		}
+		logger.Info("Falco reload requested", logger.Args("pid", pid))
+	}
+	return errors.Join(errs...)
+}
+
+// signalProcess sends the signal to the process with the given id.
+func signalProcess(pid int, sig os.Signal) error {
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return err
+	}
+	return p.Signal(sig)
+}
+
+// findProcesses returns the ids of the running processes with the given name.
+func findProcesses(name string) ([]int, error) {
+	entries, err := os.ReadDir(procRoot)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list processes: %w", err)
+	}
+
+	var pids []int
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil || !entry.IsDir() {
+			continue
+		}
+		// Processes may exit while being listed.
+		comm, err := os.ReadFile(filepath.Join(procRoot, entry.Name(), "comm"))
+		if err != nil {
+			continue
+		}
+		if strings.TrimSpace(string(comm)) == name {
+			pids = append(pids, pid)
+		}
+	}
+
+	if len(pids) == 0 {
+		return nil, fmt.Errorf("no running %s process found", name)
+	}
+	return pids, nil
+}
@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package install
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestFindProcesses(t *testing.T) {
+	root := t.TempDir()
+	defer func(old string) { procRoot = old }(procRoot)
+	procRoot = root
+
+	write := func(pid, comm string) {
+		if err := os.MkdirAll(filepath.Join(root, pid), 0o750); err != nil {
+			t.Fatalf("unable to create process dir: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(root, pid, "comm"), []byte(comm+"\n"), 0o600); err != nil {
+			t.Fatalf("unable to write comm: %v", err)
+		}
+	}
+	write("1", "systemd")
+	write("42", "falco")
+	write("43", "falcoctl")
+	write("self", "falco")
+	write("100", "falco")
+
+	pids, err := findProcesses("falco")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(pids, []int{100, 42}) {
+		t.Errorf("unexpected pids: %v", pids)
+	}
+
+	if _, err := findProcesses("missing"); err == nil {
+		t.Errorf("expected an error when no process matches")
+	}
+}
@@ -0,0 +1,972 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2023 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package install
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pterm/pterm"
+	"github.com/pterm/pterm/putils"
+	"oras.land/oras-go/v2/registry"
+
+	"github.com/falcosecurity/falcoctl/internal/config"
+	"github.com/falcosecurity/falcoctl/internal/consts"
+	"github.com/falcosecurity/falcoctl/internal/utils"
+	"github.com/falcosecurity/falcoctl/pkg/index/index"
+	"github.com/falcosecurity/falcoctl/pkg/lockfile"
+	"github.com/falcosecurity/falcoctl/pkg/oci"
+	ocicache "github.com/falcosecurity/falcoctl/pkg/oci/cache"
+	ocipuller "github.com/falcosecurity/falcoctl/pkg/oci/puller"
+	ociutils "github.com/falcosecurity/falcoctl/pkg/oci/utils"
+	"github.com/falcosecurity/falcoctl/pkg/oci/verify"
+	"github.com/falcosecurity/falcoctl/pkg/output"
+)
+
+// Installer installs artifacts as the install command does, according to its Options. It keeps the state of the
+// install, such as the artifacts recorded in the lockfile before installing, hence one is needed for each install.
+type Installer struct {
+	*Options
+	os, arch   string
+	selector   oci.AnnotationSelector
+	pullerOpts []ocipuller.Option
+	destDirs   map[string]string
+	// previousDigests maps the names of the artifacts being updated by --update-all to the digests they were installed with.
+	previousDigests map[string]string
+	// jobPlatforms maps the references being installed to their platforms, as recorded in the lockfile.
+	jobPlatforms map[string]string
+	// lockedSourceDigests maps the sources recorded in the lockfile, when installing from it, to their digests.
+	lockedSourceDigests map[string]string
+	// detectFalcoVersion guards the detection of the installed Falco version, whose outcome is kept in detectedFalcoVer.
+	detectFalcoVersion sync.Once
+	detectedFalcoVer   struct {
+		version string
+		err     error
+	}
+	// installed is the lockfile as found before installing, recording the files written by previous installs.
+	installed *lockfile.Lockfile
+}
+
+// validateOptions validates the options of the install.
+func (o *Installer) validateOptions() error {
+	if o.Parallelism < 1 {
+		return fmt.Errorf("--%s must be greater than zero", FlagParallelism)
+	}
+
+	if o.Concurrency < 1 {
+		return fmt.Errorf("--%s must be greater than zero", FlagConcurrency)
+	}
+
+	if o.MaxRetries < 0 {
+		return fmt.Errorf("--%s must not be negative", FlagMaxRetries)
+	}
+
+	if o.Timeout < 0 {
+		return fmt.Errorf("--%s must not be negative", FlagInstallTimeout)
+	}
+
+	if o.LockTimeout < 0 {
+		return fmt.Errorf("--%s must not be negative", FlagLockTimeout)
+	}
+
+	tokens := strings.Split(o.Platform, "/")
+	if len(tokens) != 2 || tokens[0] == "" || tokens[1] == "" {
+		return fmt.Errorf("invalid platform format %q: needs to be in OS/ARCH format", o.Platform)
+	}
+	o.os, o.arch = tokens[0], tokens[1]
+
+	if o.FromDir != "" && o.FromTar != "" {
+		return fmt.Errorf("--%s and --%s are mutually exclusive", FlagFromDir, FlagFromTar)
+	}
+
+	if o.VerifySignature && o.NoVerify {
+		return fmt.Errorf("--%s and --%s are mutually exclusive", FlagVerifySignature, FlagNoVerify)
+	}
+
+	if o.UpdateAll && o.FromLock != "" {
+		return fmt.Errorf("--%s and --%s are mutually exclusive", FlagUpdateAll, FlagFromLock)
+	}
+
+	if o.FromStdin && (o.FromLock != "" || o.UpdateAll || o.DryRun) {
+		return fmt.Errorf("--%s cannot be used with --%s, --%s or --%s", FlagFromStdin, FlagFromLock, FlagUpdateAll, FlagDryRun)
+	}
+
+	if o.FromFile != "" && (o.FromLock != "" || o.UpdateAll || o.FromStdin) {
+		return fmt.Errorf("--%s cannot be used with --%s, --%s or --%s", FlagFromFile, FlagFromLock, FlagUpdateAll, FlagFromStdin)
+	}
+
+	if o.UpdateAll && o.LockFile == "" {
+		return fmt.Errorf("--%s needs the lockfile of the installed artifacts, set through --%s", FlagUpdateAll, FlagLockFile)
+	}
+
+	selector, err := oci.ParseAnnotationSelector(o.Selectors)
+	if err != nil {
+		return err
+	}
+	o.selector = selector
+
+	if err := utils.ValidateExcludePatterns(o.Exclude); err != nil {
+		return err
+	}
+
+	if o.StripComponents < 0 {
+		return fmt.Errorf("--%s must not be negative", FlagStripComponents)
+	}
+
+	return nil
+}
+
+// install resolves, pulls, verifies and extracts the given artifacts, returning the outcome of each of them
+// in the order they have been resolved in. Results are returned, alongside the errors, even when some of the
+// artifacts could not be installed, while no results are returned when failing before installing anything.
+// In dry-run mode, the artifacts are only resolved and the planned results are returned.
+func (o *Installer) install(ctx context.Context, args []string) (results []*Result, err error) {
+	logger := o.Printer.Logger
+
+	if o.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.Timeout)
+		defer cancel()
+		defer func() {
+			if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				err = fmt.Errorf("the install did not complete within the --%s of %s: %w", FlagInstallTimeout, o.Timeout, err)
+			}
+		}()
+	}
+
+	// The arguments are checked before taking the lock, not to wait for another install to fail on them.
+	switch {
+	case len(args) == 0:
+	case o.FromLock != "":
+		return nil, fmt.Errorf("artifacts cannot be passed as arguments when installing from a lockfile with --%s", FlagFromLock)
+	case o.UpdateAll:
+		return nil, fmt.Errorf("artifacts cannot be passed as arguments when updating all the installed ones with --%s", FlagUpdateAll)
+	case o.FromStdin:
+		return nil, fmt.Errorf("artifacts cannot be passed as arguments when installing from the standard input with --%s", FlagFromStdin)
+	}
+
+	// Installs running at the same time on the node would clobber the files and the lockfile of each other.
+	if !o.DryRun {
+		unlock, err := o.lockInstall(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			if unlockErr := unlock(); unlockErr != nil {
+				logger.Warn("Unable to release the install lock", logger.Args("path", config.InstallLockFile, "reason", unlockErr))
+			}
+		}()
+	}
+
+	// Updating is installing again the tags tracked by the installed artifacts, skipping the ones not moved.
+	if o.UpdateAll {
+		if args, err = o.refsToUpdate(); err != nil {
+			return nil, err
+		}
+		o.OnlyNewer = true
+	}
+
+	if o.FromStdin {
+		return o.installStdin(ctx)
+	}
+
+	// Sources are neither resolved through the indexes nor pulled from a registry, hence they are set apart.
+	var sources []string
+	if args, sources, err = splitSources(args); err != nil {
+		return nil, err
+	}
+
+	if err := o.checkIndexesConfigured(args); err != nil {
+		return nil, err
+	}
+
+	if args, err = o.expandArgs(args); err != nil {
+		return nil, err
+	}
+
+	if o.DestDirMapping != "" {
+		if o.destDirs, err = loadDestDirMapping(o.DestDirMapping); err != nil {
+			return nil, err
+		}
+	}
+
+	// Create temp dir where to put pulled artifacts
+	tmpDir, err := os.MkdirTemp("", "falcoctl")
+	if err != nil {
+		return nil, fmt.Errorf("cannot create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	o.pullerOpts = []ocipuller.Option{
+		ocipuller.WithRetry(o.MaxRetries, o.RetryBackoff),
+		ocipuller.WithMaxSize(int64(o.MaxSize)),
+		ocipuller.WithVariant(o.PlatformVariant),
+	}
+	if layout := o.localLayout(); layout != "" {
+		source, err := ocipuller.NewLocalSource(ctx, layout)
+		if err != nil {
+			return nil, err
+		}
+		o.pullerOpts = append(o.pullerOpts, ocipuller.WithSource(source))
+		logger.Info("Installing artifacts from local OCI layout", logger.Args("path", layout))
+	} else if !o.DryRun {
+		// Partial downloads are resumed by later installs only when kept in the cache.
+		partialDir := tmpDir
+		if !o.NoCache {
+			layerCache, err := ocicache.New(ctx, config.CacheDir)
+			if err != nil {
+				logger.Warn("Unable to use the cache, artifacts will be downloaded", logger.Args("reason", err))
+			} else {
+				o.pullerOpts = append(o.pullerOpts, ocipuller.WithCache(layerCache))
+				partialDir = layerCache.PartialDir()
+			}
+		}
+		o.pullerOpts = append(o.pullerOpts, ocipuller.WithRangedDownloads(partialDir, ocipuller.DefaultChunkSize, o.Concurrency))
+	}
+
+	// Create registry puller with auto login enabled
+	puller, err := ociutils.Puller(o.Registry, o.Printer, o.pullerOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Specify how to pull config layer for each artifact requested by user.
+	resolver := artifactConfigResolver(func(ref string) (*oci.RegistryResult, error) {
+		ref, err := o.IndexCache.ResolveReference(ref)
+		if err != nil {
+			return nil, err
+		}
+
+		opCtx, cancel := o.OperationContext(ctx)
+		defer cancel()
+
+		platformOS, platformArch := o.queryPlatform()
+		artifactConfig, err := puller.ArtifactConfig(opCtx, ref, platformOS, platformArch)
+		if err != nil {
+			return nil, err
+		}
+
+		return &oci.RegistryResult{
+			Config: *artifactConfig,
+		}, nil
+	})
+
+	signatures := make(map[string]*index.Signature)
+
+	// Compute input to install dependencies
+	for i, arg := range args {
+		if name, constraint := utils.SplitVersionConstraint(arg); constraint != "" {
+			if arg, err = o.resolveVersionConstraint(ctx, puller, name, constraint); err != nil {
+				return nil, err
+			}
+		}
+		ref, err := o.IndexCache.ResolveReference(arg)
+		if err != nil {
+			return nil, err
+		}
+		if sig := o.IndexCache.SignatureForIndexRef(arg); sig != nil {
+			signatures[ref] = sig
+		}
+		args[i] = ref
+	}
+	args = o.dedupRefs(ctx, puller, args)
+
+	var refs []string
+	// lockedRefs maps the digest references read from the lockfile to the originally recorded references.
+	lockedRefs := make(map[string]string)
+	switch {
+	case o.FromLock != "":
+		// The lockfile already contains the resolved dependencies, so there is nothing to solve.
+		if refs, sources, lockedRefs, err = o.refsFromLock(); err != nil {
+			return nil, err
+		}
+	case o.ResolveDeps:
+		// Solve dependencies
+		logger.Info("Resolving dependencies ...")
+		var tree []*DepNode
+		refs, tree, err = ResolveDepsTree(resolver, args...)
+		if err != nil {
+			return nil, err
+		}
+		if err := o.printDepsTree(tree); err != nil {
+			return nil, err
+		}
+	default:
+		refs = args
+	}
+
+	if err := o.checkPinnedRefs(append(slices.Clone(refs), sources...)); err != nil {
+		return nil, err
+	}
+
+	if o.DryRun {
+		return o.plan(ctx, puller, refs, sources)
+	}
+
+	// Fail before pulling anything if the artifacts cannot be written where they are going to be installed.
+	if err := o.checkDestDirs(ctx, puller, refs); err != nil {
+		return nil, err
+	}
+
+	// Files modified since a previous install are told apart by the digests recorded in the lockfile.
+	if o.installed, err = lockfile.New(o.LockFile); err != nil {
+		return nil, err
+	}
+
+	jobs, err := o.installJobs(ctx, puller, refs)
+	if err != nil {
+		return nil, err
+	}
+	for _, source := range sources {
+		jobs = append(jobs, installJob{ref: source, source: true})
+	}
+	o.jobPlatforms = jobPlatforms(jobs)
+
+	logger.Info("Installing artifacts", logger.Args("refs", append(slices.Clone(refs), sources...)))
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		errs    []error
+		entries []*lockfile.Entry
+		// completed is the number of artifacts whose install is over, whatever its outcome.
+		completed int
+	)
+	// results are kept in the same order as jobs, whatever the order artifacts are installed in.
+	results = make([]*Result, len(jobs))
+	// Artifacts installed concurrently share the printer, and a single spinner, for the time of the install.
+	if o.Parallelism > 1 {
+		defer func(printer *output.Printer) { o.Printer = printer }(o.Printer)
+		o.Printer = o.Printer.Concurrent()
+	}
+	// sem bounds the number of artifacts being pulled and installed at the same time.
+	sem := make(chan struct{}, o.Parallelism)
+	for i, job := range jobs {
+		results[i] = &Result{Ref: job.ref}
+		results[i].Name, _ = utils.NameFromRef(job.ref)
+		if o.AllPlatforms && !job.source {
+			results[i].Platform = platformString(job.platform)
+		}
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(job installJob, res *Result) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			var entry *lockfile.Entry
+			var err error
+			if job.source {
+				entry, err = o.installSource(ctx, job.ref, tmpDir, res)
+			} else {
+				entry, err = o.installArtifact(ctx, puller, job, tmpDir, signatures, res)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			// The progress is reported once the status of the artifact is known.
+			defer func() {
+				completed++
+				if len(jobs) > 1 {
+					o.Printer.Logger.Info("Install progress", o.Printer.Logger.Args("completed", fmt.Sprintf("%d/%d", completed, len(jobs)),
+						"name", res.Name, "status", res.Status))
+				}
+			}()
+			if err != nil {
+				res.Status, res.Error = StatusFailed, err.Error()
+				errs = append(errs, err)
+				return
+			}
+			if entry == nil {
+				// The artifact has been skipped, unless already up to date.
+				if res.Status != StatusUpToDate {
+					res.Status = StatusSkipped
+				}
+				return
+			}
+			res.Status = StatusInstalled
+			if lockedRef, ok := lockedRefs[entry.Ref]; ok {
+				entry.Ref = lockedRef
+			}
+			entries = append(entries, entry)
+		}(job, results[i])
+	}
+	wg.Wait()
+	if o.AllPlatforms {
+		entries = mergePlatformEntries(entries)
+	}
+	countResults(ctx, results)
+
+	// Record what has been installed, even if some of the artifacts failed.
+	if len(entries) > 0 {
+		if err := o.recordLock(entries); err != nil {
+			errs = append(errs, err)
+		}
+		if err := o.runPostInstallHooks(ctx, entries); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return results, errors.Join(errs...)
+}
+
+// refsFromLock returns the digest references of the artifacts recorded in the lockfile,
+// together with a map from each of them to the reference recorded in the lockfile. The artifacts installed from
+// sources are returned apart, and their recorded digests are kept to verify them once fetched again.
+func (o *Installer) refsFromLock() (refs, sources []string, lockedRefs map[string]string, err error) {
+	lock, err := lockfile.New(o.FromLock)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if len(lock.Artifacts) == 0 {
+		return nil, nil, nil, fmt.Errorf("no artifacts to install found in lockfile %q", o.FromLock)
+	}
+
+	lockedRefs = make(map[string]string, len(lock.Artifacts))
+	o.lockedSourceDigests = make(map[string]string)
+	for _, entry := range lock.Artifacts {
+		if IsSource(entry.Ref) {
+			sources = append(sources, entry.Ref)
+			o.lockedSourceDigests[entry.Ref] = entry.Digest
+			continue
+		}
+
+		// Only plugins are platform specific, and all the platforms are installed with --all-platforms anyway.
+		if entry.Type == oci.Plugin.String() && !o.AllPlatforms && entry.Platform != o.Platform {
+			return nil, nil, nil, fmt.Errorf("plugin %q was recorded for platform %s in lockfile %q, which does not match the requested platform %s",
+				entry.Name, entry.Platform, o.FromLock, o.Platform)
+		}
+
+		repo, err := utils.RepositoryFromRef(entry.Ref)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		ref := fmt.Sprintf("%s@%s", repo, entry.Digest)
+		refs = append(refs, ref)
+		lockedRefs[ref] = entry.Ref
+	}
+
+	return refs, sources, lockedRefs, nil
+}
+
+// checkIndexesConfigured fails when no index is configured but some of the artifacts are given by name, or by
+// pattern, since they could not be resolved to a reference.
+func (o *Installer) checkIndexesConfigured(args []string) error {
+	if !o.IndexCache.Empty() {
+		return nil
+	}
+
+	var names []string
+	for _, arg := range args {
+		name, _ := utils.SplitVersionConstraint(arg)
+		if _, err := registry.ParseReference(name); err != nil {
+			names = append(names, arg)
+		}
+	}
+	if len(names) > 0 {
+		return fmt.Errorf("no indexes are configured to resolve %s, please add an index with \"falcoctl index add\" "+
+			"or pass the full references of the artifacts, e.g. \"ghcr.io/falcosecurity/plugins/plugin/cloudtrail:latest\"",
+			strings.Join(names, ", "))
+	}
+
+	return nil
+}
+
+// recordLock upserts the given entries into the lockfile.
+func (o *Installer) recordLock(entries []*lockfile.Entry) error {
+	lock, err := lockfile.New(o.LockFile)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		lock.Upsert(entry)
+	}
+
+	if err := lock.Write(o.LockFile); err != nil {
+		return fmt.Errorf("unable to write lockfile %q: %w", o.LockFile, err)
+	}
+
+	o.Printer.Logger.Debug("Lockfile updated", o.Printer.Logger.Args("path", o.LockFile))
+	return nil
+}
+
+// installArtifact pulls, verifies and extracts a single artifact into its destination directory.
+// When multiple artifacts are installed concurrently, each invocation uses its own puller, since it is not safe
+// for concurrent use, and the extractions in progress are shown by the spinner of the concurrent printer.
+// A nil entry is returned when the artifact is skipped since its type is not allowed or its annotations do not
+// match the selector. What is learned about the artifact along the way is recorded in res, also when failing.
+func (o *Installer) installArtifact(ctx context.Context, puller *ocipuller.Puller, job installJob, tmpDir string,
+	signatures map[string]*index.Signature, res *Result) (*lockfile.Entry, error) {
+	logger := o.Printer.Logger
+	start := time.Now()
+
+	ref, err := o.IndexCache.ResolveReference(job.ref)
+	if err != nil {
+		return nil, err
+	}
+	res.Ref = ref
+
+	if o.Parallelism > 1 {
+		if puller, err = ociutils.Puller(o.Registry, nil, o.pullerOpts...); err != nil {
+			return nil, err
+		}
+	}
+
+	// Each artifact gets its own working directory so that concurrent pulls do not clash.
+	artifactDir, err := os.MkdirTemp(tmpDir, "artifact-")
+	if err != nil {
+		return nil, fmt.Errorf("cannot create temporary directory: %w", err)
+	}
+
+	logger.Info("Preparing to pull artifact", logger.Args("ref", ref))
+
+	// Bound the interaction with the registry, so that an unresponsive registry cannot hang the installation.
+	opCtx, cancel := o.OperationContext(ctx)
+	defer cancel()
+
+	if o.OnlyNewer && !o.Force {
+		// Only the digest is resolved, so that artifacts already up to date are not pulled at all.
+		desc, err := puller.Descriptor(opCtx, ref)
+		if err != nil {
+			return nil, err
+		}
+		name, err := utils.NameFromRef(ref)
+		if err != nil {
+			return nil, err
+		}
+		if dgst := desc.Digest.String(); o.upToDate(name, dgst, o.jobPlatforms[ref]) {
+			logger.Info("Artifact up to date", logger.Args("name", name, "ref", ref, "digest", dgst))
+			res.Name, res.Digest, res.Type, res.Status = name, dgst, o.installed.Get(name).Type, StatusUpToDate
+			return nil, nil
+		}
+	}
+
+	if err := puller.CheckAllowedType(opCtx, ref, job.platform.OS, job.platform.Architecture, o.AllowedTypes.Types); err != nil {
+		if errors.Is(err, ocipuller.ErrTypeNotPermitted) && !o.StrictTypes {
+			logger.Warn("Skipping artifact", logger.Args("ref", ref, "reason", err.Error()))
+			res.Error = err.Error()
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if len(o.selector) > 0 || !o.IgnoreFalcoVersion {
+		annotations, err := puller.Annotations(opCtx, ref, job.platform.OS, job.platform.Architecture)
+		if err != nil {
+			return nil, err
+		}
+		if req, unmatched := o.selector.Unmatched(annotations); unmatched {
+			reason := fmt.Sprintf("annotations do not match selector %q", req.String())
+			logger.Warn("Skipping artifact", logger.Args("ref", ref, "reason", reason))
+			res.Error = reason
+			return nil, nil
+		}
+		if !o.IgnoreFalcoVersion {
+			if err := o.checkFalcoVersion(ctx, ref, annotations); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Install the artifact for the requested platform, which defaults to the current OS and architecture.
+	result, err := puller.Pull(opCtx, ref, artifactDir, job.platform.OS, job.platform.Architecture)
+	if err != nil {
+		return nil, maxSizeHint(err)
+	}
+	if result.Ref != ref {
+		logger.Info("Artifact pulled from mirror", logger.Args("ref", ref, "registry", result.Registry))
+	}
+	logger.Info("Artifact pulled", logger.Args("ref", ref, "resolvedRef", result.ResolvedRef, "digest", result.RootDigest))
+	res.Digest, res.Type = result.RootDigest, result.Type.String()
+	if !isDigestRef(ref) {
+		logger.Warn("Installing from a mutable tag, pin the reference to the digest for reproducible installs",
+			logger.Args("ref", ref, "digest", result.RootDigest))
+	}
+
+	sig, ok := signatures[ref]
+	if !ok {
+		// try to get the signature from the index
+		sig = o.IndexCache.SignatureForIndexRef(ref)
+	}
+
+	// Signature data passed by the user always takes precedence over the one found in the index.
+	if o.Signature != (index.CosignSignature{}) {
+		sig = &index.Signature{Cosign: &o.Signature}
+	}
+
+	if o.VerifySignature && (sig == nil || sig.Cosign == nil) {
+		return nil, fmt.Errorf("signature verification is required but no signature data is available for %q, "+
+			"please specify --%s or the keyless certificate flags", ref, FlagKey)
+	}
+
+	if sig != nil && !o.NoVerify {
+		// Signatures are stored in the registry next to the artifacts, hence they cannot be checked offline.
+		if o.localLayout() != "" {
+			return nil, fmt.Errorf("cannot verify signature of %q when installing from a local OCI layout, use --%s to skip it",
+				ref, FlagNoVerify)
+		}
+
+		// The signature is verified against the repository that served the artifact, which may be a mirror.
+		repo, err := utils.RepositoryFromRef(result.Ref)
+		if err != nil {
+			return nil, err
+		}
+
+		// In order to prevent TOCTOU issues we'll perform signature verification after we complete a pull
+		// and obtained a digest but before files are written to disk. This way we ensure that we're verifying
+		// the exact digest that we just pulled, even if the tag gets overwritten in the meantime.
+		digestRef := fmt.Sprintf("%s@%s", repo, result.RootDigest)
+
+		logger.Info("Verifying signature for artifact", logger.Args("digest", digestRef))
+		err = verify.Signature(opCtx, digestRef, sig)
+		if err != nil {
+			return nil, fmt.Errorf("error while verifying signature for %s: %w", digestRef, err)
+		}
+		logger.Info("Signature successfully verified!")
+	}
+
+	// Like signatures, the provenance is fetched before writing anything to disk, so that a required but missing
+	// attestation leaves the destination untouched.
+	provenance, err := o.fetchProvenance(opCtx, puller, ref, result)
+	if err != nil {
+		return nil, err
+	}
+	sbom, err := o.fetchSBOM(opCtx, puller, ref, result)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := utils.NameFromRef(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	destDir, err := o.destDir(name, result.Type)
+	if err != nil {
+		return nil, err
+	}
+	// With the versioned layout, the rulesfile is installed in a directory of its own version, linked once installed.
+	var versionedDir, version string
+	if o.VersionedLayout && result.Type == oci.Rulesfile {
+		versionedDir = filepath.Join(destDir, name)
+		version = o.rulesfileVersion(opCtx, puller, ref, result.RootDigest, job.platform.OS, job.platform.Architecture)
+		destDir = filepath.Join(versionedDir, version)
+		if err := os.MkdirAll(destDir, 0o755); err != nil {
+			return nil, fmt.Errorf("cannot create directory %q: %w", destDir, err)
+		}
+	}
+	if o.AllPlatforms {
+		// Each platform gets its own subdirectory, since the files of the platforms have the same names.
+		destDir = filepath.Join(destDir, platformDir(job.platform))
+		if err := os.MkdirAll(destDir, 0o755); err != nil {
+			return nil, fmt.Errorf("cannot create directory %q: %w", destDir, err)
+		}
+	}
+	res.Name, res.DestDir = name, destDir
+
+	// Check if directory exists and is writable.
+	err = utils.ExistsAndIsWritable(destDir)
+	if err != nil {
+		return nil, fmt.Errorf("cannot use directory %q as install destination: %w", destDir, err)
+	}
+
+	logger.Info("Extracting and installing artifact", logger.Args("type", result.Type, "file", result.Filename))
+
+	done := o.Printer.StartOperation(fmt.Sprintf("Extracting and installing %s", ref))
+	defer done()
+
+	result.Filename = filepath.Join(artifactDir, result.Filename)
+
+	if !o.SkipDigestCheck {
+		if err := utils.VerifyFileDigest(result.Filename, result.LayerDigest); err != nil {
+			return nil, fmt.Errorf("cannot verify integrity of artifact %q: %w", ref, err)
+		}
+	}
+
+	files, digests, err := o.extractArchive(ctx, ref, result.Type, result.Filename, destDir)
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.Remove(result.Filename)
+	if err != nil {
+		return nil, err
+	}
+
+	if versionedDir != "" {
+		if err := linkCurrent(versionedDir, version); err != nil {
+			return nil, err
+		}
+	}
+
+	provenancePath, err := o.writeProvenance(name, provenance)
+	if err != nil {
+		return nil, err
+	}
+	sbomPath, err := o.writeSBOM(name, sbom)
+	if err != nil {
+		return nil, err
+	}
+
+	done()
+	logger.Info("Artifact successfully installed", logger.Args("name", name, "ref", ref, "type", result.Type, "digest", result.Digest,
+		"directory", destDir, "duration", time.Since(start).Round(time.Millisecond).String()))
+
+	return &lockfile.Entry{
+		Name:               name,
+		Ref:                ref,
+		Digest:             result.RootDigest,
+		Type:               result.Type.String(),
+		Platform:           platformString(job.platform),
+		InstalledTimestamp: time.Now().Format(consts.TimeFormat),
+		Files:              files,
+		Digests:            digests,
+		Provenance:         provenancePath,
+		SBOM:               sbomPath,
+		VersionedDir:       versionedDir,
+	}, nil
+}
+
+// extractArchive installs the content of the archive of the artifact with the given ref and type in destDir, returning
+// the installed files, including the modified ones kept in place, and their digests. With --validate, rulesfiles are
+// validated once extracted, and nothing is installed if they do not pass.
+func (o *Installer) extractArchive(ctx context.Context, ref string, artifactType oci.ArtifactType, archive, destDir string) (
+	files []string, digests map[string]string, err error) {
+	f, err := os.Open(archive)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+	// Fail before writing anything if the files of the artifact would not fit in the destination.
+	if err := o.checkAvailableSpace(ref, f, destDir); err != nil {
+		return nil, nil, err
+	}
+
+	return o.extractStream(ctx, ref, artifactType, f, archive, destDir)
+}
+
+// extractStream installs the content of the archive read from r, named archive in the errors, as extractArchive does,
+// without being able to check the space left for it beforehand.
+func (o *Installer) extractStream(ctx context.Context, ref string, artifactType oci.ArtifactType, r io.Reader,
+	archive, destDir string) (files []string, digests map[string]string, err error) {
+	logger := o.Printer.Logger
+
+	// Extract the artifact in a staging directory and move its content to the destination directory only once the
+	// whole archive has been extracted, so that a failure does not leave a half written artifact for Falco to load.
+	// The staging directory lives in the destination one, hence the content is usually just renamed, and the move is
+	// rolled back if it fails midway.
+	stagingDir, err := os.MkdirTemp(destDir, ".falcoctl-staging-")
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot create staging directory in %q: %w", destDir, err)
+	}
+	defer os.RemoveAll(stagingDir)
+
+	stagedDigests := make(map[string]string)
+	staged, err := utils.ExtractTarGz(ctx, r, stagingDir, o.StripComponents, utils.WithMaxSize(int64(o.MaxSize)),
+		utils.WithExclude(o.Exclude, func(name string) {
+			logger.Debug("Skipping excluded file", logger.Args("ref", ref, "file", name))
+		}),
+		utils.WithWritten(func(path, digest string) {
+			stagedDigests[path] = digest
+		}))
+	if err != nil {
+		return nil, nil, maxSizeHint(fmt.Errorf("cannot extract %q to %q: %w", archive, destDir, err))
+	}
+
+	staged, kept, err := o.keepModifiedFiles(stagingDir, destDir, staged)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if o.Validate && artifactType == oci.Rulesfile {
+		if err := o.validateRules(ctx, ref, staged); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	files, err = utils.MoveTree(stagingDir, destDir, staged)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot move %q content to %q: %w", archive, destDir, err)
+	}
+
+	// The digests computed while extracting are recorded, and logged for auditing, under the final paths, which
+	// MoveTree returns in the same order as the staged ones.
+	digests = make(map[string]string)
+	for i, path := range files {
+		digest, ok := stagedDigests[staged[i]]
+		if !ok {
+			continue
+		}
+		digests[path] = digest
+		logger.Debug("Installed file", logger.Args("ref", ref, "file", path, "digest", digest))
+	}
+	// The modified files are still owned by the artifact, and keep the digest they had when last installed.
+	for _, path := range kept {
+		files = append(files, path)
+		digests[path] = o.installed.FileDigest(path)
+	}
+
+	return files, digests, nil
+}
+
+// maxSizeHint points to --max-size when err is due to an artifact exceeding it, either while being downloaded or
+// while being extracted.
+func maxSizeHint(err error) error {
+	if errors.Is(err, ocipuller.ErrMaxDownloadSize) || errors.Is(err, utils.ErrMaxExtractedSize) {
+		return fmt.Errorf("%w, the limit can be raised with --%s", err, FlagMaxSize)
+	}
+	return err
+}
+
+// localLayout returns the path of the local OCI layout to install from, if any.
+func (o *Installer) localLayout() string {
+	if o.FromDir != "" {
+		return o.FromDir
+	}
+	return o.FromTar
+}
+
+// resolveVersionConstraint returns the name, or the reference, of the artifact tagged with the highest version
+// satisfying the constraint, among the tags of its repository.
+func (o *Installer) resolveVersionConstraint(ctx context.Context, puller *ocipuller.Puller,
+	name, constraint string) (string, error) {
+	logger := o.Printer.Logger
+
+	ref, err := o.IndexCache.ResolveReference(name)
+	if err != nil {
+		return "", err
+	}
+
+	opCtx, cancel := o.OperationContext(ctx)
+	defer cancel()
+
+	tags, err := puller.Tags(opCtx, ref)
+	if err != nil {
+		return "", fmt.Errorf("unable to list tags of %q: %w", ref, err)
+	}
+
+	tag, err := utils.HighestMatchingVersion(tags, constraint)
+	if err != nil {
+		return "", fmt.Errorf("cannot resolve %q: %w", name+"@"+constraint, err)
+	}
+	logger.Info("Version constraint resolved", logger.Args("name", name, "constraint", constraint, "tag", tag))
+
+	parsedRef, err := registry.ParseReference(name)
+	if err != nil {
+		// Not a full reference, hence the name of an artifact in the indexes.
+		return name + ":" + tag, nil
+	}
+	parsedRef.Reference = tag
+
+	return parsedRef.String(), nil
+}
+
+// printDepsTree prints the tree of the resolved dependencies, with a root for each requested artifact.
+func (o *Installer) printDepsTree(tree []*DepNode) error {
+	if o.Printer.Quiet {
+		return nil
+	}
+
+	var list pterm.LeveledList
+	var walk func(n *DepNode, level int)
+	walk = func(n *DepNode, level int) {
+		text := fmt.Sprintf("%s (%s)", n.Name, n.Ref)
+		if n.Cycle {
+			text += " [cycle]"
+		}
+		list = append(list, pterm.LeveledListItem{Level: level, Text: text})
+		for _, dep := range n.Dependencies {
+			walk(dep, level+1)
+		}
+	}
+	for _, root := range tree {
+		walk(root, 0)
+	}
+
+	rendered, err := pterm.DefaultTree.WithRoot(putils.TreeFromLeveledList(list)).Srender()
+	if err != nil {
+		return fmt.Errorf("unable to render dependency tree: %w", err)
+	}
+	o.Printer.DefaultText.Print(rendered)
+
+	return nil
+}
+
+// plan returns what would be installed for each reference and source, without pulling or writing anything.
+// Digest and type are resolved on a best-effort basis: unreachable artifacts are still listed, leaving out the
+// values that could not be resolved.
+func (o *Installer) plan(ctx context.Context, puller *ocipuller.Puller, refs, sources []string) ([]*Result, error) {
+	logger := o.Printer.Logger
+
+	var results []*Result
+	for _, ref := range refs {
+		// The name is only used to look up the destination directories mapping.
+		name, _ := utils.NameFromRef(ref)
+		res := &Result{Name: name, Ref: ref, Status: StatusPlanned}
+		opCtx, cancel := o.OperationContext(ctx)
+
+		if desc, err := puller.Descriptor(opCtx, ref); err != nil {
+			logger.Warn("Unable to resolve digest", logger.Args("ref", ref, "reason", err.Error()))
+		} else {
+			res.Digest = desc.Digest.String()
+		}
+
+		platformOS, platformArch := o.queryPlatform()
+		if t, err := puller.ArtifactType(opCtx, ref, platformOS, platformArch); err != nil {
+			logger.Warn("Unable to resolve artifact type", logger.Args("ref", ref, "reason", err.Error()))
+		} else if dir, err := o.destDir(name, t); err == nil {
+			res.Type, res.DestDir = t.String(), dir
+			if o.VersionedLayout && t == oci.Rulesfile && res.Digest != "" {
+				version := o.rulesfileVersion(opCtx, puller, ref, res.Digest, platformOS, platformArch)
+				res.DestDir = filepath.Join(dir, name, version)
+			}
+		}
+		cancel()
+
+		results = append(results, res)
+	}
+
+	// Sources are typed by --source-type, and only the digests of local archives are known without downloading.
+	for _, source := range sources {
+		name, err := sourceName(source)
+		if err != nil {
+			return nil, err
+		}
+		res := &Result{Name: name, Ref: source, Type: o.SourceType.String(), Status: StatusPlanned}
+		if !isURL(source) {
+			if d, err := utils.FileDigest(source); err != nil {
+				logger.Warn("Unable to compute digest", logger.Args("source", source, "reason", err.Error()))
+			} else {
+				res.Digest = d
+			}
+		}
+		if dir, err := o.destDir(name, o.SourceType); err == nil {
+			res.DestDir = dir
+		}
+		results = append(results, res)
+	}
+
+	return results, nil
+}
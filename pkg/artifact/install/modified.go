@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package install
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/falcosecurity/falcoctl/internal/utils"
+)
+
+// NewFileExt is appended to the name of the files that are not installed in place of a locally modified one.
+const NewFileExt = ".new"
+
+// keepModifiedFiles looks for the staged files whose destination has been modified since it was last installed,
+// that is whose digest differs from the one recorded in the lockfile. Files without a recorded digest are not
+// considered modified. Unless --force is given, the staged files are renamed with the NewFileExt extension, so that
+// they are installed next to the modified ones instead of replacing them. It returns the staged paths to move and
+// the destination paths of the kept files.
+func (o *Installer) keepModifiedFiles(stagingDir, destDir string, staged []string) (paths, kept []string, err error) {
+	logger := o.Printer.Logger
+	if o.installed == nil {
+		return staged, kept, nil
+	}
+
+	if destDir, err = filepath.Abs(destDir); err != nil {
+		return nil, nil, err
+	}
+
+	paths = make([]string, 0, len(staged))
+	for _, path := range staged {
+		rel, err := filepath.Rel(stagingDir, path)
+		if err != nil {
+			return nil, nil, err
+		}
+		dst := filepath.Join(destDir, rel)
+
+		modified, err := o.isModified(path, dst)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !modified {
+			paths = append(paths, path)
+			continue
+		}
+		if o.Force {
+			logger.Warn("Overwriting locally modified file", logger.Args("file", dst))
+			paths = append(paths, path)
+			continue
+		}
+
+		if err := os.Rename(path, path+NewFileExt); err != nil {
+			return nil, nil, err
+		}
+		logger.Warn(fmt.Sprintf("Keeping locally modified file, use --%s to overwrite it", FlagForce),
+			logger.Args("file", dst, "new", dst+NewFileExt))
+		paths = append(paths, path+NewFileExt)
+		kept = append(kept, dst)
+	}
+
+	return paths, kept, nil
+}
+
+// isModified reports whether dst, about to be replaced by the staged regular file at path, differs from the file
+// last installed there.
+func (o *Installer) isModified(path, dst string) (bool, error) {
+	recorded := o.installed.FileDigest(dst)
+	if recorded == "" {
+		return false, nil
+	}
+
+	if info, err := os.Lstat(path); err != nil || !info.Mode().IsRegular() {
+		return false, err
+	}
+	info, err := os.Lstat(dst)
+	if os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	if !info.Mode().IsRegular() {
+		return false, nil
+	}
+
+	current, err := utils.FileDigest(dst)
+	if err != nil {
+		return false, err
+	}
+
+	return current != recorded, nil
+}
@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package install
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/falcosecurity/falcoctl/internal/config"
+	"github.com/falcosecurity/falcoctl/internal/utils"
+)
+
+// DefaultLockTimeout is the default time waited for the other installs running on the node to complete.
+const DefaultLockTimeout = 5 * time.Minute
+
+// lockInstall locks config.InstallLockFile, so that the installs running at the same time on the node, e.g. from
+// several systemd units, are serialized instead of writing the same directories. Installs are not serialized on
+// the platforms not supporting file locks. The returned function releases the lock.
+func (o *Installer) lockInstall(ctx context.Context) (func() error, error) {
+	logger := o.Printer.Logger
+
+	unlock, err := utils.LockFile(ctx, config.InstallLockFile, 0)
+	if errors.Is(err, utils.ErrLocked) && o.LockTimeout > 0 {
+		logger.Info("Waiting for another install running on the node to complete", logger.Args("lock", config.InstallLockFile,
+			"timeout", o.LockTimeout))
+		unlock, err = utils.LockFile(ctx, config.InstallLockFile, o.LockTimeout)
+	}
+
+	switch {
+	case errors.Is(err, errors.ErrUnsupported):
+		logger.Debug("Installs running at the same time are not serialized on this platform")
+		return func() error { return nil }, nil
+	case errors.Is(err, utils.ErrLocked):
+		return nil, fmt.Errorf("another install is running on the node and holds the lock %q: it did not complete within the --%s of %s",
+			config.InstallLockFile, FlagLockTimeout, o.LockTimeout)
+	case err != nil:
+		return nil, err
+	}
+	return unlock, nil
+}
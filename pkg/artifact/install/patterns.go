@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package install
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/falcosecurity/falcoctl/pkg/index/index"
+)
+
+// patternWildcards are the characters that make an artifact argument a pattern.
+const patternWildcards = "*?"
+
+// isPattern returns true if the artifact argument contains wildcards.
+func isPattern(arg string) bool {
+	return strings.ContainsAny(arg, patternWildcards)
+}
+
+// expandArgs replaces every pattern among the artifact arguments with the names of the matching index entries,
+// printing the expanded list. A pattern that matches nothing is an error. The artifacts matched more than once are
+// deduplicated along with the other references, see dedupRefs.
+func (o *Installer) expandArgs(args []string) ([]string, error) {
+	logger := o.Printer.Logger
+	var expanded []string
+	for _, arg := range args {
+		names := []string{arg}
+		if isPattern(arg) {
+			var err error
+			if names, err = expandPattern(o.IndexCache.MergedIndexes.Entries, arg); err != nil {
+				return nil, err
+			}
+			logger.Info("Expanded pattern", logger.Args("pattern", arg, "artifacts", strings.Join(names, ", ")))
+		}
+		expanded = append(expanded, names...)
+	}
+	return expanded, nil
+}
+
+// expandPattern returns the sorted names of the index entries matching the pattern. The '*' wildcard matches
+// any sequence of characters, '/' included, and '?' any single character. The pattern is matched against
+// the entry name, its repository and its registry/repository reference, and may end with the same ":tag"
+// or "@constraint" suffix as an artifact name, which is kept on every expanded name.
+func expandPattern(entries []*index.Entry, pattern string) ([]string, error) {
+	glob, suffix := splitPatternSuffix(pattern)
+	re := globRegexp(glob)
+
+	var names []string
+	for _, entry := range entries {
+		if re.MatchString(entry.Name) || re.MatchString(entry.Repository) ||
+			re.MatchString(entry.Registry+"/"+entry.Repository) {
+			names = append(names, entry.Name+suffix)
+		}
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("pattern %q does not match any artifact in the configured indexes", pattern)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// splitPatternSuffix splits a pattern into the glob and its ":tag" or "@constraint" suffix, looked for
+// in the last path element only so that a registry port is not taken for a tag.
+func splitPatternSuffix(pattern string) (glob, suffix string) {
+	last := strings.LastIndex(pattern, "/") + 1
+	if i := strings.IndexAny(pattern[last:], ":@"); i >= 0 {
+		return pattern[:last+i], pattern[last+i:]
+	}
+	return pattern, ""
+}
+
+// globRegexp compiles the glob into a regular expression matching whole strings.
+func globRegexp(glob string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("^")
+	for _, r := range glob {
+		switch r {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package install
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/falcosecurity/falcoctl/pkg/index/index"
+)
+
+func TestExpandPattern(t *testing.T) {
+	entries := []*index.Entry{
+		{Name: "cloudtrail", Registry: "ghcr.io", Repository: "myorg/plugins/cloudtrail"},
+		{Name: "cloudtrail-rules", Registry: "ghcr.io", Repository: "myorg/plugins/ruleset/cloudtrail"},
+		{Name: "k8saudit", Registry: "ghcr.io", Repository: "otherorg/plugins/k8saudit"},
+	}
+
+	tests := []struct {
+		pattern string
+		want    []string
+	}{
+		{"myorg/*", []string{"cloudtrail", "cloudtrail-rules"}},
+		{"ghcr.io/otherorg/*", []string{"k8saudit"}},
+		{"cloudtrail*", []string{"cloudtrail", "cloudtrail-rules"}},
+		{"k8s?udit:0.1.0", []string{"k8saudit:0.1.0"}},
+		{"*-rules@^1.0.0", []string{"cloudtrail-rules@^1.0.0"}},
+	}
+	for _, tt := range tests {
+		got, err := expandPattern(entries, tt.pattern)
+		if err != nil {
+			t.Fatalf("pattern %q: unexpected error: %v", tt.pattern, err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("pattern %q: expected %v, got %v", tt.pattern, tt.want, got)
+		}
+	}
+
+	if _, err := expandPattern(entries, "nomatch/*"); err == nil {
+		t.Error("expected an error for a pattern matching nothing")
+	}
+}
+
+func TestSplitPatternSuffix(t *testing.T) {
+	tests := []struct {
+		pattern, glob, suffix string
+	}{
+		{"myorg/*", "myorg/*", ""},
+		{"localhost:5000/myorg/*", "localhost:5000/myorg/*", ""},
+		{"localhost:5000/myorg/*:latest", "localhost:5000/myorg/*", ":latest"},
+		{"*@>=1.0.0", "*", "@>=1.0.0"},
+	}
+	for _, tt := range tests {
+		glob, suffix := splitPatternSuffix(tt.pattern)
+		if glob != tt.glob || suffix != tt.suffix {
+			t.Errorf("pattern %q: expected (%q, %q), got (%q, %q)", tt.pattern, tt.glob, tt.suffix, glob, suffix)
+		}
+	}
+}
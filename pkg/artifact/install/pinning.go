@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package install
+
+import (
+	"fmt"
+	"strings"
+
+	"oras.land/oras-go/v2/registry"
+)
+
+// isDigestRef reports whether the reference pins the artifact to a digest, e.g.
+// "ghcr.io/falcosecurity/plugins/plugin/cloudtrail@sha256:123abc...", hence is immutable.
+func isDigestRef(ref string) bool {
+	parsedRef, err := registry.ParseReference(ref)
+	if err != nil {
+		return false
+	}
+	return parsedRef.ValidateReferenceAsDigest() == nil
+}
+
+// checkPinnedRefs fails, when digest pinned references are required, if any of the references to install can change
+// over time, as it is the case for tags. The dependencies are checked as well, since they are referenced by tag.
+func (o *Installer) checkPinnedRefs(refs []string) error {
+	if !o.RequireDigest {
+		return nil
+	}
+
+	var unpinned []string
+	for _, ref := range refs {
+		// Sources are only pinned when installed from a lockfile, since their digests are verified.
+		if _, locked := o.lockedSourceDigests[ref]; !isDigestRef(ref) && !locked {
+			unpinned = append(unpinned, ref)
+		}
+	}
+	if len(unpinned) > 0 {
+		return fmt.Errorf("--%s is set, but the following references are not pinned to a digest: %s",
+			FlagRequireDigest, strings.Join(unpinned, ", "))
+	}
+
+	return nil
+}
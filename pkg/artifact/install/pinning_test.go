@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package install
+
+import "testing"
+
+func TestIsDigestRef(t *testing.T) {
+	digest := "sha256:1d2643f0f6c1d06d1e4ef9c1358a629d5c2dc43fb2a8a6f0d507e44cd40d2578"
+	tests := []struct {
+		ref  string
+		want bool
+	}{
+		{"ghcr.io/falcosecurity/plugins/plugin/cloudtrail@" + digest, true},
+		{"localhost:5000/cloudtrail@" + digest, true},
+		{"ghcr.io/falcosecurity/plugins/plugin/cloudtrail:latest", false},
+		{"ghcr.io/falcosecurity/plugins/plugin/cloudtrail", false},
+		{"cloudtrail@sha256:notadigest", false},
+		{"cloudtrail", false},
+	}
+	for _, tt := range tests {
+		if got := isDigestRef(tt.ref); got != tt.want {
+			t.Errorf("%q: expected %v, got %v", tt.ref, tt.want, got)
+		}
+	}
+}
+
+func TestCheckPinnedRefs(t *testing.T) {
+	pinned := "ghcr.io/falcosecurity/plugins/plugin/cloudtrail@sha256:1d2643f0f6c1d06d1e4ef9c1358a629d5c2dc43fb2a8a6f0d507e44cd40d2578"
+	tagged := "ghcr.io/falcosecurity/plugins/plugin/json:latest"
+
+	o := &Installer{Options: &Options{}}
+	if err := o.checkPinnedRefs([]string{pinned, tagged}); err != nil {
+		t.Fatalf("unexpected error when digests are not required: %v", err)
+	}
+
+	o.RequireDigest = true
+	if err := o.checkPinnedRefs([]string{pinned}); err != nil {
+		t.Fatalf("unexpected error for a pinned reference: %v", err)
+	}
+	err := o.checkPinnedRefs([]string{pinned, tagged})
+	if err == nil {
+		t.Fatal("expected an error for a tagged reference")
+	}
+	want := "--require-digest is set, but the following references are not pinned to a digest: " + tagged
+	if err.Error() != want {
+		t.Errorf("expected error %q, got %q", want, err.Error())
+	}
+}
@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package install
+
+import (
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/falcosecurity/falcoctl/pkg/lockfile"
+	"github.com/falcosecurity/falcoctl/pkg/oci"
+)
+
+const (
+	// PlanActionInstall is the action of the artifacts that would be installed.
+	PlanActionInstall = "install"
+	// PlanActionSkip is the action of the artifacts that would be skipped since their type is not allowed.
+	PlanActionSkip = "skip"
+	// PlanActionUpToDate is the action of the artifacts that would be skipped with --only-newer since already installed.
+	PlanActionUpToDate = "up-to-date"
+)
+
+// PlanEntry is an artifact of the plan printed with --print-plan. All the fields are always present, empty when
+// they could not be resolved, so that the plans of different runs can be diffed.
+type PlanEntry struct {
+	Name    string `json:"name"`
+	Ref     string `json:"ref"`
+	Digest  string `json:"digest"`
+	Type    string `json:"type"`
+	DestDir string `json:"destDir"`
+	Action  string `json:"action"`
+}
+
+// PlanEntries returns the plan entries of the planned results, telling which of them would be installed.
+func (o *Installer) PlanEntries(results []*Result) ([]PlanEntry, error) {
+	if o.OnlyNewer && !o.Force {
+		// The lockfile is only read, to tell the artifacts already up to date apart.
+		installed, err := lockfile.New(o.LockFile)
+		if err != nil {
+			return nil, err
+		}
+		o.installed = installed
+	}
+
+	entries := make([]PlanEntry, 0, len(results))
+	for _, res := range results {
+		entry := PlanEntry{Name: res.Name, Ref: res.Ref, Digest: res.Digest, Type: res.Type, DestDir: res.DestDir, Action: PlanActionInstall}
+		switch {
+		case res.Type != "" && !o.isAllowedType(oci.ArtifactType(res.Type)):
+			entry.Action = PlanActionSkip
+		case res.Digest != "" && o.upToDate(res.Name, res.Digest, o.planPlatforms(res)):
+			entry.Action = PlanActionUpToDate
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// planPlatforms returns the platforms the planned artifact would be installed for, as recorded in the lockfile,
+// or an empty string when they are not known without pulling it, as with --all-platforms, or not relevant.
+func (o *Installer) planPlatforms(res *Result) string {
+	if o.AllPlatforms || IsSource(res.Ref) {
+		return ""
+	}
+	return platformString(v1.Platform{OS: o.os, Architecture: o.arch})
+}
@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package install
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/falcosecurity/falcoctl/pkg/lockfile"
+	"github.com/falcosecurity/falcoctl/pkg/oci"
+	"github.com/falcosecurity/falcoctl/pkg/options"
+)
+
+func TestPlanEntries(t *testing.T) {
+	dir := t.TempDir()
+	rulesFile := filepath.Join(dir, "k8saudit_rules.yaml")
+	if err := os.WriteFile(rulesFile, []byte("- rule: test\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	lockFile := filepath.Join(dir, "falcoctl.lock.yaml")
+	lock := &lockfile.Lockfile{Artifacts: []*lockfile.Entry{{
+		Name:     "k8saudit-rules",
+		Digest:   "sha256:recorded",
+		Type:     oci.Rulesfile.String(),
+		Platform: "linux/amd64",
+		Files:    []string{rulesFile},
+	}}}
+	if err := lock.Write(lockFile); err != nil {
+		t.Fatal(err)
+	}
+
+	results := []*Result{
+		{Name: "k8saudit-rules", Ref: "ghcr.io/falcosecurity/rules/k8saudit-rules:0.1.0", Digest: "sha256:recorded",
+			Type: oci.Rulesfile.String(), DestDir: dir, Status: StatusPlanned},
+		{Name: "cloudtrail", Ref: "ghcr.io/falcosecurity/plugins/cloudtrail:0.1.0", Digest: "sha256:cloudtrail",
+			Type: oci.Plugin.String(), DestDir: dir, Status: StatusPlanned},
+		{Name: "unreachable", Ref: "example.com/unreachable:0.1.0", Status: StatusPlanned},
+	}
+
+	tests := []struct {
+		name      string
+		onlyNewer bool
+		allowed   []oci.ArtifactType
+		want      []string
+	}{
+		{name: "all installed", want: []string{PlanActionInstall, PlanActionInstall, PlanActionInstall}},
+		{name: "only newer", onlyNewer: true, want: []string{PlanActionUpToDate, PlanActionInstall, PlanActionInstall}},
+		{name: "disallowed type", allowed: []oci.ArtifactType{oci.Rulesfile}, want: []string{PlanActionInstall, PlanActionSkip, PlanActionInstall}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &Installer{
+				Options: &Options{
+					Directory:    &options.Directory{RulesfilesDir: dir},
+					OnlyNewer:    tt.onlyNewer,
+					LockFile:     lockFile,
+					AllowedTypes: oci.ArtifactTypeSlice{Types: tt.allowed},
+				},
+				os:   "linux",
+				arch: "amd64",
+			}
+			entries, err := o.PlanEntries(results)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var got []string
+			for _, e := range entries {
+				got = append(got, e.Action)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected actions %v, got %v", tt.want, got)
+			}
+			if entries[2].Digest != "" || entries[2].Ref != results[2].Ref {
+				t.Errorf("expected the unresolved values to be left empty, got %+v", entries[2])
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package install
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/falcosecurity/falcoctl/pkg/lockfile"
+	ocipuller "github.com/falcosecurity/falcoctl/pkg/oci/puller"
+)
+
+// installJob is the installation of an artifact for one platform.
+type installJob struct {
+	ref      string
+	platform v1.Platform
+	// source is set when ref is a source, see IsSource, which is installed as is whatever the platform.
+	source bool
+}
+
+// installJobs returns the jobs installing the given references: one for the requested platform each, or, with
+// --all-platforms, one for each platform of their image index. Single-platform artifacts cannot be installed for
+// all the platforms, hence they are an error.
+func (o *Installer) installJobs(ctx context.Context, puller *ocipuller.Puller, refs []string) ([]installJob, error) {
+	jobs := make([]installJob, 0, len(refs))
+	for _, ref := range refs {
+		if !o.AllPlatforms {
+			jobs = append(jobs, installJob{ref: ref, platform: v1.Platform{OS: o.os, Architecture: o.arch}})
+			continue
+		}
+
+		resolved, err := o.IndexCache.ResolveReference(ref)
+		if err != nil {
+			return nil, err
+		}
+		opCtx, cancel := o.OperationContext(ctx)
+		platforms, err := puller.Platforms(opCtx, resolved)
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("unable to list the platforms of %q: %w", resolved, err)
+		}
+		if len(platforms) == 0 {
+			return nil, fmt.Errorf("cannot install %q for all the platforms with --%s: it is a single-platform artifact, "+
+				"without an image index listing its platforms", resolved, FlagAllPlatforms)
+		}
+		for _, platform := range platforms {
+			jobs = append(jobs, installJob{ref: ref, platform: platform})
+		}
+	}
+
+	return jobs, nil
+}
+
+// queryPlatform returns the platform used to read what the platforms of an artifact have in common, e.g. its type,
+// before pulling it. With --all-platforms it is empty, so that the first platform of the image index is used.
+func (o *Installer) queryPlatform() (os, arch string) {
+	if o.AllPlatforms {
+		return "", ""
+	}
+	return o.os, o.arch
+}
+
+// platformString returns the platform in the OS/ARCH format of the --platform flag.
+func platformString(platform v1.Platform) string {
+	return platform.OS + "/" + platform.Architecture
+}
+
+// platformDir returns the name of the subdirectory where an artifact is installed for the given platform with
+// --all-platforms, e.g. "linux-amd64".
+func platformDir(platform v1.Platform) string {
+	return platform.OS + "-" + platform.Architecture
+}
+
+// mergePlatformEntries merges the lockfile entries of the artifacts installed for several platforms, so that each
+// artifact gets a single entry recording the files of all its platforms and the comma separated list of them.
+func mergePlatformEntries(entries []*lockfile.Entry) []*lockfile.Entry {
+	var merged []*lockfile.Entry
+	byName := make(map[string]*lockfile.Entry)
+	for _, entry := range entries {
+		m, ok := byName[entry.Name]
+		if !ok {
+			byName[entry.Name] = entry
+			merged = append(merged, entry)
+			continue
+		}
+		platforms := append(strings.Split(m.Platform, ","), entry.Platform)
+		sort.Strings(platforms)
+		m.Platform = strings.Join(platforms, ",")
+		m.Files = append(m.Files, entry.Files...)
+		sort.Strings(m.Files)
+		for path, digest := range entry.Digests {
+			m.Digests[path] = digest
+		}
+	}
+	return merged
+}
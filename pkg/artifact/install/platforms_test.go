@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package install
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/falcosecurity/falcoctl/pkg/lockfile"
+)
+
+func TestPlatformDir(t *testing.T) {
+	platform := v1.Platform{OS: "linux", Architecture: "arm64"}
+	if got := platformDir(platform); got != "linux-arm64" {
+		t.Errorf("expected directory %q, got %q", "linux-arm64", got)
+	}
+	if got := platformString(platform); got != "linux/arm64" {
+		t.Errorf("expected platform %q, got %q", "linux/arm64", got)
+	}
+}
+
+func TestMergePlatformEntries(t *testing.T) {
+	entries := []*lockfile.Entry{
+		{Name: "cloudtrail", Platform: "linux/arm64", Files: []string{"/plugins/linux-arm64/libcloudtrail.so"},
+			Digests: map[string]string{"/plugins/linux-arm64/libcloudtrail.so": "sha256:arm"}},
+		{Name: "k8saudit-rules", Platform: "linux/amd64", Files: []string{"/rules/k8saudit_rules.yaml"},
+			Digests: map[string]string{"/rules/k8saudit_rules.yaml": "sha256:rules"}},
+		{Name: "cloudtrail", Platform: "linux/amd64", Files: []string{"/plugins/linux-amd64/libcloudtrail.so"},
+			Digests: map[string]string{"/plugins/linux-amd64/libcloudtrail.so": "sha256:amd"}},
+	}
+
+	merged := mergePlatformEntries(entries)
+	if len(merged) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(merged))
+	}
+
+	cloudtrail := merged[0]
+	if cloudtrail.Name != "cloudtrail" || cloudtrail.Platform != "linux/amd64,linux/arm64" {
+		t.Errorf("unexpected merged entry %q for platforms %q", cloudtrail.Name, cloudtrail.Platform)
+	}
+	wantFiles := []string{"/plugins/linux-amd64/libcloudtrail.so", "/plugins/linux-arm64/libcloudtrail.so"}
+	if !reflect.DeepEqual(cloudtrail.Files, wantFiles) {
+		t.Errorf("expected files %v, got %v", wantFiles, cloudtrail.Files)
+	}
+	wantDigests := map[string]string{
+		"/plugins/linux-amd64/libcloudtrail.so": "sha256:amd",
+		"/plugins/linux-arm64/libcloudtrail.so": "sha256:arm",
+	}
+	if !reflect.DeepEqual(cloudtrail.Digests, wantDigests) {
+		t.Errorf("expected digests %v, got %v", wantDigests, cloudtrail.Digests)
+	}
+
+	if merged[1] != entries[1] {
+		t.Errorf("expected the single platform entry to be kept as is")
+	}
+}
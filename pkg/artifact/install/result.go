@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package install
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/falcosecurity/falcoctl/internal/telemetry"
+)
+
+const (
+	// StatusInstalled is the status of the artifacts successfully installed.
+	StatusInstalled = "installed"
+	// StatusSkipped is the status of the artifacts skipped since their type is not allowed or they are not selected.
+	StatusSkipped = "skipped"
+	// StatusFailed is the status of the artifacts that could not be installed.
+	StatusFailed = "failed"
+	// StatusPlanned is the status of the artifacts that would be installed in dry-run mode.
+	StatusPlanned = "planned"
+	// StatusUpToDate is the status of the artifacts skipped with --only-newer since already installed.
+	StatusUpToDate = "up-to-date"
+)
+
+// Result is the outcome of the installation of an artifact, as returned by Artifacts and printed by the install
+// command when the output format is JSON or YAML. Fields are filled in as the installation goes on, hence failed
+// artifacts report what was known when failing.
+type Result struct {
+	Name    string `json:"name"`
+	Ref     string `json:"ref"`
+	Digest  string `json:"digest,omitempty"`
+	Type    string `json:"type,omitempty"`
+	DestDir string `json:"destDir,omitempty"`
+	// Platform is only set with --all-platforms, since the artifact is installed once for each platform.
+	Platform string `json:"platform,omitempty"`
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+}
+
+// countResults counts the installed and failed artifacts in the telemetry metrics.
+func countResults(ctx context.Context, results []*Result) {
+	for _, res := range results {
+		attrs := []attribute.KeyValue{attribute.String("name", res.Name), attribute.String("type", res.Type)}
+		switch res.Status {
+		case StatusInstalled:
+			telemetry.Count(ctx, "falcoctl.artifact.installs", "Number of artifacts installed", attrs...)
+		case StatusFailed:
+			telemetry.Count(ctx, "falcoctl.artifact.install.failures", "Number of artifacts that could not be installed", attrs...)
+		}
+	}
+}
+
+// printInstallSummary logs how many artifacts have been installed, skipped and failed.
+func (o *Installer) printInstallSummary(results []*Result) {
+	logger := o.Printer.Logger
+
+	var installed, skipped, upToDate, failed int
+	for _, res := range results {
+		switch res.Status {
+		case StatusInstalled:
+			installed++
+		case StatusSkipped:
+			skipped++
+		case StatusUpToDate:
+			upToDate++
+		case StatusFailed:
+			failed++
+		}
+	}
+
+	logger.Info("Install completed", logger.Args("installed", installed, "skipped", skipped, "up-to-date", upToDate, "failed", failed))
+}
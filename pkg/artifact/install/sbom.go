@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package install
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/falcosecurity/falcoctl/internal/utils"
+	"github.com/falcosecurity/falcoctl/pkg/oci"
+	ocipuller "github.com/falcosecurity/falcoctl/pkg/oci/puller"
+)
+
+// fetchSBOM retrieves, when --save-sbom is set, the SBOM attached to the pulled artifact in the repository that
+// served it. The SBOMs are saved for compliance only, hence failing to retrieve one, or finding none, is not an error.
+func (o *Installer) fetchSBOM(ctx context.Context, puller *ocipuller.Puller, ref string,
+	result *oci.RegistryResult) (*ocipuller.SBOM, error) {
+	if o.SaveSBOM == "" {
+		return nil, nil
+	}
+	logger := o.Printer.Logger
+
+	repo, err := utils.RepositoryFromRef(result.Ref)
+	if err != nil {
+		return nil, err
+	}
+	digestRef := fmt.Sprintf("%s@%s", repo, result.RootDigest)
+
+	sbom, err := puller.SBOM(ctx, digestRef)
+	if err != nil {
+		logger.Warn("Unable to fetch SBOM", logger.Args("ref", ref, "reason", err.Error()))
+		return nil, nil
+	}
+	if sbom == nil {
+		logger.Warn("No SBOM attached to artifact, nothing saved", logger.Args("ref", ref, "digest", digestRef))
+		return nil, nil
+	}
+
+	return sbom, nil
+}
+
+// writeSBOM writes the SBOM of the named artifact in the --save-sbom directory, named after the artifact and with
+// the extension of its format, and returns the path of the file. Nothing is written when there is no SBOM.
+func (o *Installer) writeSBOM(name string, sbom *ocipuller.SBOM) (string, error) {
+	if sbom == nil {
+		return "", nil
+	}
+
+	path := filepath.Join(o.SaveSBOM, name+sbom.Extension())
+	if err := writeFileAtomically(path, sbom.Content); err != nil {
+		return "", fmt.Errorf("unable to write SBOM %q: %w", path, err)
+	}
+
+	o.Printer.Logger.Debug("SBOM saved", o.Printer.Logger.Args("name", name, "type", sbom.ArtifactType, "path", path))
+	return path, nil
+}
@@ -0,0 +1,230 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package install
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/falcosecurity/falcoctl/internal/consts"
+	"github.com/falcosecurity/falcoctl/internal/utils"
+	"github.com/falcosecurity/falcoctl/pkg/lockfile"
+	ocipuller "github.com/falcosecurity/falcoctl/pkg/oci/puller"
+)
+
+// archiveExtensions are the extensions stripped from the file name of a source to get the artifact name.
+var archiveExtensions = []string{".tar.gz", ".tgz", ".tar.zst", ".tar"}
+
+// IsSource reports whether the argument is a source, i.e. an HTTP(S) URL or a local path of an archive, rather
+// than the reference or the name of an artifact. Local paths must be explicit, i.e. absolute or starting with "./"
+// or "../", or be an existing file with an archive extension.
+func IsSource(arg string) bool {
+	switch {
+	case strings.HasPrefix(arg, "http://"), strings.HasPrefix(arg, "https://"):
+		return true
+	case filepath.IsAbs(arg), strings.HasPrefix(arg, "./"), strings.HasPrefix(arg, "../"):
+		return true
+	}
+
+	for _, ext := range archiveExtensions {
+		if strings.HasSuffix(arg, ext) {
+			info, err := os.Stat(arg)
+			return err == nil && info.Mode().IsRegular()
+		}
+	}
+	return false
+}
+
+// splitSources splits the arguments into the references of artifacts and the sources, see IsSource. Local paths are
+// made absolute, so that the sources recorded in the lockfile can be installed again from any directory.
+func splitSources(args []string) (refs, sources []string, err error) {
+	for _, arg := range args {
+		if !IsSource(arg) {
+			refs = append(refs, arg)
+			continue
+		}
+		if !isURL(arg) {
+			if arg, err = filepath.Abs(arg); err != nil {
+				return nil, nil, fmt.Errorf("cannot resolve path of source %q: %w", arg, err)
+			}
+		}
+		sources = append(sources, arg)
+	}
+	return refs, sources, nil
+}
+
+// isURL reports whether the source is an HTTP(S) URL.
+func isURL(source string) bool {
+	return strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://")
+}
+
+// sourceName returns the name of the artifact installed from the given source, i.e. the base name of its file
+// without the archive extension.
+func sourceName(source string) (string, error) {
+	name := filepath.Base(source)
+	if isURL(source) {
+		u, err := url.Parse(source)
+		if err != nil {
+			return "", fmt.Errorf("invalid source URL %q: %w", source, err)
+		}
+		name = path.Base(u.Path)
+	}
+
+	for _, ext := range archiveExtensions {
+		if strings.HasSuffix(name, ext) {
+			name = strings.TrimSuffix(name, ext)
+			break
+		}
+	}
+	if name == "" || name == "." || name == "/" {
+		return "", fmt.Errorf("cannot extract artifact name from source %q", source)
+	}
+	return name, nil
+}
+
+// fetchSource returns the path of the archive of the given source, downloading it in dir when it is a URL.
+func (o *Installer) fetchSource(ctx context.Context, source, dir string) (string, error) {
+	if !isURL(source) {
+		return source, nil
+	}
+
+	opCtx, cancel := o.OperationContext(ctx)
+	defer cancel()
+	req, err := http.NewRequestWithContext(opCtx, http.MethodGet, source, http.NoBody)
+	if err != nil {
+		return "", fmt.Errorf("cannot download %q: %w", source, err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("cannot download %q: %w", source, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("cannot download %q: unexpected status %s", source, resp.Status)
+	}
+
+	archive := filepath.Join(dir, "source")
+	f, err := os.Create(archive)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	body := io.Reader(resp.Body)
+	if o.MaxSize > 0 {
+		// Read one more byte than allowed, to tell an archive of exactly the maximum size from a bigger one.
+		body = io.LimitReader(resp.Body, int64(o.MaxSize)+1)
+	}
+	n, err := io.Copy(f, body)
+	if err != nil {
+		return "", fmt.Errorf("cannot download %q: %w", source, err)
+	}
+	if o.MaxSize > 0 && n > int64(o.MaxSize) {
+		return "", maxSizeHint(fmt.Errorf("cannot download %q: it exceeds the %w of %d bytes", source,
+			ocipuller.ErrMaxDownloadSize, int64(o.MaxSize)))
+	}
+	return archive, f.Close()
+}
+
+// installSource installs the archive of the given source, typed according to --source-type, going through the same
+// extraction as the artifacts pulled from the registries. The digest of the archive is recorded in the lockfile
+// and, when installing from a lockfile, must match the recorded one.
+func (o *Installer) installSource(ctx context.Context, source, tmpDir string, res *Result) (*lockfile.Entry, error) {
+	logger := o.Printer.Logger
+	start := time.Now()
+
+	name, err := sourceName(source)
+	if err != nil {
+		return nil, err
+	}
+	res.Name, res.Type = name, o.SourceType.String()
+
+	if o.RequireAttestation {
+		return nil, fmt.Errorf("cannot install %q: sources carry no provenance attestation, required by --%s",
+			source, FlagRequireAttestation)
+	}
+
+	if !o.isAllowedType(o.SourceType) {
+		err := fmt.Errorf("cannot install source of type %q: type not permitted", o.SourceType)
+		if !o.StrictTypes {
+			logger.Warn("Skipping artifact", logger.Args("source", source, "reason", err.Error()))
+			res.Error = err.Error()
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	sourceDir, err := os.MkdirTemp(tmpDir, "source-")
+	if err != nil {
+		return nil, fmt.Errorf("cannot create temporary directory: %w", err)
+	}
+
+	logger.Info("Preparing to install artifact from source", logger.Args("source", source))
+	archive, err := o.fetchSource(ctx, source, sourceDir)
+	if err != nil {
+		return nil, err
+	}
+
+	digest, err := utils.FileDigest(archive)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read source %q: %w", source, err)
+	}
+	res.Digest = digest
+	if locked, ok := o.lockedSourceDigests[source]; ok && locked != digest && !o.SkipDigestCheck {
+		return nil, fmt.Errorf("cannot verify integrity of source %q: digest %s does not match %s recorded in the lockfile",
+			source, digest, locked)
+	}
+	if o.OnlyNewer && !o.Force && o.upToDate(name, digest, "") {
+		logger.Info("Artifact up to date", logger.Args("name", name, "source", source, "digest", digest))
+		res.Status = StatusUpToDate
+		return nil, nil
+	}
+
+	destDir, err := o.destDir(name, o.SourceType)
+	if err != nil {
+		return nil, err
+	}
+	res.DestDir = destDir
+	if err := utils.ExistsAndIsWritable(destDir); err != nil {
+		return nil, fmt.Errorf("cannot use directory %q as install destination: %w", destDir, err)
+	}
+
+	logger.Info("Extracting and installing artifact", logger.Args("type", o.SourceType, "file", filepath.Base(source)))
+	files, digests, err := o.extractArchive(ctx, source, o.SourceType, archive, destDir)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("Artifact successfully installed", logger.Args("name", name, "source", source, "type", o.SourceType, "digest", digest,
+		"directory", destDir, "duration", time.Since(start).Round(time.Millisecond).String()))
+
+	return &lockfile.Entry{
+		Name:               name,
+		Ref:                source,
+		Digest:             digest,
+		Type:               o.SourceType.String(),
+		InstalledTimestamp: time.Now().Format(consts.TimeFormat),
+		Files:              files,
+		Digests:            digests,
+	}, nil
+}
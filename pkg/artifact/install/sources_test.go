@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package install
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIsSource(t *testing.T) {
+	dir := t.TempDir()
+	archive := filepath.Join(dir, "rules.tar.gz")
+	if err := os.WriteFile(archive, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(wd) }()
+
+	tests := []struct {
+		arg  string
+		want bool
+	}{
+		{"https://example.com/rules.tar.gz", true},
+		{"http://example.com/rules.tgz", true},
+		{"/tmp/rules.tar.gz", true},
+		{"./rules", true},
+		{"../rules.tar.gz", true},
+		{"rules.tar.gz", true},
+		{"missing.tar.gz", false},
+		{"cloudtrail-rules", false},
+		{"cloudtrail-rules:latest", false},
+		{"ghcr.io/falcosecurity/rules/cloudtrail-rules:0.1.0", false},
+	}
+	for _, tt := range tests {
+		if got := IsSource(tt.arg); got != tt.want {
+			t.Errorf("IsSource(%q): expected %v, got %v", tt.arg, tt.want, got)
+		}
+	}
+}
+
+func TestSourceName(t *testing.T) {
+	tests := []struct {
+		source, want string
+	}{
+		{"https://example.com/download/cloudtrail-rules.tar.gz?version=1", "cloudtrail-rules"},
+		{"/tmp/my-plugin.tgz", "my-plugin"},
+		{"/tmp/rules.tar.zst", "rules"},
+		{"/tmp/rules.tar", "rules"},
+		{"/tmp/archive", "archive"},
+	}
+	for _, tt := range tests {
+		got, err := sourceName(tt.source)
+		if err != nil {
+			t.Fatalf("source %q: unexpected error: %v", tt.source, err)
+		}
+		if got != tt.want {
+			t.Errorf("source %q: expected %q, got %q", tt.source, tt.want, got)
+		}
+	}
+
+	if _, err := sourceName("https://example.com/"); err == nil {
+		t.Error("expected an error for a URL without file name")
+	}
+}
@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package install
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/falcosecurity/falcoctl/internal/utils"
+)
+
+// availableSpace returns the bytes available on the filesystem holding a directory, replaced in tests.
+var availableSpace = utils.AvailableSpace
+
+// checkAvailableSpace fails when the filesystem of destDir has not enough space left for the files of the archive,
+// so that the extraction does not stop halfway with ENOSPC. The check is skipped, with a debug log, when the available
+// space cannot be told, e.g. on the platforms not supporting it. The archive is read from the start again afterwards.
+func (o *Installer) checkAvailableSpace(ref string, archive *os.File, destDir string) error {
+	logger := o.Printer.Logger
+
+	needed, err := utils.ExtractedSize(archive)
+	if err != nil {
+		return fmt.Errorf("cannot read %q: %w", archive.Name(), err)
+	}
+	if _, err := archive.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	available, err := availableSpace(destDir)
+	if err != nil {
+		logger.Debug("Unable to check the available space", logger.Args("directory", destDir, "reason", err.Error()))
+		return nil
+	}
+
+	if needed > available {
+		return fmt.Errorf("not enough space left to install %q in %q: %d bytes needed once extracted, %d bytes available",
+			ref, destDir, needed, available)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package install
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/pterm/pterm"
+
+	"github.com/falcosecurity/falcoctl/pkg/options"
+)
+
+func TestCheckAvailableSpace(t *testing.T) {
+	opt := options.NewOptions()
+	opt.Initialize(options.WithWriter(io.Discard))
+	var logs bytes.Buffer
+	opt.Printer.Logger = opt.Printer.Logger.WithWriter(&logs).WithLevel(pterm.LogLevelDebug)
+	o := &Installer{Options: &Options{Common: opt}}
+
+	tests := []struct {
+		name      string
+		available int64
+		err       error
+		wantErr   string
+		// wantLog is set when the check is skipped, which is logged.
+		wantLog bool
+	}{
+		{name: "enough space", available: 1 << 30},
+		{name: "not enough space", available: 1, wantErr: "not enough space left"},
+		{name: "unsupported platform", err: errors.ErrUnsupported, wantLog: true},
+		{name: "filesystem not readable", err: errors.New("permission denied"), wantLog: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			restore := availableSpace
+			t.Cleanup(func() { availableSpace = restore })
+			availableSpace = func(string) (int64, error) { return tt.available, tt.err }
+			logs.Reset()
+
+			f, err := os.Open("../../../pkg/test/data/rules.tar.gz")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			err = o.checkAvailableSpace("ghcr.io/falcosecurity/rules/k8saudit-rules:0.1.0", f, t.TempDir())
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+
+			if logged := strings.Contains(logs.String(), "Unable to check the available space"); logged != tt.wantLog {
+				t.Errorf("expected the skipped check to be logged: %t, got logs %q", tt.wantLog, logs.String())
+			}
+
+			// The archive must be readable from the start again.
+			if offset, err := f.Seek(0, io.SeekCurrent); err != nil || offset != 0 {
+				t.Errorf("expected the archive to be rewound, got offset %d (%v)", offset, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package install
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+
+	"github.com/falcosecurity/falcoctl/internal/utils"
+	"github.com/falcosecurity/falcoctl/pkg/lockfile"
+)
+
+const (
+	// stdinSource is the reference reported for the archive read from the standard input.
+	stdinSource = "-"
+	// stdinName is the name of the artifact installed from the standard input, which has no file name to take it from.
+	stdinName = "stdin"
+)
+
+// installStdin installs the archive piped to the standard input, typed according to --source-type. The archive is
+// extracted while being read, without being written to a temporary file first, hence it is not recorded in the
+// lockfile: it could not be installed again from there.
+func (o *Installer) installStdin(ctx context.Context) ([]*Result, error) {
+	res := &Result{Name: stdinName, Ref: stdinSource}
+	results := []*Result{res}
+
+	// Files modified since a previous install are told apart by the digests recorded in the lockfile.
+	installed, err := lockfile.New(o.LockFile)
+	if err != nil {
+		return nil, err
+	}
+	o.installed = installed
+
+	entry, err := o.installStream(ctx, o.Stdin, res)
+	switch {
+	case err != nil:
+		res.Status, res.Error = StatusFailed, err.Error()
+	case entry == nil:
+		res.Status = StatusSkipped
+	default:
+		res.Status = StatusInstalled
+	}
+	countResults(ctx, results)
+	if err != nil || entry == nil {
+		return results, err
+	}
+
+	return results, o.runPostInstallHooks(ctx, []*lockfile.Entry{entry})
+}
+
+// installStream installs the archive read from r as installSource does with the archive of a source.
+func (o *Installer) installStream(ctx context.Context, r io.Reader, res *Result) (*lockfile.Entry, error) {
+	logger := o.Printer.Logger
+	start := time.Now()
+	res.Type = o.SourceType.String()
+
+	if o.RequireAttestation {
+		return nil, fmt.Errorf("cannot install from the standard input: it carries no provenance attestation, required by --%s",
+			FlagRequireAttestation)
+	}
+
+	if !o.isAllowedType(o.SourceType) {
+		err := fmt.Errorf("cannot install source of type %q: type not permitted", o.SourceType)
+		if !o.StrictTypes {
+			logger.Warn("Skipping artifact", logger.Args("source", stdinSource, "reason", err.Error()))
+			res.Error = err.Error()
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	destDir, err := o.destDir(stdinName, o.SourceType)
+	if err != nil {
+		return nil, err
+	}
+	res.DestDir = destDir
+	if err := utils.ExistsAndIsWritable(destDir); err != nil {
+		return nil, fmt.Errorf("cannot use directory %q as install destination: %w", destDir, err)
+	}
+
+	logger.Info("Extracting and installing artifact from the standard input", logger.Args("type", o.SourceType))
+	digester := digest.Canonical.Digester()
+	tee := io.TeeReader(r, digester.Hash())
+	files, digests, err := o.extractStream(ctx, stdinSource, o.SourceType, tee, stdinName, destDir)
+	if err != nil {
+		return nil, err
+	}
+	// The archive may end with padding the extraction does not read, which is part of its digest.
+	if _, err := io.Copy(io.Discard, tee); err != nil {
+		return nil, fmt.Errorf("cannot read the standard input: %w", err)
+	}
+	res.Digest = digester.Digest().String()
+
+	logger.Info("Artifact successfully installed", logger.Args("name", stdinName, "source", stdinSource, "type", o.SourceType,
+		"digest", res.Digest, "directory", destDir, "duration", time.Since(start).Round(time.Millisecond).String()))
+
+	return &lockfile.Entry{
+		Name:    stdinName,
+		Ref:     stdinSource,
+		Digest:  res.Digest,
+		Type:    o.SourceType.String(),
+		Files:   files,
+		Digests: digests,
+	}, nil
+}
@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package install
+
+import (
+	"fmt"
+
+	"github.com/falcosecurity/falcoctl/pkg/lockfile"
+)
+
+// refsToUpdate returns the references recorded in the lockfile for the installed artifacts, i.e. the tags they
+// track, so that they are resolved again by --update-all. The digests they are installed with are kept to tell
+// what changed once updated.
+func (o *Installer) refsToUpdate() ([]string, error) {
+	lock, err := lockfile.New(o.LockFile)
+	if err != nil {
+		return nil, err
+	}
+	if len(lock.Artifacts) == 0 {
+		return nil, fmt.Errorf("no installed artifacts to update found in lockfile %q", o.LockFile)
+	}
+
+	refs := make([]string, 0, len(lock.Artifacts))
+	o.previousDigests = make(map[string]string, len(lock.Artifacts))
+	for _, entry := range lock.Artifacts {
+		refs = append(refs, entry.Ref)
+		o.previousDigests[entry.Name] = entry.Digest
+	}
+	return refs, nil
+}
+
+// printUpdateSummary reports, once --update-all completed, the artifacts updated from the digest they were installed
+// with to the new one, followed by the count of the updated, up to date and failed artifacts.
+func (o *Installer) printUpdateSummary(results []*Result) {
+	logger := o.Printer.Logger
+
+	var updated, upToDate, failed int
+	for _, res := range results {
+		switch res.Status {
+		case StatusInstalled:
+			updated++
+			logger.Info("Artifact updated", logger.Args("name", res.Name, "ref", res.Ref,
+				"from", o.previousDigests[res.Name], "to", res.Digest))
+		case StatusUpToDate:
+			upToDate++
+		case StatusFailed:
+			failed++
+			logger.Warn("Artifact not updated", logger.Args("name", res.Name, "ref", res.Ref, "reason", res.Error))
+		}
+	}
+
+	logger.Info("Update completed", logger.Args("updated", updated, "up-to-date", upToDate, "failed", failed))
+}
@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package install
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/falcosecurity/falcoctl/pkg/oci"
+)
+
+// jobPlatforms returns, for each reference, the platforms the jobs install it for, sorted and comma separated as
+// they are recorded in the lockfile.
+func jobPlatforms(jobs []installJob) map[string]string {
+	byRef := make(map[string][]string)
+	for _, job := range jobs {
+		if !job.source {
+			byRef[job.ref] = append(byRef[job.ref], platformString(job.platform))
+		}
+	}
+
+	platforms := make(map[string]string, len(byRef))
+	for ref, p := range byRef {
+		sort.Strings(p)
+		platforms[ref] = strings.Join(p, ",")
+	}
+	return platforms
+}
+
+// upToDate tells whether the named artifact is recorded in the lockfile with the given digest and for the given
+// platforms, with all its files still in place in its install directory, so that installing it again can be
+// skipped. Platforms are not compared when empty, as for the artifacts installed from sources.
+func (o *Installer) upToDate(name, digest, platforms string) bool {
+	if o.installed == nil {
+		return false
+	}
+	entry := o.installed.Get(name)
+	if entry == nil || entry.Digest != digest || (platforms != "" && entry.Platform != platforms) {
+		return false
+	}
+
+	// The artifact has to be installed again when its install directory changed since it was recorded.
+	destDir, err := o.destDir(name, oci.ArtifactType(entry.Type))
+	if err != nil {
+		return false
+	}
+	if destDir, err = filepath.Abs(destDir); err != nil {
+		return false
+	}
+	for _, path := range entry.Files {
+		if rel, err := filepath.Rel(destDir, path); err != nil || strings.HasPrefix(rel, "..") {
+			return false
+		}
+		if _, err := os.Lstat(path); err != nil {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,104 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package install
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/falcosecurity/falcoctl/pkg/lockfile"
+	"github.com/falcosecurity/falcoctl/pkg/oci"
+	"github.com/falcosecurity/falcoctl/pkg/options"
+)
+
+func TestJobPlatforms(t *testing.T) {
+	jobs := []installJob{
+		{ref: "ghcr.io/falcosecurity/plugins/cloudtrail:0.1.0", platform: v1.Platform{OS: "linux", Architecture: "arm64"}},
+		{ref: "ghcr.io/falcosecurity/rules/k8saudit-rules:0.1.0", platform: v1.Platform{OS: "linux", Architecture: "amd64"}},
+		{ref: "ghcr.io/falcosecurity/plugins/cloudtrail:0.1.0", platform: v1.Platform{OS: "linux", Architecture: "amd64"}},
+		{ref: "./my-rules.tar.gz", source: true},
+	}
+
+	want := map[string]string{
+		"ghcr.io/falcosecurity/plugins/cloudtrail:0.1.0":   "linux/amd64,linux/arm64",
+		"ghcr.io/falcosecurity/rules/k8saudit-rules:0.1.0": "linux/amd64",
+	}
+	if got := jobPlatforms(jobs); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected platforms %v, got %v", want, got)
+	}
+}
+
+func TestUpToDate(t *testing.T) {
+	rulesDir := t.TempDir()
+	rulesFile := filepath.Join(rulesDir, "k8saudit_rules.yaml")
+	if err := os.WriteFile(rulesFile, []byte("- rule: test\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	o := &Installer{
+		Options: &Options{Directory: &options.Directory{RulesfilesDir: rulesDir}},
+		installed: &lockfile.Lockfile{Artifacts: []*lockfile.Entry{{
+			Name:     "k8saudit-rules",
+			Digest:   "sha256:recorded",
+			Type:     oci.Rulesfile.String(),
+			Platform: "linux/amd64",
+			Files:    []string{rulesFile},
+		}}},
+	}
+
+	tests := []struct {
+		name      string
+		artifact  string
+		digest    string
+		platforms string
+		setup     func(t *testing.T)
+		want      bool
+	}{
+		{name: "same digest and platform", artifact: "k8saudit-rules", digest: "sha256:recorded", platforms: "linux/amd64", want: true},
+		{name: "platforms not compared", artifact: "k8saudit-rules", digest: "sha256:recorded", want: true},
+		{name: "different digest", artifact: "k8saudit-rules", digest: "sha256:newer", platforms: "linux/amd64"},
+		{name: "different platform", artifact: "k8saudit-rules", digest: "sha256:recorded", platforms: "linux/arm64"},
+		{name: "not installed", artifact: "cloudtrail-rules", digest: "sha256:recorded"},
+		{
+			name: "moved install directory", artifact: "k8saudit-rules", digest: "sha256:recorded",
+			setup: func(t *testing.T) { o.RulesfilesDir = t.TempDir() },
+		},
+		{
+			name: "missing file", artifact: "k8saudit-rules", digest: "sha256:recorded",
+			setup: func(t *testing.T) {
+				if err := os.Remove(rulesFile); err != nil {
+					t.Fatal(err)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o.RulesfilesDir = rulesDir
+			if tt.setup != nil {
+				tt.setup(t)
+			}
+			if got := o.upToDate(tt.artifact, tt.digest, tt.platforms); got != tt.want {
+				t.Errorf("expected up to date to be %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package install
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// defaultFalcoBin is the falco binary run to detect the Falco version and to validate the rulesfiles, looked up
+// in $PATH.
+const defaultFalcoBin = "falco"
+
+// validateRules runs "falco --validate" on the YAML files among the staged files of the rulesfile with the given
+// ref, all at once so that the rules can refer to each other. The staged files are validated before being moved
+// to the destination directory, hence nothing is installed when the validation fails.
+func (o *Installer) validateRules(ctx context.Context, ref string, staged []string) error {
+	logger := o.Printer.Logger
+
+	var args []string
+	for _, path := range staged {
+		if info, err := os.Lstat(path); err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		// Files kept next to the locally modified ones are validated as well, since they are the new version.
+		if ext := filepath.Ext(strings.TrimSuffix(path, NewFileExt)); ext != ".yaml" && ext != ".yml" {
+			continue
+		}
+		args = append(args, "--validate", path)
+	}
+	if len(args) == 0 {
+		logger.Debug("No rulesfiles to validate", logger.Args("ref", ref))
+		return nil
+	}
+
+	logger.Info("Validating rulesfiles", logger.Args("ref", ref, "files", len(args)/2, "falco", o.FalcoBin))
+	//nolint:gosec // the binary is explicitly given by the user
+	out, err := exec.CommandContext(ctx, o.FalcoBin, args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("rulesfile %q did not pass the validation of %q, nothing has been installed: %w: %s",
+			ref, o.FalcoBin, err, strings.TrimSpace(string(out)))
+	}
+	logger.Info("Rulesfiles successfully validated", logger.Args("ref", ref))
+
+	return nil
+}
@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package install
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	ocipuller "github.com/falcosecurity/falcoctl/pkg/oci/puller"
+)
+
+// CurrentLink is the name of the symlink pointing to the version of a rulesfile in use, installed in
+// <rulesfiles-dir>/<name>/<version> with --versioned-layout.
+const CurrentLink = "current"
+
+// rulesfileVersion returns the name of the directory where the given version of a rulesfile is installed with
+// --versioned-layout: the version declared in its config layer or, when missing or not usable as a directory
+// name, the shortened digest.
+func (o *Installer) rulesfileVersion(ctx context.Context, puller *ocipuller.Puller,
+	ref, digest, platformOS, platformArch string) string {
+	if cfg, err := puller.ArtifactConfig(ctx, ref, platformOS, platformArch); err == nil && validVersionDir(cfg.Version) {
+		return cfg.Version
+	}
+
+	o.Printer.Logger.Debug("No usable version in the config layer, naming the version after the digest",
+		o.Printer.Logger.Args("ref", ref))
+	version := strings.ReplaceAll(digest, ":", "-")
+	if len(version) > len("sha256-")+12 {
+		version = version[:len("sha256-")+12]
+	}
+	return version
+}
+
+// validVersionDir tells whether the version can be used as is as the name of a directory.
+func validVersionDir(version string) bool {
+	return version != "" && version != "." && version != ".." && version != CurrentLink &&
+		!strings.ContainsAny(version, `/\`)
+}
+
+// linkCurrent points the CurrentLink symlink in dir to the given version directory. The symlink is relative, so that
+// dir can be moved, and replaced atomically, so that Falco never finds it missing.
+func linkCurrent(dir, version string) error {
+	link := filepath.Join(dir, CurrentLink)
+	tmp := link + ".tmp"
+	if err := os.Remove(tmp); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := os.Symlink(version, tmp); err != nil {
+		return fmt.Errorf("cannot link %q to version %q: %w", link, version, err)
+	}
+	if err := os.Rename(tmp, link); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("cannot link %q to version %q: %w", link, version, err)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package install
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidVersionDir(t *testing.T) {
+	for version, want := range map[string]bool{
+		"0.1.0": true, "1.0.0-rc1": true, "": false, ".": false, "..": false, "current": false, "1/0": false, `1\0`: false,
+	} {
+		if got := validVersionDir(version); got != want {
+			t.Errorf("validVersionDir(%q): expected %t, got %t", version, want, got)
+		}
+	}
+}
+
+func TestLinkCurrent(t *testing.T) {
+	dir := t.TempDir()
+	for _, version := range []string{"0.1.0", "0.2.0"} {
+		if err := os.Mkdir(filepath.Join(dir, version), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := linkCurrent(dir, version); err != nil {
+			t.Fatalf("unexpected error linking version %q: %v", version, err)
+		}
+		if target, err := os.Readlink(filepath.Join(dir, CurrentLink)); err != nil || target != version {
+			t.Errorf("expected the current symlink to point to %q, got %q and error %v", version, target, err)
+		}
+	}
+	if _, err := os.Lstat(filepath.Join(dir, CurrentLink+".tmp")); !os.IsNotExist(err) {
+		t.Errorf("expected the temporary symlink to be gone, got %v", err)
+	}
+}
@@ -332,7 +332,8 @@ func downloadKernelSrc(ctx context.Context,
 		return env, err
 	}
 
-	_, err = utils.ExtractTarGz(ctx, resp.Body, fullKernelDir, stripComponents)
+	// Kernel sources are way bigger than any artifact, hence do not bound the extraction.
+	_, err = utils.ExtractTarGz(ctx, resp.Body, fullKernelDir, stripComponents, utils.WithMaxFiles(0), utils.WithMaxSize(0))
 	if err != nil {
 		return env, err
 	}
@@ -42,6 +42,54 @@ type Cache struct {
 	fetchedIndexes []*index.Index
 	// Track the indexes that have been removed, needed when writing the cache to file.
 	removedIndexes []string
+	// lenient makes the indexes failing to load be skipped, see WithLenient.
+	lenient bool
+	// failures are the indexes skipped since they failed to load.
+	failures []Failure
+	// maxAge is the age after which a cached index file is refreshed, see WithMaxAge.
+	maxAge time.Duration
+	// staleIndexes are the cached index files found older than maxAge.
+	staleIndexes []StaleIndex
+	// checkedIndexes are the indexes reported unchanged by the backend, their files are touched when writing.
+	checkedIndexes []string
+}
+
+// Failure is an index that could not be loaded, skipped by a lenient cache.
+type Failure struct {
+	Name string
+	Err  error
+}
+
+// StaleIndex is an index whose cached file was older than the maximum age, see WithMaxAge.
+type StaleIndex struct {
+	Name string
+	// Age is the age of the cached file when the cache was created.
+	Age time.Duration
+	// Refreshed reports whether the index has been fetched again, otherwise the cached file is used.
+	Refreshed bool
+	// Err is the reason why the index could not be refreshed, or why the refreshed index could not be saved.
+	Err error
+}
+
+// Option is a functional option used to configure a Cache.
+type Option func(*Cache)
+
+// WithLenient makes NewFromConfig skip the indexes that cannot be fetched or read from the disk, e.g. a corrupt
+// cached index, instead of failing. The skipped indexes are reported by Failures, and NewFromConfig still fails
+// when none of the indexes can be loaded.
+func WithLenient() Option {
+	return func(c *Cache) {
+		c.lenient = true
+	}
+}
+
+// WithMaxAge makes NewFromConfig refresh the cached index files older than maxAge, i.e. last written or updated
+// more than maxAge ago, by fetching them again and saving them to the disk. When the refresh fails the cached file
+// is used anyway. The stale indexes are reported by StaleIndexes. A zero maxAge disables the check.
+func WithMaxAge(maxAge time.Duration) Option {
+	return func(c *Cache) {
+		c.maxAge = maxAge
+	}
 }
 
 // New creates a new cache object. For each entry in the indexes.yaml file it loads the respective index file
@@ -92,7 +140,7 @@ func New(ctx context.Context, indexFile, indexesDir string) (*Cache, error) {
 
 // NewFromConfig creates a new cache object from a set of indexes. The new cache fetches the indexes only if they do not
 // exist in the filesystem. The local indexes info is ignored, it takes into account the indexes passed as arguments.
-func NewFromConfig(ctx context.Context, indexFile, indexesDir string, indexes []config.Index) (*Cache, error) {
+func NewFromConfig(ctx context.Context, indexFile, indexesDir string, indexes []config.Index, opts ...Option) (*Cache, error) {
 	var err error
 	var idx *index.Index
 	indexConfig := &indexConf.Config{}
@@ -105,6 +153,10 @@ func NewFromConfig(ctx context.Context, indexFile, indexesDir string, indexes []
 		MergedIndexes:    index.NewMergedIndexes(),
 	}
 
+	for _, o := range opts {
+		o(c)
+	}
+
 	for i := range indexes {
 		cfg := &indexes[i]
 		// If the index is in the local persistent cache we just load it.
@@ -112,11 +164,21 @@ func NewFromConfig(ctx context.Context, indexFile, indexesDir string, indexes []
 		if idx, err = c.loadIndex(cfg.Name); err != nil && errors.Is(err, fs.ErrNotExist) {
 			// If the index is not found in the local persistent cache we fetch it from the url.
 			if idx, err = c.fetcher.Fetch(ctx, indexConf.EntryFromIndex(cfg)); err != nil {
-				return nil, fmt.Errorf("unable to fetch index %q with URL %q: %w", cfg.Name, cfg.URL, err)
+				err = fmt.Errorf("unable to fetch index %q with URL %q: %w", cfg.Name, cfg.URL, err)
+			} else {
+				c.fetchedIndexes = append(c.fetchedIndexes, idx)
 			}
-			c.fetchedIndexes = append(c.fetchedIndexes, idx)
 		} else if err != nil {
-			return nil, fmt.Errorf("an error occurred while loading cache from disk: %w", err)
+			err = fmt.Errorf("an error occurred while loading cache from disk: %w", err)
+		} else if c.maxAge > 0 {
+			idx = c.refreshIfStale(ctx, cfg, idx)
+		}
+		if err != nil {
+			if !c.lenient {
+				return nil, err
+			}
+			c.failures = append(c.failures, Failure{Name: cfg.Name, Err: err})
+			continue
 		}
 		c.localIndexes.Configs = append(c.localIndexes.Configs, &indexConf.Entry{
 			AddedTimestamp:   ts,
@@ -128,9 +190,69 @@ func NewFromConfig(ctx context.Context, indexFile, indexesDir string, indexes []
 		c.Merge(idx)
 	}
 
+	if len(indexes) > 0 && len(c.failures) == len(indexes) {
+		errs := make([]error, 0, len(c.failures))
+		for _, f := range c.failures {
+			errs = append(errs, f.Err)
+		}
+		return nil, fmt.Errorf("none of the %d configured indexes could be loaded: %w", len(indexes), errors.Join(errs...))
+	}
+
 	return c, nil
 }
 
+// refreshIfStale fetches again the given index if its cached file is older than the maximum age, and saves it to
+// the disk. It returns the refreshed index, or the cached one if it is not stale or cannot be refreshed.
+func (c *Cache) refreshIfStale(ctx context.Context, cfg *config.Index, cached *index.Index) *index.Index {
+	age, err := c.IndexAge(cfg.Name)
+	if err != nil || age <= c.maxAge {
+		return cached
+	}
+
+	stale := StaleIndex{Name: cfg.Name, Age: age}
+	defer func() { c.staleIndexes = append(c.staleIndexes, stale) }()
+
+	idx, err := c.fetcher.Fetch(ctx, indexConf.EntryFromIndex(cfg))
+	if err != nil {
+		stale.Err = fmt.Errorf("unable to fetch index %q with URL %q: %w", cfg.Name, cfg.URL, err)
+		return cached
+	}
+	stale.Refreshed = true
+
+	if err = idx.Write(c.indexPath(cfg.Name)); err != nil {
+		stale.Err = fmt.Errorf("an error occurred while writing index %q to file %q: %w", cfg.Name, c.indexPath(cfg.Name), err)
+	}
+
+	return idx
+}
+
+// IndexAge returns the time elapsed since the cached file of the given index was last written or updated.
+func (c *Cache) IndexAge(name string) (time.Duration, error) {
+	info, err := os.Stat(c.indexPath(name))
+	if err != nil {
+		return 0, fmt.Errorf("unable to get the age of index %q: %w", name, err)
+	}
+
+	return time.Since(info.ModTime()), nil
+}
+
+// StaleIndexes returns the indexes whose cached file was older than the maximum age, in the order they are
+// configured. See WithMaxAge.
+func (c *Cache) StaleIndexes() []StaleIndex {
+	return c.staleIndexes
+}
+
+// Failures returns the indexes that could not be loaded, in the order they are configured, when the cache is
+// lenient. See WithLenient.
+func (c *Cache) Failures() []Failure {
+	return c.failures
+}
+
+// Empty returns true if no index is configured, hence artifacts can only be referenced by their full reference.
+func (c *Cache) Empty() bool {
+	return len(c.localIndexes.Configs) == 0
+}
+
 // Add adds a new index file to the cache. If the index file already exists in the cache it
 // does nothing. On the other hand, it fetches the index file using the provided URL and adds
 // it to the in memory cache. It does not write it to the filesystem. It is idempotent.
@@ -164,6 +286,8 @@ func (c *Cache) Add(ctx context.Context, name, backend, url string) error {
 		UpdatedTimestamp: ts,
 		URL:              url,
 		Backend:          backend,
+		ETag:             entry.ETag,
+		LastModified:     entry.LastModified,
 	}
 	c.localIndexes.Add(entry)
 
@@ -226,22 +350,33 @@ func (c *Cache) Remove(name string) error {
 
 // Update updates an index entry by fetching the new content from the configured URL for the
 // given index. The new content is kept in memory, it does not overwrite the existing index file
-// on the disk.
-func (c *Cache) Update(ctx context.Context, name string) error {
+// on the disk. The content is not downloaded again if the backend reports it unchanged since the
+// last fetch, in which case false is returned.
+func (c *Cache) Update(ctx context.Context, name string) (bool, error) {
 	var idx *index.Index
 	var err error
 	newMergedIndex := index.NewMergedIndexes()
 	// Check if the entry exists.
 	entry := c.localIndexes.Get(name)
 	if entry == nil {
-		return fmt.Errorf("unable to update index %s: not found in the cache, please make sure to add it before updating", name)
+		return false, fmt.Errorf("unable to update index %s: not found in the cache, please make sure to add it before updating", name)
+	}
+
+	// The validators of the last fetch are meaningless if the index file is not on disk anymore.
+	if _, err = c.loadIndex(name); err != nil {
+		entry.ETag, entry.LastModified = "", ""
 	}
 
 	ts := time.Now().Format(consts.TimeFormat)
 	// Fetch the index from the remote url.
 	updatedIndex, err := c.fetcher.Fetch(ctx, entry)
-	if err != nil {
-		return fmt.Errorf("unable to fetch index %q with URL %q: %w", name, entry.URL, err)
+	if errors.Is(err, fetch.ErrNotModified) {
+		entry.UpdatedTimestamp = ts
+		c.localIndexes.Upsert(entry)
+		c.checkedIndexes = append(c.checkedIndexes, name)
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("unable to fetch index %q with URL %q: %w", name, entry.URL, err)
 	}
 
 	// Update the existing index entry by setting the new timestamp.
@@ -257,10 +392,10 @@ func (c *Cache) Update(ctx context.Context, name string) error {
 			if idx, err = c.loadIndex(cfg.Name); err != nil && errors.Is(err, fs.ErrNotExist) {
 				idx = findIndexInSlice(c.fetchedIndexes, cfg.Name)
 				if idx == nil {
-					return fmt.Errorf("index %q not found in the local persisten storage neither in the fetched indexes", cfg.Name)
+					return false, fmt.Errorf("index %q not found in the local persisten storage neither in the fetched indexes", cfg.Name)
 				}
 			} else if err != nil {
-				return err
+				return false, err
 			}
 			newMergedIndex.Merge(idx)
 		} else {
@@ -268,7 +403,7 @@ func (c *Cache) Update(ctx context.Context, name string) error {
 		}
 	}
 
-	return nil
+	return true, nil
 }
 
 // Write dumps the in-memory cache to disk. Based on the cache operations it does different things.
@@ -276,7 +411,8 @@ func (c *Cache) Update(ctx context.Context, name string) error {
 // config.IndexesDir.
 // Remove: the removed entry is wiped out from the config.IndexesFile and the related index file is deleted.
 // Update: the entry in the config.IndexesFile for the updated index is updated. The related index file is
-// replaced by the new content fetched by the update operation.
+// replaced by the new content fetched by the update operation, or just touched if the content was unchanged, so
+// that its age is reset.
 // Returns the indexConf.Config written to the config.IndexesFile.
 func (c *Cache) Write() (*indexConf.Config, error) {
 	for _, idx := range c.fetchedIndexes {
@@ -289,6 +425,13 @@ func (c *Cache) Write() (*indexConf.Config, error) {
 		}
 	}
 
+	for _, name := range c.checkedIndexes {
+		now := time.Now()
+		if err := os.Chtimes(c.indexPath(name), now, now); err != nil {
+			return nil, fmt.Errorf("an error occurred while touching index %q file %q: %w", name, c.indexPath(name), err)
+		}
+	}
+
 	for _, name := range c.removedIndexes {
 		indexFileName := fmt.Sprintf("%s%s", name, ".yaml")
 		indexPath := filepath.Join(c.indexesDir, indexFileName)
@@ -318,6 +461,10 @@ func (c *Cache) loadIndex(name string) (*index.Index, error) {
 	return idx, nil
 }
 
+func (c *Cache) indexPath(name string) string {
+	return filepath.Join(c.indexesDir, fmt.Sprintf("%s%s", name, ".yaml"))
+}
+
 func findIndexInSlice(indexes []*index.Index, name string) *index.Index {
 	for _, idx := range indexes {
 		if idx.Name == name {
@@ -0,0 +1,168 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/falcosecurity/falcoctl/internal/config"
+)
+
+// writeIndexes writes the given index files in a temporary directory, keyed by index name, and returns it.
+func writeIndexes(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name+".yaml"), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+const goodIndex = `- name: k8saudit-rules
+  type: rulesfile
+  registry: ghcr.io
+  repository: falcosecurity/rules/k8saudit-rules
+`
+
+func TestNewFromConfigLenient(t *testing.T) {
+	ctx := context.Background()
+	indexes := []config.Index{{Name: "bad", URL: "https://example.com/bad.yaml"}, {Name: "good", URL: "https://example.com/good.yaml"}}
+	dir := writeIndexes(t, map[string]string{"bad": "{not an index", "good": goodIndex})
+
+	if _, err := NewFromConfig(ctx, "", dir, indexes); err == nil {
+		t.Fatal("expected an error loading a corrupt index without WithLenient")
+	}
+
+	c, err := NewFromConfig(ctx, "", dir, indexes, WithLenient())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if failures := c.Failures(); len(failures) != 1 || failures[0].Name != "bad" || failures[0].Err == nil {
+		t.Errorf("expected the corrupt index to be reported as failed, got %v", failures)
+	}
+	if _, ok := c.MergedIndexes.EntryByName("k8saudit-rules"); !ok {
+		t.Error("expected the entries of the valid index to be loaded")
+	}
+	if c.Empty() {
+		t.Error("expected the valid index to be configured")
+	}
+}
+
+func TestNewFromConfigLenientNoIndexLoaded(t *testing.T) {
+	ctx := context.Background()
+	indexes := []config.Index{{Name: "bad1"}, {Name: "bad2"}}
+	dir := writeIndexes(t, map[string]string{"bad1": "{not an index", "bad2": "{not an index"})
+
+	_, err := NewFromConfig(ctx, "", dir, indexes, WithLenient())
+	if err == nil || !strings.Contains(err.Error(), "none of the 2 configured indexes could be loaded") {
+		t.Fatalf("expected an error when no index is loaded, got %v", err)
+	}
+}
+
+func TestNewFromConfigLenientWithoutIndexes(t *testing.T) {
+	c, err := NewFromConfig(context.Background(), "", t.TempDir(), nil, WithLenient())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !c.Empty() || len(c.Failures()) != 0 {
+		t.Error("expected an empty cache without failures")
+	}
+}
+
+const refreshedIndex = `- name: cloudtrail-rules
+  type: rulesfile
+  registry: ghcr.io
+  repository: falcosecurity/rules/cloudtrail-rules
+`
+
+// age sets the modification time of the cached file of the given index to age ago.
+func age(t *testing.T, dir, name string, age time.Duration) {
+	t.Helper()
+	mtime := time.Now().Add(-age)
+	if err := os.Chtimes(filepath.Join(dir, name+".yaml"), mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNewFromConfigMaxAge(t *testing.T) {
+	ctx := context.Background()
+	downloads := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		downloads++
+		if _, err := w.Write([]byte(refreshedIndex)); err != nil {
+			t.Error(err)
+		}
+	}))
+	defer ts.Close()
+	indexes := []config.Index{{Name: "fresh", URL: ts.URL}, {Name: "stale", URL: ts.URL}}
+	dir := writeIndexes(t, map[string]string{"fresh": goodIndex, "stale": goodIndex})
+	age(t, dir, "fresh", time.Hour)
+	age(t, dir, "stale", 48*time.Hour)
+
+	c, err := NewFromConfig(ctx, "", dir, indexes, WithMaxAge(24*time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if downloads != 1 {
+		t.Errorf("expected only the stale index to be fetched, got %d downloads", downloads)
+	}
+	stale := c.StaleIndexes()
+	if len(stale) != 1 || stale[0].Name != "stale" || !stale[0].Refreshed || stale[0].Err != nil {
+		t.Fatalf("expected the stale index to be refreshed, got %v", stale)
+	}
+	if _, ok := c.MergedIndexes.EntryByName("cloudtrail-rules"); !ok {
+		t.Error("expected the entries of the refreshed index to be loaded")
+	}
+	if a, err := c.IndexAge("stale"); err != nil || a > time.Hour {
+		t.Errorf("expected the refreshed index to be saved, got age %s and error %v", a, err)
+	}
+	content, err := os.ReadFile(filepath.Join(dir, "stale.yaml"))
+	if err != nil || !strings.Contains(string(content), "cloudtrail-rules") {
+		t.Errorf("expected the refreshed content to be saved, got %q and error %v", content, err)
+	}
+}
+
+func TestNewFromConfigMaxAgeRefreshFailure(t *testing.T) {
+	ctx := context.Background()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+	indexes := []config.Index{{Name: "stale", URL: ts.URL}}
+	dir := writeIndexes(t, map[string]string{"stale": goodIndex})
+	age(t, dir, "stale", 48*time.Hour)
+
+	c, err := NewFromConfig(ctx, "", dir, indexes, WithMaxAge(24*time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stale := c.StaleIndexes()
+	if len(stale) != 1 || stale[0].Refreshed || stale[0].Err == nil || stale[0].Age < 48*time.Hour {
+		t.Fatalf("expected the stale index to be reported as not refreshed, got %v", stale)
+	}
+	if _, ok := c.MergedIndexes.EntryByName("k8saudit-rules"); !ok {
+		t.Error("expected the entries of the cached index to be used")
+	}
+}
@@ -33,6 +33,10 @@ type Entry struct {
 	UpdatedTimestamp string `yaml:"updated_timestamp"`
 	URL              string `yaml:"url"`
 	Backend          string `yaml:"backend"`
+	// ETag and LastModified are the validators returned by HTTP/S backends for the last fetched content,
+	// used to avoid downloading it again when unchanged.
+	ETag         string `yaml:"etag,omitempty"`
+	LastModified string `yaml:"last_modified,omitempty"`
 	// TODO: add support for HTTP and other backend configs.
 	// HTTP             http.BackendConfig `yaml:"http"`
 }
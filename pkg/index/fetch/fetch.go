@@ -17,6 +17,7 @@ package fetch
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
 	"strings"
@@ -27,6 +28,10 @@ import (
 	"github.com/falcosecurity/falcoctl/pkg/index/index"
 )
 
+// ErrNotModified is returned by Fetch when the remote index has not changed since it was last fetched,
+// according to the validators stored in the entry.
+var ErrNotModified = http.ErrNotModified
+
 // Func is a prototype for fetching indices for a specific index backend.
 type Func func(context.Context, *config.Entry) ([]byte, error)
 
@@ -83,7 +88,9 @@ func (f *Fetcher) Fetch(ctx context.Context, conf *config.Entry) (*index.Index,
 	}
 
 	bytes, err := fetcher(ctx, conf)
-	if err != nil {
+	if errors.Is(err, ErrNotModified) {
+		return nil, err
+	} else if err != nil {
 		return nil, fmt.Errorf("unable to fetch index: %w", err)
 	}
 
@@ -17,6 +17,7 @@ package fetch
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -54,3 +55,48 @@ func TestFetch(t *testing.T) {
 		t.Errorf("cannot fetch index")
 	}
 }
+
+func TestFetchNotModified(t *testing.T) {
+	const etag = `"v1"`
+	fetcher := NewFetcher()
+	downloads := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		bytes, err := os.ReadFile("../testdata/index.yaml")
+		if err != nil {
+			t.Error(err)
+		}
+
+		downloads++
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Last-Modified", "Wed, 14 Oct 2026 08:00:00 GMT")
+		if _, err := w.Write(bytes); err != nil {
+			t.Error(err)
+		}
+	}))
+	defer ts.Close()
+
+	indexConf := &config.Entry{
+		Name:    "falcosecurity",
+		Backend: "http",
+		URL:     ts.URL,
+	}
+
+	if _, err := fetcher.Fetch(context.Background(), indexConf); err != nil {
+		t.Fatalf("cannot fetch index: %v", err)
+	}
+	if indexConf.ETag != etag || indexConf.LastModified == "" {
+		t.Errorf("validators not recorded, got etag %q and last modified %q", indexConf.ETag, indexConf.LastModified)
+	}
+
+	if _, err := fetcher.Fetch(context.Background(), indexConf); !errors.Is(err, ErrNotModified) {
+		t.Errorf("expected ErrNotModified, got %v", err)
+	}
+	if downloads != 1 {
+		t.Errorf("expected the index to be downloaded once, got %d downloads", downloads)
+	}
+}
@@ -17,6 +17,7 @@ package http
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -24,12 +25,23 @@ import (
 	"github.com/falcosecurity/falcoctl/pkg/index/config"
 )
 
-// Fetch fetches the raw index file from the given HTTP/S url.
+// ErrNotModified is returned by Fetch when the index has not changed since it was last fetched.
+var ErrNotModified = errors.New("index not modified")
+
+// Fetch fetches the raw index file from the given HTTP/S url. If the entry holds the validators of a previous
+// fetch, the index is only downloaded if changed in the meantime, ErrNotModified is returned otherwise.
+// On success the validators of the entry are updated with the ones returned by the server.
 func Fetch(ctx context.Context, conf *config.Entry) ([]byte, error) {
 	req, err := http.NewRequestWithContext(ctx, "GET", conf.URL, http.NoBody)
 	if err != nil {
 		return nil, fmt.Errorf("cannot fetch index: %w", err)
 	}
+	if conf.ETag != "" {
+		req.Header.Set("If-None-Match", conf.ETag)
+	}
+	if conf.LastModified != "" {
+		req.Header.Set("If-Modified-Since", conf.LastModified)
+	}
 
 	client := &http.Client{}
 	resp, err := client.Do(req)
@@ -38,6 +50,10 @@ func Fetch(ctx context.Context, conf *config.Entry) ([]byte, error) {
 	}
 	defer resp.Body.Close() // #nosec G307 closing errors should not happen
 
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, ErrNotModified
+	}
+
 	if resp.StatusCode >= http.StatusBadRequest && resp.StatusCode <= http.StatusNetworkAuthenticationRequired {
 		return nil, fmt.Errorf("cannot fetch index: %s", resp.Status)
 	}
@@ -47,5 +63,8 @@ func Fetch(ctx context.Context, conf *config.Entry) ([]byte, error) {
 		return nil, fmt.Errorf("cannot read bytes from response body: %w", err)
 	}
 
+	conf.ETag = resp.Header.Get("ETag")
+	conf.LastModified = resp.Header.Get("Last-Modified")
+
 	return bytes, nil
 }
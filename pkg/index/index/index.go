@@ -83,6 +83,17 @@ type Index struct {
 type MergedIndexes struct {
 	Index
 	indexByEntry map[*Entry]*Index
+	collisions   []Collision
+}
+
+// Collision is an entry name defined by more than one of the merged indexes, see MergedIndexes.Merge.
+type Collision struct {
+	// Name is the name of the entry.
+	Name string
+	// Index is the name of the index whose entry is used.
+	Index string
+	// Ignored is the name of the index whose entry is ignored.
+	Ignored string
 }
 
 // New returns a new empty Index.
@@ -128,6 +139,50 @@ func (i *Index) EntryByName(name string) (*Entry, bool) {
 	return entry, ok
 }
 
+// Names returns the names of the entries of the index, sorted lexically.
+func (i *Index) Names() []string {
+	names := make([]string, 0, len(i.Entries))
+	for _, entry := range i.Entries {
+		names = append(names, entry.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Search returns the entries whose name, description or keywords contain the query, ignoring the case, sorted by
+// name. Unlike SearchByKeywords, names are not matched approximately. An empty query matches all the entries.
+func (i *Index) Search(query string) []*Entry {
+	query = strings.ToLower(query)
+
+	var result []*Entry
+	for _, entry := range i.Entries {
+		fields := append([]string{entry.Name, entry.Description}, entry.Keywords...)
+		for _, field := range fields {
+			if strings.Contains(strings.ToLower(field), query) {
+				result = append(result, entry)
+				break
+			}
+		}
+	}
+
+	sort.SliceStable(result, func(k, j int) bool {
+		return result[k].Name < result[j].Name
+	})
+
+	return result
+}
+
+// EntriesByType returns the entries of the given artifact type, in the order they are listed in the index.
+func (i *Index) EntriesByType(artifactType oci.ArtifactType) []*Entry {
+	var result []*Entry
+	for _, entry := range i.Entries {
+		if oci.ArtifactType(entry.Type) == artifactType {
+			result = append(result, entry)
+		}
+	}
+	return result
+}
+
 // Normalize the index to the canonical form (i.e., entries sorted by name,
 // lexically byte-wise in ascending order).
 //
@@ -222,40 +277,53 @@ func NewMergedIndexes() *MergedIndexes {
 }
 
 // Merge creates a new index by merging all the indexes that are passed.
-// Orders matters. Be sure to pass an ordered list of indexes. For our use case, sort by added time.
+// Orders matters. Be sure to pass an ordered list of indexes. For our use case, the order they are configured in.
+// When several indexes define an entry with the same name, the entry of the first one merged is used and the
+// others are recorded as collisions, see Collisions. Merging an index again replaces its entries.
 func (m *MergedIndexes) Merge(indexes ...*Index) {
 	for _, index := range indexes {
-		for _, Entry := range index.Entries {
-			m.Upsert(Entry)
-			m.indexByEntry[Entry] = index
+		for _, entry := range index.Entries {
+			if existing, ok := m.EntryByName(entry.Name); ok {
+				if origin := m.indexByEntry[existing]; origin.Name != index.Name {
+					m.collisions = append(m.collisions, Collision{Name: entry.Name, Index: origin.Name, Ignored: index.Name})
+					continue
+				}
+				delete(m.indexByEntry, existing)
+			}
+			m.Upsert(entry)
+			m.indexByEntry[entry] = index
 		}
 	}
 }
 
-// SearchByKeywords search for entries matching the given keywords in MergedIndexes.
+// Collisions returns the entry names defined by more than one of the merged indexes, in the order they are found.
+func (m *MergedIndexes) Collisions() []Collision {
+	return m.collisions
+}
+
+// SearchByKeywords search for entries matching the given keywords in MergedIndexes, sorted by name.
 // minScore is the minimum score to consider a match between a name of an artifact and a keyword.
-// if minScore is not reached, we fallback to a simple partial matching on keywords.
+// if minScore is not reached, we fallback to a case-insensitive partial matching on the name, description and keywords.
 func (i *Index) SearchByKeywords(minScore float64, keywords ...string) []*Entry {
-	matches := make(map[*Entry]struct{})
+	var result []*Entry
 
 	for _, entry := range i.Entries {
-		entryKeywords := strings.Join(entry.Keywords, " ")
+		text := strings.ToLower(strings.Join(append([]string{entry.Name, entry.Description}, entry.Keywords...), " "))
 
 		for _, keyword := range keywords {
 			// Compute score between the keyword and entry name.
 			score := score(entry.Name, keyword)
 
-			if score >= minScore || strings.Contains(entryKeywords, keyword) {
-				matches[entry] = struct{}{}
+			if score >= minScore || strings.Contains(text, strings.ToLower(keyword)) {
+				result = append(result, entry)
 				break
 			}
 		}
 	}
 
-	result := make([]*Entry, 0, len(matches))
-	for k := range matches {
-		result = append(result, k)
-	}
+	sort.SliceStable(result, func(k, j int) bool {
+		return result[k].Name < result[j].Name
+	})
 
 	return result
 }
@@ -265,6 +333,16 @@ func (m *MergedIndexes) IndexByEntry(entry *Entry) *Index {
 	return m.indexByEntry[entry]
 }
 
+// IndexByEntryName returns the index the entry with the given name comes from, i.e. the first merged index
+// defining it.
+func (m *MergedIndexes) IndexByEntryName(name string) (*Index, bool) {
+	entry, ok := m.EntryByName(name)
+	if !ok {
+		return nil, false
+	}
+	return m.indexByEntry[entry], true
+}
+
 // SignatureForIndexRef is a helper function that will identify signature data if available for the specified name
 // corresponding to an entry in the index.
 // Returns nil if not found or if the specified name is a full reference.
@@ -18,11 +18,13 @@ package index
 import (
 	"crypto/sha256"
 	"fmt"
+	"reflect"
 	"testing"
 
 	"gopkg.in/yaml.v3"
 
 	"github.com/falcosecurity/falcoctl/pkg/index/config"
+	"github.com/falcosecurity/falcoctl/pkg/oci"
 )
 
 const expectedIndexNormalized = `- name: baz
@@ -133,6 +135,10 @@ func TestMerge(t *testing.T) {
 		mergedIndex.IndexByEntry(okta).Name != "index2" {
 		t.Errorf("cannot correctly retrieve original index from merged index")
 	}
+
+	if names := mergedIndex.Names(); !reflect.DeepEqual(names, []string{"cloudtrail", "github", "okta"}) {
+		t.Errorf("expected the sorted names of the merged entries, got %v", names)
+	}
 }
 
 func TestSearchByKeywords(t *testing.T) {
@@ -176,6 +182,26 @@ func TestSearchByKeywords(t *testing.T) {
 	if len(noDuplicates) != 1 {
 		t.Errorf("error in SearchByKeywords, not expecting duplicates")
 	}
+
+	// Test partial match on name and description, ignoring the case.
+	i.Upsert(&Entry{
+		Name:        "k8saudit",
+		Description: "Read Kubernetes Audit Events and monitor Kubernetes clusters",
+	})
+	partialNameMatch := i.SearchByKeywords(1, "AUDIT")
+	if len(partialNameMatch) != 2 {
+		t.Errorf("error in SearchByKeywords, expected to find a partial match with name and keyword")
+	}
+	partialDescriptionMatch := i.SearchByKeywords(1, "kubernetes")
+	if len(partialDescriptionMatch) != 1 {
+		t.Errorf("error in SearchByKeywords, expected to find a partial match with description")
+	}
+
+	// Check that matches are sorted by name
+	sorted := i.SearchByKeywords(1, "audit", "cloudtrail")
+	if len(sorted) != 3 || sorted[0].Name != "cloudtrail" || sorted[1].Name != "github" || sorted[2].Name != "k8saudit" {
+		t.Errorf("error in SearchByKeywords, expected matches sorted by name, got %v", sorted)
+	}
 }
 
 func TestNormalize(t *testing.T) {
@@ -241,3 +267,94 @@ func TestConfig(t *testing.T) {
 		t.Error(fmt.Errorf("entry \"test\" not found"))
 	}
 }
+
+func TestSearch(t *testing.T) {
+	mergedIndex := NewMergedIndexes()
+	i := New("index")
+	i.Upsert(&Entry{Name: "k8saudit", Type: "plugin", Description: "Read Kubernetes Audit Events"})
+	i.Upsert(&Entry{Name: "cloudtrail", Type: "plugin", Keywords: []string{"aws", "audit"}})
+	i.Upsert(&Entry{Name: "cloudtrail-rules", Type: "rulesfile"})
+	mergedIndex.Merge(i)
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"cloud", []string{"cloudtrail", "cloudtrail-rules"}},
+		{"AUDIT", []string{"cloudtrail", "k8saudit"}},
+		{"kubernetes", []string{"k8saudit"}},
+		{"", []string{"cloudtrail", "cloudtrail-rules", "k8saudit"}},
+		{"cloudtrailz", nil},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, entry := range mergedIndex.Search(tt.query) {
+			got = append(got, entry.Name)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("query %q: expected %v, got %v", tt.query, tt.want, got)
+		}
+	}
+}
+
+func TestEntriesByType(t *testing.T) {
+	mergedIndex := NewMergedIndexes()
+	i := New("index")
+	i.Upsert(&Entry{Name: "k8saudit", Type: "plugin"})
+	i.Upsert(&Entry{Name: "cloudtrail-rules", Type: "rulesfile"})
+	i.Upsert(&Entry{Name: "cloudtrail", Type: "plugin"})
+	mergedIndex.Merge(i)
+
+	var plugins []string
+	for _, entry := range mergedIndex.EntriesByType(oci.Plugin) {
+		plugins = append(plugins, entry.Name)
+	}
+	if !reflect.DeepEqual(plugins, []string{"k8saudit", "cloudtrail"}) {
+		t.Errorf("expected the plugins in index order, got %v", plugins)
+	}
+
+	if entries := mergedIndex.EntriesByType(oci.Asset); len(entries) != 0 {
+		t.Errorf("expected no assets, got %d entries", len(entries))
+	}
+}
+
+func TestMergeCollisions(t *testing.T) {
+	i1 := New("index1")
+	i1.Upsert(&Entry{Name: "cloudtrail", Repository: "index1/cloudtrail"})
+	i2 := New("index2")
+	i2.Upsert(&Entry{Name: "cloudtrail", Repository: "index2/cloudtrail"})
+	i2.Upsert(&Entry{Name: "okta", Repository: "index2/okta"})
+
+	mergedIndex := NewMergedIndexes()
+	mergedIndex.Merge(i1, i2)
+
+	entry, ok := mergedIndex.EntryByName("cloudtrail")
+	if !ok || entry.Repository != "index1/cloudtrail" {
+		t.Errorf("expected the entry of the first merged index to be used, got %v", entry)
+	}
+	if origin, ok := mergedIndex.IndexByEntryName("cloudtrail"); !ok || origin.Name != "index1" {
+		t.Errorf("expected the entry to come from index1, got %v", origin)
+	}
+	if origin, ok := mergedIndex.IndexByEntryName("okta"); !ok || origin.Name != "index2" {
+		t.Errorf("expected the entry to come from index2, got %v", origin)
+	}
+	if _, ok := mergedIndex.IndexByEntryName("github"); ok {
+		t.Errorf("expected no index for a missing entry")
+	}
+
+	want := []Collision{{Name: "cloudtrail", Index: "index1", Ignored: "index2"}}
+	if !reflect.DeepEqual(mergedIndex.Collisions(), want) {
+		t.Errorf("expected collisions %v, got %v", want, mergedIndex.Collisions())
+	}
+
+	// Merging an index again replaces its entries, without collisions.
+	updated := New("index1")
+	updated.Upsert(&Entry{Name: "cloudtrail", Repository: "index1/cloudtrail-updated"})
+	mergedIndex.Merge(updated)
+	if entry, _ := mergedIndex.EntryByName("cloudtrail"); entry.Repository != "index1/cloudtrail-updated" {
+		t.Errorf("expected the entry to be replaced, got %v", entry)
+	}
+	if len(mergedIndex.Collisions()) != 1 || len(mergedIndex.Entries) != 2 {
+		t.Errorf("expected no new collision nor entry, got %v and %d entries", mergedIndex.Collisions(), len(mergedIndex.Entries))
+	}
+}
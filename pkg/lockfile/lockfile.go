@@ -0,0 +1,160 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lockfile
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// DefaultFilePermissions are the default permissions used for the lockfile.
+	DefaultFilePermissions = 0o644
+	// DefaultDirPermissions are the default permissions used for the lockfile directory.
+	DefaultDirPermissions = 0o755
+)
+
+// Entry records a single installed artifact.
+type Entry struct {
+	// Name of the artifact, as extracted from the reference.
+	Name string `yaml:"name"`
+	// Ref is the reference the user asked for, after being resolved against the indexes.
+	Ref string `yaml:"ref"`
+	// Digest is the digest the reference resolved to at install time.
+	Digest string `yaml:"digest"`
+	// Type of the artifact.
+	Type string `yaml:"type"`
+	// Platform in OS/ARCH format the artifact was installed for.
+	Platform string `yaml:"platform"`
+	// InstalledTimestamp is when the artifact was installed.
+	InstalledTimestamp string `yaml:"installed_timestamp"`
+	// Files are the paths written on disk while installing the artifact.
+	Files []string `yaml:"files,omitempty"`
+	// Digests of the regular files among Files, keyed by path, as they were written while installing the artifact.
+	// They tell whether a file has been modified on disk since then.
+	Digests map[string]string `yaml:"digests,omitempty"`
+	// Provenance is the path of the file holding the SLSA provenance attestations found for the artifact at install time.
+	Provenance string `yaml:"provenance,omitempty"`
+	// SBOM is the path of the file holding the SBOM attached to the artifact, when saved at install time.
+	SBOM string `yaml:"sbom,omitempty"`
+	// VersionedDir is the directory holding the installed versions of the artifact, each in its own subdirectory, and
+	// the "current" symlink to the one in use, when installed with the versioned layout. Files are in the current one.
+	VersionedDir string `yaml:"versioned_dir,omitempty"`
+}
+
+// Lockfile aggregates the entries of the installed artifacts.
+type Lockfile struct {
+	Artifacts []*Entry `yaml:"artifacts"`
+}
+
+// New loads a lockfile from disk. A missing file results in an empty lockfile.
+func New(path string) (*Lockfile, error) {
+	var lock Lockfile
+	file, err := os.ReadFile(filepath.Clean(path))
+	if os.IsNotExist(err) {
+		return &lock, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err = yaml.Unmarshal(file, &lock); err != nil {
+		return nil, fmt.Errorf("unable to parse lockfile %q: %w", path, err)
+	}
+
+	return &lock, nil
+}
+
+// Upsert replaces the entry with the same name if already exists, otherwise just appends it.
+func (l *Lockfile) Upsert(entry *Entry) {
+	for i, e := range l.Artifacts {
+		if entry.Name == e.Name {
+			l.Artifacts[i] = entry
+			return
+		}
+	}
+	l.Artifacts = append(l.Artifacts, entry)
+}
+
+// Get returns the entry with the given name, or nil if not found.
+func (l *Lockfile) Get(name string) *Entry {
+	for _, e := range l.Artifacts {
+		if e.Name == name {
+			return e
+		}
+	}
+
+	return nil
+}
+
+// Remove removes the entry with the given name, returning false if not found.
+func (l *Lockfile) Remove(name string) bool {
+	for i, e := range l.Artifacts {
+		if e.Name == name {
+			l.Artifacts = append(l.Artifacts[:i], l.Artifacts[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+// EntryByFile returns the entry that installed the file at the given path, or nil if not found.
+func (l *Lockfile) EntryByFile(path string) *Entry {
+	for _, e := range l.Artifacts {
+		for _, f := range e.Files {
+			if f == path {
+				return e
+			}
+		}
+	}
+
+	return nil
+}
+
+// FileDigest returns the digest recorded for the file at the given path when installed, or "" if not found.
+func (l *Lockfile) FileDigest(path string) string {
+	for _, e := range l.Artifacts {
+		if d, ok := e.Digests[path]; ok {
+			return d
+		}
+	}
+
+	return ""
+}
+
+// Write writes the lockfile to disk, creating its directory if it does not exist.
+func (l *Lockfile) Write(path string) error {
+	dir, _ := filepath.Split(path)
+	if dir != "" {
+		if _, err := os.Stat(dir); errors.Is(err, fs.ErrNotExist) {
+			if err = os.MkdirAll(dir, DefaultDirPermissions); err != nil {
+				return err
+			}
+		}
+	}
+
+	data, err := yaml.Marshal(l)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, DefaultFilePermissions)
+}
@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lockfile
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMissingFile(t *testing.T) {
+	lock, err := New(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.NoError(t, err)
+	assert.Empty(t, lock.Artifacts)
+}
+
+func TestUpsertAndWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "falcoctl.lock.yaml")
+
+	lock := &Lockfile{}
+	lock.Upsert(&Entry{Name: "cloudtrail", Ref: "ghcr.io/falcosecurity/plugins/plugin/cloudtrail:latest", Digest: "sha256:aaa"})
+	lock.Upsert(&Entry{Name: "k8saudit-rules", Ref: "ghcr.io/falcosecurity/rules/k8saudit-rules:0.5", Digest: "sha256:bbb"})
+	lock.Upsert(&Entry{Name: "cloudtrail", Ref: "ghcr.io/falcosecurity/plugins/plugin/cloudtrail:latest", Digest: "sha256:ccc",
+		Files: []string{"/usr/share/falco/plugins/libcloudtrail.so"}})
+	require.Len(t, lock.Artifacts, 2)
+
+	require.NoError(t, lock.Write(path))
+
+	loaded, err := New(path)
+	require.NoError(t, err)
+	assert.Equal(t, lock, loaded)
+	assert.Equal(t, "sha256:ccc", loaded.Get("cloudtrail").Digest)
+	assert.Nil(t, loaded.Get("missing"))
+	assert.Equal(t, "cloudtrail", loaded.EntryByFile("/usr/share/falco/plugins/libcloudtrail.so").Name)
+	assert.Nil(t, loaded.EntryByFile("/usr/share/falco/plugins/libmissing.so"))
+}
+
+func TestRemove(t *testing.T) {
+	lock := &Lockfile{}
+	lock.Upsert(&Entry{Name: "cloudtrail"})
+	lock.Upsert(&Entry{Name: "k8saudit-rules"})
+
+	assert.True(t, lock.Remove("cloudtrail"))
+	assert.False(t, lock.Remove("cloudtrail"))
+	require.Len(t, lock.Artifacts, 1)
+	assert.Equal(t, "k8saudit-rules", lock.Artifacts[0].Name)
+}
+
+func TestFileDigest(t *testing.T) {
+	lock := &Lockfile{}
+	lock.Upsert(&Entry{Name: "cloudtrail", Digests: map[string]string{"/plugins/libcloudtrail.so": "sha256:aaaa"}})
+	lock.Upsert(&Entry{Name: "k8saudit-rules", Digests: map[string]string{"/rules/k8s_audit_rules.yaml": "sha256:bbbb"}})
+
+	assert.Equal(t, "sha256:bbbb", lock.FileDigest("/rules/k8s_audit_rules.yaml"))
+	assert.Empty(t, lock.FileDigest("/rules/unknown.yaml"))
+}
@@ -22,6 +22,7 @@ import (
 	"oras.land/oras-go/v2/registry/remote/auth"
 
 	"github.com/falcosecurity/falcoctl/internal/login"
+	"github.com/falcosecurity/falcoctl/pkg/oci/registry"
 )
 
 // AutoLoginHandler performs registry logins automatically exactly once.
@@ -29,6 +30,7 @@ type AutoLoginHandler struct {
 	client         *auth.Client
 	autoLoginCache map[string]bool
 	credStore      credentials.Store
+	plainHTTP      bool
 }
 
 // NewAutoLoginHandler creates a new AutoLoginHandler.
@@ -44,7 +46,8 @@ func NewAutoLoginHandler(credStore credentials.Store) *AutoLoginHandler {
 func (a *AutoLoginHandler) Login(ctx context.Context, reg string) error {
 	// only login if we did not already login for this registry
 	if _, exists := a.autoLoginCache[reg]; !exists {
-		return login.PerformAuthsFromConfigWithMap(ctx, a.client, a.credStore, map[string]bool{reg: true})
+		return login.PerformAuthsFromConfigWithMap(ctx, a.client, a.credStore, map[string]bool{reg: true},
+			registry.WithPlainHTTP(a.plainHTTP))
 	}
 	return nil
 }
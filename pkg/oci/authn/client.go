@@ -17,16 +17,22 @@ package authn
 
 import (
 	"context"
+	"crypto/tls"
 	"net"
 	"net/http"
+	"net/url"
+	"slices"
 	"time"
 
 	credentials "github.com/oras-project/oras-credentials-go"
+	"golang.org/x/net/http/httpproxy"
 	"oras.land/oras-go/v2/registry/remote/auth"
 )
 
 const (
 	falcoctlUserAgent = "falcoctl"
+	// DefaultConnectTimeout is the default maximum amount of time a dial to the registry will wait for a connect to complete.
+	DefaultConnectTimeout = 30 * time.Second
 )
 
 // Options used for the HTTP client that can authenticate with auth.Credentials or via OAuth2.0 Options Credentials flow.
@@ -36,12 +42,26 @@ type Options struct {
 	CredentialsFuncs      []func(context.Context, string) (auth.Credential, error)
 	AutoLoginHandler      *AutoLoginHandler
 	ClientTokenCache      auth.Cache
+	Proxy                 func(*http.Request) (*url.URL, error)
+	ConnectTimeout        time.Duration
+	// Anonymous disables all the credential sources, including the auto login.
+	Anonymous bool
+	// PlainHTTP is set to true if the registries are reached in plain http, it is used by the auto login.
+	PlainHTTP bool
+	// TLSConfig is the TLS configuration used to reach the registries, nil means the default one.
+	TLSConfig *tls.Config
+	// Token, when set, holds the bearer or identity token used for the TokenHosts instead of the credential sources.
+	Token *auth.Credential
+	// TokenHosts are the registries, as host[:port], the Token is sent to; the other ones use the credential sources.
+	TokenHosts []string
 }
 
 // NewClient creates a new authenticated client to interact with a remote registry.
 func NewClient(options ...func(*Options)) *auth.Client {
 	opt := &Options{
 		CredentialsFuncsCache: make(map[string]func(context.Context, string) (auth.Credential, error)),
+		Proxy:                 http.ProxyFromEnvironment,
+		ConnectTimeout:        DefaultConnectTimeout,
 	}
 
 	for _, o := range options {
@@ -51,9 +71,9 @@ func NewClient(options ...func(*Options)) *auth.Client {
 	authClient := auth.Client{
 		Client: &http.Client{
 			Transport: &http.Transport{
-				Proxy: http.ProxyFromEnvironment,
+				Proxy: opt.Proxy,
 				DialContext: (&net.Dialer{
-					Timeout:   30 * time.Second,
+					Timeout:   opt.ConnectTimeout,
 					KeepAlive: 30 * time.Second,
 				}).DialContext,
 				ForceAttemptHTTP2:     true,
@@ -61,11 +81,19 @@ func NewClient(options ...func(*Options)) *auth.Client {
 				IdleConnTimeout:       90 * time.Second,
 				TLSHandshakeTimeout:   10 * time.Second,
 				ExpectContinueTimeout: 1 * time.Second,
-				// TODO(loresuso, alacuku): tls config.
+				TLSClientConfig:       opt.TLSConfig,
 			},
 		},
 		Cache: opt.ClientTokenCache,
 		Credential: func(ctx context.Context, reg string) (auth.Credential, error) {
+			if opt.Anonymous {
+				return auth.EmptyCredential, nil
+			}
+
+			if opt.Token != nil && slices.Contains(opt.TokenHosts, reg) {
+				return *opt.Token, nil
+			}
+
 			// try cred func from cache first
 			credFunc, exists := opt.CredentialsFuncsCache[reg]
 			if exists {
@@ -99,6 +127,12 @@ func NewClient(options ...func(*Options)) *auth.Client {
 
 	authClient.SetUserAgent(falcoctlUserAgent)
 
+	// The auto login checks the credentials going through the same transport and protocol of the client.
+	if opt.AutoLoginHandler != nil {
+		opt.AutoLoginHandler.client.Client = authClient.Client
+		opt.AutoLoginHandler.plainHTTP = opt.PlainHTTP
+	}
+
 	return &authClient
 }
 
@@ -109,6 +143,53 @@ func WithAutoLogin(handler *AutoLoginHandler) func(c *Options) {
 	}
 }
 
+// WithAnonymous disables all the credential sources of the client: no credential store, credential helper
+// or auto login is ever used and requests are sent without credentials, only anonymous tokens are requested.
+func WithAnonymous(anonymous bool) func(c *Options) {
+	return func(c *Options) {
+		c.Anonymous = anonymous
+	}
+}
+
+// WithPlainHTTP specifies if the registries are reached in plain http.
+func WithPlainHTTP(plainHTTP bool) func(c *Options) {
+	return func(c *Options) {
+		c.PlainHTTP = plainHTTP
+	}
+}
+
+// WithTLSConfig sets the TLS configuration, e.g. custom CA certificates, used to reach the registries.
+func WithTLSConfig(tlsConfig *tls.Config) func(c *Options) {
+	return func(c *Options) {
+		c.TLSConfig = tlsConfig
+	}
+}
+
+// WithToken makes the client send the given bearer token to the given registries, or exchange the given identity
+// token for bearer tokens with them, instead of looking up the credential sources and auto logging in. The other
+// registries, e.g. mirrors or the ones of dependencies, keep using the credential sources. Empty tokens are ignored.
+func WithToken(accessToken, identityToken string, hosts ...string) func(c *Options) {
+	return func(c *Options) {
+		if accessToken == "" && identityToken == "" {
+			return
+		}
+		c.Token = &auth.Credential{
+			AccessToken:  accessToken,
+			RefreshToken: identityToken,
+		}
+		c.TokenHosts = hosts
+	}
+}
+
+// IsAnonymous reports whether the given options configure an anonymous client.
+func IsAnonymous(options ...func(*Options)) bool {
+	opt := &Options{}
+	for _, o := range options {
+		o(opt)
+	}
+	return opt.Anonymous
+}
+
 // EmptyCredentialFunc provides empty auth credentials.
 func EmptyCredentialFunc(context.Context, string) (auth.Credential, error) {
 	return auth.EmptyCredential, nil
@@ -145,9 +226,49 @@ func WithStore(store credentials.Store) func(c *Options) {
 	}
 }
 
+// WithDockerCredentials adds the Docker configuration, see DockerCredential, as credential source to the client.
+func WithDockerCredentials(configPath string) func(c *Options) {
+	return func(c *Options) {
+		c.CredentialsFuncs = append(c.CredentialsFuncs, DockerCredential(configPath))
+	}
+}
+
 // WithClientTokenCache adds a cache to the auth.Client used to store auth tokens.
 func WithClientTokenCache(cache auth.Cache) func(c *Options) {
 	return func(c *Options) {
 		c.ClientTokenCache = cache
 	}
 }
+
+// WithProxy overrides the proxy settings found in the environment (HTTP_PROXY, HTTPS_PROXY and NO_PROXY).
+// When proxyURL is empty the proxy from the environment is kept and only the no-proxy list is replaced.
+// The no-proxy list is a comma-separated list of hosts, domains and CIDRs that must be reached directly.
+func WithProxy(proxyURL, noProxy string) func(c *Options) {
+	return func(c *Options) {
+		if proxyURL == "" && noProxy == "" {
+			return
+		}
+
+		cfg := httpproxy.FromEnvironment()
+		if proxyURL != "" {
+			cfg.HTTPProxy = proxyURL
+			cfg.HTTPSProxy = proxyURL
+		}
+		if noProxy != "" {
+			cfg.NoProxy = noProxy
+		}
+
+		proxyFunc := cfg.ProxyFunc()
+		c.Proxy = func(req *http.Request) (*url.URL, error) {
+			return proxyFunc(req.URL)
+		}
+	}
+}
+
+// WithConnectTimeout sets the maximum amount of time a dial to the registry will wait for a connect to complete.
+// A zero timeout means no timeout, apart from the one enforced by the operating system.
+func WithConnectTimeout(timeout time.Duration) func(c *Options) {
+	return func(c *Options) {
+		c.ConnectTimeout = timeout
+	}
+}
@@ -0,0 +1,174 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authn
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+
+	"github.com/falcosecurity/falcoctl/pkg/oci/registry"
+)
+
+const proxiedRegistry = "http://registry.invalid/v2/"
+
+// newProxy starts a fake forward proxy that answers every request on behalf of the upstream registry.
+func newProxy(t *testing.T, hits *atomic.Int32) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Requests sent to a forward proxy carry the absolute URL of the destination.
+		if r.URL.String() != proxiedRegistry {
+			t.Errorf("unexpected proxied request %q", r.URL.String())
+		}
+		hits.Add(1)
+		w.Header().Set("Docker-Distribution-API-Version", "registry/2.0")
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func get(t *testing.T, opts ...func(*Options)) (*http.Response, error) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, proxiedRegistry, http.NoBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewClient(opts...).Do(req)
+}
+
+func TestNewClientProxyFromEnvironment(t *testing.T) {
+	var hits atomic.Int32
+	proxy := newProxy(t, &hits)
+	t.Setenv("HTTP_PROXY", proxy.URL)
+	t.Setenv("NO_PROXY", "")
+
+	resp, err := get(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if hits.Load() != 1 {
+		t.Fatalf("expected the request to go through the proxy from the environment, got %d hits", hits.Load())
+	}
+}
+
+func TestWithProxy(t *testing.T) {
+	var envHits, hits atomic.Int32
+	envProxy := newProxy(t, &envHits)
+	proxy := newProxy(t, &hits)
+	t.Setenv("HTTP_PROXY", envProxy.URL)
+
+	resp, err := get(t, WithProxy(proxy.URL, ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if hits.Load() != 1 {
+		t.Fatalf("expected the request to go through the given proxy, got %d hits", hits.Load())
+	}
+	if envHits.Load() != 0 {
+		t.Fatalf("expected the proxy from the environment to be overridden, got %d hits", envHits.Load())
+	}
+}
+
+func TestWithProxyNoProxy(t *testing.T) {
+	var hits atomic.Int32
+	proxy := newProxy(t, &hits)
+	t.Setenv("HTTP_PROXY", proxy.URL)
+
+	// The host is not resolvable, so the request fails when it does not go through the proxy.
+	if resp, err := get(t, WithProxy("", "registry.invalid")); err == nil {
+		resp.Body.Close()
+		t.Fatal("expected the request to bypass the proxy and fail")
+	}
+
+	if hits.Load() != 0 {
+		t.Fatalf("expected the request to bypass the proxy, got %d hits", hits.Load())
+	}
+}
+
+func TestWithAnonymous(t *testing.T) {
+	opts := []func(*Options){
+		WithCredentials(&auth.Credential{Username: "user", Password: "pass"}),
+		WithAnonymous(true),
+	}
+	if !IsAnonymous(opts...) {
+		t.Fatal("expected the options to configure an anonymous client")
+	}
+
+	cred, err := NewClient(opts...).Credential(context.Background(), "registry.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cred != auth.EmptyCredential {
+		t.Fatalf("expected anonymous client to never return credentials, got %+v", cred)
+	}
+
+	if IsAnonymous(WithAnonymous(false)) {
+		t.Fatal("expected the options to configure an authenticated client")
+	}
+}
+
+func TestWithToken(t *testing.T) {
+	stored := auth.Credential{Username: "user", Password: "pass"}
+	client := NewClient(
+		WithCredentials(&stored),
+		WithToken("secret", "", "registry.example.com"),
+	)
+
+	cred, err := client.Credential(context.Background(), "registry.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cred.AccessToken != "secret" {
+		t.Fatalf("expected the token to be sent to its registry, got %+v", cred)
+	}
+
+	// Other registries, e.g. mirrors, must not receive the token and keep using the stored credentials.
+	cred, err = client.Credential(context.Background(), "mirror.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cred != stored {
+		t.Fatalf("expected the stored credentials for another registry, got %+v", cred)
+	}
+}
+
+func TestCheckConnectionThroughProxy(t *testing.T) {
+	var hits atomic.Int32
+	proxy := newProxy(t, &hits)
+
+	// The connection check of an anonymous client must go through the client transport as well.
+	client := NewClient(WithProxy(proxy.URL, ""), WithAnonymous(true), WithPlainHTTP(true))
+	reg, err := registry.NewRegistry("registry.invalid", registry.WithClient(client), registry.WithPlainHTTP(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := reg.CheckConnection(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if hits.Load() != 1 {
+		t.Fatalf("expected the connection check to go through the proxy, got %d hits", hits.Load())
+	}
+}
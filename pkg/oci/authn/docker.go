@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authn
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	credentials "github.com/oras-project/oras-credentials-go"
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// DockerCredential returns a credential function backed by the Docker configuration found at configPath,
+// including the configured credential helpers, e.g. ecr-login or gcloud. When configPath is empty the
+// default Docker configuration is used: $DOCKER_CONFIG/config.json or $HOME/.docker/config.json.
+// The configuration is loaded the first time credentials are requested.
+func DockerCredential(configPath string) func(context.Context, string) (auth.Credential, error) {
+	var (
+		once    sync.Once
+		store   credentials.Store
+		loadErr error
+	)
+
+	return func(ctx context.Context, reg string) (auth.Credential, error) {
+		once.Do(func() {
+			if configPath == "" {
+				store, loadErr = credentials.NewStoreFromDocker(credentials.StoreOptions{})
+			} else {
+				store, loadErr = credentials.NewStore(configPath, credentials.StoreOptions{})
+			}
+		})
+
+		var (
+			cred = auth.EmptyCredential
+			err  = loadErr
+		)
+		if err == nil {
+			cred, err = credentials.Credential(store)(ctx, reg)
+		}
+
+		switch {
+		case err == nil:
+			return cred, nil
+		case configPath == "":
+			// The default Docker configuration is only a best effort source: a broken configuration or a missing
+			// credential helper must not prevent interacting with registries that do not need it.
+			return auth.EmptyCredential, nil
+		default:
+			return auth.EmptyCredential, fmt.Errorf("unable to retrieve credentials for %q from docker config %q: %w", reg, configPath, err)
+		}
+	}
+}
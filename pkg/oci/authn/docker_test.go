@@ -0,0 +1,97 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authn
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+func writeDockerConfig(t *testing.T, dir, content string) string {
+	t.Helper()
+	configPath := filepath.Join(dir, "config.json")
+	if err := os.WriteFile(configPath, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return configPath
+}
+
+func dockerAuths(reg, username, password string) string {
+	encoded := base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+	return fmt.Sprintf(`{"auths": {%q: {"auth": %q}}}`, reg, encoded)
+}
+
+func TestDockerCredential(t *testing.T) {
+	configPath := writeDockerConfig(t, t.TempDir(), dockerAuths("registry.example.com", "user", "pass"))
+	credFunc := DockerCredential(configPath)
+
+	cred, err := credFunc(context.Background(), "registry.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cred.Username != "user" || cred.Password != "pass" {
+		t.Fatalf("unexpected credentials: %+v", cred)
+	}
+
+	cred, err = credFunc(context.Background(), "other.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cred != auth.EmptyCredential {
+		t.Fatalf("expected empty credentials for an unknown registry, got %+v", cred)
+	}
+}
+
+func TestDockerCredentialDefaultConfig(t *testing.T) {
+	configDir := t.TempDir()
+	writeDockerConfig(t, configDir, dockerAuths("registry.example.com", "user", "pass"))
+	t.Setenv("DOCKER_CONFIG", configDir)
+
+	cred, err := DockerCredential("")(context.Background(), "registry.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cred.Username != "user" || cred.Password != "pass" {
+		t.Fatalf("unexpected credentials: %+v", cred)
+	}
+}
+
+func TestDockerCredentialMissingHelper(t *testing.T) {
+	configDir := t.TempDir()
+	content := `{"credHelpers": {"registry.example.com": "falcoctl-does-not-exist"}}`
+
+	// Errors are ignored when relying on the default configuration.
+	t.Setenv("DOCKER_CONFIG", configDir)
+	writeDockerConfig(t, configDir, content)
+	cred, err := DockerCredential("")(context.Background(), "registry.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cred != auth.EmptyCredential {
+		t.Fatalf("expected empty credentials, got %+v", cred)
+	}
+
+	// Errors are reported when the configuration is explicitly given.
+	if _, err := DockerCredential(filepath.Join(configDir, "config.json"))(context.Background(), "registry.example.com"); err == nil {
+		t.Fatal("expected an error for the missing credential helper")
+	}
+}
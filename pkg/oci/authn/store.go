@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authn
+
+import (
+	"context"
+
+	credentials "github.com/oras-project/oras-credentials-go"
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// helperStore is a credential store delegating the registries with a configured credential helper to it.
+type helperStore struct {
+	credentials.Store
+	helpers map[string]credentials.Store
+}
+
+// NewStore returns the credential store whose configuration is found at configPath, see credentials.NewStore,
+// where the credentials of the registries listed in helpers are instead retrieved, stored and erased running their
+// credential helper. Helpers map the registries to the suffix of their helper program, e.g. "internal" for
+// "docker-credential-internal", which implements the get, store and erase commands of the Docker credential
+// helper protocol, the same used by the credHelpers of the Docker configuration.
+func NewStore(configPath string, helpers map[string]string, opts credentials.StoreOptions) (credentials.Store, error) {
+	store, err := credentials.NewStore(configPath, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(helpers) == 0 {
+		return store, nil
+	}
+
+	hs := &helperStore{
+		Store:   store,
+		helpers: make(map[string]credentials.Store, len(helpers)),
+	}
+	for reg, helper := range helpers {
+		hs.helpers[credentials.ServerAddressFromRegistry(reg)] = credentials.NewNativeStore(helper)
+	}
+	return hs, nil
+}
+
+// store returns the store holding the credentials of the given server address.
+func (hs *helperStore) store(serverAddress string) credentials.Store {
+	if store, ok := hs.helpers[credentials.ServerAddressFromRegistry(serverAddress)]; ok {
+		return store
+	}
+	return hs.Store
+}
+
+// Get retrieves the credentials of the given server address.
+func (hs *helperStore) Get(ctx context.Context, serverAddress string) (auth.Credential, error) {
+	return hs.store(serverAddress).Get(ctx, serverAddress)
+}
+
+// Put saves the credentials of the given server address.
+func (hs *helperStore) Put(ctx context.Context, serverAddress string, cred auth.Credential) error {
+	return hs.store(serverAddress).Put(ctx, serverAddress, cred)
+}
+
+// Delete removes the credentials of the given server address.
+func (hs *helperStore) Delete(ctx context.Context, serverAddress string) error {
+	return hs.store(serverAddress).Delete(ctx, serverAddress)
+}
@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authn
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	credentials "github.com/oras-project/oras-credentials-go"
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// credentialHelper is a credential helper keeping the credentials of a single server in a file next to it.
+const credentialHelper = `#!/bin/sh
+creds="$(dirname "$0")/creds.json"
+case "$1" in
+get)
+	if [ ! -f "$creds" ]; then
+		echo "credentials not found in native keychain"
+		exit 1
+	fi
+	cat "$creds"
+	;;
+store)
+	cat > "$creds"
+	;;
+erase)
+	rm -f "$creds"
+	;;
+esac
+`
+
+func installCredentialHelper(t *testing.T, suffix string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "docker-credential-"+suffix), []byte(credentialHelper), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return dir
+}
+
+func TestNewStoreCredentialHelper(t *testing.T) {
+	helperDir := installCredentialHelper(t, "falcoctl-test")
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	store, err := NewStore(configPath, map[string]string{"registry.example.com": "falcoctl-test"},
+		credentials.StoreOptions{AllowPlaintextPut: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	cred := auth.Credential{Username: "user", Password: "token"}
+	if err := store.Put(ctx, "registry.example.com", cred); err != nil {
+		t.Fatal(err)
+	}
+	stored, err := os.ReadFile(filepath.Join(helperDir, "creds.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(stored), `"ServerURL":"registry.example.com"`) {
+		t.Fatalf("unexpected credentials stored by the helper: %s", stored)
+	}
+	if _, err := os.Stat(configPath); !os.IsNotExist(err) {
+		t.Fatalf("expected the credentials not to be stored in the config file, got %v", err)
+	}
+
+	got, err := credentials.Credential(store)(ctx, "registry.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != cred {
+		t.Fatalf("expected credentials %+v, got %+v", cred, got)
+	}
+
+	// Registries without a helper are still served by the config file.
+	other := auth.Credential{Username: "other", Password: "pass"}
+	if err := store.Put(ctx, "other.example.com", other); err != nil {
+		t.Fatal(err)
+	}
+	if got, err = store.Get(ctx, "other.example.com"); err != nil || got != other {
+		t.Fatalf("expected credentials %+v from the config file, got %+v (%v)", other, got, err)
+	}
+
+	if err := store.Delete(ctx, "registry.example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if got, err = store.Get(ctx, "registry.example.com"); err != nil || got != auth.EmptyCredential {
+		t.Fatalf("expected no credentials once erased, got %+v (%v)", got, err)
+	}
+}
+
+func TestNewStoreMissingCredentialHelper(t *testing.T) {
+	store, err := NewStore(filepath.Join(t.TempDir(), "config.json"), map[string]string{"registry.example.com": "falcoctl-does-not-exist"},
+		credentials.StoreOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := store.Get(context.Background(), "registry.example.com"); err == nil {
+		t.Fatal("expected an error running a missing credential helper")
+	}
+}
@@ -0,0 +1,353 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/oci"
+
+	falcooci "github.com/falcosecurity/falcoctl/pkg/oci"
+)
+
+var (
+	// ErrInvalidNumberLayers error when rulesfiles and assets do not have exactly one layer.
+	ErrInvalidNumberLayers = errors.New("invalid number of layers")
+	// ErrInvalidPlatform error when the platform of a plugin layer is missing, invalid or repeated.
+	ErrInvalidPlatform = errors.New("invalid platform")
+)
+
+// Layer is the content of an artifact for a platform.
+type Layer struct {
+	// Path is either a tar.gz archive, stored as it is, or a file or a directory of loose files, e.g. rulesfiles,
+	// archived with the paths relative to the directory.
+	Path string
+	// Platform is the platform in OS/ARCH[/VARIANT] format of the layer. It is required for plugins, which have
+	// a layer for each platform, and ignored for the other types.
+	Platform string
+}
+
+// Build assembles in target an artifact of the given type holding the given layers, and returns the descriptor of
+// its root: the image manifest, or the image index listing a manifest for each platform of a plugin. The blobs
+// already in target are not stored again, and nothing is tagged.
+func Build(ctx context.Context, target oras.Target, artifactType falcooci.ArtifactType, layers []Layer,
+	options ...Option) (*v1.Descriptor, error) {
+	o := &opts{}
+	for _, f := range options {
+		f(o)
+	}
+
+	layerMediaType, err := layerMediaType(artifactType)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateLayers(artifactType, layers); err != nil {
+		return nil, err
+	}
+
+	configDesc, err := pushJSON(ctx, target, artifactType.ToConfigMediaType(), o.config)
+	if err != nil {
+		return nil, fmt.Errorf("unable to store config layer: %w", err)
+	}
+
+	// Loose files are archived in a temporary directory before being stored.
+	tmpDir, err := os.MkdirTemp("", "falcoctl-build-")
+	if err != nil {
+		return nil, fmt.Errorf("cannot create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	manifestDescs := make([]v1.Descriptor, len(layers))
+	for i, layer := range layers {
+		dataDesc, err := pushLayer(ctx, target, layerMediaType, layer.Path, tmpDir)
+		if err != nil {
+			return nil, err
+		}
+
+		packOptions := oras.PackManifestOptions{
+			Layers:              []v1.Descriptor{*dataDesc},
+			ConfigDescriptor:    configDesc,
+			ManifestAnnotations: o.annotations,
+		}
+		manifestDesc, err := oras.PackManifest(ctx, target, oras.PackManifestVersion1_0, "", packOptions)
+		if err != nil {
+			return nil, fmt.Errorf("unable to generate manifest for layer %q: %w", layer.Path, err)
+		}
+		if artifactType == falcooci.Plugin {
+			manifestDesc.Platform = parsePlatform(layer.Platform)
+		}
+		manifestDescs[i] = manifestDesc
+	}
+
+	if artifactType != falcooci.Plugin {
+		return &manifestDescs[0], nil
+	}
+
+	index := v1.Index{
+		Versioned:   specs.Versioned{SchemaVersion: 2},
+		MediaType:   v1.MediaTypeImageIndex,
+		Manifests:   manifestDescs,
+		Annotations: o.annotations,
+	}
+	indexDesc, err := pushJSON(ctx, target, v1.MediaTypeImageIndex, index)
+	if err != nil {
+		return nil, fmt.Errorf("unable to store image index: %w", err)
+	}
+	return indexDesc, nil
+}
+
+// BuildLayout assembles the artifact in the OCI image layout at dir, created if missing, and tags it with ref,
+// e.g. "ghcr.io/falcosecurity/rules/my-rules:0.1.0", as the layouts exported by falcoctl do. The layout can be
+// pushed as it is or given to "falcoctl artifact install --from-dir".
+func BuildLayout(ctx context.Context, dir, ref string, artifactType falcooci.ArtifactType, layers []Layer,
+	options ...Option) (*v1.Descriptor, error) {
+	store, err := oci.New(dir)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open OCI layout %q: %w", dir, err)
+	}
+
+	desc, err := Build(ctx, store, artifactType, layers, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := store.Tag(ctx, *desc, ref); err != nil {
+		return nil, fmt.Errorf("unable to tag artifact as %q in OCI layout %q: %w", ref, dir, err)
+	}
+	return desc, nil
+}
+
+// layerMediaType returns the media type of the layers of the given artifact type.
+func layerMediaType(artifactType falcooci.ArtifactType) (string, error) {
+	switch artifactType {
+	case falcooci.Rulesfile:
+		return falcooci.FalcoRulesfileLayerMediaType, nil
+	case falcooci.Plugin:
+		return falcooci.FalcoPluginLayerMediaType, nil
+	case falcooci.Asset:
+		return falcooci.FalcoAssetLayerMediaType, nil
+	default:
+		return "", fmt.Errorf("unknown media type for artifact type %q", artifactType)
+	}
+}
+
+// validateLayers makes sure that rulesfiles and assets have exactly one layer, and that plugins have a layer for
+// each of their platforms.
+func validateLayers(artifactType falcooci.ArtifactType, layers []Layer) error {
+	if artifactType != falcooci.Plugin {
+		if len(layers) != 1 {
+			return fmt.Errorf("expecting 1 layer for %s artifacts, received %d: %w", artifactType, len(layers), ErrInvalidNumberLayers)
+		}
+		return nil
+	}
+
+	if len(layers) == 0 {
+		return fmt.Errorf("expecting at least 1 layer for plugin artifacts: %w", ErrInvalidNumberLayers)
+	}
+	platforms := make(map[string]bool, len(layers))
+	for _, layer := range layers {
+		if parsePlatform(layer.Platform) == nil {
+			return fmt.Errorf("platform %q of layer %q needs to be in OS/ARCH[/VARIANT] format: %w", layer.Platform, layer.Path,
+				ErrInvalidPlatform)
+		}
+		if platforms[layer.Platform] {
+			return fmt.Errorf("platform %q given for more than one layer: %w", layer.Platform, ErrInvalidPlatform)
+		}
+		platforms[layer.Platform] = true
+	}
+	return nil
+}
+
+// parsePlatform parses a platform in OS/ARCH[/VARIANT] format, returning nil when invalid.
+func parsePlatform(platform string) *v1.Platform {
+	tokens := strings.Split(platform, "/")
+	if len(tokens) < 2 || len(tokens) > 3 {
+		return nil
+	}
+	for _, token := range tokens {
+		if token == "" {
+			return nil
+		}
+	}
+
+	p := &v1.Platform{OS: tokens[0], Architecture: tokens[1]}
+	if len(tokens) == 3 {
+		p.Variant = tokens[2]
+	}
+	return p
+}
+
+// pushLayer stores the layer at path in target, archiving it in tmpDir first unless it is already a tar.gz archive.
+// The name of the archive is recorded in the title annotation, as the file name used when pulling the artifact.
+func pushLayer(ctx context.Context, target oras.Target, mediaType, path, tmpDir string) (*v1.Descriptor, error) {
+	archive := filepath.Clean(path)
+	if !isTarGz(path) {
+		var err error
+		if archive, err = archiveTarGz(path, tmpDir); err != nil {
+			return nil, fmt.Errorf("unable to archive %q: %w", path, err)
+		}
+	}
+
+	f, err := os.Open(archive)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open layer %q: %w", path, err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("unable to open layer %q: %w", path, err)
+	}
+	dgst, err := digest.FromReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("unable to compute digest of layer %q: %w", path, err)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("unable to read layer %q: %w", path, err)
+	}
+
+	desc := v1.Descriptor{
+		MediaType:   mediaType,
+		Digest:      dgst,
+		Size:        info.Size(),
+		Annotations: map[string]string{v1.AnnotationTitle: filepath.Base(archive)},
+	}
+	if err := pushIfMissing(ctx, target, desc, f); err != nil {
+		return nil, fmt.Errorf("unable to store layer %q: %w", path, err)
+	}
+	return &desc, nil
+}
+
+// pushJSON stores data in target, marshaled to JSON, with the given media type.
+func pushJSON(ctx context.Context, target oras.Target, mediaType string, data interface{}) (*v1.Descriptor, error) {
+	dataBytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal data of media type %q: %w", mediaType, err)
+	}
+
+	desc := content.NewDescriptorFromBytes(mediaType, dataBytes)
+	if err := pushIfMissing(ctx, target, desc, bytes.NewReader(dataBytes)); err != nil {
+		return nil, err
+	}
+	return &desc, nil
+}
+
+// pushIfMissing stores the content read from r in target, unless target already holds it.
+func pushIfMissing(ctx context.Context, target oras.Target, desc v1.Descriptor, r io.Reader) error {
+	exists, err := target.Exists(ctx, desc)
+	if err != nil || exists {
+		return err
+	}
+	return target.Push(ctx, desc, r)
+}
+
+// isTarGz returns true when path names a tar.gz archive.
+func isTarGz(path string) bool {
+	return strings.HasSuffix(path, ".tar.gz") || strings.HasSuffix(path, ".tgz")
+}
+
+// archiveTarGz writes to tmpDir a tar.gz archive holding the file at path, or the content of the directory at path
+// with the paths relative to it, and returns the path of the archive, named after the file or directory.
+func archiveTarGz(path, tmpDir string) (archive string, err error) {
+	root := filepath.Clean(path)
+	info, err := os.Stat(root)
+	if err != nil {
+		return "", err
+	}
+
+	name := filepath.Base(root)
+	if !info.IsDir() {
+		name = strings.TrimSuffix(name, filepath.Ext(name))
+	}
+	archive = filepath.Join(tmpDir, name+".tar.gz")
+
+	out, err := os.Create(filepath.Clean(archive))
+	if err != nil {
+		return "", err
+	}
+	gzw := gzip.NewWriter(out)
+	tw := tar.NewWriter(gzw)
+	defer func() {
+		err = errors.Join(err, tw.Close(), gzw.Close(), out.Close())
+	}()
+
+	if !info.IsDir() {
+		return archive, addToTar(tw, root, filepath.Base(root), info)
+	}
+
+	err = filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || p == root {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		return addToTar(tw, p, filepath.ToSlash(rel), info)
+	})
+	return archive, err
+}
+
+// addToTar writes to tw the entry named name of the regular file or directory at path.
+func addToTar(tw *tar.Writer, path, name string, info fs.FileInfo) error {
+	if !info.IsDir() && !info.Mode().IsRegular() {
+		return fmt.Errorf("%q is neither a regular file nor a directory", path)
+	}
+
+	header, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return err
+	}
+	header.Name = name
+	if info.IsDir() {
+		header.Name += "/"
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return nil
+	}
+
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(tw, f)
+	return err
+}
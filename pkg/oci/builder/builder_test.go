@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"oras.land/oras-go/v2/content/memory"
+
+	"github.com/falcosecurity/falcoctl/internal/utils"
+	"github.com/falcosecurity/falcoctl/pkg/oci"
+	ocipuller "github.com/falcosecurity/falcoctl/pkg/oci/puller"
+)
+
+func TestBuildLayoutRulesfile(t *testing.T) {
+	ctx := context.Background()
+	rulesDir := filepath.Join(t.TempDir(), "my-rules")
+	require.NoError(t, os.MkdirAll(filepath.Join(rulesDir, "extra"), 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(rulesDir, "my_rules.yaml"), []byte("- rule: my rule\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(rulesDir, "extra", "other_rules.yaml"), []byte("- rule: other\n"), 0o600))
+
+	layoutDir := t.TempDir()
+	const ref = "ghcr.io/falcosecurity/rules/my-rules:0.1.0"
+	desc, err := BuildLayout(ctx, layoutDir, ref, oci.Rulesfile, []Layer{{Path: rulesDir}},
+		WithConfig(oci.ArtifactConfig{Name: "my-rules", Version: "0.1.0"}),
+		WithAnnotations(map[string]string{oci.FalcoMinVersionAnnotation: "0.37.0"}))
+	require.NoError(t, err)
+	assert.Equal(t, v1.MediaTypeImageManifest, desc.MediaType)
+
+	// The layout is read as the ones given to the install through --from-dir.
+	source, err := ocipuller.NewLocalSource(ctx, layoutDir)
+	require.NoError(t, err)
+	puller := ocipuller.NewPuller(nil, false, nil, ocipuller.WithSource(source))
+
+	metadata, err := puller.Metadata(ctx, ref, "linux", "amd64")
+	require.NoError(t, err)
+	assert.Equal(t, oci.Rulesfile, metadata.Type)
+	assert.Equal(t, desc.Digest.String(), metadata.Digest)
+	assert.Equal(t, "my-rules", metadata.Config.Name)
+	assert.Equal(t, "0.37.0", metadata.Annotations[oci.FalcoMinVersionAnnotation])
+	require.Len(t, metadata.Layers, 1)
+	assert.Equal(t, oci.FalcoRulesfileLayerMediaType, metadata.Layers[0].MediaType)
+
+	pullDir := t.TempDir()
+	result, err := puller.Pull(ctx, ref, pullDir, "linux", "amd64")
+	require.NoError(t, err)
+	assert.Equal(t, "my-rules.tar.gz", result.Filename)
+
+	f, err := os.Open(filepath.Join(pullDir, result.Filename))
+	require.NoError(t, err)
+	defer f.Close()
+	destDir := t.TempDir()
+	_, err = utils.ExtractTarGz(ctx, f, destDir, 0)
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(destDir, "my_rules.yaml"))
+	assert.FileExists(t, filepath.Join(destDir, "extra", "other_rules.yaml"))
+}
+
+func TestBuildPlugin(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	for _, name := range []string{"libamd64.so", "libarm64.so"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(name), 0o600))
+	}
+
+	store := memory.New()
+	desc, err := Build(ctx, store, oci.Plugin, []Layer{
+		{Path: filepath.Join(dir, "libamd64.so"), Platform: "linux/amd64"},
+		{Path: filepath.Join(dir, "libarm64.so"), Platform: "linux/arm64/v8"},
+	}, WithConfig(oci.ArtifactConfig{Name: "my-plugin", Version: "0.1.0"}))
+	require.NoError(t, err)
+	assert.Equal(t, v1.MediaTypeImageIndex, desc.MediaType)
+	exists, err := store.Exists(ctx, *desc)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	// Building again the same artifact stores nothing new and gives the same digest.
+	again, err := Build(ctx, store, oci.Plugin, []Layer{
+		{Path: filepath.Join(dir, "libamd64.so"), Platform: "linux/amd64"},
+		{Path: filepath.Join(dir, "libarm64.so"), Platform: "linux/arm64/v8"},
+	}, WithConfig(oci.ArtifactConfig{Name: "my-plugin", Version: "0.1.0"}))
+	require.NoError(t, err)
+	assert.Equal(t, desc.Digest, again.Digest)
+}
+
+func TestBuildInvalidLayers(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("- rule: my rule\n"), 0o600))
+
+	tests := []struct {
+		name         string
+		artifactType oci.ArtifactType
+		layers       []Layer
+		wantErr      error
+	}{
+		{name: "no layers", artifactType: oci.Rulesfile, wantErr: ErrInvalidNumberLayers},
+		{name: "several rulesfile layers", artifactType: oci.Rulesfile, layers: []Layer{{Path: path}, {Path: path}},
+			wantErr: ErrInvalidNumberLayers},
+		{name: "no plugin layers", artifactType: oci.Plugin, wantErr: ErrInvalidNumberLayers},
+		{name: "missing platform", artifactType: oci.Plugin, layers: []Layer{{Path: path}}, wantErr: ErrInvalidPlatform},
+		{name: "invalid platform", artifactType: oci.Plugin, layers: []Layer{{Path: path, Platform: "linux"}},
+			wantErr: ErrInvalidPlatform},
+		{name: "repeated platform", artifactType: oci.Plugin,
+			layers:  []Layer{{Path: path, Platform: "linux/amd64"}, {Path: path, Platform: "linux/amd64"}},
+			wantErr: ErrInvalidPlatform},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Build(ctx, memory.New(), tt.artifactType, tt.layers)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+
+	_, err := Build(ctx, memory.New(), oci.Rulesfile, []Layer{{Path: filepath.Join(t.TempDir(), "missing")}})
+	assert.ErrorContains(t, err, "unable to archive")
+}
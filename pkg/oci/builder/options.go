@@ -0,0 +1,42 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder
+
+import (
+	"github.com/falcosecurity/falcoctl/pkg/oci"
+)
+
+type opts struct {
+	config      oci.ArtifactConfig
+	annotations map[string]string
+}
+
+// Option is a functional option for Build.
+type Option func(*opts)
+
+// WithConfig sets the config layer of the artifact, holding its name, version, dependencies and requirements.
+func WithConfig(config oci.ArtifactConfig) Option {
+	return func(o *opts) {
+		o.config = config
+	}
+}
+
+// WithAnnotations sets the annotations of the manifests of the artifact, and of its image index for plugins.
+func WithAnnotations(annotations map[string]string) Option {
+	return func(o *opts) {
+		o.annotations = annotations
+	}
+}
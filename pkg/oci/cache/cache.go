@@ -0,0 +1,219 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content/oci"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry"
+)
+
+// partialDir is the directory, under the cache one, holding the partially downloaded layers.
+const partialDir = "partial"
+
+// Cache is a content-addressable store, laid out as an OCI image layout, where the manifests and layers pulled
+// from the registries are kept, keyed by digest, so that they are not downloaded again.
+type Cache struct {
+	dir   string
+	store *oci.Store
+}
+
+// New opens the cache in the given directory, creating it if it does not exist.
+func New(ctx context.Context, dir string) (*Cache, error) {
+	store, err := oci.NewWithContext(ctx, dir)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open cache in %q: %w", dir, err)
+	}
+	// Contents are only removed on Prune and Clean, even when no more referenced by a cached manifest.
+	store.AutoGC = false
+
+	return &Cache{dir: dir, store: store}, nil
+}
+
+// Dir returns the directory of the cache.
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+// Target returns a target reading from the cache the contents already stored in it, and from remote the other
+// ones, which are stored in the cache while being read. References are always resolved against remote, unless
+// they point to a digest whose manifest is already cached.
+func (c *Cache) Target(remote oras.ReadOnlyTarget) oras.ReadOnlyTarget {
+	return &cachedTarget{remote: remote, cache: c}
+}
+
+// PartialDir returns the directory of the cache where the partially downloaded layers are kept until completed.
+func (c *Cache) PartialDir() string {
+	return filepath.Join(c.dir, partialDir)
+}
+
+// Clean removes all the contents of the cache.
+func (c *Cache) Clean() error {
+	if err := os.RemoveAll(c.dir); err != nil {
+		return fmt.Errorf("unable to clean cache in %q: %w", c.dir, err)
+	}
+	return nil
+}
+
+// Prune removes the contents of the cache, and the partial downloads, that have not been used since the given time.
+// It returns the number of removed files and the bytes freed.
+func (c *Cache) Prune(ctx context.Context, before time.Time) (removed int, freed int64, err error) {
+	blobsDir := filepath.Join(c.dir, v1.ImageBlobsDir)
+	err = walkStale(blobsDir, before, func(path string, info fs.FileInfo) error {
+		algorithm := filepath.Base(filepath.Dir(path))
+		dgst := digest.NewDigestFromEncoded(digest.Algorithm(algorithm), info.Name())
+		// Manifests are deleted through the store, so that they are removed from its index too.
+		if desc, err := c.store.Resolve(ctx, dgst.String()); err == nil && desc.Size == info.Size() {
+			if err := c.store.Delete(ctx, desc); err != nil && !errors.Is(err, errdef.ErrNotFound) {
+				return err
+			}
+		} else if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+
+		removed++
+		freed += info.Size()
+		return nil
+	})
+	if err == nil {
+		err = walkStale(c.PartialDir(), before, func(path string, info fs.FileInfo) error {
+			if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return err
+			}
+			removed++
+			freed += info.Size()
+			return nil
+		})
+	}
+	if err != nil {
+		return removed, freed, fmt.Errorf("unable to prune cache in %q: %w", c.dir, err)
+	}
+
+	return removed, freed, nil
+}
+
+// walkStale calls fn for each file under dir last modified before the given time.
+func walkStale(dir string, before time.Time, fn func(path string, info fs.FileInfo) error) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if !info.ModTime().Before(before) {
+			return nil
+		}
+
+		return fn(path, info)
+	})
+}
+
+// blobPath returns the path where the blob with the given digest is stored.
+func (c *Cache) blobPath(dgst digest.Digest) string {
+	return filepath.Join(c.dir, v1.ImageBlobsDir, dgst.Algorithm().String(), dgst.Encoded())
+}
+
+// cachedTarget reads from the cache, falling back to the remote target for the contents not cached yet.
+type cachedTarget struct {
+	remote oras.ReadOnlyTarget
+	cache  *Cache
+}
+
+// Resolve resolves the reference against the cache when it points to a cached digest, against remote otherwise.
+func (t *cachedTarget) Resolve(ctx context.Context, reference string) (v1.Descriptor, error) {
+	if ref, err := registry.ParseReference(reference); err == nil && ref.ValidateReferenceAsDigest() == nil {
+		if desc, err := t.cache.store.Resolve(ctx, ref.Reference); err == nil && desc.MediaType != "" {
+			if exists, err := t.cache.store.Exists(ctx, desc); err == nil && exists {
+				return desc, nil
+			}
+		}
+	}
+
+	return t.remote.Resolve(ctx, reference)
+}
+
+// Exists returns true if the content exists either in the cache or in remote.
+func (t *cachedTarget) Exists(ctx context.Context, target v1.Descriptor) (bool, error) {
+	if exists, err := t.cache.store.Exists(ctx, target); err == nil && exists {
+		return true, nil
+	}
+
+	return t.remote.Exists(ctx, target)
+}
+
+// Fetch reads the content from the cache. Contents not cached yet are fetched from remote and stored in the cache
+// first. When the cache cannot be written, the content is read from remote directly.
+func (t *cachedTarget) Fetch(ctx context.Context, target v1.Descriptor) (io.ReadCloser, error) {
+	if rc, err := t.fetchCached(ctx, target); err == nil {
+		return rc, nil
+	}
+
+	rc, err := t.remote.Fetch(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+	// The store verifies the size and digest of the content while pushing it.
+	err = t.cache.store.Push(ctx, target, rc)
+	rc.Close()
+	if err != nil && !errors.Is(err, errdef.ErrAlreadyExists) {
+		return t.remote.Fetch(ctx, target)
+	}
+
+	if rc, err := t.fetchCached(ctx, target); err == nil {
+		return rc, nil
+	}
+	return t.remote.Fetch(ctx, target)
+}
+
+// fetchCached reads the content from the cache, marking it as recently used.
+func (t *cachedTarget) fetchCached(ctx context.Context, target v1.Descriptor) (io.ReadCloser, error) {
+	exists, err := t.cache.store.Exists(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("%s: %w", target.Digest, errdef.ErrNotFound)
+	}
+
+	// The modification time tracks the last use of the content, see Prune.
+	now := time.Now()
+	_ = os.Chtimes(t.cache.blobPath(target.Digest), now, now)
+
+	return t.cache.store.Fetch(ctx, target)
+}
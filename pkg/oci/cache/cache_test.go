@@ -0,0 +1,234 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+)
+
+// pushManifest pushes to the store a manifest with a single layer, returning the descriptors of both.
+func pushManifest(ctx context.Context, t *testing.T, store *memory.Store) (manifestDesc, layerDesc v1.Descriptor) {
+	t.Helper()
+
+	layer := []byte("layer content")
+	layerDesc = content.NewDescriptorFromBytes(v1.MediaTypeImageLayerGzip, layer)
+	if err := store.Push(ctx, layerDesc, bytes.NewReader(layer)); err != nil {
+		t.Fatal(err)
+	}
+
+	manifest, err := json.Marshal(v1.Manifest{
+		MediaType: v1.MediaTypeImageManifest,
+		Config:    v1.DescriptorEmptyJSON,
+		Layers:    []v1.Descriptor{layerDesc},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifestDesc = content.NewDescriptorFromBytes(v1.MediaTypeImageManifest, manifest)
+	if err := store.Push(ctx, manifestDesc, bytes.NewReader(manifest)); err != nil {
+		t.Fatal(err)
+	}
+
+	return manifestDesc, layerDesc
+}
+
+func TestTargetFetch(t *testing.T) {
+	ctx := context.Background()
+	remote := memory.New()
+	manifestDesc, layerDesc := pushManifest(ctx, t, remote)
+
+	cache, err := New(ctx, t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := content.FetchAll(ctx, cache.Target(remote), layerDesc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "layer content" {
+		t.Errorf("unexpected layer content %q", data)
+	}
+	if _, err := content.FetchAll(ctx, cache.Target(remote), manifestDesc); err != nil {
+		t.Fatal(err)
+	}
+
+	// Once cached, contents are read without contacting remote at all.
+	target := cache.Target(memory.New())
+	if data, err = content.FetchAll(ctx, target, layerDesc); err != nil {
+		t.Fatalf("expected layer to be read from the cache: %v", err)
+	}
+	if string(data) != "layer content" {
+		t.Errorf("unexpected cached layer content %q", data)
+	}
+
+	desc, err := target.Resolve(ctx, "localhost:5000/repo@"+manifestDesc.Digest.String())
+	if err != nil {
+		t.Fatalf("expected manifest digest to be resolved from the cache: %v", err)
+	}
+	if desc.Digest != manifestDesc.Digest || desc.MediaType != v1.MediaTypeImageManifest {
+		t.Errorf("unexpected descriptor %+v", desc)
+	}
+
+	if _, err := target.Resolve(ctx, "localhost:5000/repo:latest"); err == nil {
+		t.Errorf("expected tags to be resolved against remote")
+	}
+}
+
+func TestTargetFetchCorrupted(t *testing.T) {
+	ctx := context.Background()
+	remote := memory.New()
+	_, layerDesc := pushManifest(ctx, t, remote)
+
+	cache, err := New(ctx, t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A descriptor not matching the remote content must not end up in the cache.
+	wrongDesc := layerDesc
+	wrongDesc.Digest = digest.FromString("something else")
+	if _, err := content.FetchAll(ctx, cache.Target(remote), wrongDesc); err == nil {
+		t.Errorf("expected fetching a non existing content to fail")
+	}
+	if _, err := os.Stat(cache.blobPath(wrongDesc.Digest)); !os.IsNotExist(err) {
+		t.Errorf("expected no blob to be cached for %s", wrongDesc.Digest)
+	}
+}
+
+func TestPrune(t *testing.T) {
+	ctx := context.Background()
+	remote := memory.New()
+	manifestDesc, layerDesc := pushManifest(ctx, t, remote)
+
+	cache, err := New(ctx, t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, desc := range []v1.Descriptor{manifestDesc, layerDesc} {
+		if _, err := content.FetchAll(ctx, cache.Target(remote), desc); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Only the layer has not been used recently.
+	old := time.Now().Add(-48 * time.Hour)
+	if err := os.Chtimes(cache.blobPath(layerDesc.Digest), old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	removed, freed, err := cache.Prune(ctx, time.Now().Add(-24*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed != 1 || freed != layerDesc.Size {
+		t.Errorf("expected 1 blob and %d bytes to be pruned, got %d and %d", layerDesc.Size, removed, freed)
+	}
+	if _, err := os.Stat(cache.blobPath(layerDesc.Digest)); !os.IsNotExist(err) {
+		t.Errorf("expected layer to be pruned")
+	}
+	if _, err := os.Stat(cache.blobPath(manifestDesc.Digest)); err != nil {
+		t.Errorf("expected manifest to be kept: %v", err)
+	}
+
+	// Pruned contents are fetched from remote again.
+	if _, err := content.FetchAll(ctx, cache.Target(remote), layerDesc); err != nil {
+		t.Fatal(err)
+	}
+
+	// Pruned manifests are not resolved from the cache anymore.
+	if err := os.Chtimes(cache.blobPath(manifestDesc.Digest), old, old); err != nil {
+		t.Fatal(err)
+	}
+	if removed, _, err = cache.Prune(ctx, time.Now().Add(-24*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if removed != 1 {
+		t.Errorf("expected 1 blob to be pruned, got %d", removed)
+	}
+	if _, err := cache.Target(memory.New()).Resolve(ctx, "localhost:5000/repo@"+manifestDesc.Digest.String()); err == nil {
+		t.Errorf("expected pruned manifest not to be resolved from the cache")
+	}
+}
+
+func TestClean(t *testing.T) {
+	ctx := context.Background()
+	remote := memory.New()
+	_, layerDesc := pushManifest(ctx, t, remote)
+
+	cache, err := New(ctx, t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := content.FetchAll(ctx, cache.Target(remote), layerDesc); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cache.Clean(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(cache.Dir()); !os.IsNotExist(err) {
+		t.Errorf("expected cache directory to be removed")
+	}
+}
+
+func TestPrunePartialDownloads(t *testing.T) {
+	ctx := context.Background()
+	cache, err := New(ctx, t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.MkdirAll(cache.PartialDir(), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	stale := filepath.Join(cache.PartialDir(), "stale.part")
+	fresh := filepath.Join(cache.PartialDir(), "fresh.part")
+	for _, path := range []string{stale, fresh} {
+		if err := os.WriteFile(path, []byte("partial"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	old := time.Now().Add(-48 * time.Hour)
+	if err := os.Chtimes(stale, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	removed, _, err := cache.Prune(ctx, time.Now().Add(-24*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed != 1 {
+		t.Errorf("expected 1 partial download to be pruned, got %d", removed)
+	}
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Errorf("expected stale partial download to be pruned")
+	}
+	if _, err := os.Stat(fresh); err != nil {
+		t.Errorf("expected fresh partial download to be kept: %v", err)
+	}
+}
@@ -35,6 +35,25 @@ const (
 	// FalcoAssetLayerMediaType is the MediaType for assets.
 	FalcoAssetLayerMediaType = "application/vnd.cncf.falco.asset.layer.v1+tar.gz"
 
+	// FalcoMinVersionAnnotation is the manifest annotation declaring the minimum Falco version an artifact supports.
+	FalcoMinVersionAnnotation = "io.falcosecurity.falco.version.min"
+
+	// FalcoMaxVersionAnnotation is the manifest annotation declaring the maximum Falco version an artifact supports.
+	FalcoMaxVersionAnnotation = "io.falcosecurity.falco.version.max"
+
+	// DSSEEnvelopeMediaType is the MediaType of the layers holding in-toto attestations wrapped in DSSE envelopes,
+	// as attached to artifacts by cosign.
+	DSSEEnvelopeMediaType = "application/vnd.dsse.envelope.v1+json"
+
+	// SLSAProvenancePredicatePrefix prefixes the in-toto predicate types of the SLSA provenance attestations.
+	SLSAProvenancePredicatePrefix = "https://slsa.dev/provenance/"
+
+	// SPDXMediaType is the artifact type of the SBOMs in the SPDX JSON format attached to artifacts.
+	SPDXMediaType = "application/spdx+json"
+
+	// CycloneDXMediaType is the artifact type of the SBOMs in the CycloneDX JSON format attached to artifacts.
+	CycloneDXMediaType = "application/vnd.cyclonedx+json"
+
 	// DefaultTag is the default tag reference to be used when none is provided.
 	DefaultTag = "latest"
 )
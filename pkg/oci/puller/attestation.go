@@ -0,0 +1,139 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package puller
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+
+	"github.com/falcosecurity/falcoctl/pkg/oci"
+	"github.com/falcosecurity/falcoctl/pkg/oci/repository"
+)
+
+// Attestation is an in-toto attestation attached to an artifact.
+type Attestation struct {
+	// PredicateType of the in-toto statement, e.g. "https://slsa.dev/provenance/v1".
+	PredicateType string
+	// Envelope is the DSSE envelope wrapping the statement, as stored in the registry.
+	Envelope []byte
+}
+
+// IsSLSAProvenance tells whether the attestation is a SLSA provenance.
+func (a *Attestation) IsSLSAProvenance() bool {
+	return strings.HasPrefix(a.PredicateType, oci.SLSAProvenancePredicatePrefix)
+}
+
+// dsseEnvelope is the part of a DSSE envelope needed to read the statement it wraps.
+type dsseEnvelope struct {
+	Payload string `json:"payload"`
+}
+
+// inTotoStatement is the part of an in-toto statement needed to tell what it attests.
+type inTotoStatement struct {
+	PredicateType string `json:"predicateType"`
+}
+
+// AttestationTag returns the tag under which cosign stores the attestations of the artifact with the given digest.
+func AttestationTag(dgst string) string {
+	return strings.Replace(dgst, ":", "-", 1) + ".att"
+}
+
+// Attestations retrieves the in-toto attestations attached to the artifact pointed by ref, which must be a digest
+// reference. Following the cosign convention, they are the DSSE envelopes in the layers of the manifest tagged
+// with AttestationTag in the repository of the artifact. No attestation, and no error, is returned when there is
+// no such manifest. Mirrors are not considered, since the attestations must come from the repository that served
+// the artifact.
+func (p *Puller) Attestations(ctx context.Context, ref string) ([]Attestation, error) {
+	repo, err := repository.NewRepository(ref, repository.WithClient(p.Client), repository.WithPlainHTTP(p.plainHTTP))
+	if err != nil {
+		return nil, err
+	}
+
+	dgst := repo.Reference.Reference
+	if !strings.Contains(dgst, ":") {
+		return nil, fmt.Errorf("unable to retrieve attestations of %q: not a digest reference", ref)
+	}
+	attRef := fmt.Sprintf("%s/%s:%s", repo.Reference.Registry, repo.Reference.Repository, AttestationTag(dgst))
+
+	src, srcRef, err := p.target(ctx, repo, attRef)
+	if errors.Is(err, errdef.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch attestations %q: %w", attRef, err)
+	}
+
+	_, manifestBytes, err := oras.FetchBytes(ctx, src, srcRef, oras.DefaultFetchBytesOptions)
+	if errors.Is(err, errdef.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch attestations %q: %w", attRef, err)
+	}
+
+	var manifest v1.Manifest
+	if err := json.Unmarshal(manifestBytes, &manifest); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal attestations manifest: %w", err)
+	}
+
+	var attestations []Attestation
+	for _, layer := range manifest.Layers {
+		if layer.MediaType != oci.DSSEEnvelopeMediaType {
+			continue
+		}
+
+		envelope, err := content.FetchAll(ctx, src, layer)
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch attestation %s: %w", layer.Digest, err)
+		}
+
+		predicateType, err := predicateTypeOf(envelope)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read attestation %s: %w", layer.Digest, err)
+		}
+		attestations = append(attestations, Attestation{PredicateType: predicateType, Envelope: envelope})
+	}
+
+	return attestations, nil
+}
+
+// predicateTypeOf returns the predicate type of the in-toto statement wrapped in the DSSE envelope.
+func predicateTypeOf(envelope []byte) (string, error) {
+	var env dsseEnvelope
+	if err := json.Unmarshal(envelope, &env); err != nil {
+		return "", fmt.Errorf("unable to unmarshal DSSE envelope: %w", err)
+	}
+
+	payload, err := base64.StdEncoding.DecodeString(env.Payload)
+	if err != nil {
+		return "", fmt.Errorf("unable to decode DSSE payload: %w", err)
+	}
+
+	var statement inTotoStatement
+	if err := json.Unmarshal(payload, &statement); err != nil {
+		return "", fmt.Errorf("unable to unmarshal in-toto statement: %w", err)
+	}
+	return statement.PredicateType, nil
+}
@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package puller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content/oci"
+	"oras.land/oras-go/v2/errdef"
+)
+
+// NewLocalSource returns a read-only target backed by the OCI image layout at path, to be used with WithSource.
+// The path can either be a directory holding the layout or a tar archive of it.
+func NewLocalSource(ctx context.Context, path string) (oras.ReadOnlyTarget, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open OCI layout %q: %w", path, err)
+	}
+
+	var store *oci.ReadOnlyStore
+	if info.IsDir() {
+		store, err = oci.NewFromFS(ctx, os.DirFS(path))
+	} else {
+		store, err = oci.NewFromTar(ctx, path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to open OCI layout %q: %w", path, err)
+	}
+
+	return store, nil
+}
+
+// resolveLocalReference finds how the artifact pointed by ref is referred to in a local OCI layout.
+// Layouts written by falcoctl tag artifacts with their full reference, while other tools usually
+// tag them just with the tag; digests can always be resolved.
+func resolveLocalReference(ctx context.Context, source oras.ReadOnlyTarget, ref, reference string) (string, error) {
+	for _, candidate := range []string{ref, reference} {
+		if candidate == "" {
+			continue
+		}
+
+		_, err := source.Resolve(ctx, candidate)
+		if err == nil {
+			return candidate, nil
+		}
+		if !errors.Is(err, errdef.ErrNotFound) && !errors.Is(err, errdef.ErrInvalidDigest) {
+			return "", err
+		}
+	}
+
+	return "", fmt.Errorf("%s: %w", ref, errdef.ErrNotFound)
+}
@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package puller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"oras.land/oras-go/v2/registry"
+)
+
+// WithMirrors makes the puller fall back, in order, to the given mirrors when an artifact cannot be read from
+// its own registry. Mirrors are registry hosts, optionally followed by a path prefixed to the repositories,
+// e.g. "mirror.example.com" or "mirror.example.com/falcosecurity". Mirrors are ignored when reading from a local
+// source, see WithSource.
+func WithMirrors(mirrors ...string) Option {
+	return func(p *Puller) {
+		for _, m := range mirrors {
+			if m = strings.TrimSuffix(strings.TrimSpace(m), "/"); m != "" {
+				p.mirrors = append(p.mirrors, m)
+			}
+		}
+	}
+}
+
+// MirrorRef returns the reference of the artifact pointed by ref in the given mirror, which holds the same
+// repository, tag and digest.
+func MirrorRef(mirror, ref string) (string, error) {
+	parsed, err := registry.ParseReference(ref)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse reference %q: %w", ref, err)
+	}
+
+	mirrorRef := mirror + "/" + parsed.Repository
+	switch {
+	case parsed.Reference == "":
+	case parsed.ValidateReferenceAsDigest() == nil:
+		mirrorRef += "@" + parsed.Reference
+	default:
+		mirrorRef += ":" + parsed.Reference
+	}
+
+	return mirrorRef, nil
+}
+
+// withMirrors calls fn with ref and, if it fails, with the reference of the same artifact in each of the mirrors,
+// until one of them succeeds. It returns the reference that succeeded, or all the errors otherwise.
+func (p *Puller) withMirrors(ctx context.Context, ref string, fn func(ref string) error) (string, error) {
+	err := fn(ref)
+	if err == nil || p.source != nil || len(p.mirrors) == 0 {
+		return ref, err
+	}
+
+	errs := []error{err}
+	for _, mirror := range p.mirrors {
+		// Do not keep trying when the operation has been canceled or has timed out.
+		if ctx.Err() != nil {
+			break
+		}
+
+		mirrorRef, err := MirrorRef(mirror, ref)
+		if err != nil {
+			return "", err
+		}
+
+		err = fn(mirrorRef)
+		if err == nil {
+			return mirrorRef, nil
+		}
+		errs = append(errs, fmt.Errorf("mirror %s: %w", mirror, err))
+	}
+
+	return "", errors.Join(errs...)
+}
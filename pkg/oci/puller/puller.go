@@ -18,39 +18,163 @@ package puller
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"go.opentelemetry.io/otel/attribute"
 	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
 	"oras.land/oras-go/v2/content/file"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry"
 	"oras.land/oras-go/v2/registry/remote"
 
+	"github.com/falcosecurity/falcoctl/internal/telemetry"
 	"github.com/falcosecurity/falcoctl/pkg/oci"
+	"github.com/falcosecurity/falcoctl/pkg/oci/cache"
 	"github.com/falcosecurity/falcoctl/pkg/oci/repository"
 	"github.com/falcosecurity/falcoctl/pkg/output"
 )
 
+var (
+	// ErrTypeNotPermitted is returned by CheckAllowedType when the type of an artifact is not among the allowed ones.
+	ErrTypeNotPermitted = errors.New("type not permitted")
+	// ErrMaxDownloadSize is returned by Pull when an artifact is bigger than the limit set with WithMaxSize.
+	ErrMaxDownloadSize = errors.New("maximum download size")
+)
+
 // Puller implements pull operations.
 type Puller struct {
 	Client    remote.Client
 	tracker   output.Tracker
 	plainHTTP bool
+	// source, when set, is used in place of the remote repositories to read artifacts from.
+	source oras.ReadOnlyTarget
+	// mirrors are tried, in order, when an artifact cannot be read from its own registry.
+	mirrors []string
+	// cache, when set, is consulted before reading manifests and layers from the remote repositories.
+	cache *cache.Cache
+	// ranged, when set, makes the layers be downloaded in chunks, see WithRangedDownloads.
+	ranged *rangedDownloads
+	// maxSize, when positive, bounds the bytes downloaded by each pull, see WithMaxSize.
+	maxSize int64
+	// variant is the variant of the platforms requested, e.g. "v7" or "v8" on arm, see WithVariant.
+	variant string
+}
+
+// Option is a functional option used to configure a Puller.
+type Option func(*Puller)
+
+// WithRetry makes the puller retry requests failed because of transient registry or network errors
+// up to maxRetries times, waiting an exponentially increasing time starting from backoff between attempts.
+// The requests rejected by the rate limit of a registry are retried as long as it is worth it, see RateLimitError,
+// which is returned even with zero retries.
+func WithRetry(maxRetries int, backoff time.Duration) Option {
+	return func(p *Puller) {
+		if maxRetries < 0 {
+			return
+		}
+		if backoff <= 0 {
+			backoff = DefaultRetryBackoff
+		}
+		p.Client = &retryClient{
+			client:     p.Client,
+			maxRetries: maxRetries,
+			backoff:    backoff,
+		}
+	}
+}
+
+// WithSource makes the puller read artifacts from the given target, e.g. an OCI image layout on disk,
+// instead of contacting the remote registries. See NewLocalSource.
+func WithSource(source oras.ReadOnlyTarget) Option {
+	return func(p *Puller) {
+		p.source = source
+	}
+}
+
+// WithCache makes the puller read the manifests and layers already pulled from the given cache, storing there
+// the ones read from the remote repositories. The cache is not used when reading from a local source.
+func WithCache(c *cache.Cache) Option {
+	return func(p *Puller) {
+		p.cache = c
+	}
+}
+
+// WithMaxSize makes Pull fail as soon as the manifests and layers of an artifact add up to more than maxSize bytes,
+// before downloading the one exceeding the limit. The size of each of them is the one declared by its descriptor,
+// which bounds the bytes read from the registry as well. 0 means no limit.
+func WithMaxSize(maxSize int64) Option {
+	return func(p *Puller) {
+		p.maxSize = maxSize
+	}
+}
+
+// WithVariant makes the puller select, among the manifests of an image index matching the requested operating system
+// and architecture, the one with the given platform variant, e.g. "v7" for "linux/arm", falling back to the manifest
+// without variant. Without a variant, the manifest without variant is preferred over the ones having one.
+func WithVariant(variant string) Option {
+	return func(p *Puller) {
+		p.variant = variant
+	}
 }
 
 // NewPuller create a new puller that can be used for pull operations.
 // The client must be ready to be used by the puller.
-func NewPuller(client remote.Client, plainHTTP bool, tracker output.Tracker) *Puller {
-	return &Puller{
+func NewPuller(client remote.Client, plainHTTP bool, tracker output.Tracker, opts ...Option) *Puller {
+	p := &Puller{
 		Client:    client,
 		tracker:   tracker,
 		plainHTTP: plainHTTP,
 	}
+
+	for _, o := range opts {
+		o(p)
+	}
+
+	return p
 }
 
-// Pull an artifact from a remote registry.
+// Pull an artifact from a remote registry, or from the configured mirrors if that fails.
 // Ref format follows: REGISTRY/REPO[:TAG|@DIGEST]. Ex. localhost:5000/hello:latest.
+// The pull is traced, with the reference, digest and size of the artifact, when telemetry is enabled.
 func (p *Puller) Pull(ctx context.Context, ref, destDir, os, arch string) (*oci.RegistryResult, error) {
+	ctx, span := telemetry.StartSpan(ctx, "Pull", attribute.String("ref", ref), attribute.String("platform", os+"/"+arch))
+	result, err := p.pullWithMirrors(ctx, ref, destDir, os, arch)
+	if err == nil {
+		span.SetAttributes(attribute.String("registry", result.Registry), attribute.String("digest", result.RootDigest),
+			attribute.Int64("bytes", result.Size))
+	}
+	telemetry.EndSpan(span, err)
+	return result, err
+}
+
+func (p *Puller) pullWithMirrors(ctx context.Context, ref, destDir, os, arch string) (*oci.RegistryResult, error) {
+	var result *oci.RegistryResult
+	servedRef, err := p.withMirrors(ctx, ref, func(ref string) (err error) {
+		result, err = p.pull(ctx, ref, destDir, os, arch)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	parsedRef, err := registry.ParseReference(servedRef)
+	if err != nil {
+		return nil, err
+	}
+	result.Ref, result.Registry = servedRef, parsedRef.Registry
+	result.ResolvedRef = fmt.Sprintf("%s/%s@%s", parsedRef.Registry, parsedRef.Repository, result.RootDigest)
+
+	return result, nil
+}
+
+func (p *Puller) pull(ctx context.Context, ref, destDir, os, arch string) (*oci.RegistryResult, error) {
 	fileStore, err := file.New(destDir)
 	if err != nil {
 		return nil, err
@@ -69,7 +193,12 @@ func (p *Puller) Pull(ctx context.Context, ref, destDir, os, arch string) (*oci.
 		repo.Reference.Reference = oci.DefaultTag
 	}
 
-	refDesc, _, err := repo.FetchReference(ctx, ref)
+	src, srcRef, err := p.target(ctx, repo, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	refDesc, err := src.Resolve(ctx, srcRef)
 	if err != nil {
 		return nil, err
 	}
@@ -77,11 +206,23 @@ func (p *Puller) Pull(ctx context.Context, ref, destDir, os, arch string) (*oci.
 	copyOpts := oras.CopyOptions{}
 	copyOpts.Concurrency = 1
 	if refDesc.MediaType == v1.MediaTypeImageIndex {
-		plt := &v1.Platform{
-			OS:           os,
-			Architecture: arch,
+		manifestDesc, err := p.platformManifest(ctx, src, refDesc, os, arch)
+		if err != nil {
+			return nil, err
+		}
+		// The manifest is copied by digest, since the target platform of the copy would select the first manifest
+		// matching the operating system and architecture, whatever its variant.
+		srcRef = manifestDesc.Digest.String()
+	}
+
+	if p.maxSize > 0 {
+		var size atomic.Int64
+		copyOpts.PreCopy = func(_ context.Context, desc v1.Descriptor) error {
+			if size.Add(desc.Size) > p.maxSize {
+				return fmt.Errorf("artifact %q exceeds the %w of %d bytes", ref, ErrMaxDownloadSize, p.maxSize)
+			}
+			return nil
 		}
-		copyOpts.WithTargetPlatform(plt)
 	}
 
 	localTarget := oras.Target(fileStore)
@@ -89,7 +230,7 @@ func (p *Puller) Pull(ctx context.Context, ref, destDir, os, arch string) (*oci.
 	if p.tracker != nil {
 		localTarget = p.tracker(localTarget)
 	}
-	desc, err := oras.Copy(ctx, repo, ref, localTarget, ref, copyOpts)
+	desc, err := oras.Copy(ctx, src, srcRef, localTarget, ref, copyOpts)
 
 	if err != nil {
 		return nil, fmt.Errorf("unable to pull artifact %s with tag %s from repo %s: %w",
@@ -101,43 +242,317 @@ func (p *Puller) Pull(ctx context.Context, ref, destDir, os, arch string) (*oci.
 		return nil, err
 	}
 
-	var artifactType oci.ArtifactType
-	switch manifest.Layers[0].MediaType {
-	case oci.FalcoPluginLayerMediaType:
-		artifactType = oci.Plugin
-	case oci.FalcoRulesfileLayerMediaType:
-		artifactType = oci.Rulesfile
-	case oci.FalcoAssetLayerMediaType:
-		artifactType = oci.Asset
-	default:
-		return nil, fmt.Errorf("unknown media type: %q", manifest.Layers[0].MediaType)
+	artifactType, err := oci.ArtifactTypeFromManifest(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("unable to determine the type of artifact %q: %w", ref, err)
 	}
 
 	filename := manifest.Layers[0].Annotations[v1.AnnotationTitle]
 
 	return &oci.RegistryResult{
-		RootDigest: string(refDesc.Digest),
-		Digest:     string(desc.Digest),
-		Type:       artifactType,
-		Filename:   filename,
+		RootDigest:  string(refDesc.Digest),
+		Digest:      string(desc.Digest),
+		LayerDigest: string(manifest.Layers[0].Digest),
+		Size:        manifest.Layers[0].Size,
+		Type:        artifactType,
+		Filename:    filename,
 	}, nil
 }
 
+// Export copies the artifact pointed by ref to dst, tagging it with ref. Manifests and digests are preserved,
+// so the copied artifact can be verified or pushed again as is. All the platforms are copied unless os and arch
+// are set, in which case only the manifest of the given platform is. When copying all the platforms, the cosign
+// signature attached to the artifact, if any, is copied too.
+func (p *Puller) Export(ctx context.Context, ref string, dst oras.Target, os, arch string) (*v1.Descriptor, error) {
+	repo, err := repository.NewRepository(ref,
+		repository.WithClient(p.Client),
+		repository.WithPlainHTTP(p.plainHTTP))
+	if err != nil {
+		return nil, err
+	}
+
+	// if no tag was specified, "latest" is used
+	if repo.Reference.Reference == "" {
+		ref += ":" + oci.DefaultTag
+		repo.Reference.Reference = oci.DefaultTag
+	}
+
+	src, srcRef, err := p.target(ctx, repo, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	refDesc, err := src.Resolve(ctx, srcRef)
+	if err != nil {
+		return nil, err
+	}
+
+	copyOpts := oras.CopyOptions{}
+	copyOpts.Concurrency = 1
+	allPlatforms := os == "" && arch == ""
+	if !allPlatforms && refDesc.MediaType == v1.MediaTypeImageIndex {
+		manifestDesc, err := p.platformManifest(ctx, src, refDesc, os, arch)
+		if err != nil {
+			return nil, err
+		}
+		srcRef = manifestDesc.Digest.String()
+	}
+
+	target := dst
+	if p.tracker != nil {
+		target = p.tracker(target)
+	}
+
+	desc, err := oras.Copy(ctx, src, srcRef, target, ref, copyOpts)
+	if err != nil {
+		return nil, fmt.Errorf("unable to export artifact %s with tag %s: %w",
+			repo.Reference.Repository, repo.Reference.Reference, err)
+	}
+
+	if allPlatforms {
+		// Cosign stores the signature of an artifact in the same repository, tagged after the artifact digest.
+		sigRef := fmt.Sprintf("%s/%s:%s-%s.sig", repo.Reference.Registry, repo.Reference.Repository,
+			refDesc.Digest.Algorithm(), refDesc.Digest.Encoded())
+		sigSrc, sigSrcRef, err := p.target(ctx, repo, sigRef)
+		if err == nil {
+			_, err = oras.Copy(ctx, sigSrc, sigSrcRef, target, sigRef, oras.CopyOptions{})
+		}
+		if err != nil && !errors.Is(err, errdef.ErrNotFound) {
+			return nil, fmt.Errorf("unable to export signature of artifact %s: %w", ref, err)
+		}
+	}
+
+	return &desc, nil
+}
+
 // Descriptor retrieves the descriptor of an artifact from a remote repository.
 func (p *Puller) Descriptor(ctx context.Context, ref string) (*v1.Descriptor, error) {
+	var result *v1.Descriptor
+	_, err := p.withMirrors(ctx, ref, func(ref string) (err error) {
+		result, err = p.descriptor(ctx, ref)
+		return err
+	})
+	return result, err
+}
+
+func (p *Puller) descriptor(ctx context.Context, ref string) (*v1.Descriptor, error) {
 	repo, err := repository.NewRepository(ref, repository.WithClient(p.Client), repository.WithPlainHTTP(p.plainHTTP))
 	if err != nil {
 		return nil, err
 	}
 
-	desc, _, err := repo.FetchReference(ctx, ref)
+	src, srcRef, err := p.target(ctx, repo, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	desc, err := src.Resolve(ctx, srcRef)
 	if err != nil {
 		return nil, err
 	}
 	return &desc, nil
 }
 
-func manifestFromDesc(ctx context.Context, target oras.Target, desc *v1.Descriptor) (*v1.Manifest, error) {
+// Platforms lists the platforms of the manifests in the image index of the artifact pointed by ref, in the order
+// of the index. No platform is returned for single-platform artifacts, whose manifest is not wrapped in an index.
+func (p *Puller) Platforms(ctx context.Context, ref string) ([]v1.Platform, error) {
+	var result []v1.Platform
+	_, err := p.withMirrors(ctx, ref, func(ref string) (err error) {
+		result, err = p.platforms(ctx, ref)
+		return err
+	})
+	return result, err
+}
+
+func (p *Puller) platforms(ctx context.Context, ref string) ([]v1.Platform, error) {
+	repo, err := repository.NewRepository(ref, repository.WithClient(p.Client), repository.WithPlainHTTP(p.plainHTTP))
+	if err != nil {
+		return nil, err
+	}
+
+	src, srcRef, err := p.target(ctx, repo, ref)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch reference %q: %w", ref, err)
+	}
+
+	rootDesc, rootBytes, err := oras.FetchBytes(ctx, src, srcRef, oras.DefaultFetchBytesOptions)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch reference %q: %w", ref, err)
+	}
+	if rootDesc.MediaType != v1.MediaTypeImageIndex {
+		return nil, nil
+	}
+
+	var index v1.Index
+	if err = json.Unmarshal(rootBytes, &index); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal image index: %w", err)
+	}
+
+	var platforms []v1.Platform
+	for _, m := range index.Manifests {
+		if m.Platform != nil {
+			platforms = append(platforms, *m.Platform)
+		}
+	}
+	return platforms, nil
+}
+
+// Tags lists the tags of the repository of the artifact pointed by ref, whose tag or digest, if any, is ignored.
+// When a local source has been configured, the tags are read from it, both the full references and the bare
+// tags of the repository being considered.
+func (p *Puller) Tags(ctx context.Context, ref string) ([]string, error) {
+	var result []string
+	_, err := p.withMirrors(ctx, ref, func(ref string) (err error) {
+		result, err = p.tags(ctx, ref)
+		return err
+	})
+	return result, err
+}
+
+func (p *Puller) tags(ctx context.Context, ref string) ([]string, error) {
+	repo, err := repository.NewRepository(ref, repository.WithClient(p.Client), repository.WithPlainHTTP(p.plainHTTP))
+	if err != nil {
+		return nil, err
+	}
+
+	if p.source == nil {
+		return repo.Tags(ctx)
+	}
+
+	lister, ok := p.source.(registry.TagLister)
+	if !ok {
+		return nil, fmt.Errorf("unable to list tags of %q from the local source", ref)
+	}
+
+	prefix := repo.Reference.Registry + "/" + repo.Reference.Repository + ":"
+	var tags []string
+	err = lister.Tags(ctx, "", func(page []string) error {
+		for _, tag := range page {
+			switch {
+			case strings.HasPrefix(tag, prefix):
+				tags = append(tags, strings.TrimPrefix(tag, prefix))
+			case !strings.ContainsAny(tag, "/:@"):
+				tags = append(tags, tag)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+// target returns where the artifact pointed by ref has to be read from, together with the reference to be used
+// on it. Unless a local source has been configured, it is the remote repository itself, behind the cache if any.
+func (p *Puller) target(ctx context.Context, repo *repository.Repository, ref string) (oras.ReadOnlyTarget, string, error) {
+	if p.source == nil {
+		var src oras.ReadOnlyTarget = repo
+		if p.ranged != nil {
+			src = &rangedTarget{repo: repo, settings: p.ranged}
+		}
+		if p.cache != nil {
+			src = p.cache.Target(src)
+		}
+		return src, ref, nil
+	}
+
+	localRef, err := resolveLocalReference(ctx, p.source, ref, repo.Reference.Reference)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return p.source, localRef, nil
+}
+
+// ArtifactType retrieves the type of an artifact from its manifest, without pulling its layers.
+func (p *Puller) ArtifactType(ctx context.Context, ref, os, arch string) (oci.ArtifactType, error) {
+	manifest, err := p.manifest(ctx, ref, os, arch)
+	if err != nil {
+		return "", err
+	}
+
+	artifactType, err := oci.ArtifactTypeFromManifest(manifest)
+	if err != nil {
+		return "", fmt.Errorf("unable to determine the type of artifact %q: %w", ref, err)
+	}
+	return artifactType, nil
+}
+
+// Annotations retrieves the annotations of the manifest of an artifact, without pulling its layers.
+// If the artifact has a v1.MediaTypeImageIndex descriptor then the manifest for the specified platform is used.
+func (p *Puller) Annotations(ctx context.Context, ref, os, arch string) (map[string]string, error) {
+	manifest, err := p.manifest(ctx, ref, os, arch)
+	if err != nil {
+		return nil, err
+	}
+
+	return manifest.Annotations, nil
+}
+
+// platformManifest returns the descriptor of the manifest for the given platform in the image index pointed by
+// indexDesc, failing if the index has none.
+func (p *Puller) platformManifest(ctx context.Context, target oras.ReadOnlyTarget, indexDesc v1.Descriptor,
+	os, arch string) (*v1.Descriptor, error) {
+	indexReader, err := target.Fetch(ctx, indexDesc)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch image index with digest %s: %w", indexDesc.Digest.String(), err)
+	}
+	defer indexReader.Close()
+
+	var index v1.Index
+	if err := json.NewDecoder(indexReader).Decode(&index); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal image index: %w", err)
+	}
+
+	return manifestForPlatform(&index, os, arch, p.variant)
+}
+
+// manifestForPlatform returns the descriptor of the manifest matching the given platform. When both os and arch are
+// empty the manifest of the first platform is returned, which is enough to read what the platforms have in common,
+// e.g. the type or the config of the artifact. Among the manifests matching os and arch, the one with the given
+// variant is returned, or else the one without variant. When no variant is given, the first manifest with a variant
+// is returned if none is without. The returned error lists the platforms available in the index.
+func manifestForPlatform(index *v1.Index, os, arch, variant string) (*v1.Descriptor, error) {
+	var fallback *v1.Descriptor
+	available := make([]string, 0, len(index.Manifests))
+	for i := range index.Manifests {
+		platform := index.Manifests[i].Platform
+		if platform == nil {
+			continue
+		}
+		if os == "" && arch == "" {
+			return &index.Manifests[i], nil
+		}
+		if platform.OS == os && platform.Architecture == arch {
+			if platform.Variant == variant {
+				return &index.Manifests[i], nil
+			}
+			if fallback == nil && (variant == "" || platform.Variant == "") {
+				fallback = &index.Manifests[i]
+			}
+		}
+		available = append(available, platformName(platform.OS, platform.Architecture, platform.Variant))
+	}
+
+	if fallback != nil {
+		return fallback, nil
+	}
+
+	return nil, fmt.Errorf("unable to find a manifest matching the given platform: %s (available platforms: %s)",
+		platformName(os, arch, variant), strings.Join(available, ", "))
+}
+
+// platformName returns the platform in the OS/ARCH format, followed by "/VARIANT" when variant is not empty.
+func platformName(os, arch, variant string) string {
+	if variant == "" {
+		return os + "/" + arch
+	}
+	return os + "/" + arch + "/" + variant
+}
+
+func manifestFromDesc(ctx context.Context, target oras.ReadOnlyTarget, desc *v1.Descriptor) (*v1.Manifest, error) {
 	var manifest v1.Manifest
 
 	descReader, err := target.Fetch(ctx, *desc)
@@ -184,12 +599,26 @@ func (p *Puller) manifest(ctx context.Context, ref, os, arch string) (*v1.Manife
 // If the artifact has a v1.MediaTypeImageIndex descriptor then it fetches the manifest for the
 // specified platform.
 func (p *Puller) RawManifest(ctx context.Context, ref, os, arch string) ([]byte, error) {
+	var result []byte
+	_, err := p.withMirrors(ctx, ref, func(ref string) (err error) {
+		result, err = p.rawManifest(ctx, ref, os, arch)
+		return err
+	})
+	return result, err
+}
+
+func (p *Puller) rawManifest(ctx context.Context, ref, os, arch string) ([]byte, error) {
 	repo, err := repository.NewRepository(ref, repository.WithClient(p.Client), repository.WithPlainHTTP(p.plainHTTP))
 	if err != nil {
 		return nil, err
 	}
 
-	desc, manifestReader, err := repo.FetchReference(ctx, ref)
+	src, srcRef, err := p.target(ctx, repo, ref)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch reference %q: %w", ref, err)
+	}
+
+	desc, manifestReader, err := oras.Fetch(ctx, src, srcRef, oras.DefaultFetchOptions)
 	if err != nil {
 		return nil, fmt.Errorf("unable to fetch reference %q: %w", ref, err)
 	}
@@ -209,21 +638,13 @@ func (p *Puller) RawManifest(ctx context.Context, ref, os, arch string) ([]byte,
 			return nil, fmt.Errorf("unable to unmarshal manifest: %w", err)
 		}
 
-		found := false
-		for _, manifest := range index.Manifests {
-			if manifest.Platform.OS == os &&
-				manifest.Platform.Architecture == arch {
-				desc = manifest
-				found = true
-				break
-			}
-		}
-
-		if !found {
-			return nil, fmt.Errorf("unable to find a manifest matching the given platform: %s/%s", os, arch)
+		manifestDesc, err := manifestForPlatform(&index, os, arch, p.variant)
+		if err != nil {
+			return nil, err
 		}
+		desc = *manifestDesc
 
-		manifestReader, err = repo.Fetch(ctx, desc)
+		manifestReader, err = src.Fetch(ctx, desc)
 		if err != nil {
 			return nil, fmt.Errorf("unable to fetch manifest desc with digest %s: %w", desc.Digest.String(), err)
 		}
@@ -254,28 +675,109 @@ func (p *Puller) ArtifactConfig(ctx context.Context, ref, os, arch string) (*oci
 	return &artifactConfig, nil
 }
 
+// Metadata retrieves the metadata of an artifact from its manifest and config layer, without pulling its layers.
+// If the artifact has a v1.MediaTypeImageIndex descriptor then the platforms available in the index are reported
+// and the manifest for the specified platform is used.
+func (p *Puller) Metadata(ctx context.Context, ref, os, arch string) (*oci.ArtifactMetadata, error) {
+	repo, err := repository.NewRepository(ref, repository.WithClient(p.Client), repository.WithPlainHTTP(p.plainHTTP))
+	if err != nil {
+		return nil, err
+	}
+
+	src, srcRef, err := p.target(ctx, repo, ref)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch reference %q: %w", ref, err)
+	}
+
+	rootDesc, rootBytes, err := oras.FetchBytes(ctx, src, srcRef, oras.DefaultFetchBytesOptions)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch reference %q: %w", ref, err)
+	}
+
+	metadata := &oci.ArtifactMetadata{
+		Ref:    ref,
+		Digest: rootDesc.Digest.String(),
+	}
+
+	manifestDesc := rootDesc
+	if rootDesc.MediaType == v1.MediaTypeImageIndex {
+		var index v1.Index
+		if err = json.Unmarshal(rootBytes, &index); err != nil {
+			return nil, fmt.Errorf("unable to unmarshal image index: %w", err)
+		}
+
+		for _, m := range index.Manifests {
+			if m.Platform != nil {
+				metadata.Platforms = append(metadata.Platforms, platformName(m.Platform.OS, m.Platform.Architecture, m.Platform.Variant))
+			}
+		}
+
+		desc, err := manifestForPlatform(&index, os, arch, p.variant)
+		if err != nil {
+			return nil, err
+		}
+		manifestDesc = *desc
+	}
+
+	manifest, err := manifestFromDesc(ctx, src, &manifestDesc)
+	if err != nil {
+		return nil, err
+	}
+
+	if metadata.Type, err = oci.ArtifactTypeFromManifest(manifest); err != nil {
+		return nil, fmt.Errorf("unable to determine the type of artifact %q: %w", ref, err)
+	}
+	metadata.ManifestDigest = manifestDesc.Digest.String()
+	metadata.Annotations = manifest.Annotations
+	metadata.ConfigAnnotations = manifest.Config.Annotations
+	metadata.Layers = manifest.Layers
+
+	configBytes, err := content.FetchAll(ctx, src, manifest.Config)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch config layer with digest %s: %w", manifest.Config.Digest.String(), err)
+	}
+	if err = json.Unmarshal(configBytes, &metadata.Config); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal config layer: %w", err)
+	}
+
+	return metadata, nil
+}
+
 // RawConfigLayer fetches only the config layer from a given ref.
 // If the artifact has a v1.MediaTypeImageIndex descriptor then it fetches the config layer for the
 // specified platform.
 func (p *Puller) RawConfigLayer(ctx context.Context, ref, os, arch string) ([]byte, error) {
+	var result []byte
+	_, err := p.withMirrors(ctx, ref, func(ref string) (err error) {
+		result, err = p.rawConfigLayer(ctx, ref, os, arch)
+		return err
+	})
+	return result, err
+}
+
+func (p *Puller) rawConfigLayer(ctx context.Context, ref, os, arch string) ([]byte, error) {
 	repo, err := repository.NewRepository(ref, repository.WithClient(p.Client), repository.WithPlainHTTP(p.plainHTTP))
 	if err != nil {
 		return nil, err
 	}
 
-	manifest, err := p.manifest(ctx, ref, os, arch)
+	// The manifest and the config layer must be read from the same registry, hence mirrors are not tried here.
+	manifestBytes, err := p.rawManifest(ctx, ref, os, arch)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("unable to get manifest: %w", err)
 	}
 
-	configRef := manifest.Config.Digest.String()
+	var manifest v1.Manifest
+	if err = json.Unmarshal(manifestBytes, &manifest); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal manifest: %w", err)
+	}
 
-	descriptor, err := repo.Blobs().Resolve(ctx, configRef)
+	src, _, err := p.target(ctx, repo, ref)
 	if err != nil {
 		return nil, err
 	}
 
-	rc, err := repo.Fetch(ctx, descriptor)
+	rc, err := src.Fetch(ctx, manifest.Config)
 	if err != nil {
 		return nil, err
 	}
@@ -305,15 +807,16 @@ func (p *Puller) CheckAllowedType(ctx context.Context, ref, os, arch string, all
 		return err
 	}
 
-	if len(manifest.Layers) == 0 {
-		return fmt.Errorf("malformed artifact, expected to find at least one layer for ref %q", ref)
+	artifactType, err := oci.ArtifactTypeFromManifest(manifest)
+	if err != nil {
+		return fmt.Errorf("unable to determine the type of artifact %q: %w", ref, err)
 	}
 
 	for _, t := range allowedTypes {
-		if manifest.Layers[0].MediaType == t.ToMediaType() {
+		if artifactType == t {
 			return nil
 		}
 	}
 
-	return fmt.Errorf("cannot download artifact of type %q: type not permitted", oci.HumanReadableMediaType(manifest.Layers[0].MediaType))
+	return fmt.Errorf("cannot download artifact of type %q: %w", artifactType, ErrTypeNotPermitted)
 }
@@ -17,6 +17,8 @@ package puller_test
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
@@ -36,6 +38,7 @@ import (
 
 	"github.com/falcosecurity/falcoctl/pkg/oci"
 	"github.com/falcosecurity/falcoctl/pkg/oci/authn"
+	ocipuller "github.com/falcosecurity/falcoctl/pkg/oci/puller"
 	ocipusher "github.com/falcosecurity/falcoctl/pkg/oci/pusher"
 	"github.com/falcosecurity/falcoctl/pkg/oci/repository"
 	testutils "github.com/falcosecurity/falcoctl/pkg/test"
@@ -49,11 +52,20 @@ var (
 	testPluginPlatform1       = "linux/amd64"
 	testPluginPlatform2       = "windows/amd64"
 	testPluginPlatform3       = "linux/arm64"
+	testRulesSource           = "github.com/falcosecurity/rules"
 	ctx                       = context.Background()
 	destinationDir            string
 	pluginMultiPlatformRef    string
 	rulesRef                  string
 	artifactWithuoutConfigRef string
+	attestedRef               string
+	referredRef               string
+	variantsRef               string
+	variantDigests            map[string]string
+	testProvenancePredicate   = "https://slsa.dev/provenance/v1"
+	testVulnPredicate         = "https://cosign.sigstore.dev/attestation/vuln/v1"
+	testSBOMType              = "application/spdx+json"
+	testSignatureType         = "application/vnd.dev.sigstore.bundle.v0.3+json"
 )
 
 func TestPuller(t *testing.T) {
@@ -121,6 +133,7 @@ var _ = BeforeSuite(func() {
 		filePaths,
 		ocipusher.WithTags("latest"),
 		ocipusher.WithArtifactConfig(artConfig),
+		ocipusher.WithAnnotationSource(testRulesSource),
 	}
 	// Push a new artifact
 	rulesRef = localRegistryHost + "/rulesfiles:regular"
@@ -131,8 +144,156 @@ var _ = BeforeSuite(func() {
 	artifactWithuoutConfigRef = localRegistryHost + "/artifact:noconfig"
 	err = pushArtifactWithoutConfigLayer(ctx, artifactWithuoutConfigRef, testRuleTarball, authn.NewClient(authn.WithCredentials(&auth.EmptyCredential)))
 	Expect(err).ShouldNot(HaveOccurred())
+
+	// Push a rulesfile artifact with a provenance and a vulnerability scan attestation attached.
+	attestedRef = localRegistryHost + "/attested:latest"
+	_, err = pusher.Push(ctx, oci.Rulesfile, attestedRef, ocipusher.WithFilepaths([]string{testRuleTarball}))
+	Expect(err).ShouldNot(HaveOccurred())
+	err = pushAttestations(ctx, attestedRef, authn.NewClient(authn.WithCredentials(&auth.EmptyCredential)),
+		testProvenancePredicate, testVulnPredicate)
+	Expect(err).ShouldNot(HaveOccurred())
+
+	// Push a rulesfile artifact with an SBOM and a signature attached as referrers.
+	referredRef = localRegistryHost + "/referred:latest"
+	_, err = pusher.Push(ctx, oci.Rulesfile, referredRef, ocipusher.WithFilepaths([]string{testRuleTarball}))
+	Expect(err).ShouldNot(HaveOccurred())
+	err = pushReferrers(ctx, referredRef, authn.NewClient(authn.WithCredentials(&auth.EmptyCredential)),
+		testSignatureType, testSBOMType)
+	Expect(err).ShouldNot(HaveOccurred())
+
+	// Push a plugin artifact whose image index has several variants of the linux/arm platform.
+	variantsRef = localRegistryHost + "/plugins:variants"
+	variantDigests, err = pushVariants(ctx, pusher, variantsRef, "v6", "v7", "")
+	Expect(err).ShouldNot(HaveOccurred())
 })
 
+// pushVariants pushes, under ref, an image index with a manifest of a plugin for each of the given variants of the
+// linux/arm platform, an empty one meaning no variant. It returns the digests of the manifests keyed by variant.
+func pushVariants(ctx context.Context, pusher *ocipusher.Pusher, ref string, variants ...string) (map[string]string, error) {
+	repo, err := repository.NewRepository(ref, repository.WithClient(pusher.Client), repository.WithPlainHTTP(true))
+	if err != nil {
+		return nil, err
+	}
+
+	digests := make(map[string]string, len(variants))
+	index := v1.Index{MediaType: v1.MediaTypeImageIndex}
+	index.SchemaVersion = 2
+	for _, variant := range variants {
+		// Each variant gets its own config, and hence its own manifest.
+		variantRef := ref + "-variant" + variant
+		_, err := pusher.Push(ctx, oci.Plugin, variantRef,
+			ocipusher.WithFilepathsAndPlatforms([]string{testPluginTarball}, []string{"linux/arm"}),
+			ocipusher.WithArtifactConfig(oci.ArtifactConfig{Name: "variants", Version: "0.0.1-" + variant}))
+		if err != nil {
+			return nil, err
+		}
+		_, indexBytes, err := oras.FetchBytes(ctx, repo, variantRef, oras.DefaultFetchBytesOptions)
+		if err != nil {
+			return nil, err
+		}
+		var variantIndex v1.Index
+		if err := json.Unmarshal(indexBytes, &variantIndex); err != nil {
+			return nil, err
+		}
+		manifest := variantIndex.Manifests[0]
+		manifest.Platform.Variant = variant
+		index.Manifests = append(index.Manifests, manifest)
+		digests[variant] = manifest.Digest.String()
+	}
+
+	indexBytes, err := json.Marshal(index)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := oras.TagBytes(ctx, repo, v1.MediaTypeImageIndex, indexBytes, repo.Reference.Reference); err != nil {
+		return nil, err
+	}
+
+	return digests, nil
+}
+
+// pushAttestations attaches to the artifact pointed by ref, following the cosign convention, DSSE envelopes
+// wrapping in-toto statements with the given predicate types.
+func pushAttestations(ctx context.Context, ref string, client remote.Client, predicateTypes ...string) error {
+	repo, err := repository.NewRepository(ref,
+		repository.WithClient(client),
+		repository.WithPlainHTTP(true))
+	if err != nil {
+		return err
+	}
+
+	desc, err := repo.Resolve(ctx, repo.Reference.Reference)
+	if err != nil {
+		return err
+	}
+
+	var layers []v1.Descriptor
+	for _, predicateType := range predicateTypes {
+		statement, err := json.Marshal(map[string]any{
+			"_type":         "https://in-toto.io/Statement/v1",
+			"predicateType": predicateType,
+			"subject":       []map[string]any{{"name": ref, "digest": map[string]string{"sha256": desc.Digest.Encoded()}}},
+			"predicate":     map[string]any{},
+		})
+		if err != nil {
+			return err
+		}
+		envelope, err := json.Marshal(map[string]string{
+			"payloadType": "application/vnd.in-toto+json",
+			"payload":     base64.StdEncoding.EncodeToString(statement),
+		})
+		if err != nil {
+			return err
+		}
+		layer, err := oras.PushBytes(ctx, repo, oci.DSSEEnvelopeMediaType, envelope)
+		if err != nil {
+			return err
+		}
+		layers = append(layers, layer)
+	}
+
+	manifest, err := oras.PackManifest(ctx, repo, oras.PackManifestVersion1_1, "application/vnd.dsse.envelope.v1+json",
+		oras.PackManifestOptions{Layers: layers})
+	if err != nil {
+		return err
+	}
+
+	return repo.Tag(ctx, manifest, ocipuller.AttestationTag(desc.Digest.String()))
+}
+
+// pushReferrers attaches to the artifact pointed by ref a manifest for each of the given artifact types, having
+// the artifact as subject.
+func pushReferrers(ctx context.Context, ref string, client remote.Client, artifactTypes ...string) error {
+	repo, err := repository.NewRepository(ref,
+		repository.WithClient(client),
+		repository.WithPlainHTTP(true))
+	if err != nil {
+		return err
+	}
+
+	desc, err := repo.Resolve(ctx, repo.Reference.Reference)
+	if err != nil {
+		return err
+	}
+
+	// The test registry does not allow deleting manifests, hence the superseded referrers indexes are kept.
+	repo.SkipReferrersGC = true
+
+	for _, artifactType := range artifactTypes {
+		layer, err := oras.PushBytes(ctx, repo, artifactType, []byte(artifactType))
+		if err != nil {
+			return err
+		}
+		_, err = oras.PackManifest(ctx, repo, oras.PackManifestVersion1_1, artifactType,
+			oras.PackManifestOptions{Subject: &desc, Layers: []v1.Descriptor{layer}})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func pushArtifactWithoutConfigLayer(ctx context.Context, ref, artifactPath string, client remote.Client) error {
 	repo, err := repository.NewRepository(ref,
 		repository.WithClient(client),
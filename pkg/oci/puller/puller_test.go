@@ -16,19 +16,30 @@
 package puller_test
 
 import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
 	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/opencontainers/go-digest"
 	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	ocilayout "oras.land/oras-go/v2/content/oci"
 	"oras.land/oras-go/v2/registry/remote/auth"
 
 	"github.com/falcosecurity/falcoctl/pkg/oci"
 	"github.com/falcosecurity/falcoctl/pkg/oci/authn"
 	ocipuller "github.com/falcosecurity/falcoctl/pkg/oci/puller"
+	"github.com/falcosecurity/falcoctl/pkg/oci/repository"
 	"github.com/falcosecurity/falcoctl/pkg/output"
 )
 
@@ -81,6 +92,9 @@ var _ = Describe("Puller", func() {
 					Expect(err).Should(BeNil())
 					Expect(result).ShouldNot(BeNil())
 					Expect(result.Type).Should(Equal(oci.Plugin))
+					// The resolved reference points to the image index, not to the manifest of the platform.
+					Expect(result.ResolvedRef).Should(HaveSuffix("@" + result.RootDigest))
+					Expect(result.RootDigest).ShouldNot(Equal(result.Digest))
 					// Check that config file and plugins exists.
 					_, err := os.Stat(filepath.Join(destinationDir, result.Filename))
 					Expect(err).ShouldNot(HaveOccurred())
@@ -90,6 +104,22 @@ var _ = Describe("Puller", func() {
 					Expect(os.Remove(filepath.Join(destinationDir, result.Filename))).ShouldNot(HaveOccurred())
 				})
 			})
+
+			When("with non existing platform", func() {
+				BeforeEach(func() {
+					ref = pluginMultiPlatformRef
+					OS = "linux"
+					ARCH = "non-existing"
+				})
+
+				It("should error listing the available platforms", func() {
+					Expect(err).Should(HaveOccurred())
+					Expect(err.Error()).Should(ContainSubstring("unable to find a manifest matching the given platform: linux/non-existing"))
+					Expect(err.Error()).Should(ContainSubstring(strings.Join(
+						[]string{testPluginPlatform1, testPluginPlatform2, testPluginPlatform3}, ", ")))
+					Expect(result).Should(BeNil())
+				})
+			})
 		})
 
 		Describe("rulesfile artifact", func() {
@@ -113,6 +143,9 @@ var _ = Describe("Puller", func() {
 					Expect(err).Should(BeNil())
 					Expect(result).ShouldNot(BeNil())
 					Expect(result.Type).Should(Equal(oci.Rulesfile))
+					Expect(result.Ref).Should(Equal(ref))
+					Expect(result.RootDigest).Should(HavePrefix("sha256:"))
+					Expect(result.ResolvedRef).Should(Equal(localRegistryHost + "/rulesfiles@" + result.RootDigest))
 					// Check that config file and plugins exists.
 					_, err := os.Stat(filepath.Join(destinationDir, result.Filename))
 					Expect(err).ShouldNot(HaveOccurred())
@@ -144,6 +177,104 @@ var _ = Describe("Puller", func() {
 		})
 	})
 
+	Context("Pull func with a maximum size", func() {
+		var (
+			maxSize int64
+			dir     string
+			result  *oci.RegistryResult
+			err     error
+		)
+		JustBeforeEach(func() {
+			dir = GinkgoT().TempDir()
+			puller = ocipuller.NewPuller(authn.NewClient(authn.WithCredentials(&auth.EmptyCredential)), plainHTTP, tracker,
+				ocipuller.WithMaxSize(maxSize))
+			result, err = puller.Pull(ctx, rulesRef, dir, "", "")
+		})
+
+		When("the artifact exceeds it", func() {
+			BeforeEach(func() {
+				maxSize = 100
+			})
+
+			It("should error without downloading the layer", func() {
+				Expect(err).Should(MatchError(ocipuller.ErrMaxDownloadSize))
+				Expect(err.Error()).Should(ContainSubstring("maximum download size of 100 bytes"))
+				Expect(result).Should(BeNil())
+				entries, err := os.ReadDir(dir)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(entries).Should(BeEmpty())
+			})
+		})
+
+		When("the artifact fits in it", func() {
+			BeforeEach(func() {
+				maxSize = 1 << 20
+			})
+
+			It("should succeed", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(filepath.Join(dir, result.Filename)).Should(BeARegularFile())
+			})
+		})
+	})
+
+	Context("Pull func with a platform variant", func() {
+		var (
+			variant    string
+			platformOS string
+			result     *oci.RegistryResult
+			err        error
+		)
+		BeforeEach(func() {
+			variant, platformOS = "", "linux"
+		})
+		JustBeforeEach(func() {
+			puller = ocipuller.NewPuller(authn.NewClient(authn.WithCredentials(&auth.EmptyCredential)), plainHTTP, tracker,
+				ocipuller.WithVariant(variant))
+			result, err = puller.Pull(ctx, variantsRef, GinkgoT().TempDir(), platformOS, "arm")
+		})
+
+		When("the variant is in the image index", func() {
+			BeforeEach(func() {
+				variant = "v7"
+			})
+
+			It("should pull the manifest of the variant", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(result.Digest).Should(Equal(variantDigests["v7"]))
+			})
+		})
+
+		When("the variant is not in the image index", func() {
+			BeforeEach(func() {
+				variant = "v8"
+			})
+
+			It("should fall back to the manifest without variant", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(result.Digest).Should(Equal(variantDigests[""]))
+			})
+		})
+
+		When("no variant is given", func() {
+			It("should prefer the manifest without variant", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(result.Digest).Should(Equal(variantDigests[""]))
+			})
+		})
+
+		When("no manifest matches the platform", func() {
+			BeforeEach(func() {
+				variant, platformOS = "v7", "windows"
+			})
+
+			It("should error listing the available platforms with their variants", func() {
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).Should(ContainSubstring("windows/arm/v7 (available platforms: linux/arm/v6, linux/arm/v7, linux/arm)"))
+			})
+		})
+	})
+
 	Context("RawConfigLayer func", func() {
 		var (
 			ref      string
@@ -243,6 +374,377 @@ var _ = Describe("Puller", func() {
 
 	})
 
+	Context("Metadata func", func() {
+		var (
+			ref      string
+			os       string
+			arch     string
+			metadata *oci.ArtifactMetadata
+			err      error
+		)
+		JustBeforeEach(func() {
+			puller = ocipuller.NewPuller(authn.NewClient(authn.WithCredentials(&auth.EmptyCredential)), plainHTTP, tracker)
+			metadata, err = puller.Metadata(ctx, ref, os, arch)
+		})
+
+		JustAfterEach(func() {
+			metadata = nil
+			err = nil
+			os = ""
+			arch = ""
+		})
+
+		When("Artifact does not exist", func() {
+			BeforeEach(func() {
+				ref = nonExistingArtifact
+			})
+
+			It("should error", func() {
+				Expect(err).Should(HaveOccurred())
+				Expect(metadata).Should(BeNil())
+			})
+		})
+
+		When("Artifact has multiple platforms", func() {
+			BeforeEach(func() {
+				ref = pluginMultiPlatformRef
+				tokens := strings.Split(testPluginPlatform3, "/")
+				os = tokens[0]
+				arch = tokens[1]
+			})
+
+			It("should get the metadata of the given platform", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(metadata.Type).Should(Equal(oci.Plugin))
+				Expect(metadata.Platforms).Should(ConsistOf(testPluginPlatform1, testPluginPlatform2, testPluginPlatform3))
+				Expect(metadata.Digest).ShouldNot(Equal(metadata.ManifestDigest))
+				Expect(metadata.Layers).Should(HaveLen(1))
+				Expect(metadata.Layers[0].MediaType).Should(Equal(oci.FalcoPluginLayerMediaType))
+				Expect(metadata.Layers[0].Size).Should(BeNumerically(">", 0))
+				Expect(metadata.Config.Dependencies).Should(HaveLen(1))
+				Expect(metadata.Config.Dependencies[0].Name).Should(Equal("my-dep"))
+				Expect(metadata.Config.Requirements).Should(HaveLen(1))
+			})
+		})
+
+		When("Artifact has no platforms", func() {
+			BeforeEach(func() {
+				ref = rulesRef
+			})
+
+			It("should get the metadata from the manifest", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(metadata.Type).Should(Equal(oci.Rulesfile))
+				Expect(metadata.Platforms).Should(BeEmpty())
+				Expect(metadata.Digest).Should(Equal(metadata.ManifestDigest))
+				Expect(metadata.Layers[0].Digest.String()).Should(HavePrefix("sha256:"))
+				Expect(metadata.Config.Dependencies).Should(HaveLen(2))
+			})
+		})
+
+		When("Artifact has multiple platforms and no platform is given", func() {
+			BeforeEach(func() {
+				ref = pluginMultiPlatformRef
+			})
+
+			It("should get the metadata of the first platform", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(metadata.Type).Should(Equal(oci.Plugin))
+				Expect(metadata.Digest).ShouldNot(Equal(metadata.ManifestDigest))
+			})
+		})
+
+		When("Artifact has no manifest for the given platform", func() {
+			BeforeEach(func() {
+				ref = pluginMultiPlatformRef
+				os = "linux"
+				arch = "non-existing"
+			})
+
+			It("should error", func() {
+				Expect(err).Should(MatchError(ContainSubstring("unable to find a manifest matching the given platform")))
+				Expect(metadata).Should(BeNil())
+			})
+		})
+	})
+
+	Context("Annotations func", func() {
+		var (
+			ref         string
+			annotations map[string]string
+			err         error
+		)
+		JustBeforeEach(func() {
+			puller = ocipuller.NewPuller(authn.NewClient(authn.WithCredentials(&auth.EmptyCredential)), plainHTTP, tracker)
+			annotations, err = puller.Annotations(ctx, ref, "", "")
+		})
+
+		JustAfterEach(func() {
+			annotations = nil
+			err = nil
+		})
+
+		When("Artifact does not exist", func() {
+			BeforeEach(func() {
+				ref = nonExistingArtifact
+			})
+
+			It("should error", func() {
+				Expect(err).Should(HaveOccurred())
+				Expect(annotations).Should(BeNil())
+			})
+		})
+
+		When("Artifact has annotations", func() {
+			BeforeEach(func() {
+				ref = rulesRef
+			})
+
+			It("should get the manifest annotations", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(annotations).Should(HaveKeyWithValue(v1.AnnotationSource, testRulesSource))
+			})
+		})
+	})
+
+	Context("Tags func", func() {
+		var (
+			ref  string
+			tags []string
+			err  error
+		)
+		JustBeforeEach(func() {
+			puller = ocipuller.NewPuller(authn.NewClient(authn.WithCredentials(&auth.EmptyCredential)), plainHTTP, tracker)
+			tags, err = puller.Tags(ctx, ref)
+		})
+
+		When("Artifact has multiple tags", func() {
+			BeforeEach(func() {
+				ref = rulesRef
+			})
+
+			It("should list all the tags of the repository", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(tags).Should(ConsistOf("regular", "latest"))
+			})
+		})
+
+		When("Repository does not exist", func() {
+			BeforeEach(func() {
+				ref = nonExistingArtifact
+			})
+
+			It("should error", func() {
+				Expect(err).Should(HaveOccurred())
+			})
+		})
+	})
+
+	Context("Platforms func", func() {
+		var (
+			ref       string
+			platforms []v1.Platform
+			err       error
+		)
+		JustBeforeEach(func() {
+			puller = ocipuller.NewPuller(authn.NewClient(authn.WithCredentials(&auth.EmptyCredential)), plainHTTP, tracker)
+			platforms, err = puller.Platforms(ctx, ref)
+		})
+
+		When("Artifact has multiple platforms", func() {
+			BeforeEach(func() {
+				ref = pluginMultiPlatformRef
+			})
+
+			It("should list the platforms of the image index", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				var names []string
+				for _, p := range platforms {
+					names = append(names, p.OS+"/"+p.Architecture)
+				}
+				Expect(names).Should(ConsistOf(testPluginPlatform1, testPluginPlatform2, testPluginPlatform3))
+			})
+		})
+
+		When("Artifact has no platforms", func() {
+			BeforeEach(func() {
+				ref = rulesRef
+			})
+
+			It("should not list any platform", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(platforms).Should(BeEmpty())
+			})
+		})
+
+		When("Artifact does not exist", func() {
+			BeforeEach(func() {
+				ref = nonExistingArtifact
+			})
+
+			It("should error", func() {
+				Expect(err).Should(HaveOccurred())
+			})
+		})
+	})
+
+	Context("Attestations func", func() {
+		var (
+			ref          string
+			attestations []ocipuller.Attestation
+			err          error
+		)
+		JustBeforeEach(func() {
+			puller = ocipuller.NewPuller(authn.NewClient(authn.WithCredentials(&auth.EmptyCredential)), plainHTTP, tracker)
+			attestations, err = puller.Attestations(ctx, ref)
+		})
+
+		When("Artifact has attestations", func() {
+			BeforeEach(func() {
+				desc, err := ocipuller.NewPuller(authn.NewClient(authn.WithCredentials(&auth.EmptyCredential)), plainHTTP, tracker).
+					Descriptor(ctx, attestedRef)
+				Expect(err).ShouldNot(HaveOccurred())
+				ref = localRegistryHost + "/attested@" + desc.Digest.String()
+			})
+
+			It("should retrieve all of them", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(attestations).Should(HaveLen(2))
+				Expect(attestations[0].PredicateType).Should(Equal(testProvenancePredicate))
+				Expect(attestations[0].IsSLSAProvenance()).Should(BeTrue())
+				Expect(attestations[0].Envelope).ShouldNot(BeEmpty())
+				Expect(attestations[1].PredicateType).Should(Equal(testVulnPredicate))
+				Expect(attestations[1].IsSLSAProvenance()).Should(BeFalse())
+			})
+		})
+
+		When("Artifact has no attestations", func() {
+			BeforeEach(func() {
+				desc, err := ocipuller.NewPuller(authn.NewClient(authn.WithCredentials(&auth.EmptyCredential)), plainHTTP, tracker).
+					Descriptor(ctx, rulesRef)
+				Expect(err).ShouldNot(HaveOccurred())
+				ref = localRegistryHost + "/rulesfiles@" + desc.Digest.String()
+			})
+
+			It("should not retrieve any", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(attestations).Should(BeEmpty())
+			})
+		})
+
+		When("Reference is not a digest", func() {
+			BeforeEach(func() {
+				ref = attestedRef
+			})
+
+			It("should error", func() {
+				Expect(err).Should(HaveOccurred())
+			})
+		})
+	})
+
+	Context("Referrers func", func() {
+		var (
+			ref          string
+			artifactType string
+			referrers    []v1.Descriptor
+			err          error
+		)
+		JustBeforeEach(func() {
+			puller = ocipuller.NewPuller(authn.NewClient(authn.WithCredentials(&auth.EmptyCredential)), plainHTTP, tracker)
+			referrers, err = puller.Referrers(ctx, ref, artifactType)
+		})
+
+		AfterEach(func() {
+			artifactType = ""
+		})
+
+		When("Artifact has referrers", func() {
+			BeforeEach(func() {
+				ref = referredRef
+			})
+
+			It("should list all of them sorted by artifact type", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(referrers).Should(HaveLen(2))
+				Expect(referrers[0].ArtifactType).Should(Equal(testSBOMType))
+				Expect(referrers[1].ArtifactType).Should(Equal(testSignatureType))
+				Expect(referrers[0].Digest).ShouldNot(BeEmpty())
+			})
+		})
+
+		When("Filtering by artifact type", func() {
+			BeforeEach(func() {
+				ref = referredRef
+				artifactType = testSignatureType
+			})
+
+			It("should list only the referrers of that type", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(referrers).Should(HaveLen(1))
+				Expect(referrers[0].ArtifactType).Should(Equal(testSignatureType))
+			})
+		})
+
+		When("Artifact has no referrers", func() {
+			BeforeEach(func() {
+				ref = rulesRef
+			})
+
+			It("should not list any", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(referrers).Should(BeEmpty())
+			})
+		})
+
+		When("Artifact does not exist", func() {
+			BeforeEach(func() {
+				ref = localRegistryHost + "/referred:nonexisting"
+			})
+
+			It("should error", func() {
+				Expect(err).Should(HaveOccurred())
+			})
+		})
+	})
+
+	Context("SBOM func", func() {
+		var (
+			ref  string
+			sbom *ocipuller.SBOM
+			err  error
+		)
+		JustBeforeEach(func() {
+			puller = ocipuller.NewPuller(authn.NewClient(authn.WithCredentials(&auth.EmptyCredential)), plainHTTP, tracker)
+			sbom, err = puller.SBOM(ctx, ref)
+		})
+
+		When("Artifact has an SBOM attached", func() {
+			BeforeEach(func() {
+				ref = referredRef
+			})
+
+			It("should retrieve it", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(sbom).ShouldNot(BeNil())
+				Expect(sbom.ArtifactType).Should(Equal(testSBOMType))
+				Expect(sbom.Extension()).Should(Equal(".spdx.json"))
+				Expect(sbom.Content).Should(Equal([]byte(testSBOMType)))
+			})
+		})
+
+		When("Artifact has no SBOM attached", func() {
+			BeforeEach(func() {
+				ref = rulesRef
+			})
+
+			It("should not retrieve any", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(sbom).Should(BeNil())
+			})
+		})
+	})
+
 	Context("Descriptor func", func() {
 		var (
 			ref  string
@@ -352,4 +854,379 @@ var _ = Describe("Puller", func() {
 			})
 		})
 	})
+
+	Context("WithRetry option", func() {
+		var (
+			server   *httptest.Server
+			status   int
+			headers  map[string]string
+			requests atomic.Int32
+			err      error
+		)
+		const maxRetries = 2
+
+		BeforeEach(func() {
+			headers = nil
+		})
+
+		JustBeforeEach(func() {
+			requests.Store(0)
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				requests.Add(1)
+				for k, v := range headers {
+					w.Header().Set(k, v)
+				}
+				w.WriteHeader(status)
+			}))
+			ref := strings.TrimPrefix(server.URL, "http://") + "/repo:tag"
+			puller = ocipuller.NewPuller(authn.NewClient(authn.WithCredentials(&auth.EmptyCredential)), plainHTTP, tracker,
+				ocipuller.WithRetry(maxRetries, time.Millisecond))
+			_, err = puller.Descriptor(ctx, ref)
+		})
+
+		JustAfterEach(func() {
+			server.Close()
+		})
+
+		When("the registry answers with a transient error", func() {
+			BeforeEach(func() {
+				status = http.StatusServiceUnavailable
+			})
+
+			It("should retry up to the maximum number of retries", func() {
+				Expect(err).Should(HaveOccurred())
+				Expect(requests.Load()).Should(BeEquivalentTo(maxRetries + 1))
+			})
+		})
+
+		When("the registry answers with a non retriable error", func() {
+			BeforeEach(func() {
+				status = http.StatusNotFound
+			})
+
+			It("should fail fast without retrying", func() {
+				Expect(err).Should(HaveOccurred())
+				Expect(requests.Load()).Should(BeEquivalentTo(1))
+			})
+		})
+
+		When("the registry keeps rate limiting the requests", func() {
+			BeforeEach(func() {
+				status = http.StatusTooManyRequests
+				headers = map[string]string{"Retry-After": "0"}
+			})
+
+			It("should retry after the given time and suggest to authenticate", func() {
+				Expect(err).Should(MatchError(ocipuller.ErrRateLimited))
+				Expect(err.Error()).Should(ContainSubstring(`authenticate with "falcoctl registry login" to raise the limit`))
+				Expect(requests.Load()).Should(BeEquivalentTo(maxRetries + 1))
+			})
+		})
+
+		When("the registry asks to wait too long", func() {
+			BeforeEach(func() {
+				status = http.StatusTooManyRequests
+				headers = map[string]string{"Retry-After": "3600"}
+			})
+
+			It("should fail fast telling when to retry", func() {
+				var rateLimitErr *ocipuller.RateLimitError
+				Expect(errors.As(err, &rateLimitErr)).Should(BeTrue())
+				Expect(rateLimitErr.RetryAfter).Should(Equal(time.Hour))
+				Expect(err.Error()).Should(ContainSubstring("retry in 1h0m0s"))
+				Expect(requests.Load()).Should(BeEquivalentTo(1))
+			})
+		})
+
+		When("no request is left in the rate limit window of Docker Hub", func() {
+			BeforeEach(func() {
+				status = http.StatusTooManyRequests
+				headers = map[string]string{"RateLimit-Limit": "100;w=21600", "RateLimit-Remaining": "0;w=21600"}
+			})
+
+			It("should fail fast describing the limit", func() {
+				Expect(err).Should(MatchError(ocipuller.ErrRateLimited))
+				Expect(err.Error()).Should(ContainSubstring("(100 requests per 6h0m0s)"))
+				Expect(requests.Load()).Should(BeEquivalentTo(1))
+			})
+		})
+	})
+
+	Context("WithMirrors option", func() {
+		var (
+			server  *httptest.Server
+			mirrors []string
+			ref     string
+			result  *oci.RegistryResult
+			config  []byte
+			err     error
+		)
+
+		BeforeEach(func() {
+			// The registry of the artifact is down.
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}))
+			ref = strings.TrimPrefix(server.URL, "http://") + "/rulesfiles:regular"
+		})
+
+		JustBeforeEach(func() {
+			destinationDir = GinkgoT().TempDir()
+			puller = ocipuller.NewPuller(authn.NewClient(authn.WithCredentials(&auth.EmptyCredential)), plainHTTP, tracker,
+				ocipuller.WithMirrors(mirrors...))
+			result, err = puller.Pull(ctx, ref, destinationDir, "", "")
+			if err == nil {
+				config, err = puller.RawConfigLayer(ctx, ref, "", "")
+			}
+		})
+
+		JustAfterEach(func() {
+			server.Close()
+			result, config, err = nil, nil, nil
+		})
+
+		When("a mirror holds the artifact", func() {
+			BeforeEach(func() {
+				mirrors = []string{server.Listener.Addr().String() + "/other", localRegistryHost + "/"}
+			})
+
+			It("should pull the artifact from the mirror", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(result.Registry).Should(Equal(localRegistryHost))
+				Expect(result.Ref).Should(Equal(localRegistryHost + "/rulesfiles:regular"))
+				Expect(result.Type).Should(Equal(oci.Rulesfile))
+				Expect(config).ShouldNot(BeEmpty())
+			})
+		})
+
+		When("no mirror holds the artifact", func() {
+			BeforeEach(func() {
+				mirrors = []string{localRegistryHost + "/missing"}
+			})
+
+			It("should report the errors of the registry and of the mirrors", func() {
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).Should(ContainSubstring("mirror " + localRegistryHost + "/missing"))
+				Expect(result).Should(BeNil())
+			})
+		})
+	})
+
+	Context("MirrorRef func", func() {
+		It("should keep the repository, tag and digest of the reference", func() {
+			const digest = "sha256:4df5bc5d7e0ee37c1c7b4dd192c3b3a8c43d4a69e0ef3eaa3c4fa1a7e1e6b1c4"
+			for ref, expected := range map[string]string{
+				"ghcr.io/falcosecurity/rules/k8saudit-rules:0.6":       "mirror.example.com/falcosecurity/rules/k8saudit-rules:0.6",
+				"ghcr.io/falcosecurity/rules/k8saudit-rules@" + digest: "mirror.example.com/falcosecurity/rules/k8saudit-rules@" + digest,
+				"ghcr.io/falcosecurity/rules/k8saudit-rules":           "mirror.example.com/falcosecurity/rules/k8saudit-rules",
+			} {
+				mirrorRef, err := ocipuller.MirrorRef("mirror.example.com", ref)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(mirrorRef).Should(Equal(expected))
+			}
+
+			mirrorRef, err := ocipuller.MirrorRef("mirror.example.com/ghcr", "ghcr.io/falcosecurity/plugins:latest")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(mirrorRef).Should(Equal("mirror.example.com/ghcr/falcosecurity/plugins:latest"))
+		})
+	})
+
+	Context("WithRangedDownloads option", func() {
+		const chunkSize = 512
+		var (
+			server        *httptest.Server
+			ignoreRanges  bool
+			rangeRequests atomic.Int32
+			partialDir    string
+			partPath      string
+			layer         []byte
+			ref           string
+			result        *oci.RegistryResult
+			err           error
+		)
+
+		BeforeEach(func() {
+			ignoreRanges = false
+			rangeRequests.Store(0)
+			partialDir = GinkgoT().TempDir()
+
+			// The pushed layer is the tarball itself.
+			layer, err = os.ReadFile(testRuleTarball)
+			Expect(err).ShouldNot(HaveOccurred())
+			dgst := digest.FromBytes(layer)
+			partPath = filepath.Join(partialDir, dgst.Algorithm().String()+"-"+dgst.Encoded()+".part")
+
+			// Proxy the local registry, counting the range requests or discarding them as unsupported.
+			target, err := url.Parse("http://" + localRegistryHost)
+			Expect(err).ShouldNot(HaveOccurred())
+			proxy := httputil.NewSingleHostReverseProxy(target)
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Range") != "" {
+					rangeRequests.Add(1)
+					if ignoreRanges {
+						r.Header.Del("Range")
+					}
+				}
+				proxy.ServeHTTP(w, r)
+			}))
+			ref = strings.TrimPrefix(server.URL, "http://") + "/rulesfiles:regular"
+		})
+
+		JustBeforeEach(func() {
+			destinationDir = GinkgoT().TempDir()
+			puller = ocipuller.NewPuller(authn.NewClient(authn.WithCredentials(&auth.EmptyCredential)), plainHTTP, tracker,
+				ocipuller.WithRangedDownloads(partialDir, chunkSize, 2))
+			result, err = puller.Pull(ctx, ref, destinationDir, "", "")
+		})
+
+		JustAfterEach(func() {
+			server.Close()
+		})
+
+		When("the registry supports range requests", func() {
+			It("should download the layer in chunks", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(result.Type).Should(Equal(oci.Rulesfile))
+				Expect(rangeRequests.Load()).Should(BeEquivalentTo((len(layer) + chunkSize - 1) / chunkSize))
+				Expect(partPath).ShouldNot(BeAnExistingFile())
+			})
+		})
+
+		When("a partial download exists", func() {
+			BeforeEach(func() {
+				Expect(os.WriteFile(partPath, layer[:chunkSize+10], 0o600)).Should(Succeed())
+			})
+
+			It("should resume it", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(rangeRequests.Load()).Should(BeEquivalentTo((len(layer) - chunkSize - 10 + chunkSize - 1) / chunkSize))
+				Expect(partPath).ShouldNot(BeAnExistingFile())
+			})
+		})
+
+		When("a corrupted partial download exists", func() {
+			BeforeEach(func() {
+				Expect(os.WriteFile(partPath, make([]byte, chunkSize), 0o600)).Should(Succeed())
+			})
+
+			It("should error and discard it", func() {
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).Should(ContainSubstring("does not match its digest"))
+				Expect(partPath).ShouldNot(BeAnExistingFile())
+			})
+		})
+
+		When("the registry does not support range requests", func() {
+			BeforeEach(func() {
+				ignoreRanges = true
+				Expect(os.WriteFile(partPath, layer[:chunkSize], 0o600)).Should(Succeed())
+			})
+
+			It("should download the layer in a single stream", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(result.Type).Should(Equal(oci.Rulesfile))
+				Expect(partPath).ShouldNot(BeAnExistingFile())
+			})
+		})
+	})
+
+	Context("WithSource option", func() {
+		var (
+			layoutDir string
+			result    *oci.RegistryResult
+			err       error
+		)
+
+		BeforeEach(func() {
+			// Export the rulesfile artifact to an OCI layout, tagged with its full reference.
+			layoutDir = GinkgoT().TempDir()
+			store, err := ocilayout.New(layoutDir)
+			Expect(err).ShouldNot(HaveOccurred())
+			repo, err := repository.NewRepository(rulesRef, repository.WithPlainHTTP(plainHTTP))
+			Expect(err).ShouldNot(HaveOccurred())
+			_, err = oras.Copy(ctx, repo, rulesRef, store, rulesRef, oras.DefaultCopyOptions)
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			source, err := ocipuller.NewLocalSource(ctx, layoutDir)
+			Expect(err).ShouldNot(HaveOccurred())
+			// The client is not expected to be ever used.
+			puller = ocipuller.NewPuller(nil, plainHTTP, tracker, ocipuller.WithSource(source))
+		})
+
+		When("the artifact is in the layout", func() {
+			It("should pull it from the layout", func() {
+				result, err = puller.Pull(ctx, rulesRef, destinationDir, runtime.GOOS, runtime.GOARCH)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(result.Type).Should(Equal(oci.Rulesfile))
+				Expect(os.Remove(filepath.Join(destinationDir, result.Filename))).ShouldNot(HaveOccurred())
+			})
+		})
+
+		When("the artifact is not in the layout", func() {
+			It("should error", func() {
+				result, err = puller.Pull(ctx, pluginMultiPlatformRef, destinationDir, runtime.GOOS, runtime.GOARCH)
+				Expect(err).Should(HaveOccurred())
+				Expect(result).Should(BeNil())
+			})
+		})
+	})
+
+	Context("Export func", func() {
+		var (
+			store *ocilayout.Store
+			OS    string
+			ARCH  string
+			desc  *v1.Descriptor
+			err   error
+		)
+
+		JustBeforeEach(func() {
+			store, err = ocilayout.New(GinkgoT().TempDir())
+			Expect(err).ShouldNot(HaveOccurred())
+			puller = ocipuller.NewPuller(authn.NewClient(authn.WithCredentials(&auth.EmptyCredential)), plainHTTP, tracker)
+			desc, err = puller.Export(ctx, pluginMultiPlatformRef, store, OS, ARCH)
+		})
+
+		When("all platforms are exported", func() {
+			BeforeEach(func() {
+				OS, ARCH = "", ""
+			})
+
+			It("should preserve the image index and its digest", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				remoteDesc, err := puller.Descriptor(ctx, pluginMultiPlatformRef)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(desc.Digest).Should(Equal(remoteDesc.Digest))
+				Expect(desc.MediaType).Should(Equal(v1.MediaTypeImageIndex))
+				localDesc, err := store.Resolve(ctx, pluginMultiPlatformRef)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(localDesc.Digest).Should(Equal(remoteDesc.Digest))
+			})
+		})
+
+		When("a single platform is exported", func() {
+			BeforeEach(func() {
+				tokens := strings.Split(testPluginPlatform3, "/")
+				OS, ARCH = tokens[0], tokens[1]
+			})
+
+			It("should export only the platform manifest", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(desc.MediaType).Should(Equal(v1.MediaTypeImageManifest))
+			})
+		})
+
+		When("a non existing platform is requested", func() {
+			BeforeEach(func() {
+				OS, ARCH = "linux", "non-existing"
+			})
+
+			It("should error", func() {
+				Expect(err).Should(HaveOccurred())
+				Expect(desc).Should(BeNil())
+			})
+		})
+	})
 })
@@ -0,0 +1,273 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package puller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/opencontainers/go-digest"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/registry/remote/auth"
+
+	"github.com/falcosecurity/falcoctl/pkg/oci/repository"
+)
+
+const (
+	// DefaultChunkSize is the default size of the chunks in which layers are downloaded, see WithRangedDownloads.
+	DefaultChunkSize = 8 << 20
+
+	// partialSuffix is the suffix of the files holding the partially downloaded layers.
+	partialSuffix = ".part"
+)
+
+// errRangeNotSupported is returned when the registry ignores a range request, sending the whole content back.
+var errRangeNotSupported = errors.New("range requests not supported")
+
+// rangedDownloads holds the settings of the ranged downloads.
+type rangedDownloads struct {
+	dir         string
+	chunkSize   int64
+	concurrency int
+}
+
+// WithRangedDownloads makes the puller download the layers bigger than chunkSize in chunks of that size through
+// HTTP range requests, up to concurrency chunks at a time. The bytes downloaded so far are kept in a ".part" file
+// under partialDir, so that a download failed midway is resumed by the next pull of the same layer instead of
+// starting over. Layers are downloaded in a single stream from the registries not supporting range requests.
+func WithRangedDownloads(partialDir string, chunkSize int64, concurrency int) Option {
+	return func(p *Puller) {
+		if chunkSize <= 0 {
+			chunkSize = DefaultChunkSize
+		}
+		if concurrency <= 0 {
+			concurrency = 1
+		}
+		p.ranged = &rangedDownloads{
+			dir:         partialDir,
+			chunkSize:   chunkSize,
+			concurrency: concurrency,
+		}
+	}
+}
+
+// rangedTarget reads from a remote repository, downloading the big layers through range requests.
+type rangedTarget struct {
+	repo     *repository.Repository
+	settings *rangedDownloads
+}
+
+// Resolve resolves the reference against the remote repository.
+func (t *rangedTarget) Resolve(ctx context.Context, reference string) (v1.Descriptor, error) {
+	return t.repo.Resolve(ctx, reference)
+}
+
+// Exists returns true if the content exists in the remote repository.
+func (t *rangedTarget) Exists(ctx context.Context, target v1.Descriptor) (bool, error) {
+	return t.repo.Exists(ctx, target)
+}
+
+// Fetch downloads the blobs bigger than the chunk size in chunks, the other contents in a single request.
+// The returned reader removes the downloaded file once closed.
+func (t *rangedTarget) Fetch(ctx context.Context, target v1.Descriptor) (io.ReadCloser, error) {
+	if target.Size <= t.settings.chunkSize || isManifest(target) {
+		return t.repo.Fetch(ctx, target)
+	}
+
+	if err := os.MkdirAll(t.settings.dir, 0o750); err != nil {
+		return nil, fmt.Errorf("unable to create directory for partial downloads %q: %w", t.settings.dir, err)
+	}
+	partPath := filepath.Join(t.settings.dir, target.Digest.Algorithm().String()+"-"+target.Digest.Encoded()+partialSuffix)
+
+	if err := t.download(ctx, target, partPath); err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(filepath.Clean(partPath))
+	if err != nil {
+		return nil, err
+	}
+	verifier := target.Digest.Verifier()
+	if _, err := io.Copy(verifier, f); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if !verifier.Verified() {
+		f.Close()
+		// Do not resume from corrupted content next time.
+		_ = os.Remove(partPath)
+		return nil, fmt.Errorf("downloaded content of blob %s does not match its digest", target.Digest)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return &partialFile{File: f}, nil
+}
+
+// download writes the content to partPath, resuming from the bytes already there.
+func (t *rangedTarget) download(ctx context.Context, target v1.Descriptor, partPath string) error {
+	f, err := os.OpenFile(filepath.Clean(partPath), os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return fmt.Errorf("unable to open partial download %q: %w", partPath, err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	offset := info.Size()
+	if offset > target.Size {
+		if err := f.Truncate(0); err != nil {
+			return err
+		}
+		offset = 0
+	}
+
+	for offset < target.Size {
+		chunks := t.fetchChunks(ctx, target, offset)
+		// Chunks are written in order, so that the file always holds a prefix of the content to resume from.
+		for _, c := range chunks {
+			if errors.Is(c.err, errRangeNotSupported) {
+				return t.downloadStream(ctx, target, f)
+			}
+			if c.err != nil {
+				return c.err
+			}
+			if _, err := f.WriteAt(c.data, offset); err != nil {
+				return fmt.Errorf("unable to write partial download %q: %w", partPath, err)
+			}
+			offset += int64(len(c.data))
+		}
+	}
+
+	return nil
+}
+
+// downloadStream writes the whole content to f in a single request, discarding what f already holds.
+func (t *rangedTarget) downloadStream(ctx context.Context, target v1.Descriptor, f *os.File) error {
+	if err := f.Truncate(0); err != nil {
+		return err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	rc, err := t.repo.Fetch(ctx, target)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	_, err = io.Copy(f, rc)
+	return err
+}
+
+// chunk is the result of a range request.
+type chunk struct {
+	data []byte
+	err  error
+}
+
+// fetchChunks downloads, concurrently, up to the configured number of chunks of the content starting from offset.
+func (t *rangedTarget) fetchChunks(ctx context.Context, target v1.Descriptor, offset int64) []chunk {
+	remaining := (target.Size - offset + t.settings.chunkSize - 1) / t.settings.chunkSize
+	chunks := make([]chunk, min(remaining, int64(t.settings.concurrency)))
+
+	var wg sync.WaitGroup
+	for i := range chunks {
+		start := offset + int64(i)*t.settings.chunkSize
+		end := min(start+t.settings.chunkSize, target.Size)
+		wg.Add(1)
+		go func(c *chunk) {
+			defer wg.Done()
+			c.data, c.err = t.fetchRange(ctx, target.Digest, start, end)
+		}(&chunks[i])
+	}
+	wg.Wait()
+
+	return chunks
+}
+
+// fetchRange downloads the bytes of the blob in the [start, end) range.
+func (t *rangedTarget) fetchRange(ctx context.Context, dgst digest.Digest, start, end int64) ([]byte, error) {
+	ref := t.repo.Reference
+	scheme := "https"
+	if t.repo.PlainHTTP {
+		scheme = "http"
+	}
+	url := fmt.Sprintf("%s://%s/v2/%s/blobs/%s", scheme, ref.Host(), ref.Repository, dgst)
+
+	ctx = auth.AppendRepositoryScope(ctx, ref, auth.ActionPull)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", start, end-1))
+
+	resp, err := t.repo.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+	case http.StatusOK:
+		return nil, errRangeNotSupported
+	default:
+		return nil, fmt.Errorf("unable to fetch bytes %d-%d of blob %s: unexpected status %q", start, end-1, dgst, resp.Status)
+	}
+
+	data := make([]byte, end-start)
+	if _, err := io.ReadFull(resp.Body, data); err != nil {
+		return nil, fmt.Errorf("unable to read bytes %d-%d of blob %s: %w", start, end-1, dgst, err)
+	}
+
+	return data, nil
+}
+
+// isManifest returns true if the descriptor points to a manifest or an index, which are not served as blobs.
+func isManifest(desc v1.Descriptor) bool {
+	switch desc.MediaType {
+	case v1.MediaTypeImageManifest, v1.MediaTypeImageIndex,
+		"application/vnd.docker.distribution.manifest.v2+json", "application/vnd.docker.distribution.manifest.list.v2+json":
+		return true
+	}
+	return false
+}
+
+// partialFile is a completed download, removed once closed.
+type partialFile struct {
+	*os.File
+}
+
+// Close closes and removes the file.
+func (f *partialFile) Close() error {
+	err := f.File.Close()
+	if rmErr := os.Remove(f.Name()); rmErr != nil && err == nil {
+		err = rmErr
+	}
+	return err
+}
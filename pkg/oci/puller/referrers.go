@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package puller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/falcosecurity/falcoctl/pkg/oci/repository"
+)
+
+// Referrers lists the descriptors of the manifests attached to the artifact pointed by ref, i.e. having it as
+// subject, sorted by artifact type and digest. The referrers API of the registry is queried, falling back to the
+// referrers tag schema when the registry does not support it. When artifactType is not empty, only the referrers
+// of that type are listed. Mirrors and local sources are not considered, since the referrers must come from the
+// repository of the artifact.
+func (p *Puller) Referrers(ctx context.Context, ref, artifactType string) ([]v1.Descriptor, error) {
+	repo, err := repository.NewRepository(ref, repository.WithClient(p.Client), repository.WithPlainHTTP(p.plainHTTP))
+	if err != nil {
+		return nil, err
+	}
+
+	desc, err := repo.Resolve(ctx, repo.Reference.Reference)
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve reference %q: %w", ref, err)
+	}
+
+	referrers := []v1.Descriptor{}
+	err = repo.Referrers(ctx, desc, artifactType, func(page []v1.Descriptor) error {
+		referrers = append(referrers, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list referrers of %q: %w", ref, err)
+	}
+
+	sort.SliceStable(referrers, func(i, j int) bool {
+		if referrers[i].ArtifactType != referrers[j].ArtifactType {
+			return referrers[i].ArtifactType < referrers[j].ArtifactType
+		}
+		return referrers[i].Digest < referrers[j].Digest
+	})
+
+	return referrers, nil
+}
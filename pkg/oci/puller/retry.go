@@ -0,0 +1,240 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package puller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"oras.land/oras-go/v2/registry/remote"
+)
+
+const (
+	// DefaultMaxRetries is the default number of times a request is retried.
+	DefaultMaxRetries = 3
+	// DefaultRetryBackoff is the default initial wait time between retries.
+	DefaultRetryBackoff = time.Second
+	// maxRetryBackoff caps the wait time between two consecutive retries.
+	maxRetryBackoff = 30 * time.Second
+	// maxRetryAfter caps the wait time a registry can ask for through the Retry-After header: when it asks for more,
+	// the request fails right away instead of blocking the command.
+	maxRetryAfter = 2 * time.Minute
+)
+
+// ErrRateLimited is matched by the errors returned when a registry rejects the requests because of its rate limit,
+// see RateLimitError.
+var ErrRateLimited = errors.New("rate limit exceeded")
+
+// RateLimitError is returned when a registry keeps answering 429 Too Many Requests, e.g. once the pull rate limit of
+// Docker Hub has been hit. It matches ErrRateLimited.
+type RateLimitError struct {
+	// Host is the registry that rejected the request.
+	Host string
+	// RetryAfter is how long the registry asked to wait through the Retry-After header, zero when not given.
+	RetryAfter time.Duration
+	// Limit and Remaining are the values of the RateLimit-Limit and RateLimit-Remaining headers, e.g. "100;w=21600"
+	// for 100 requests every 6 hours, empty when not given.
+	Limit, Remaining string
+	// hasRetryAfter tells a Retry-After header asking to retry right away from a missing one.
+	hasRetryAfter bool
+}
+
+// Error implements the error interface, suggesting to authenticate since registries grant higher limits to
+// authenticated users.
+func (e *RateLimitError) Error() string {
+	msg := fmt.Sprintf("%s of registry %s", ErrRateLimited, e.Host)
+	if limit := describeRateLimit(e.Limit); limit != "" {
+		msg += " (" + limit + ")"
+	}
+	if e.RetryAfter > 0 {
+		msg += ", retry in " + e.RetryAfter.String()
+	}
+	return msg + `: authenticate with "falcoctl registry login" to raise the limit`
+}
+
+// Is makes RateLimitError match ErrRateLimited.
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
+// newRateLimitError builds the RateLimitError of a request rejected with 429 Too Many Requests.
+func newRateLimitError(req *http.Request, resp *http.Response) *RateLimitError {
+	retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"))
+	return &RateLimitError{
+		Host:          req.URL.Host,
+		RetryAfter:    retryAfter,
+		Limit:         resp.Header.Get("RateLimit-Limit"),
+		Remaining:     resp.Header.Get("RateLimit-Remaining"),
+		hasRetryAfter: ok,
+	}
+}
+
+// retryDelay returns how long to wait before retrying the rejected request, given the exponential backoff delay,
+// or false if retrying is pointless: the registry asked to wait longer than maxRetryAfter, or no request is left in
+// the current window of the rate limit, which Docker Hub counts over hours.
+func (e *RateLimitError) retryDelay(backoff time.Duration) (time.Duration, bool) {
+	if e.hasRetryAfter {
+		return e.RetryAfter, e.RetryAfter <= maxRetryAfter
+	}
+	if remaining, _, _ := strings.Cut(e.Remaining, ";"); strings.TrimSpace(remaining) == "0" {
+		return 0, false
+	}
+	return backoff, true
+}
+
+// parseRetryAfter parses the value of a Retry-After header, either a number of seconds or an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date).Round(time.Second), 0), true
+	}
+	return 0, false
+}
+
+// describeRateLimit turns the value of a RateLimit-Limit header, e.g. "100;w=21600", into a readable description,
+// e.g. "100 requests per 6h0m0s".
+func describeRateLimit(limit string) string {
+	quota, params, _ := strings.Cut(limit, ";")
+	quota = strings.TrimSpace(quota)
+	if quota == "" {
+		return ""
+	}
+	for _, param := range strings.Split(params, ";") {
+		if window, ok := strings.CutPrefix(strings.TrimSpace(param), "w="); ok {
+			if seconds, err := strconv.Atoi(window); err == nil {
+				return fmt.Sprintf("%s requests per %s", quota, time.Duration(seconds)*time.Second)
+			}
+		}
+	}
+	return quota + " requests"
+}
+
+// retryClient wraps a remote.Client retrying requests that failed because of transient errors.
+type retryClient struct {
+	client     remote.Client
+	maxRetries int
+	backoff    time.Duration
+}
+
+// Do sends the request through the wrapped client, retrying it with exponential backoff and jitter when the
+// registry answers with a retriable status code or the connection fails because of a transient network error.
+// Any other response, including 401, 403 and 404, is returned right away. Requests rejected with 429 Too Many
+// Requests are retried after the time given by the Retry-After header, if any, and fail with a RateLimitError
+// once retrying is pointless or the retries are exhausted.
+func (c *retryClient) Do(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := c.client.Do(req)
+		if !retriable(resp, err) {
+			return resp, err
+		}
+
+		delay, retry := c.delay(attempt), attempt < c.maxRetries
+		if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+			limited := newRateLimitError(req, resp)
+			if retry {
+				delay, retry = limited.retryDelay(delay)
+			}
+			if !retry {
+				resp.Body.Close()
+				return nil, limited
+			}
+		}
+		if !retry {
+			return resp, err
+		}
+
+		// Requests with a body can be retried only if the body can be replayed.
+		if req.Body != nil && req.Body != http.NoBody {
+			if req.GetBody == nil {
+				return resp, err
+			}
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return resp, err
+			}
+			req.Body = body
+		}
+
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		if err := sleep(req.Context(), delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// delay returns the wait time before the given retry attempt, doubling the backoff at each attempt.
+// A random jitter in [delay/2, delay) avoids all the clients hitting the registry at the same time.
+func (c *retryClient) delay(attempt int) time.Duration {
+	d := c.backoff << attempt
+	if d <= 0 || d > maxRetryBackoff {
+		d = maxRetryBackoff
+	}
+
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1)) // #nosec G404 //jitter does not need a secure random source
+}
+
+// retriable checks whether a request that produced the given response or error is worth retrying.
+func retriable(resp *http.Response, err error) bool {
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return false
+		}
+
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			return true
+		}
+
+		return errors.Is(err, syscall.ECONNRESET)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package puller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+
+	"github.com/falcosecurity/falcoctl/pkg/oci"
+	"github.com/falcosecurity/falcoctl/pkg/oci/repository"
+)
+
+// SBOM is a software bill of materials attached to an artifact.
+type SBOM struct {
+	// ArtifactType is the format of the SBOM, either oci.SPDXMediaType or oci.CycloneDXMediaType.
+	ArtifactType string
+	// Digest of the manifest the SBOM has been attached with.
+	Digest string
+	// Content is the SBOM document.
+	Content []byte
+}
+
+// Extension returns the file extension conventionally used for the format of the SBOM.
+func (s *SBOM) Extension() string {
+	if s.ArtifactType == oci.CycloneDXMediaType {
+		return ".cdx.json"
+	}
+	return ".spdx.json"
+}
+
+// isSBOMType tells whether the artifact type is the one of a supported SBOM format.
+func isSBOMType(artifactType string) bool {
+	return artifactType == oci.SPDXMediaType || artifactType == oci.CycloneDXMediaType
+}
+
+// SBOM retrieves the SBOM attached, as a referrer, to the artifact pointed by ref. When several are attached, an
+// SPDX one is preferred over a CycloneDX one. No SBOM, and no error, is returned when none is attached. As for the
+// referrers, mirrors and local sources are not considered.
+func (p *Puller) SBOM(ctx context.Context, ref string) (*SBOM, error) {
+	referrers, err := p.Referrers(ctx, ref, "")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, desc := range referrers {
+		if !isSBOMType(desc.ArtifactType) {
+			continue
+		}
+
+		return p.fetchSBOM(ctx, ref, desc)
+	}
+
+	return nil, nil
+}
+
+// fetchSBOM fetches the SBOM document from the layers of the referrer manifest described by desc, in the repository
+// of the artifact pointed by ref.
+func (p *Puller) fetchSBOM(ctx context.Context, ref string, desc v1.Descriptor) (*SBOM, error) {
+	repo, err := repository.NewRepository(ref, repository.WithClient(p.Client), repository.WithPlainHTTP(p.plainHTTP))
+	if err != nil {
+		return nil, err
+	}
+
+	_, manifestBytes, err := oras.FetchBytes(ctx, repo, desc.Digest.String(), oras.DefaultFetchBytesOptions)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch SBOM manifest %s: %w", desc.Digest, err)
+	}
+
+	var manifest v1.Manifest
+	if err := json.Unmarshal(manifestBytes, &manifest); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal SBOM manifest: %w", err)
+	}
+
+	// The document is the layer of the SBOM media type, or the only layer of the manifest.
+	var layer *v1.Descriptor
+	for i := range manifest.Layers {
+		if manifest.Layers[i].MediaType == desc.ArtifactType {
+			layer = &manifest.Layers[i]
+			break
+		}
+	}
+	if layer == nil && len(manifest.Layers) == 1 {
+		layer = &manifest.Layers[0]
+	}
+	if layer == nil {
+		return nil, fmt.Errorf("unable to find the SBOM document in manifest %s", desc.Digest)
+	}
+
+	document, err := content.FetchAll(ctx, repo, *layer)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch SBOM %s: %w", layer.Digest, err)
+	}
+
+	return &SBOM{ArtifactType: desc.ArtifactType, Digest: desc.Digest.String(), Content: document}, nil
+}
@@ -159,7 +159,7 @@ func (p *Pusher) Push(ctx context.Context, artifactType oci.ArtifactType,
 
 		// Now we can create manifest, using the Config descriptor and principal Layer descriptor.
 		if manifestDescs[i], err = p.packManifest(ctx, fileStore, configDesc,
-			dataDesc, platform, o.AnnotationSource); err != nil {
+			dataDesc, platform, o.annotations()); err != nil {
 			return nil, err
 		}
 
@@ -177,7 +177,7 @@ func (p *Pusher) Push(ctx context.Context, artifactType oci.ArtifactType,
 		if fileStore, err = file.New(""); err != nil {
 			return nil, err
 		}
-		if rootDesc, err = p.storeArtifactsIndex(ctx, fileStore, manifestDescs, o.AnnotationSource); err != nil {
+		if rootDesc, err = p.storeArtifactsIndex(ctx, fileStore, manifestDescs, o.annotations()); err != nil {
 			return nil, err
 		}
 	}
@@ -234,15 +234,8 @@ func (p *Pusher) storeMainLayer(ctx context.Context, fileStore *file.Store,
 
 func (p *Pusher) storeConfigLayer(ctx context.Context, fileStore *file.Store,
 	artifactType oci.ArtifactType, artifactConfig *oci.ArtifactConfig) (*v1.Descriptor, error) {
-	var layerMediaType string
-	switch artifactType {
-	case oci.Rulesfile:
-		layerMediaType = oci.FalcoRulesfileConfigMediaType
-	case oci.Plugin:
-		layerMediaType = oci.FalcoPluginConfigMediaType
-	case oci.Asset:
-		layerMediaType = oci.FalcoAssetConfigMediaType
-	default:
+	layerMediaType := artifactType.ToConfigMediaType()
+	if layerMediaType == "" {
 		return nil, fmt.Errorf("unknown media type for config layer: %s", artifactType)
 	}
 
@@ -265,16 +258,12 @@ func (p *Pusher) storeConfigLayer(ctx context.Context, fileStore *file.Store,
 }
 
 func (p *Pusher) storeArtifactsIndex(ctx context.Context, fileStore *file.Store,
-	manifestDescs []*v1.Descriptor, annotationSource string) (*v1.Descriptor, error) {
+	manifestDescs []*v1.Descriptor, annotations map[string]string) (*v1.Descriptor, error) {
 	// fat manifest
 	index := &v1.Index{
-		Versioned: specs.Versioned{SchemaVersion: 2},
-		MediaType: v1.MediaTypeImageIndex,
-	}
-
-	if annotationSource != "" {
-		index.Annotations = make(map[string]string)
-		index.Annotations[v1.AnnotationSource] = annotationSource
+		Versioned:   specs.Versioned{SchemaVersion: 2},
+		MediaType:   v1.MediaTypeImageIndex,
+		Annotations: annotations,
 	}
 
 	// copy manifests
@@ -316,22 +305,12 @@ func (p *Pusher) toFileStore(ctx context.Context, fileStore *file.Store, mediaTy
 }
 
 func (p *Pusher) packManifest(ctx context.Context, fileStore *file.Store,
-	configDesc, dataDesc *v1.Descriptor, platform, annotationSource string) (*v1.Descriptor, error) {
+	configDesc, dataDesc *v1.Descriptor, platform string, annotations map[string]string) (*v1.Descriptor, error) {
 	// Now we can create manifest, using the Config descriptor and principal Layer descriptor.
-	// In case annotation source is passed, we put it in the ManifestAnnotations.
-	var packOptions oras.PackOptions
-
+	// In case annotations are passed, we put them in the ManifestAnnotations.
 	// Currently, Manifests are not pushed as OCI Artifact Manifest.
 	// Always pushed as OCI Image Manifest.
-	packImageManifest := true
-
-	if annotationSource != "" {
-		annotations := make(map[string]string)
-		annotations[v1.AnnotationSource] = annotationSource
-		packOptions = oras.PackOptions{ConfigDescriptor: configDesc, ManifestAnnotations: annotations, PackImageManifest: packImageManifest}
-	} else {
-		packOptions = oras.PackOptions{ConfigDescriptor: configDesc, PackImageManifest: packImageManifest}
-	}
+	packOptions := oras.PackOptions{ConfigDescriptor: configDesc, ManifestAnnotations: annotations, PackImageManifest: true}
 
 	desc, err := oras.Pack(ctx, fileStore, "", []v1.Descriptor{*dataDesc}, packOptions)
 	if err != nil {
@@ -21,8 +21,12 @@ import (
 	"net/http"
 	"reflect"
 
+	"go.opentelemetry.io/otel/attribute"
 	"oras.land/oras-go/v2/registry/remote"
 	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras-go/v2/registry/remote/errcode"
+
+	"github.com/falcosecurity/falcoctl/internal/telemetry"
 )
 
 // Registry is an HTTP client to interact with a remote registry.
@@ -63,8 +67,42 @@ func WithPlainHTTP(plainHTTP bool) func(r *Registry) {
 	}
 }
 
+// WithRepositoryListPageSize sets the maximum number of repositories requested to the registry for each page
+// of its catalog. The page size is left to the registry when n is not positive.
+func WithRepositoryListPageSize(n int) func(r *Registry) {
+	return func(r *Registry) {
+		r.RepositoryListPageSize = n
+	}
+}
+
+// Catalog lists the repositories of the registry through the catalog endpoint, following every page of results.
+// When last is not empty, only the repositories sorted after it are listed.
+func (r *Registry) Catalog(ctx context.Context, last string) ([]string, error) {
+	var result []string
+	// The function is called once per page of repositories.
+	err := r.Registry.Repositories(ctx, last, func(repos []string) error {
+		result = append(result, repos...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list the repositories of registry %q: %w", r.RepositoryOptions.Reference.Registry, err)
+	}
+
+	return result, nil
+}
+
 // CheckConnection checks whether the underlying HTTP client can correctly interact with the remote registry.
+// When the client has no credentials for the registry, e.g. it is anonymous, the check is unauthenticated.
+// The check is traced when telemetry is enabled.
 func (r *Registry) CheckConnection(ctx context.Context) error {
+	ctx, span := telemetry.StartSpan(ctx, "CheckConnection",
+		attribute.String("registry", r.RepositoryOptions.Reference.Registry), attribute.Bool("plainHTTP", r.PlainHTTP))
+	err := r.checkConnection(ctx)
+	telemetry.EndSpan(span, err)
+	return err
+}
+
+func (r *Registry) checkConnection(ctx context.Context) error {
 	if authClient, ok := r.Client.(*auth.Client); ok {
 		cred, err := authClient.Credential(ctx, r.RepositoryOptions.Reference.Registry)
 		if err != nil {
@@ -90,7 +128,13 @@ func (r *Registry) checkConnectionUnauthenticated(ctx context.Context) error {
 		return err
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	// Go through the transport of the client, if any, to honor its settings, e.g. proxy and timeouts.
+	httpClient := http.DefaultClient
+	if authClient, ok := r.Client.(*auth.Client); ok && authClient.Client != nil {
+		httpClient = authClient.Client
+	}
+
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		return err
 	}
@@ -106,6 +150,8 @@ func (r *Registry) checkConnectionUnauthenticated(ctx context.Context) error {
 		}
 		return fmt.Errorf("remote registry %q does not implement Docker Registry HTTP API V2: %q", url, resp.Status)
 	default:
-		return fmt.Errorf("unable to check remote registry %q: %q", url, resp.Status)
+		// The status code is kept, so that callers can tell transient failures apart.
+		return fmt.Errorf("unable to check remote registry %q: %w", url,
+			&errcode.ErrorResponse{Method: req.Method, URL: req.URL, StatusCode: resp.StatusCode})
 	}
 }
@@ -60,15 +60,30 @@ func WithPlainHTTP(plainHTTP bool) func(r *Repository) {
 	}
 }
 
+// WithTagListPageSize sets the maximum number of tags requested to the registry for each page of tags.
+// The page size is left to the registry when n is not positive.
+func WithTagListPageSize(n int) func(r *Repository) {
+	return func(r *Repository) {
+		r.TagListPageSize = n
+	}
+}
+
 // Tags returns the list of all available tags of an artifact given a reference to a repository.
 func (r *Repository) Tags(ctx context.Context) ([]string, error) {
+	return r.TagsAfter(ctx, "")
+}
+
+// TagsAfter returns the list of the available tags of an artifact sorted after last, following every page of tags.
+// All the tags are returned when last is empty.
+func (r *Repository) TagsAfter(ctx context.Context, last string) ([]string, error) {
 	var result []string
+	// The function is called once per page of tags.
 	var tagRetriever = func(tags []string) error {
-		result = tags
+		result = append(result, tags...)
 		return nil
 	}
 
-	err := r.Repository.Tags(ctx, "", tagRetriever)
+	err := r.Repository.Tags(ctx, last, tagRetriever)
 	if err != nil {
 		return nil, err
 	}
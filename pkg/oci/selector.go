@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oci
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AnnotationRequirement is a condition on the value of a manifest annotation.
+type AnnotationRequirement struct {
+	Key   string
+	Value string
+	// NotEqual is true when the annotation must be missing or have a different value.
+	NotEqual bool
+}
+
+// String returns the requirement in the "key=value" or "key!=value" format.
+func (r AnnotationRequirement) String() string {
+	if r.NotEqual {
+		return r.Key + "!=" + r.Value
+	}
+	return r.Key + "=" + r.Value
+}
+
+// Matches returns true if the given annotations meet the requirement.
+func (r AnnotationRequirement) Matches(annotations map[string]string) bool {
+	value, ok := annotations[r.Key]
+	if r.NotEqual {
+		return !ok || value != r.Value
+	}
+	return ok && value == r.Value
+}
+
+// AnnotationSelector selects the artifacts whose manifest annotations meet all its requirements.
+type AnnotationSelector []AnnotationRequirement
+
+// ParseAnnotationSelector parses requirements in the "key=value" or "key!=value" format.
+func ParseAnnotationSelector(requirements []string) (AnnotationSelector, error) {
+	selector := make(AnnotationSelector, 0, len(requirements))
+	for _, req := range requirements {
+		var r AnnotationRequirement
+		key, value, ok := strings.Cut(req, "!=")
+		if ok {
+			r.NotEqual = true
+		} else if key, value, ok = strings.Cut(req, "="); !ok {
+			return nil, fmt.Errorf("invalid selector %q: needs to be in key=value or key!=value format", req)
+		}
+
+		if r.Key = strings.TrimSpace(key); r.Key == "" {
+			return nil, fmt.Errorf("invalid selector %q: annotation key cannot be empty", req)
+		}
+		r.Value = strings.TrimSpace(value)
+		selector = append(selector, r)
+	}
+
+	return selector, nil
+}
+
+// Unmatched returns the first requirement not met by the given annotations, if any.
+func (s AnnotationSelector) Unmatched(annotations map[string]string) (AnnotationRequirement, bool) {
+	for _, r := range s {
+		if !r.Matches(annotations) {
+			return r, true
+		}
+	}
+
+	return AnnotationRequirement{}, false
+}
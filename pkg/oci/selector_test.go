@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oci
+
+import "testing"
+
+func TestParseAnnotationSelector(t *testing.T) {
+	selector, err := ParseAnnotationSelector([]string{"stability=stable", "maintainer != someone"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(selector) != 2 {
+		t.Fatal("expected 2 requirements, got:", selector)
+	}
+
+	if selector[0] != (AnnotationRequirement{Key: "stability", Value: "stable"}) {
+		t.Fatal("first requirement does not match, got:", selector[0])
+	}
+
+	if selector[1] != (AnnotationRequirement{Key: "maintainer", Value: "someone", NotEqual: true}) {
+		t.Fatal("second requirement does not match, got:", selector[1])
+	}
+
+	for _, invalid := range []string{"stability", "=stable", "!=stable"} {
+		if _, err := ParseAnnotationSelector([]string{invalid}); err == nil {
+			t.Fatal("expected an error parsing", invalid)
+		}
+	}
+}
+
+func TestAnnotationSelectorUnmatched(t *testing.T) {
+	selector := AnnotationSelector{
+		{Key: "stability", Value: "stable"},
+		{Key: "maintainer", Value: "someone", NotEqual: true},
+	}
+
+	if r, ok := selector.Unmatched(map[string]string{"stability": "stable"}); ok {
+		t.Fatal("expected all requirements to be met, got unmatched:", r)
+	}
+
+	if r, ok := selector.Unmatched(map[string]string{"stability": "stable", "maintainer": "someone"}); !ok || r.String() != "maintainer!=someone" {
+		t.Fatal("expected maintainer!=someone to be unmatched, got:", r)
+	}
+
+	if r, ok := selector.Unmatched(nil); !ok || r.String() != "stability=stable" {
+		t.Fatal("expected stability=stable to be unmatched, got:", r)
+	}
+}
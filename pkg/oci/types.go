@@ -22,6 +22,8 @@ import (
 	"sort"
 	"strings"
 
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+
 	"github.com/falcosecurity/falcoctl/pkg/artifact"
 )
 
@@ -76,6 +78,63 @@ func (e *ArtifactType) ToMediaType() string {
 	return ""
 }
 
+// ToConfigMediaType converts type to the media type of its config layer.
+// Ensure this is called after a Set().
+func (e *ArtifactType) ToConfigMediaType() string {
+	switch *e {
+	case Rulesfile:
+		return FalcoRulesfileConfigMediaType
+	case Plugin:
+		return FalcoPluginConfigMediaType
+	case Asset:
+		return FalcoAssetConfigMediaType
+	}
+
+	// should never happen
+	return ""
+}
+
+// ArtifactTypeFromManifest returns the type of the artifact described by the manifest. The type is given by the
+// artifactType of the manifest or, when not set, by the media type of its config, and the media type of the first
+// layer must agree with it. Artifacts whose config is not a falcosecurity one, e.g. pushed by generic tools, are
+// typed by their layer only. An error is returned when the type cannot be determined.
+func ArtifactTypeFromManifest(manifest *v1.Manifest) (ArtifactType, error) {
+	if len(manifest.Layers) == 0 {
+		return "", fmt.Errorf("malformed artifact, expected to find at least one layer")
+	}
+
+	configMediaType := manifest.ArtifactType
+	if configMediaType == "" {
+		configMediaType = manifest.Config.MediaType
+	}
+	layerMediaType := manifest.Layers[0].MediaType
+
+	configType, configOK := artifactTypeByMediaType(configMediaType, (*ArtifactType).ToConfigMediaType)
+	layerType, layerOK := artifactTypeByMediaType(layerMediaType, (*ArtifactType).ToMediaType)
+	switch {
+	case configOK && layerOK && configType != layerType:
+		return "", fmt.Errorf("inconsistent artifact: config media type %q is of a %s but layer media type %q is of a %s",
+			configMediaType, configType, layerMediaType, layerType)
+	case configOK && !layerOK:
+		return "", fmt.Errorf("unknown layer media type %q for a %s", layerMediaType, configType)
+	case layerOK:
+		return layerType, nil
+	default:
+		return "", fmt.Errorf("unknown artifact type: neither config media type %q nor layer media type %q is a falcosecurity one",
+			configMediaType, layerMediaType)
+	}
+}
+
+// artifactTypeByMediaType returns the type whose media type, as returned by toMediaType, is the given one.
+func artifactTypeByMediaType(mediaType string, toMediaType func(*ArtifactType) string) (ArtifactType, bool) {
+	for _, t := range []ArtifactType{Rulesfile, Plugin, Asset} {
+		if toMediaType(&t) == mediaType {
+			return t, true
+		}
+	}
+	return "", false
+}
+
 // HumanReadableMediaType converts MediaType to its corresponding
 // type in a human readable format.
 func HumanReadableMediaType(s string) string {
@@ -132,11 +191,42 @@ func (e *ArtifactTypeSlice) Type() string {
 // RegistryResult represents a generic result that is generated when
 // interacting with a remote OCI registry.
 type RegistryResult struct {
+	// Ref is the reference the artifact has been pulled from, which is the one of a mirror if the artifact
+	// could not be pulled from its own registry.
+	Ref string
+	// Registry is the registry, or mirror, that served the artifact.
+	Registry string
+	// ResolvedRef is Ref pinned to RootDigest, i.e. the reference of exactly the pulled artifact.
+	ResolvedRef string
+	// RootDigest is the digest Ref resolved to, which is the one of the image index for multi-platform artifacts.
 	RootDigest string
-	Digest     string
-	Config     ArtifactConfig
-	Type       ArtifactType
-	Filename   string
+	// Digest is the digest of the pulled manifest.
+	Digest string
+	// LayerDigest is the digest of the layer holding the artifact content, as declared in the manifest.
+	LayerDigest string
+	// Size is the size in bytes of the layer holding the artifact content.
+	Size     int64
+	Config   ArtifactConfig
+	Type     ArtifactType
+	Filename string
+}
+
+// ArtifactMetadata describes an artifact as stored in a remote registry, built from its manifest and
+// config layer only.
+type ArtifactMetadata struct {
+	Ref string `json:"ref"`
+	// Digest is the digest of the root descriptor, which is an image index for multi-platform artifacts.
+	Digest string       `json:"digest"`
+	Type   ArtifactType `json:"type"`
+	// Platforms lists the platforms in OS/ARCH format available in the image index, if any.
+	Platforms []string `json:"platforms,omitempty"`
+	// ManifestDigest is the digest of the manifest the remaining fields are taken from.
+	ManifestDigest string            `json:"manifestDigest"`
+	Annotations    map[string]string `json:"annotations,omitempty"`
+	// ConfigAnnotations are the annotations of the config layer descriptor, as declared in the manifest.
+	ConfigAnnotations map[string]string `json:"configAnnotations,omitempty"`
+	Layers            []v1.Descriptor   `json:"layers"`
+	Config            ArtifactConfig    `json:"config"`
 }
 
 // ArtifactConfig is the struct stored in the config layer of rulesfile and plugin artifacts. Each type fills only the fields of interest.
@@ -15,7 +15,12 @@
 
 package oci
 
-import "testing"
+import (
+	"strings"
+	"testing"
+
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
 
 func TestParseDepedencies(t *testing.T) {
 	ac := ArtifactConfig{}
@@ -46,3 +51,43 @@ func TestParseDepedencies(t *testing.T) {
 		t.Fatal("second dep should have no alternatives, got:", ac.Dependencies[1])
 	}
 }
+
+func TestArtifactTypeFromManifest(t *testing.T) {
+	manifest := func(artifactType, configMediaType, layerMediaType string) *v1.Manifest {
+		return &v1.Manifest{
+			ArtifactType: artifactType,
+			Config:       v1.Descriptor{MediaType: configMediaType},
+			Layers:       []v1.Descriptor{{MediaType: layerMediaType}},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		manifest *v1.Manifest
+		want     ArtifactType
+		err      string
+	}{
+		{"plugin", manifest("", FalcoPluginConfigMediaType, FalcoPluginLayerMediaType), Plugin, ""},
+		{"rulesfile", manifest("", FalcoRulesfileConfigMediaType, FalcoRulesfileLayerMediaType), Rulesfile, ""},
+		{"asset from artifactType", manifest(FalcoAssetConfigMediaType, "application/vnd.oci.empty.v1+json", FalcoAssetLayerMediaType), Asset, ""},
+		{"generic config", manifest("", "application/vnd.unknown.config.v1+json", FalcoPluginLayerMediaType), Plugin, ""},
+		{"inconsistent", manifest("", FalcoRulesfileConfigMediaType, FalcoPluginLayerMediaType), "", "inconsistent artifact"},
+		{"unknown layer", manifest("", FalcoPluginConfigMediaType, "application/octet-stream"), "", "unknown layer media type"},
+		{"unknown", manifest("", "application/vnd.unknown.config.v1+json", "application/octet-stream"), "", "unknown artifact type"},
+		{"no layers", &v1.Manifest{Config: v1.Descriptor{MediaType: FalcoPluginConfigMediaType}}, "", "malformed artifact"},
+	}
+	for _, tt := range tests {
+		got, err := ArtifactTypeFromManifest(tt.manifest)
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("%s: expected error containing %q, got %v", tt.name, tt.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+		} else if got != tt.want {
+			t.Errorf("%s: expected type %q, got %q", tt.name, tt.want, got)
+		}
+	}
+}
@@ -17,78 +17,224 @@ package utils
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
+	"net/http"
+	"time"
 
 	credentials "github.com/oras-project/oras-credentials-go"
 	"oras.land/oras-go/v2/registry/remote"
 	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras-go/v2/registry/remote/errcode"
 
 	"github.com/falcosecurity/falcoctl/internal/config"
 	"github.com/falcosecurity/falcoctl/pkg/oci/authn"
 	ocipuller "github.com/falcosecurity/falcoctl/pkg/oci/puller"
 	ocipusher "github.com/falcosecurity/falcoctl/pkg/oci/pusher"
 	"github.com/falcosecurity/falcoctl/pkg/oci/registry"
+	"github.com/falcosecurity/falcoctl/pkg/options"
 	"github.com/falcosecurity/falcoctl/pkg/output"
 )
 
 // Puller returns a new ocipuller.Puller ready to be used for pulling from oci registries.
-func Puller(plainHTTP bool, printer *output.Printer) (*ocipuller.Puller, error) {
-	client, err := Client(true)
+func Puller(reg *options.Registry, printer *output.Printer, opts ...ocipuller.Option) (*ocipuller.Puller, error) {
+	clientOpts, err := reg.ClientOptions(printer)
 	if err != nil {
 		return nil, err
 	}
+	client, err := Client(true, clientOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	mirrors, err := Mirrors(reg)
+	if err != nil {
+		return nil, err
+	}
+	opts = append([]ocipuller.Option{ocipuller.WithMirrors(mirrors...)}, opts...)
 
-	return ocipuller.NewPuller(client, plainHTTP, output.NewTracker(printer, "Pulling")), nil
+	return ocipuller.NewPuller(client, reg.PlainHTTP, output.NewTracker(printer, "Pulling"), opts...), nil
+}
+
+// Mirrors returns the mirror registries to fall back to when pulling. Mirrors passed through flags take
+// precedence over the configured ones.
+func Mirrors(reg *options.Registry) ([]string, error) {
+	if len(reg.Mirrors) > 0 {
+		return reg.Mirrors, nil
+	}
+
+	mirrors, err := config.RegistryMirrors()
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve the configured registry mirrors: %w", err)
+	}
+	return mirrors, nil
 }
 
 // Pusher returns an ocipusher.Pusher ready to be used for pushing to oci registries.
-func Pusher(plainHTTP bool, printer *output.Printer) (*ocipusher.Pusher, error) {
-	client, err := Client(true)
+func Pusher(reg *options.Registry, printer *output.Printer) (*ocipusher.Pusher, error) {
+	clientOpts, err := reg.ClientOptions(printer)
+	if err != nil {
+		return nil, err
+	}
+	client, err := Client(true, clientOpts...)
 	if err != nil {
 		return nil, err
 	}
-	return ocipusher.NewPusher(client, plainHTTP, output.NewTracker(printer, "Pushing")), nil
+	return ocipusher.NewPusher(client, reg.PlainHTTP, output.NewTracker(printer, "Pushing")), nil
 }
 
 // Client returns a new auth.Client.
-// It authenticates the client if credentials are found in the system.
-func Client(enableClientTokenCache bool) (remote.Client, error) {
-	credentialStore, err := credentials.NewStore(config.RegistryCredentialConfPath(), credentials.StoreOptions{
-		AllowPlaintextPut: true,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("unable to create new store: %w", err)
-	}
+// It authenticates the client if credentials are found in the system, unless it is anonymous.
+// Additional options, e.g. the proxy settings, are applied on top of the default ones.
+func Client(enableClientTokenCache bool, opts ...func(*authn.Options)) (remote.Client, error) {
+	var ops []func(*authn.Options)
 
-	// create client that
-	// 1. auto logins into registries
-	// 2. checks basic auth credential store
-	// 3. checks oauth2 clientcredentials
-	// 4. checks gcp credentials if enabled
-	ops := []func(*authn.Options){
-		authn.WithAutoLogin(authn.NewAutoLoginHandler(credentialStore)),
-		authn.WithStore(credentialStore),
-		authn.WithOAuthCredentials(),
-		authn.WithGcpCredentials(),
+	// Anonymous clients do not use any credential source, hence the credential store is not even opened.
+	if !authn.IsAnonymous(opts...) {
+		helpers, err := config.RegistryCredentialHelpers()
+		if err != nil {
+			return nil, fmt.Errorf("unable to retrieve the configured credential helpers: %w", err)
+		}
+		credentialStore, err := authn.NewStore(config.RegistryCredentialConfPath(), helpers, credentials.StoreOptions{
+			AllowPlaintextPut: true,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to create new store: %w", err)
+		}
+
+		// create client that
+		// 1. auto logins into registries
+		// 2. checks basic auth credential store
+		// 3. checks oauth2 clientcredentials
+		// 4. checks gcp credentials if enabled
+		// 5. checks the additional sources passed as options, e.g. the docker credentials
+		ops = append(ops,
+			authn.WithAutoLogin(authn.NewAutoLoginHandler(credentialStore)),
+			authn.WithStore(credentialStore),
+			authn.WithOAuthCredentials(),
+			authn.WithGcpCredentials(),
+		)
 	}
 	if enableClientTokenCache {
 		ops = append(ops, authn.WithClientTokenCache(auth.NewCache()))
 	}
+	ops = append(ops, opts...)
 	client := authn.NewClient(ops...)
 
 	return client, nil
 }
 
+const (
+	// DefaultConnectionRetries is the default number of times a failed connection check is retried.
+	DefaultConnectionRetries = 3
+	// DefaultConnectionBackoff is the default initial wait time between two connection checks, doubled at each retry.
+	DefaultConnectionBackoff = 250 * time.Millisecond
+)
+
+// ConnectionError is the error returned by CheckConnectionForRegistry when the registry cannot be used.
+type ConnectionError struct {
+	// Registry is the registry whose connection has been checked.
+	Registry string
+	// Auth is set when the registry rejected the credentials, in which case the check is not retried.
+	Auth bool
+	// Attempts is the number of times the connection has been checked.
+	Attempts int
+	// Err is the error of the last check.
+	Err error
+}
+
+func (e *ConnectionError) Error() string {
+	if e.Auth {
+		return fmt.Sprintf("unable to authenticate to remote registry %q: %v", e.Registry, e.Err)
+	}
+	return fmt.Sprintf("unable to connect to remote registry %q: %v", e.Registry, e.Err)
+}
+
+func (e *ConnectionError) Unwrap() error {
+	return e.Err
+}
+
+// ConnectionOption is an option of CheckConnectionForRegistry.
+type ConnectionOption func(*connectionOptions)
+
+type connectionOptions struct {
+	maxRetries int
+	backoff    time.Duration
+}
+
+// WithConnectionRetry sets how many times a connection check failing because of the network, or of a registry
+// temporarily unavailable, is retried, and the initial wait time between two checks. Zero retries disable them.
+func WithConnectionRetry(maxRetries int, backoff time.Duration) ConnectionOption {
+	return func(o *connectionOptions) {
+		o.maxRetries, o.backoff = maxRetries, backoff
+	}
+}
+
 // CheckConnectionForRegistry validates the connection to an oci registry.
-func CheckConnectionForRegistry(ctx context.Context, client remote.Client, plainHTTP bool, reg string) error {
+// Anonymous clients never provide credentials, hence for them it only checks that the registry
+// implements the Docker Registry HTTP API V2, without authenticating.
+// Checks failing because of the network, e.g. at boot, are retried with exponential backoff, see
+// WithConnectionRetry, as long as the context is not done, while authentication failures are not. Failed checks
+// return a *ConnectionError.
+func CheckConnectionForRegistry(ctx context.Context, client remote.Client, plainHTTP bool, reg string, opts ...ConnectionOption) error {
+	o := connectionOptions{maxRetries: DefaultConnectionRetries, backoff: DefaultConnectionBackoff}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	r, err := registry.NewRegistry(reg, registry.WithClient(client), registry.WithPlainHTTP(plainHTTP))
 	if err != nil {
 		return fmt.Errorf("unable to create registry: %w", err)
 	}
 
-	if err := r.CheckConnection(ctx); err != nil {
-		return fmt.Errorf("unable to connect to remote registry %q: %w", reg, err)
+	backoff := o.backoff
+	for attempt := 1; ; attempt++ {
+		err := r.CheckConnection(ctx)
+		if err == nil {
+			return nil
+		}
+
+		connErr := &ConnectionError{Registry: reg, Auth: isAuthError(err), Attempts: attempt, Err: err}
+		if connErr.Auth || attempt > o.maxRetries || !isTransientError(err) {
+			return connErr
+		}
+
+		t := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return connErr
+		case <-t.C:
+		}
+		backoff *= 2
 	}
+}
+
+// isAuthError tells whether the registry rejected the request because of the credentials.
+func isAuthError(err error) bool {
+	var errResp *errcode.ErrorResponse
+	return errors.As(err, &errResp) &&
+		(errResp.StatusCode == http.StatusUnauthorized || errResp.StatusCode == http.StatusForbidden)
+}
 
-	return nil
+// isTransientError tells whether the connection check failed because of the network or of a registry temporarily
+// unavailable, hence it is worth retrying.
+func isTransientError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var errResp *errcode.ErrorResponse
+	if errors.As(err, &errResp) {
+		return errResp.StatusCode == http.StatusTooManyRequests || errResp.StatusCode >= http.StatusInternalServerError
+	}
+
+	var opErr *net.OpError
+	var dnsErr *net.DNSError
+	if errors.As(err, &opErr) || errors.As(err, &dnsErr) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
 }
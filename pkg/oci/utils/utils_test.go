@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// registryServer starts a registry answering the V2 endpoint with the given status codes, one for each request,
+// repeating the last one, and returns its host and the number of requests received.
+func registryServer(t *testing.T, statuses ...int) (string, *atomic.Int32) {
+	t.Helper()
+	var requests atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(requests.Add(1))
+		w.Header().Set("Docker-Distribution-API-Version", "registry/2.0")
+		w.WriteHeader(statuses[min(n, len(statuses))-1])
+	}))
+	t.Cleanup(ts.Close)
+	return strings.TrimPrefix(ts.URL, "http://"), &requests
+}
+
+// anonymousClient returns a client without credentials for the given registry.
+func anonymousClient(host string) *auth.Client {
+	return &auth.Client{Credential: auth.StaticCredential(host, auth.EmptyCredential)}
+}
+
+func TestCheckConnectionForRegistryRetry(t *testing.T) {
+	host, requests := registryServer(t, http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusOK)
+
+	err := CheckConnectionForRegistry(context.Background(), anonymousClient(host), true, host, WithConnectionRetry(3, time.Millisecond))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := requests.Load(); n != 3 {
+		t.Errorf("expected 3 checks, got %d", n)
+	}
+}
+
+func TestCheckConnectionForRegistryRetriesExhausted(t *testing.T) {
+	host, requests := registryServer(t, http.StatusServiceUnavailable)
+
+	err := CheckConnectionForRegistry(context.Background(), anonymousClient(host), true, host, WithConnectionRetry(2, time.Millisecond))
+	var connErr *ConnectionError
+	if !errors.As(err, &connErr) || connErr.Auth || connErr.Attempts != 3 {
+		t.Fatalf("expected a connection error after 3 attempts, got %#v", err)
+	}
+	if n := requests.Load(); n != 3 {
+		t.Errorf("expected 3 checks, got %d", n)
+	}
+}
+
+func TestCheckConnectionForRegistryAuth(t *testing.T) {
+	host, requests := registryServer(t, http.StatusUnauthorized)
+	client := &auth.Client{Credential: auth.StaticCredential(host, auth.Credential{Username: "user", Password: "wrong"})}
+
+	err := CheckConnectionForRegistry(context.Background(), client, true, host, WithConnectionRetry(3, time.Millisecond))
+	var connErr *ConnectionError
+	if !errors.As(err, &connErr) || !connErr.Auth {
+		t.Fatalf("expected an authentication error, got %#v", err)
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("expected authentication failures not to be retried, got %d checks", n)
+	}
+}
+
+func TestCheckConnectionForRegistryCanceled(t *testing.T) {
+	host, _ := registryServer(t, http.StatusServiceUnavailable)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := CheckConnectionForRegistry(ctx, anonymousClient(host), true, host, WithConnectionRetry(10, time.Second))
+	var connErr *ConnectionError
+	if !errors.As(err, &connErr) {
+		t.Fatalf("expected a connection error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected the retries to stop with the context, took %s", elapsed)
+	}
+}
@@ -13,15 +13,6 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package install
-
-const (
-	// FlagAllowedTypes is the name of the flag to specify allowed artifact types.
-	FlagAllowedTypes = "allowed-types"
-
-	// FlagResolveDeps is the name of the flag to enable artifact dependencies resolution.
-	FlagResolveDeps = "resolve-deps"
-
-	// FlagNoVerify is the name of the flag to disable signature verification.
-	FlagNoVerify = "no-verify"
-)
+// Package verify checks the cosign signatures attached to OCI artifacts, either against a public key, given as a
+// PEM file, a KMS URI or the name of a trusted key, or keyless through the Fulcio certificates.
+package verify
@@ -13,19 +13,23 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package signature
+package verify
 
 import (
 	"context"
 
 	"github.com/sigstore/cosign/v2/cmd/cosign/cli/options"
 
+	"github.com/falcosecurity/falcoctl/internal/config"
 	"github.com/falcosecurity/falcoctl/internal/cosign"
+	"github.com/falcosecurity/falcoctl/internal/keys"
 	"github.com/falcosecurity/falcoctl/pkg/index/index"
 )
 
-// Verify checks that a fully qualified reference is signed according to the parameters.
-func Verify(ctx context.Context, ref string, signature *index.Signature) error {
+// Signature checks that a fully qualified reference is signed according to the parameters. The key can be given by the
+// name of a trusted key, see keys.Resolve. The signed payload must refer to the digest of the reference, so that the
+// signature of another artifact cannot be attached to it.
+func Signature(ctx context.Context, ref string, signature *index.Signature) error {
 	if signature == nil {
 		// nothing to do
 		return nil
@@ -43,8 +47,11 @@ func Verify(ctx context.Context, ref string, signature *index.Signature) error {
 			CertOidcIssuer:       signature.Cosign.CertificateOidcIssuer,
 			CertOidcIssuerRegexp: signature.Cosign.CertificateOidcIssuerRegexp,
 		},
-		KeyRef:     signature.Cosign.KeyRef,
-		IgnoreTlog: signature.Cosign.IgnoreTlog,
+		KeyRef:      keys.Resolve(config.KeysDir, signature.Cosign.KeyRef),
+		IgnoreTlog:  signature.Cosign.IgnoreTlog,
+		CheckClaims: true,
+		// The certificate transparency logs only apply to the certificates of the keyless signatures.
+		IgnoreSCT: signature.Cosign.KeyRef != "",
 	}
 	return v.DoVerify(ctx, []string{ref})
 }
@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/distribution/distribution/v3/configuration"
+	_ "github.com/distribution/distribution/v3/registry/storage/driver/inmemory"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"oras.land/oras-go/v2/registry/remote/auth"
+
+	"github.com/falcosecurity/falcoctl/pkg/oci"
+	"github.com/falcosecurity/falcoctl/pkg/oci/authn"
+	ocipusher "github.com/falcosecurity/falcoctl/pkg/oci/pusher"
+	testutils "github.com/falcosecurity/falcoctl/pkg/test"
+)
+
+var (
+	localRegistryHost string
+	testRuleTarball   = "../../test/data/rules.tar.gz"
+	ctx               = context.Background()
+	keysDir           string
+	signingKey        *ecdsa.PrivateKey
+	publicKeyFile     string
+	otherKey          *ecdsa.PrivateKey
+	otherKeyFile      string
+)
+
+func TestVerify(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Verify Suite")
+}
+
+var _ = BeforeSuite(func() {
+	var err error
+	config := &configuration.Configuration{}
+	// Get a free port to be used by the registry.
+	port, err := testutils.FreePort()
+	Expect(err).ToNot(HaveOccurred())
+	// Create the registry address to which will bind.
+	config.HTTP.Addr = fmt.Sprintf("localhost:%d", port)
+	localRegistryHost = config.HTTP.Addr
+
+	// Start the local registry.
+	go func() {
+		err := testutils.StartRegistry(context.Background(), config)
+		Expect(err).ToNot(BeNil())
+	}()
+
+	// Check that the registry is up and accepting connections.
+	Eventually(func(g Gomega) error {
+		res, err := http.Get(fmt.Sprintf("http://%s", config.HTTP.Addr))
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(res.StatusCode).Should(Equal(http.StatusOK))
+		return err
+	}).WithTimeout(time.Second * 5).ShouldNot(HaveOccurred())
+
+	// Generate the key pairs used to sign the artifacts, and write their public keys as PEM files.
+	keysDir, err = os.MkdirTemp("", "falcoctl-verify-tests-")
+	Expect(err).ShouldNot(HaveOccurred())
+	signingKey, publicKeyFile, err = testutils.GenerateCosignKey(keysDir, "cosign.pub")
+	Expect(err).ShouldNot(HaveOccurred())
+	otherKey, otherKeyFile, err = testutils.GenerateCosignKey(keysDir, "other.pub")
+	Expect(err).ShouldNot(HaveOccurred())
+})
+
+var _ = AfterSuite(func() {
+	Expect(os.RemoveAll(keysDir)).Should(Succeed())
+})
+
+// pushArtifact pushes a rulesfile artifact to the repository of the local registry with the given name, and returns
+// its reference pinned to its digest.
+func pushArtifact(name string) (string, error) {
+	pusher := ocipusher.NewPusher(authn.NewClient(authn.WithCredentials(&auth.EmptyCredential)), true, nil)
+	res, err := pusher.Push(ctx, oci.Rulesfile, localRegistryHost+"/"+name+":latest", ocipusher.WithFilepaths([]string{testRuleTarball}))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/%s@%s", localRegistryHost, name, res.RootDigest), nil
+}
@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/falcosecurity/falcoctl/pkg/index/index"
+	"github.com/falcosecurity/falcoctl/pkg/oci/verify"
+	testutils "github.com/falcosecurity/falcoctl/pkg/test"
+)
+
+var _ = Describe("Signature", func() {
+	var (
+		ref string
+		sig *index.Signature
+		err error
+	)
+
+	BeforeEach(func() {
+		sig = &index.Signature{Cosign: &index.CosignSignature{KeyRef: publicKeyFile, IgnoreTlog: true}}
+	})
+
+	JustBeforeEach(func() {
+		err = verify.Signature(ctx, ref, sig)
+	})
+
+	When("the artifact is signed with the key", func() {
+		BeforeEach(func() {
+			ref, err = pushArtifact("signed")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(testutils.CosignSign(ctx, ref, "", signingKey)).To(Succeed())
+		})
+
+		It("should verify the signature", func() {
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	When("the artifact is signed with another key", func() {
+		BeforeEach(func() {
+			ref, err = pushArtifact("signed-other")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(testutils.CosignSign(ctx, ref, "", otherKey)).To(Succeed())
+		})
+
+		It("should reject the signature", func() {
+			Expect(err).To(MatchError(ContainSubstring("no matching signatures")))
+		})
+
+		It("should verify it against the other key", func() {
+			Expect(verify.Signature(ctx, ref,
+				&index.Signature{Cosign: &index.CosignSignature{KeyRef: otherKeyFile, IgnoreTlog: true}})).To(Succeed())
+		})
+	})
+
+	When("the signed payload claims another digest", func() {
+		BeforeEach(func() {
+			ref, err = pushArtifact("signed-copied")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(testutils.CosignSign(ctx, ref, "sha256:0000000000000000000000000000000000000000000000000000000000000000", signingKey)).To(Succeed())
+		})
+
+		It("should reject the signature", func() {
+			Expect(err).To(MatchError(ContainSubstring("no matching signatures")))
+		})
+	})
+
+	When("the artifact is not signed", func() {
+		BeforeEach(func() {
+			ref, err = pushArtifact("unsigned")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should fail as no signature matches", func() {
+			Expect(err).To(MatchError(ContainSubstring("no matching signatures")))
+		})
+	})
+
+	When("the key cannot be loaded", func() {
+		BeforeEach(func() {
+			ref, err = pushArtifact("signed-missing-key")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(testutils.CosignSign(ctx, ref, "", signingKey)).To(Succeed())
+			sig.Cosign.KeyRef = publicKeyFile + ".missing"
+		})
+
+		It("should fail loading the public key", func() {
+			Expect(err).To(MatchError(ContainSubstring("loading public key")))
+		})
+	})
+
+	When("no cosign signature data is given", func() {
+		BeforeEach(func() {
+			ref, err = pushArtifact("unverified")
+			Expect(err).ToNot(HaveOccurred())
+			sig = &index.Signature{}
+		})
+
+		It("should not verify anything", func() {
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+})
@@ -20,6 +20,7 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/blang/semver/v4"
 	"github.com/spf13/cobra"
 
 	"github.com/falcosecurity/falcoctl/pkg/oci"
@@ -36,6 +37,7 @@ type Artifact struct {
 	Requirements     []string
 	Tags             []string
 	AnnotationSource string
+	Annotations      []string
 }
 
 var platformRgx = regexp.MustCompile(`^[a-z]+/[a-z0-9_]+$`)
@@ -50,9 +52,108 @@ func (art *Artifact) Validate() error {
 	}
 	// TODO: cannot check that len(platforms) matches len(filepaths) here
 
+	if _, err := art.AnnotationsMap(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// AnnotationsMap returns the annotations given in the "key=value" format, keyed by annotation key, together with
+// the ones declaring the Falco versions supported by the artifact, as given by the "falco" requirements.
+func (art *Artifact) AnnotationsMap() (map[string]string, error) {
+	falcoVersions, err := art.falcoVersionAnnotations()
+	if err != nil {
+		return nil, err
+	}
+	if len(art.Annotations) == 0 && len(falcoVersions) == 0 {
+		return nil, nil
+	}
+
+	annotations := make(map[string]string, len(art.Annotations)+len(falcoVersions))
+	for k, v := range falcoVersions {
+		annotations[k] = v
+	}
+	for _, a := range art.Annotations {
+		key, value, ok := strings.Cut(a, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("annotation %q seems to be in the wrong format: needs to be in key=value format", a)
+		}
+		if v, ok := falcoVersions[key]; ok && v != value {
+			return nil, fmt.Errorf("annotation %q conflicts with the %q requirement, setting it to %q", a, falcoRequirementName, v)
+		}
+		annotations[key] = value
+	}
+
+	return annotations, nil
+}
+
+// falcoRequirementName is the name of the requirement declaring the Falco versions supported by the artifact, e.g.
+// "falco>=0.36.0". Unlike the other requirements, it is not stored in the config layer but in the manifest annotations
+// checked by the install command.
+const falcoRequirementName = "falco"
+
+// isFalcoRequirement reports whether the requirement is a Falco version constraint, such as "falco>=0.36.0".
+func isFalcoRequirement(requirement string) bool {
+	constraint, ok := strings.CutPrefix(requirement, falcoRequirementName)
+	return ok && strings.IndexAny(strings.TrimSpace(constraint), "<>=") == 0
+}
+
+// ConfigRequirements returns the requirements to be stored in the config layer of the artifact, i.e. all of them
+// but the Falco version constraints.
+func (art *Artifact) ConfigRequirements() []string {
+	var requirements []string
+	for _, r := range art.Requirements {
+		if !isFalcoRequirement(r) {
+			requirements = append(requirements, r)
+		}
+	}
+	return requirements
+}
+
+// falcoVersionAnnotations maps the Falco version constraints given as requirements, e.g. "falco>=0.36.0,<=0.38.0",
+// to the inclusive minimum and maximum Falco version annotations.
+func (art *Artifact) falcoVersionAnnotations() (map[string]string, error) {
+	var annotations map[string]string
+	for _, r := range art.Requirements {
+		if !isFalcoRequirement(r) {
+			continue
+		}
+
+		for _, constraint := range strings.Split(strings.TrimPrefix(r, falcoRequirementName), ",") {
+			constraint = strings.TrimSpace(constraint)
+			version := strings.TrimSpace(strings.TrimLeft(constraint, "<>=!"))
+			operator := strings.TrimSuffix(constraint, version)
+			v, err := semver.ParseTolerant(version)
+			if err != nil {
+				return nil, fmt.Errorf("requirement %q seems to be in the wrong format: invalid Falco version %q: %w", r, version, err)
+			}
+
+			var keys []string
+			switch strings.TrimSpace(operator) {
+			case ">=":
+				keys = []string{oci.FalcoMinVersionAnnotation}
+			case "<=":
+				keys = []string{oci.FalcoMaxVersionAnnotation}
+			case "=", "==":
+				keys = []string{oci.FalcoMinVersionAnnotation, oci.FalcoMaxVersionAnnotation}
+			default:
+				return nil, fmt.Errorf("requirement %q seems to be in the wrong format: the Falco versions can only be "+
+					"constrained with the inclusive >=, <= and = operators", r)
+			}
+
+			if annotations == nil {
+				annotations = make(map[string]string, 2)
+			}
+			for _, key := range keys {
+				annotations[key] = v.String()
+			}
+		}
+	}
+
+	return annotations, nil
+}
+
 // AddFlags registers the artifacts flags.
 func (art *Artifact) AddFlags(cmd *cobra.Command) error {
 	cmd.Flags().StringArrayVar(&art.Platforms, "platform", nil,
@@ -72,7 +173,8 @@ func (art *Artifact) AddFlags(cmd *cobra.Command) error {
 		}
 
 		cmd.Flags().StringArrayVarP(&art.Requirements, "requires", "r", nil,
-			`set an artifact requirement (can be specified multiple times). Example: "--requires plugin_api_version:1.2.3"`)
+			`set an artifact requirement (can be specified multiple times). Example: "--requires plugin_api_version:1.2.3", `+
+				`or "--requires falco>=0.36.0" for the supported Falco versions`)
 
 		cmd.Flags().StringArrayVarP(&art.Dependencies, "depends-on", "d", nil,
 			`set an artifact dependency (can be specified multiple times). Example: "--depends-on my-plugin:1.2.3"`)
@@ -80,6 +182,9 @@ func (art *Artifact) AddFlags(cmd *cobra.Command) error {
 		cmd.Flags().StringVar(&art.AnnotationSource, "annotation-source", "",
 			`set annotation source for the artifact`)
 
+		cmd.Flags().StringArrayVar(&art.Annotations, "annotation", nil,
+			`set an annotation of the artifact manifest (can be specified multiple times). Example: "--annotation io.falcosecurity.falco.version.min=0.38.0"`)
+
 		cmd.Flags().StringVar(&art.Name, "name", "",
 			`set the unique name of the artifact (if not set, the name is extracted from the reference)`)
 
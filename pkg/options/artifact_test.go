@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/falcosecurity/falcoctl/pkg/oci"
+)
+
+var _ = Describe("Artifact", func() {
+	var art *Artifact
+
+	BeforeEach(func() {
+		art = &Artifact{}
+	})
+
+	Context("AnnotationsMap Func", func() {
+		It("should return no annotations when none is given", func() {
+			Expect(art.AnnotationsMap()).Should(BeNil())
+		})
+
+		It("should return the annotations given in key=value format", func() {
+			art.Annotations = []string{"stability=stable", "empty="}
+			Expect(art.AnnotationsMap()).Should(Equal(map[string]string{"stability": "stable", "empty": ""}))
+		})
+
+		It("should fail on annotations not in key=value format", func() {
+			art.Annotations = []string{"stability"}
+			_, err := art.AnnotationsMap()
+			Expect(err).Should(MatchError(ContainSubstring(`annotation "stability" seems to be in the wrong format`)))
+		})
+
+		It("should map the Falco version requirements to the min and max version annotations", func() {
+			art.Requirements = []string{"plugin_api_version:3.2.1", "falco>=0.36", "falco <= 0.38.1"}
+			art.Annotations = []string{"stability=stable"}
+			Expect(art.AnnotationsMap()).Should(Equal(map[string]string{
+				oci.FalcoMinVersionAnnotation: "0.36.0",
+				oci.FalcoMaxVersionAnnotation: "0.38.1",
+				"stability":                   "stable",
+			}))
+		})
+
+		It("should accept comma separated and exact Falco version requirements", func() {
+			art.Requirements = []string{"falco>=0.36.0,<=0.37.0"}
+			Expect(art.AnnotationsMap()).Should(Equal(map[string]string{
+				oci.FalcoMinVersionAnnotation: "0.36.0",
+				oci.FalcoMaxVersionAnnotation: "0.37.0",
+			}))
+
+			art.Requirements = []string{"falco==0.37.1"}
+			Expect(art.AnnotationsMap()).Should(Equal(map[string]string{
+				oci.FalcoMinVersionAnnotation: "0.37.1",
+				oci.FalcoMaxVersionAnnotation: "0.37.1",
+			}))
+		})
+
+		It("should fail on exclusive or invalid Falco version requirements", func() {
+			art.Requirements = []string{"falco>0.36.0"}
+			_, err := art.AnnotationsMap()
+			Expect(err).Should(MatchError(ContainSubstring("inclusive >=, <= and = operators")))
+
+			art.Requirements = []string{"falco>=latest"}
+			_, err = art.AnnotationsMap()
+			Expect(err).Should(MatchError(ContainSubstring(`invalid Falco version "latest"`)))
+		})
+
+		It("should fail on annotations conflicting with the Falco version requirements", func() {
+			art.Requirements = []string{"falco>=0.36.0"}
+			art.Annotations = []string{oci.FalcoMinVersionAnnotation + "=0.37.0"}
+			_, err := art.AnnotationsMap()
+			Expect(err).Should(MatchError(ContainSubstring(`conflicts with the "falco" requirement`)))
+
+			art.Annotations = []string{oci.FalcoMinVersionAnnotation + "=0.36.0"}
+			Expect(art.AnnotationsMap()).Should(HaveKeyWithValue(oci.FalcoMinVersionAnnotation, "0.36.0"))
+		})
+	})
+
+	Context("ConfigRequirements Func", func() {
+		It("should leave out the Falco version requirements", func() {
+			art.Requirements = []string{"plugin_api_version:3.2.1", "falco>=0.36.0", "falcoapi:1.0.0"}
+			Expect(art.ConfigRequirements()).Should(Equal([]string{"plugin_api_version:3.2.1", "falcoapi:1.0.0"}))
+		})
+	})
+})
@@ -17,6 +17,7 @@ package options
 
 import (
 	"io"
+	"os"
 
 	"github.com/pterm/pterm"
 	"github.com/spf13/pflag"
@@ -34,6 +35,8 @@ type Common struct {
 	Printer *output.Printer
 	// writer is used to write the output of the printer.
 	writer io.Writer
+	// outputWriter is used to write the results of the commands, when they are printed as JSON or YAML.
+	outputWriter io.Writer
 	// Used to store the verbose flag, and then passed to the printer.
 	// Deprecated: will be removed in the future
 	verbose bool
@@ -43,18 +46,29 @@ type Common struct {
 	// Config file. It must not be possible to be reinitialized by subcommands,
 	// using the Initialize function. It will be attached as global flags.
 	ConfigFile string
+	// FalcoctlDir is the directory where the indexes and the client credentials are stored, see config.SetFalcoctlPath.
+	FalcoctlDir string
+	// IndexesFile is the file listing the configured indexes, which defaults to the one in FalcoctlDir.
+	IndexesFile string
 	// IndexCache caches the entries for the configured indexes.
 	IndexCache *cache.Cache
 
-	logLevel  *LogLevel
-	logFormat *LogFormat
+	// noColor disables the colors and the styling, as output.NoColorEnv does, see noColorFlag.
+	noColor bool
+	// quiet silences the logs below the error level, spinners and progress bars, see quietFlag.
+	quiet bool
+
+	logLevel     *LogLevel
+	logFormat    *LogFormat
+	outputFormat *OutputFormat
 }
 
 // NewOptions returns a new Common struct.
 func NewOptions() *Common {
 	return &Common{
-		logLevel:  NewLogLevel(),
-		logFormat: NewLogFormat(),
+		logLevel:     NewLogLevel(),
+		logFormat:    NewLogFormat(),
+		outputFormat: NewOutputFormat(),
 	}
 }
 
@@ -68,6 +82,23 @@ func WithWriter(writer io.Writer) Configs {
 	}
 }
 
+// WithOutputWriter sets the writer for the results printed as JSON or YAML.
+func WithOutputWriter(writer io.Writer) Configs {
+	return func(options *Common) {
+		options.outputWriter = writer
+	}
+}
+
+// WithStructuredOutput makes the results be printed as JSON, unless JSON or YAML is already requested, moving the
+// messages for humans to stderr.
+func WithStructuredOutput() Configs {
+	return func(options *Common) {
+		if !options.outputFormat.IsStructured() {
+			_ = options.outputFormat.Set(OutputFormatJSON)
+		}
+	}
+}
+
 // WithIndexCache sets the index cache.
 func WithIndexCache(c *cache.Cache) Configs {
 	return func(options *Common) {
@@ -84,9 +115,12 @@ func (o *Common) Initialize(cfgs ...Configs) {
 
 	// TODO(alacuku): remove once we remove the old flags
 	var logLevel pterm.LogLevel
-	if o.verbose {
+	switch {
+	case o.quiet:
+		logLevel = pterm.LogLevelError
+	case o.verbose:
 		logLevel = pterm.LogLevelDebug
-	} else {
+	default:
 		logLevel = o.logLevel.ToPtermLogLevel()
 	}
 
@@ -97,8 +131,45 @@ func (o *Common) Initialize(cfgs ...Configs) {
 		logFormatter = o.logFormat.ToPtermFormatter()
 	}
 
+	// When printing the results as JSON or YAML, the messages for humans are moved to stderr so that stdout can be parsed.
+	writer := o.writer
+	if o.outputFormat.IsStructured() && (writer == nil || writer == os.Stdout) {
+		writer = os.Stderr
+	}
+
 	// create the printer. The value of verbose is a flag value.
-	o.Printer = output.NewPrinter(logLevel, logFormatter, o.writer)
+	o.Printer = output.NewPrinter(logLevel, logFormatter, writer)
+
+	if o.outputFormat.IsStructured() {
+		// Spinners and progress bars are meant for humans reading a terminal, messages are logged instead.
+		o.Printer.DisableStyling = true
+		o.Printer.JSONOutput = o.outputFormat.IsJSON()
+		o.Printer.YAMLOutput = o.outputFormat.IsYAML()
+		o.Printer.Output = os.Stdout
+		if o.outputWriter != nil {
+			o.Printer.Output = o.outputWriter
+		}
+	}
+
+	if o.noColor && !o.Printer.DisableStyling {
+		// Spinners and progress bars fall back to plain log lines, as when not attached to a tty.
+		pterm.DisableStyling()
+		o.Printer.DisableStyling = true
+	}
+
+	if o.quiet {
+		// Only the errors are logged, to stderr, while the results of the commands are still printed.
+		o.Printer.Quiet = true
+		o.Printer.DisableStyling = true
+		if writer == nil || writer == os.Stdout {
+			o.Printer.Logger = o.Printer.Logger.WithWriter(os.Stderr)
+		}
+	}
+}
+
+// AnnotationsOutput returns true when only the annotations of the artifacts must be printed, as key=value lines.
+func (o *Common) AnnotationsOutput() bool {
+	return o.outputFormat.IsAnnotations()
 }
 
 // AddFlags registers the common flags.
@@ -110,7 +181,17 @@ func (o *Common) AddFlags(flags *pflag.FlagSet) {
 		"Styling is automatically disabled if not attached to a tty (default false)")
 	// Mark the disableStyling as deprecated.
 	_ = flags.MarkDeprecated("disable-styling", "please use --log-format")
-	flags.StringVar(&o.ConfigFile, "config", config.ConfigPath, "config file to be used for falcoctl")
+	flags.StringVar(&o.ConfigFile, configFlag, config.ConfigPath, "config file to be used for falcoctl")
+	flags.StringVar(&o.FalcoctlDir, falcoctlDirFlag, "",
+		"directory where falcoctl stores the indexes and the client credentials (default \"$HOME/.config/falcoctl\")")
+	flags.StringVar(&o.IndexesFile, indexesFileFlag, "",
+		"file listing the configured indexes (default \"indexes.yaml\" in the falcoctl directory)")
 	flags.Var(o.logFormat, "log-format", "Set formatting for logs "+o.logFormat.Allowed())
 	flags.Var(o.logLevel, "log-level", "Set level for logs "+o.logLevel.Allowed())
+	flags.BoolVar(&o.noColor, noColorFlag, false, "Disable colors, spinners and progress bars, as the "+output.NoColorEnv+
+		" environment variable does. They are disabled as well when not attached to a tty")
+	flags.BoolVarP(&o.quiet, quietFlag, "q", false, "Only print errors, to stderr, and the results of the commands, "+
+		"silencing the other logs, spinners and progress bars")
+	flags.Var(o.outputFormat, "output", "Set format for the results of the commands supporting it "+o.outputFormat.Allowed()+
+		", messages are written to stderr when set to json or yaml")
 }
@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/pterm/pterm"
+
+	"github.com/falcosecurity/falcoctl/pkg/output"
+)
+
+var _ = Describe("Common", func() {
+	var (
+		common *Common
+		buf    *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		common = NewOptions()
+		buf = &bytes.Buffer{}
+	})
+
+	AfterEach(func() {
+		// The printer disables the styling at pterm package level when not attached to a tty.
+		pterm.EnableStyling()
+	})
+
+	Context("quiet", func() {
+		BeforeEach(func() {
+			common.quiet = true
+			common.Initialize(WithWriter(buf))
+		})
+
+		It("should only log the errors", func() {
+			common.Printer.Logger.Info("info message")
+			common.Printer.Logger.Warn("warn message")
+			common.Printer.Logger.Error("error message")
+			Expect(buf.String()).ShouldNot(ContainSubstring("info message"))
+			Expect(buf.String()).ShouldNot(ContainSubstring("warn message"))
+			Expect(buf.String()).Should(ContainSubstring("error message"))
+		})
+
+		It("should disable spinners and progress bars", func() {
+			Expect(common.Printer.DisableStyling).Should(BeTrue())
+			Expect(common.Printer.Quiet).Should(BeTrue())
+		})
+
+		It("should still print the results of the commands", func() {
+			Expect(common.Printer.PrintTable(output.ArtifactSearch, [][]string{{"index", "artifact"}})).Should(Succeed())
+			Expect(buf.String()).Should(ContainSubstring("artifact"))
+		})
+	})
+
+	Context("no-color", func() {
+		BeforeEach(func() {
+			common.noColor = true
+			common.Initialize(WithWriter(buf))
+		})
+
+		It("should disable colors and spinners", func() {
+			Expect(common.Printer.DisableStyling).Should(BeTrue())
+			Expect(pterm.PrintColor).Should(BeFalse())
+			common.Printer.Logger.Info("info message")
+			Expect(buf.String()).ShouldNot(ContainSubstring("\x1b["))
+		})
+	})
+
+	Context("not quiet", func() {
+		BeforeEach(func() {
+			common.Initialize(WithWriter(buf))
+		})
+
+		It("should log the info messages", func() {
+			common.Printer.Logger.Info("info message")
+			Expect(buf.String()).Should(ContainSubstring("info message"))
+			Expect(common.Printer.Quiet).Should(BeFalse())
+		})
+	})
+})
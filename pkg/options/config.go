@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/falcosecurity/falcoctl/internal/config"
+	"github.com/falcosecurity/falcoctl/internal/utils"
+)
+
+const (
+	// configFlag is the name of the flag to specify the config file.
+	configFlag = "config"
+	// falcoctlDirFlag is the name of the flag to specify the directory where falcoctl stores its files.
+	falcoctlDirFlag = "falcoctl-dir"
+	// indexesFileFlag is the name of the flag to specify the file listing the configured indexes.
+	indexesFileFlag = "indexes-file"
+	// noColorFlag is the name of the flag to disable the colors and the spinners, as NO_COLOR does.
+	noColorFlag = "no-color"
+	// quietFlag is the name of the flag to only print the errors and the results of the commands.
+	quietFlag = "quiet"
+)
+
+// configKeys maps the flags shared by several commands to the config keys providing their default values.
+var configKeys = map[string]string{
+	"plain-http":               config.RegistryPlainHTTPKey,
+	"proxy":                    config.RegistryProxyKey,
+	"no-proxy":                 config.RegistryNoProxyKey,
+	"registry-timeout":         config.RegistryTimeoutKey,
+	"registry-connect-timeout": config.RegistryConnectTimeoutKey,
+	"ca-cert":                  config.RegistryCACertKey,
+	"insecure-skip-tls-verify": config.RegistryInsecureSkipTLSVerifyKey,
+	"anonymous":                config.RegistryAnonymousKey,
+	"log-level":                config.LogLevelKey,
+	"log-format":               config.LogFormatKey,
+}
+
+// LoadConfig sets the flags of the given command not set by the user from their environment variables, see
+// FlagEnvVar, then loads the config file, discovered through config.DiscoverConfigFile when --config is not given,
+// and sets the flags still not set from the config keys, either found in the environment or in the config file.
+// The files stored by falcoctl are relocated according to --falcoctl-dir and --indexes-file. "~" and the environment
+// variables are expanded in the paths given by those flags and by --config.
+// The options are initialized again, so that the configured log settings are honored.
+func (o *Common) LoadConfig(cmd *cobra.Command) error {
+	if err := OverrideFlagsFromEnv(cmd.Flags()); err != nil {
+		return err
+	}
+
+	if f := cmd.Flags().Lookup(configFlag); f == nil || !f.Changed {
+		o.ConfigFile = config.DiscoverConfigFile()
+	}
+
+	if err := utils.ExpandPaths(&o.ConfigFile, &o.FalcoctlDir, &o.IndexesFile); err != nil {
+		return err
+	}
+
+	if err := config.Load(o.ConfigFile); err != nil {
+		return err
+	}
+
+	if err := OverrideFlags(cmd.Flags(), configKeys); err != nil {
+		return err
+	}
+
+	// Always set, so that the defaults are restored when the flags are not given.
+	config.SetFalcoctlPath(o.FalcoctlDir, o.IndexesFile)
+
+	o.Initialize()
+	return nil
+}
+
+// OverrideFlags sets the given flags, keyed by name, to the value of the corresponding config keys when they are
+// not set by the user. Flags not registered in the flag set are ignored.
+func OverrideFlags(flags *pflag.FlagSet, keys map[string]string) error {
+	for name, key := range keys {
+		f := flags.Lookup(name)
+		if f == nil || f.Changed || !viper.IsSet(key) {
+			continue
+		}
+
+		var err error
+		if slice, ok := f.Value.(pflag.SliceValue); ok {
+			err = slice.Replace(viper.GetStringSlice(key))
+		} else {
+			err = flags.Set(name, fmt.Sprintf("%v", viper.Get(key)))
+		}
+		if err != nil {
+			return fmt.Errorf("unable to overwrite %q flag: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// FlagEnvVar returns the name of the environment variable setting the flag with the given name, e.g.
+// FALCOCTL_PLUGINS_DIR for --plugins-dir.
+func FlagEnvVar(name string) string {
+	return config.EnvPrefix + "_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+}
+
+// OverrideFlagsFromEnv sets the flags not set by the user to the value of their environment variable, if not empty.
+func OverrideFlagsFromEnv(flags *pflag.FlagSet) error {
+	var err error
+	flags.VisitAll(func(f *pflag.Flag) {
+		if err != nil || f.Changed || f.Name == "help" {
+			return
+		}
+
+		env := FlagEnvVar(f.Name)
+		value := os.Getenv(env)
+		if value == "" {
+			return
+		}
+		if setErr := flags.Set(f.Name, value); setErr != nil {
+			err = fmt.Errorf("unable to set %q flag from %s environment variable: %w", f.Name, env, setErr)
+		}
+	})
+
+	return err
+}
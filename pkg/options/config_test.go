@@ -0,0 +1,212 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/falcosecurity/falcoctl/internal/config"
+)
+
+var _ = Describe("LoadConfig", func() {
+	var (
+		common     *Common
+		registry   *Registry
+		cmd        *cobra.Command
+		configFile string
+		args       []string
+		err        error
+	)
+
+	const configContent = `registry:
+  plainHTTP: true
+  timeout: 5s
+log:
+  level: debug
+`
+
+	BeforeEach(func() {
+		common = NewOptions()
+		registry = &Registry{}
+		cmd = &cobra.Command{Use: "test"}
+		common.AddFlags(cmd.Flags())
+		registry.AddFlags(cmd)
+		configFile = filepath.Join(GinkgoT().TempDir(), "falcoctl.yaml")
+		Expect(os.WriteFile(configFile, []byte(configContent), 0o600)).To(Succeed())
+		args = []string{"--config", configFile}
+	})
+
+	JustBeforeEach(func() {
+		Expect(cmd.ParseFlags(args)).To(Succeed())
+		err = common.LoadConfig(cmd)
+	})
+
+	AfterEach(func() {
+		viper.Reset()
+		pterm.EnableStyling()
+		config.SetFalcoctlPath("", "")
+	})
+
+	When("the flags are not set", func() {
+		It("should take their values from the config file", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(registry.PlainHTTP).To(BeTrue())
+			Expect(registry.Timeout).To(Equal(5 * time.Second))
+			Expect(common.logLevel.String()).To(Equal(LogLevelDebug))
+		})
+	})
+
+	When("the flags are set", func() {
+		BeforeEach(func() {
+			args = append(args, "--plain-http=false", "--registry-timeout", "10s")
+		})
+
+		It("should keep the values of the flags", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(registry.PlainHTTP).To(BeFalse())
+			Expect(registry.Timeout).To(Equal(10 * time.Second))
+		})
+	})
+
+	When("the environment variables are set", func() {
+		BeforeEach(func() {
+			Expect(os.Setenv("FALCOCTL_REGISTRY_TIMEOUT", "7s")).To(Succeed())
+			DeferCleanup(os.Unsetenv, "FALCOCTL_REGISTRY_TIMEOUT")
+		})
+
+		It("should prefer them to the config file", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(registry.Timeout).To(Equal(7 * time.Second))
+			Expect(registry.PlainHTTP).To(BeTrue())
+		})
+	})
+
+	When("the environment variables of the flags are set", func() {
+		BeforeEach(func() {
+			Expect(os.Setenv("FALCOCTL_PLAIN_HTTP", "false")).To(Succeed())
+			DeferCleanup(os.Unsetenv, "FALCOCTL_PLAIN_HTTP")
+			Expect(os.Setenv("FALCOCTL_REGISTRY_TIMEOUT", "3s")).To(Succeed())
+			DeferCleanup(os.Unsetenv, "FALCOCTL_REGISTRY_TIMEOUT")
+			Expect(os.Setenv("FALCOCTL_REGISTRY_CONNECT_TIMEOUT", "4s")).To(Succeed())
+			DeferCleanup(os.Unsetenv, "FALCOCTL_REGISTRY_CONNECT_TIMEOUT")
+			args = append(args, "--registry-timeout", "10s")
+		})
+
+		It("should prefer them to the config file, but not to the flags", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(registry.PlainHTTP).To(BeFalse())
+			Expect(registry.ConnectTimeout).To(Equal(4 * time.Second))
+			Expect(registry.Timeout).To(Equal(10 * time.Second))
+		})
+	})
+
+	When("the environment variable of a flag is not valid", func() {
+		BeforeEach(func() {
+			Expect(os.Setenv("FALCOCTL_PLAIN_HTTP", "maybe")).To(Succeed())
+			DeferCleanup(os.Unsetenv, "FALCOCTL_PLAIN_HTTP")
+		})
+
+		It("should error", func() {
+			Expect(err).To(MatchError(ContainSubstring(`unable to set "plain-http" flag from FALCOCTL_PLAIN_HTTP environment variable`)))
+		})
+	})
+
+	When("the config file is given through its environment variable", func() {
+		BeforeEach(func() {
+			Expect(os.Setenv("FALCOCTL_CONFIG", configFile)).To(Succeed())
+			DeferCleanup(os.Unsetenv, "FALCOCTL_CONFIG")
+			args = nil
+		})
+
+		It("should use it", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(common.ConfigFile).To(Equal(configFile))
+			Expect(registry.PlainHTTP).To(BeTrue())
+		})
+	})
+
+	When("the config file is not given", func() {
+		BeforeEach(func() {
+			configHome := GinkgoT().TempDir()
+			configFile = filepath.Join(configHome, "falcoctl", "falcoctl.yaml")
+			Expect(os.MkdirAll(filepath.Dir(configFile), 0o700)).To(Succeed())
+			Expect(os.WriteFile(configFile, []byte(configContent), 0o600)).To(Succeed())
+			Expect(os.Setenv("XDG_CONFIG_HOME", configHome)).To(Succeed())
+			DeferCleanup(os.Unsetenv, "XDG_CONFIG_HOME")
+			args = nil
+		})
+
+		It("should use the one found in the user config directory", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(common.ConfigFile).To(Equal(configFile))
+			Expect(registry.PlainHTTP).To(BeTrue())
+		})
+	})
+
+	When("the falcoctl directory is given", func() {
+		var falcoctlDir string
+
+		BeforeEach(func() {
+			falcoctlDir = GinkgoT().TempDir()
+			args = append(args, "--falcoctl-dir", falcoctlDir)
+		})
+
+		It("should relocate the indexes, the client credentials and the trusted keys", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(config.FalcoctlPath).To(Equal(falcoctlDir))
+			Expect(config.IndexesFile).To(Equal(filepath.Join(falcoctlDir, "indexes.yaml")))
+			Expect(config.IndexesDir).To(Equal(filepath.Join(falcoctlDir, "indexes")))
+			Expect(config.ClientCredentialsFile).To(Equal(filepath.Join(falcoctlDir, "clientcredentials.json")))
+			Expect(config.KeysDir).To(Equal(filepath.Join(falcoctlDir, "keys")))
+			Expect(config.InstallLockFile).To(Equal(filepath.Join(falcoctlDir, "install.lock")))
+		})
+
+		When("the indexes file is given as well", func() {
+			var indexesFile string
+
+			BeforeEach(func() {
+				indexesFile = filepath.Join(GinkgoT().TempDir(), "tenant-indexes.yaml")
+				args = append(args, "--indexes-file", indexesFile)
+			})
+
+			It("should use it, keeping the indexes in the falcoctl directory", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(config.IndexesFile).To(Equal(indexesFile))
+				Expect(config.IndexesDir).To(Equal(filepath.Join(falcoctlDir, "indexes")))
+			})
+		})
+	})
+
+	When("the falcoctl directory is not given", func() {
+		BeforeEach(func() {
+			config.SetFalcoctlPath(GinkgoT().TempDir(), "")
+		})
+
+		It("should restore the default one", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(config.FalcoctlPath).To(Equal(filepath.Join(config.ConfigDir, "falcoctl")))
+			Expect(config.IndexesFile).To(Equal(filepath.Join(config.ConfigDir, "falcoctl", "indexes.yaml")))
+		})
+	})
+})
@@ -16,9 +16,13 @@
 package options
 
 import (
+	"fmt"
+
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
 	"github.com/falcosecurity/falcoctl/internal/config"
+	"github.com/falcosecurity/falcoctl/internal/utils"
 )
 
 const (
@@ -51,3 +55,32 @@ func (o *Directory) AddFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVarP(&o.AssetsDir, FlagAssetsFilesDir, "", config.AssetsDir,
 		"Directory where to install assets")
 }
+
+// OverrideFromConfig sets the directories flags not given by the user to the values of the given viper keys,
+// when set in the config. It must be called before Expand.
+func (o *Directory) OverrideFromConfig(cmd *cobra.Command, rulesfilesDirKey, pluginsDirKey, assetsDirKey string) error {
+	for _, override := range []struct{ flag, key string }{
+		{FlagRulesFilesDir, rulesfilesDirKey},
+		{FlagPluginsFilesDir, pluginsDirKey},
+		{FlagAssetsFilesDir, assetsDirKey},
+	} {
+		f := cmd.Flags().Lookup(override.flag)
+		if f == nil {
+			// should never happen
+			return fmt.Errorf("unable to retrieve flag %q", override.flag)
+		} else if f.Changed || !viper.IsSet(override.key) {
+			continue
+		}
+		if err := cmd.Flags().Set(f.Name, fmt.Sprintf("%v", viper.Get(override.key))); err != nil {
+			return fmt.Errorf("unable to overwrite %q flag: %w", override.flag, err)
+		}
+	}
+
+	return nil
+}
+
+// Expand expands "~" and the environment variables in the directories, see utils.ExpandPath. It must be called once
+// the directories have been overridden from the config.
+func (o *Directory) Expand() error {
+	return utils.ExpandPaths(&o.RulesfilesDir, &o.PluginsDir, &o.AssetsDir)
+}
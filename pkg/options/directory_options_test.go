@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Directory", func() {
+	Context("Expand Func", func() {
+		var home string
+
+		BeforeEach(func() {
+			home = GinkgoT().TempDir()
+			if prev, ok := os.LookupEnv("HOME"); ok {
+				DeferCleanup(os.Setenv, "HOME", prev)
+			} else {
+				DeferCleanup(os.Unsetenv, "HOME")
+			}
+			Expect(os.Setenv("HOME", home)).To(Succeed())
+			Expect(os.Setenv("FALCOCTL_TEST_ASSETS", "/opt/assets")).To(Succeed())
+			DeferCleanup(os.Unsetenv, "FALCOCTL_TEST_ASSETS")
+		})
+
+		It("Should expand the tilde and the environment variables of each directory", func() {
+			dirs := Directory{
+				RulesfilesDir: "~/falco/rules",
+				PluginsDir:    "$HOME/falco/plugins",
+				AssetsDir:     "${FALCOCTL_TEST_ASSETS}",
+			}
+			Expect(dirs.Expand()).To(Succeed())
+			Expect(dirs.RulesfilesDir).To(Equal(filepath.Join(home, "falco", "rules")))
+			Expect(dirs.PluginsDir).To(Equal(filepath.Join(home, "falco", "plugins")))
+			Expect(dirs.AssetsDir).To(Equal("/opt/assets"))
+		})
+
+		It("Should leave absolute paths untouched", func() {
+			dirs := Directory{RulesfilesDir: "/etc/falco", PluginsDir: "/usr/share/falco/plugins"}
+			Expect(dirs.Expand()).To(Succeed())
+			Expect(dirs.RulesfilesDir).To(Equal("/etc/falco"))
+			Expect(dirs.PluginsDir).To(Equal("/usr/share/falco/plugins"))
+			Expect(dirs.AssetsDir).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+const (
+	// OutputFormatText formatting option for the results of the commands, meant to be read by humans.
+	OutputFormatText = "text"
+	// OutputFormatJSON formatting option for the results of the commands, meant to be read by programs.
+	OutputFormatJSON = "json"
+	// OutputFormatYAML formatting option for the results of the commands, meant to be read by programs.
+	OutputFormatYAML = "yaml"
+	// OutputFormatAnnotations formatting option printing the annotations of the artifacts as key=value lines, meant
+	// to be read by scripts. It is supported by the artifact info command only, the others print text.
+	OutputFormatAnnotations = "annotations"
+)
+
+var outputFormats = []string{OutputFormatText, OutputFormatJSON, OutputFormatYAML, OutputFormatAnnotations}
+
+// OutputFormat data structure for output flag.
+type OutputFormat struct {
+	*Enum
+}
+
+// NewOutputFormat returns a new Enum configured for the output formats flag.
+func NewOutputFormat() *OutputFormat {
+	return &OutputFormat{
+		Enum: NewEnum(outputFormats, OutputFormatText),
+	}
+}
+
+// IsJSON returns true when the results of the commands must be printed as JSON.
+func (of *OutputFormat) IsJSON() bool {
+	return of.value == OutputFormatJSON
+}
+
+// IsYAML returns true when the results of the commands must be printed as YAML.
+func (of *OutputFormat) IsYAML() bool {
+	return of.value == OutputFormatYAML
+}
+
+// IsAnnotations returns true when only the annotations of the artifacts must be printed, as key=value lines.
+func (of *OutputFormat) IsAnnotations() bool {
+	return of.value == OutputFormatAnnotations
+}
+
+// IsStructured returns true when the results of the commands must be printed in a format meant for programs.
+func (of *OutputFormat) IsStructured() bool {
+	return of.IsJSON() || of.IsYAML()
+}
@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"bytes"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/pterm/pterm"
+)
+
+var _ = Describe("OutputFormat", func() {
+	var outputFormat *OutputFormat
+
+	BeforeEach(func() {
+		outputFormat = NewOutputFormat()
+	})
+
+	Context("NewOutputFormat Func", func() {
+		It("should return a new outputFormat defaulting to text", func() {
+			Expect(outputFormat).ShouldNot(BeNil())
+			Expect(outputFormat.value).Should(Equal(OutputFormatText))
+			Expect(outputFormat.allowed).Should(Equal(outputFormats))
+			Expect(outputFormat.IsJSON()).Should(BeFalse())
+		})
+	})
+
+	Context("JSON", func() {
+		BeforeEach(func() {
+			Expect(outputFormat.Set(OutputFormatJSON)).ShouldNot(HaveOccurred())
+		})
+
+		It("should report the json format", func() {
+			Expect(outputFormat.IsJSON()).Should(BeTrue())
+			Expect(outputFormat.IsStructured()).Should(BeTrue())
+		})
+	})
+
+	Context("YAML", func() {
+		BeforeEach(func() {
+			Expect(outputFormat.Set(OutputFormatYAML)).ShouldNot(HaveOccurred())
+		})
+
+		It("should report the yaml format", func() {
+			Expect(outputFormat.IsYAML()).Should(BeTrue())
+			Expect(outputFormat.IsJSON()).Should(BeFalse())
+			Expect(outputFormat.IsStructured()).Should(BeTrue())
+		})
+	})
+
+	Context("Annotations", func() {
+		BeforeEach(func() {
+			Expect(outputFormat.Set(OutputFormatAnnotations)).ShouldNot(HaveOccurred())
+		})
+
+		It("should report the annotations format, which is not structured", func() {
+			Expect(outputFormat.IsAnnotations()).Should(BeTrue())
+			Expect(outputFormat.IsStructured()).Should(BeFalse())
+		})
+	})
+
+	Context("Initialize Func", func() {
+		var (
+			opts       *Common
+			jsonOutput *bytes.Buffer
+		)
+
+		BeforeEach(func() {
+			opts = NewOptions()
+			jsonOutput = &bytes.Buffer{}
+			Expect(opts.outputFormat.Set(OutputFormatJSON)).ShouldNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			// The printer globally disables styling when not attached to a tty.
+			pterm.EnableStyling()
+		})
+
+		It("should move the messages to stderr and the results to the output writer", func() {
+			opts.Initialize(WithWriter(os.Stdout), WithOutputWriter(jsonOutput))
+			Expect(opts.Printer.JSONOutput).Should(BeTrue())
+			Expect(opts.Printer.DisableStyling).Should(BeTrue())
+			Expect(opts.Printer.Output).Should(Equal(jsonOutput))
+			Expect(opts.Printer.Logger.Writer).Should(Equal(os.Stderr))
+		})
+
+		It("should print the results as yaml when requested", func() {
+			Expect(opts.outputFormat.Set(OutputFormatYAML)).ShouldNot(HaveOccurred())
+			opts.Initialize(WithWriter(os.Stdout), WithOutputWriter(jsonOutput))
+			Expect(opts.Printer.YAMLOutput).Should(BeTrue())
+			Expect(opts.Printer.JSONOutput).Should(BeFalse())
+			Expect(opts.Printer.Logger.Writer).Should(Equal(os.Stderr))
+		})
+
+		It("should keep the messages writer when it is not stdout", func() {
+			messages := &bytes.Buffer{}
+			opts.Initialize(WithWriter(messages))
+			Expect(opts.Printer.Output).Should(Equal(os.Stdout))
+			Expect(opts.Printer.Logger.Writer).Should(Equal(messages))
+		})
+	})
+})
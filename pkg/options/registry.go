@@ -15,14 +15,213 @@
 
 package options
 
-import "github.com/spf13/cobra"
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/falcosecurity/falcoctl/pkg/oci/authn"
+	"github.com/falcosecurity/falcoctl/pkg/output"
+)
+
+const (
+	// DefaultRegistryTimeout is the default maximum duration of a single registry operation.
+	DefaultRegistryTimeout = 60 * time.Second
+	// registryTokenFlag is the name of the flag to specify the bearer token sent to the registries.
+	registryTokenFlag = "registry-token"
+	// identityTokenFlag is the name of the flag to specify the identity token exchanged with the registries.
+	identityTokenFlag = "identity-token"
+	// tokenHostFlag is the name of the flag to specify the registries the tokens are sent to.
+	tokenHostFlag = "registry-token-host"
+)
 
 // Registry defines options that are common while interacting with a remote registry.
 type Registry struct {
 	PlainHTTP bool
+	Proxy     string
+	NoProxy   string
+	// Timeout bounds each registry operation, e.g. checking the connection or pulling an artifact.
+	Timeout time.Duration
+	// ConnectTimeout bounds the establishment of each connection to the registry.
+	ConnectTimeout time.Duration
+	// Config is the path of a Docker configuration file used as additional source of credentials.
+	Config string
+	// Anonymous disables all the credential sources.
+	Anonymous bool
+	// CACert is the path of a PEM encoded file with CA certificates trusted in addition to the system ones.
+	CACert string
+	// InsecureSkipTLSVerify disables the verification of the registries certificates.
+	InsecureSkipTLSVerify bool
+	// Mirrors are the registries tried, in order, when pulling from the registry of an artifact fails.
+	Mirrors []string
+	// Token is the bearer token sent to the registries. It is read from a file when given as "@<path>", or from the
+	// standard input when given as "-".
+	Token string
+	// IdentityToken is the identity token exchanged for bearer tokens with the registries, given as Token.
+	IdentityToken string
+	// TokenHosts are the registries, as host[:port], Token and IdentityToken are sent to. The other registries, e.g.
+	// mirrors, keep using the stored credentials.
+	TokenHosts []string
+	// Stdin is the reader the tokens given as "-" are read from. It defaults to the input of the command the flags
+	// are registered on.
+	Stdin io.Reader
+	// cmdStdin returns the input of the command the flags are registered on, if any.
+	cmdStdin func() io.Reader
+	// tokensRead is set once the tokens have been read, so that the standard input is read only once.
+	tokensRead bool
 }
 
 // AddFlags registers the registry flags.
 func (r *Registry) AddFlags(cmd *cobra.Command) {
+	// The input is only looked up when reading the tokens, once the command has been set up.
+	r.cmdStdin = cmd.InOrStdin
 	cmd.Flags().BoolVar(&r.PlainHTTP, "plain-http", false, "allows interacting with remote registry via plain http requests")
+	cmd.Flags().StringVar(&r.Proxy, "proxy", "",
+		"proxy URL used to connect to remote registries, overrides the HTTP_PROXY and HTTPS_PROXY environment variables")
+	cmd.Flags().StringVar(&r.NoProxy, "no-proxy", "",
+		"comma-separated list of hosts, domains and CIDRs reached without proxy, overrides the NO_PROXY environment variable")
+	cmd.Flags().DurationVar(&r.Timeout, "registry-timeout", DefaultRegistryTimeout,
+		"maximum duration of each registry operation, e.g. checking the connection or pulling an artifact (0 means no timeout)")
+	cmd.Flags().DurationVar(&r.ConnectTimeout, "registry-connect-timeout", authn.DefaultConnectTimeout,
+		"maximum duration to establish a connection to the registry (0 means no timeout)")
+	cmd.Flags().StringVar(&r.Config, "registry-config", "",
+		"Docker config file whose credentials and credential helpers are used when falcoctl has none for a registry, "+
+			"defaults to $DOCKER_CONFIG/config.json or $HOME/.docker/config.json")
+	cmd.Flags().BoolVar(&r.Anonymous, "anonymous", false,
+		"interact with remote registries anonymously, without looking up credentials in any credential store or helper")
+	cmd.Flags().StringVar(&r.CACert, "ca-cert", "",
+		"PEM encoded file with the CA certificates used, in addition to the system ones, to verify the remote registries")
+	cmd.Flags().BoolVar(&r.InsecureSkipTLSVerify, "insecure-skip-tls-verify", false,
+		"skip the verification of the remote registries certificates, making the connections insecure")
+	cmd.Flags().StringSliceVar(&r.Mirrors, "registry-mirror", nil,
+		"mirror registry tried, in the given order, when pulling from the registry of an artifact fails. It can be repeated multiple times")
+	cmd.Flags().StringVar(&r.Token, registryTokenFlag, "",
+		"bearer token sent to the registries instead of the stored credentials, read from a file if given as @<path> or from the standard input if \"-\"")
+	cmd.Flags().StringVar(&r.IdentityToken, identityTokenFlag, "",
+		"identity token exchanged for bearer tokens with the registries instead of the stored credentials, "+
+			"read from a file if given as @<path> or from the standard input if \"-\"")
+	cmd.Flags().StringSliceVar(&r.TokenHosts, tokenHostFlag, nil,
+		fmt.Sprintf("registry, as host[:port], the --%s or --%s is sent to, the other registries use the stored credentials. "+
+			"It can be repeated multiple times", registryTokenFlag, identityTokenFlag))
+}
+
+// readTokens replaces the tokens given as a file or through the standard input with their content.
+func (r *Registry) readTokens(stdin io.Reader) error {
+	if r.tokensRead {
+		return nil
+	}
+	if r.Token == "-" && r.IdentityToken == "-" {
+		return fmt.Errorf("only one of --%s and --%s can be read from the standard input", registryTokenFlag, identityTokenFlag)
+	}
+
+	var err error
+	if r.Token, err = readToken(r.Token, stdin); err != nil {
+		return fmt.Errorf("unable to read --%s: %w", registryTokenFlag, err)
+	}
+	if r.IdentityToken, err = readToken(r.IdentityToken, stdin); err != nil {
+		return fmt.Errorf("unable to read --%s: %w", identityTokenFlag, err)
+	}
+	r.tokensRead = true
+	return nil
+}
+
+// readToken returns the token given by value: the content of a file if value is "@<path>", the standard input if
+// value is "-", or value itself otherwise. Tokens read from a file or the standard input are trimmed.
+func readToken(value string, stdin io.Reader) (string, error) {
+	var (
+		content []byte
+		err     error
+	)
+	switch {
+	case value == "-":
+		content, err = io.ReadAll(stdin)
+	case strings.HasPrefix(value, "@"):
+		content, err = os.ReadFile(strings.TrimPrefix(value, "@"))
+	default:
+		return value, nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	token := strings.TrimSpace(string(content))
+	if token == "" {
+		return "", fmt.Errorf("empty token")
+	}
+	return token, nil
+}
+
+// ClientOptions returns the options to be used when creating the client that interacts with remote registries.
+// If a printer is given, it is used to warn when the verification of the registries certificates is disabled.
+func (r *Registry) ClientOptions(printer *output.Printer) ([]func(*authn.Options), error) {
+	stdin := r.Stdin
+	if stdin == nil && r.cmdStdin != nil {
+		stdin = r.cmdStdin()
+	}
+	if stdin == nil {
+		stdin = os.Stdin
+	}
+	if err := r.readTokens(stdin); err != nil {
+		return nil, err
+	}
+	if r.Anonymous && (r.Token != "" || r.IdentityToken != "") {
+		return nil, fmt.Errorf("--anonymous cannot be used together with --%s or --%s", registryTokenFlag, identityTokenFlag)
+	}
+	if (r.Token != "" || r.IdentityToken != "") && len(r.TokenHosts) == 0 {
+		return nil, fmt.Errorf("--%s is required to use --%s or --%s", tokenHostFlag, registryTokenFlag, identityTokenFlag)
+	}
+
+	opts := []func(*authn.Options){
+		authn.WithProxy(r.Proxy, r.NoProxy),
+		authn.WithConnectTimeout(r.ConnectTimeout),
+		authn.WithDockerCredentials(r.Config),
+		authn.WithAnonymous(r.Anonymous),
+		authn.WithPlainHTTP(r.PlainHTTP),
+		authn.WithToken(r.Token, r.IdentityToken, r.TokenHosts...),
+	}
+
+	if r.CACert == "" && !r.InsecureSkipTLSVerify {
+		return opts, nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		//nolint:gosec // explicitly requested by the user.
+		InsecureSkipVerify: r.InsecureSkipTLSVerify,
+	}
+
+	if r.CACert != "" {
+		pem, err := os.ReadFile(r.CACert)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read CA certificates: %w", err)
+		}
+		if tlsConfig.RootCAs, err = x509.SystemCertPool(); err != nil {
+			return nil, fmt.Errorf("unable to load system CA certificates: %w", err)
+		}
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no valid PEM encoded CA certificates found in %q", r.CACert)
+		}
+	}
+
+	if r.InsecureSkipTLSVerify && printer != nil {
+		printer.Logger.Warn("TLS verification of the remote registries certificates is disabled, connections are insecure")
+	}
+
+	return append(opts, authn.WithTLSConfig(tlsConfig)), nil
+}
+
+// OperationContext returns a context bounded by the registry timeout, if any.
+// The returned cancel function must be called once the operation is completed.
+func (r *Registry) OperationContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if r.Timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, r.Timeout)
 }
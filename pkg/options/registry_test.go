@@ -0,0 +1,264 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	"github.com/falcosecurity/falcoctl/pkg/oci/authn"
+	"github.com/falcosecurity/falcoctl/pkg/output"
+)
+
+var _ = Describe("Registry", func() {
+	var (
+		server   *httptest.Server
+		registry *Registry
+		buf      *gbytes.Buffer
+		printer  *output.Printer
+		err      error
+		resp     *http.Response
+	)
+
+	BeforeEach(func() {
+		server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		DeferCleanup(server.Close)
+		registry = &Registry{}
+		buf = gbytes.NewBuffer()
+		printer = &output.Printer{Logger: pterm.DefaultLogger.WithWriter(buf)}
+	})
+
+	JustBeforeEach(func() {
+		var opts []func(*authn.Options)
+		opts, err = registry.ClientOptions(printer)
+		if err != nil {
+			return
+		}
+		// Going through the auth client, the credentials are sent when challenged by the server.
+		var req *http.Request
+		req, err = http.NewRequest(http.MethodGet, server.URL, http.NoBody)
+		Expect(err).ShouldNot(HaveOccurred())
+		resp, err = authn.NewClient(opts...).Do(req)
+		if err == nil {
+			resp.Body.Close()
+		}
+	})
+
+	Context("ClientOptions Func", func() {
+		When("using the default TLS configuration", func() {
+			It("should reject the registry certificate signed by an unknown CA", func() {
+				Expect(err).Should(MatchError(ContainSubstring("certificate")))
+			})
+		})
+
+		When("using a custom CA certificate", func() {
+			BeforeEach(func() {
+				registry.CACert = filepath.Join(GinkgoT().TempDir(), "ca.pem")
+				caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+				Expect(os.WriteFile(registry.CACert, caPEM, 0o600)).Should(Succeed())
+			})
+
+			It("should trust the registry certificate", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(resp.StatusCode).Should(Equal(http.StatusOK))
+				Expect(buf).ShouldNot(gbytes.Say("insecure"))
+			})
+		})
+
+		When("using an invalid CA certificate file", func() {
+			BeforeEach(func() {
+				registry.CACert = filepath.Join(GinkgoT().TempDir(), "ca.pem")
+				Expect(os.WriteFile(registry.CACert, []byte("not a certificate"), 0o600)).Should(Succeed())
+			})
+
+			It("should fail", func() {
+				Expect(err).Should(MatchError(ContainSubstring("no valid PEM encoded CA certificates found")))
+			})
+		})
+
+		When("skipping the TLS verification", func() {
+			BeforeEach(func() {
+				registry.InsecureSkipTLSVerify = true
+			})
+
+			It("should accept the registry certificate and warn", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(resp.StatusCode).Should(Equal(http.StatusOK))
+				Expect(buf).Should(gbytes.Say("connections are insecure"))
+			})
+		})
+	})
+
+	Context("ClientOptions Func with tokens", func() {
+		BeforeEach(func() {
+			server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/token" {
+					Expect(r.ParseForm()).To(Succeed())
+					if r.Form.Get("grant_type") != "refresh_token" || r.Form.Get("refresh_token") != "identity" {
+						w.WriteHeader(http.StatusUnauthorized)
+						return
+					}
+					w.Header().Set("Content-Type", "application/json")
+					_, _ = w.Write([]byte(`{"access_token": "exchanged"}`))
+					return
+				}
+				switch r.Header.Get("Authorization") {
+				case "Bearer secret", "Bearer exchanged":
+					w.WriteHeader(http.StatusOK)
+				default:
+					w.Header().Set("Www-Authenticate", `Bearer realm="https://`+r.Host+`/token",service="test"`)
+					w.WriteHeader(http.StatusUnauthorized)
+				}
+			}))
+			DeferCleanup(server.Close)
+			registry.InsecureSkipTLSVerify = true
+			registry.TokenHosts = []string{strings.TrimPrefix(server.URL, "https://")}
+		})
+
+		When("using a bearer token", func() {
+			BeforeEach(func() {
+				registry.Token = "secret"
+			})
+
+			It("should authenticate with the token", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(resp.StatusCode).Should(Equal(http.StatusOK))
+			})
+		})
+
+		When("using a bearer token for another registry", func() {
+			BeforeEach(func() {
+				registry.Token = "secret"
+				registry.TokenHosts = []string{"registry.example.com"}
+			})
+
+			It("should not send the token", func() {
+				// Without the token, the client tries to get an anonymous one, which the server refuses.
+				Expect(err).Should(MatchError(ContainSubstring("/token?service=test\": response status code 401")))
+			})
+		})
+
+		When("using a bearer token without its registry", func() {
+			BeforeEach(func() {
+				registry.Token = "secret"
+				registry.TokenHosts = nil
+			})
+
+			It("should fail", func() {
+				Expect(err).Should(MatchError("--registry-token-host is required to use --registry-token or --identity-token"))
+			})
+		})
+
+		When("reading the bearer token from a file", func() {
+			BeforeEach(func() {
+				tokenFile := filepath.Join(GinkgoT().TempDir(), "token")
+				Expect(os.WriteFile(tokenFile, []byte("secret\n"), 0o600)).Should(Succeed())
+				registry.Token = "@" + tokenFile
+			})
+
+			It("should authenticate with the token", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(resp.StatusCode).Should(Equal(http.StatusOK))
+				Expect(registry.Token).Should(Equal("secret"))
+			})
+		})
+
+		When("reading the bearer token from an empty file", func() {
+			BeforeEach(func() {
+				tokenFile := filepath.Join(GinkgoT().TempDir(), "token")
+				Expect(os.WriteFile(tokenFile, nil, 0o600)).Should(Succeed())
+				registry.Token = "@" + tokenFile
+			})
+
+			It("should fail", func() {
+				Expect(err).Should(MatchError("unable to read --registry-token: empty token"))
+			})
+		})
+
+		When("using an identity token", func() {
+			BeforeEach(func() {
+				registry.IdentityToken = "identity"
+			})
+
+			It("should exchange it for a bearer token", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(resp.StatusCode).Should(Equal(http.StatusOK))
+			})
+		})
+
+		When("reading the bearer token from the standard input", func() {
+			BeforeEach(func() {
+				registry.Token = "-"
+				registry.Stdin = strings.NewReader("secret\n")
+			})
+
+			It("should authenticate with the token", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(resp.StatusCode).Should(Equal(http.StatusOK))
+				Expect(registry.Token).Should(Equal("secret"))
+			})
+		})
+
+		When("reading the bearer token from the input of the command", func() {
+			BeforeEach(func() {
+				hosts := registry.TokenHosts
+				cmd := &cobra.Command{}
+				registry.AddFlags(cmd)
+				cmd.SetIn(strings.NewReader("secret\n"))
+				Expect(cmd.ParseFlags([]string{"--insecure-skip-tls-verify", "--registry-token", "-",
+					"--registry-token-host", hosts[0]})).To(Succeed())
+			})
+
+			It("should authenticate with the token", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(resp.StatusCode).Should(Equal(http.StatusOK))
+				Expect(registry.Token).Should(Equal("secret"))
+			})
+		})
+
+		When("reading both tokens from the standard input", func() {
+			BeforeEach(func() {
+				registry.Token, registry.IdentityToken = "-", "-"
+			})
+
+			It("should fail", func() {
+				Expect(err).Should(MatchError("only one of --registry-token and --identity-token can be read from the standard input"))
+			})
+		})
+
+		When("using a token anonymously", func() {
+			BeforeEach(func() {
+				registry.Token, registry.Anonymous = "secret", true
+			})
+
+			It("should fail", func() {
+				Expect(err).Should(MatchError("--anonymous cannot be used together with --registry-token or --identity-token"))
+			})
+		})
+	})
+})
@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// sizeUnits are the suffixes accepted by ByteSize, from the biggest to the smallest so that "KiB" is matched
+// before "B".
+var sizeUnits = []struct {
+	suffix string
+	bytes  int64
+}{
+	{"TiB", 1 << 40}, {"GiB", 1 << 30}, {"MiB", 1 << 20}, {"KiB", 1 << 10},
+	{"TB", 1e12}, {"GB", 1e9}, {"MB", 1e6}, {"KB", 1e3},
+	{"B", 1},
+}
+
+// ByteSize implements the flag interface for sizes in bytes. They can be given as a plain number of bytes, or
+// followed by a binary (KiB, MiB, GiB, TiB) or decimal (KB, MB, GB, TB) unit, e.g. "512MiB".
+type ByteSize int64
+
+// String returns the size with the biggest binary unit dividing it, e.g. "1GiB".
+func (s *ByteSize) String() string {
+	size := int64(*s)
+	for _, unit := range sizeUnits {
+		if strings.HasSuffix(unit.suffix, "iB") && size != 0 && size%unit.bytes == 0 {
+			return strconv.FormatInt(size/unit.bytes, 10) + unit.suffix
+		}
+	}
+	return strconv.FormatInt(size, 10)
+}
+
+// Set the value for the flag.
+func (s *ByteSize) Set(v string) error {
+	number, multiplier := strings.TrimSpace(v), int64(1)
+	for _, unit := range sizeUnits {
+		if strings.HasSuffix(number, unit.suffix) {
+			number, multiplier = strings.TrimSpace(strings.TrimSuffix(number, unit.suffix)), unit.bytes
+			break
+		}
+	}
+
+	n, err := strconv.ParseInt(number, 10, 64)
+	if err != nil || n < 0 {
+		return fmt.Errorf("invalid size %q, please provide a non negative number of bytes, optionally followed by a unit "+
+			"(KiB, MiB, GiB, TiB, KB, MB, GB, TB)", v)
+	}
+	if n > 0 && multiplier > (1<<63-1)/n {
+		return fmt.Errorf("invalid size %q: too big", v)
+	}
+
+	*s = ByteSize(n * multiplier)
+	return nil
+}
+
+// Type returns the type of the flag.
+func (s *ByteSize) Type() string {
+	return "size"
+}
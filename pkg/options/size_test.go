@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ByteSize", func() {
+	var size ByteSize
+
+	BeforeEach(func() {
+		size = 0
+	})
+
+	Context("Set Func", func() {
+		DescribeTable("Setting a valid size",
+			func(val string, expected int64) {
+				Expect(size.Set(val)).Should(Succeed())
+				Expect(int64(size)).Should(Equal(expected))
+			},
+			Entry("plain bytes", "512", int64(512)),
+			Entry("bytes unit", "512B", int64(512)),
+			Entry("binary unit", "100MiB", int64(100<<20)),
+			Entry("decimal unit", "2GB", int64(2e9)),
+			Entry("space before the unit", "1 KiB", int64(1024)),
+			Entry("zero", "0", int64(0)),
+		)
+
+		DescribeTable("Setting an invalid size",
+			func(val string) {
+				Expect(size.Set(val)).ShouldNot(Succeed())
+			},
+			Entry("unknown unit", "10XB"),
+			Entry("negative", "-1MiB"),
+			Entry("fractional", "1.5GiB"),
+			Entry("empty", ""),
+			Entry("overflowing", "9000000TiB"),
+		)
+	})
+
+	Context("String Func", func() {
+		It("Should use the biggest binary unit dividing the size", func() {
+			size = 1 << 30
+			Expect(size.String()).Should(Equal("1GiB"))
+			size = 1536 << 10
+			Expect(size.String()).Should(Equal("1536KiB"))
+		})
+
+		It("Should return the plain number of bytes otherwise", func() {
+			size = 1536
+			Expect(size.String()).Should(Equal("1536"))
+			size = 0
+			Expect(size.String()).Should(Equal("0"))
+		})
+	})
+
+	Context("Type Func", func() {
+		It("Should return the size type", func() {
+			Expect(size.Type()).Should(Equal("size"))
+		})
+	})
+})
@@ -0,0 +1,143 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+
+	"github.com/pterm/pterm"
+)
+
+// clearLine is the ANSI sequence moving the cursor to the start of the line and erasing it.
+const clearLine = "\r\033[K"
+
+// Concurrent returns a copy of the printer safe for concurrent use, for operations running in parallel. Its writes
+// are serialized and, instead of a spinner each, the operations started with StartOperation share a single spinner,
+// above which the messages are written so that they do not garble each other. Progress bars are not shown by the
+// returned printer, the progress of the transfers is logged instead.
+func (p *Printer) Concurrent() *Printer {
+	if p.shared != nil {
+		return p
+	}
+
+	w := p.Logger.Writer
+	if w == nil {
+		w = os.Stdout
+	}
+	shared := &sharedSpinner{}
+	concurrent := p.WithWriter(&lineWriter{w: w, spinning: &shared.spinning})
+	shared.template = concurrent.Spinner
+	concurrent.shared = shared
+	return concurrent
+}
+
+// StartOperation shows the spinner with the given message until the returned function is called, which can be
+// called more than once. Printers returned by Concurrent show the operations in progress in their shared spinner.
+// Nothing is shown when the styling is disabled.
+func (p *Printer) StartOperation(msg string) (done func()) {
+	if p.DisableStyling {
+		return func() {}
+	}
+
+	if p.shared == nil {
+		p.Spinner, _ = p.Spinner.Start(msg)
+		var once sync.Once
+		return func() {
+			once.Do(func() { _ = p.Spinner.Stop() })
+		}
+	}
+
+	op := &msg
+	p.shared.add(op)
+	var once sync.Once
+	return func() {
+		once.Do(func() { p.shared.remove(op) })
+	}
+}
+
+// sharedSpinner is a spinner shared by concurrent operations, which is running as long as at least one of them is
+// in progress and shows the message of the oldest one.
+type sharedSpinner struct {
+	mu       sync.Mutex
+	template *pterm.SpinnerPrinter
+	running  *pterm.SpinnerPrinter
+	ops      []*string
+	// spinning is set while the spinner is running, so that the writes clear its line first.
+	spinning atomic.Bool
+}
+
+func (s *sharedSpinner) add(op *string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ops = append(s.ops, op)
+	s.restart()
+}
+
+func (s *sharedSpinner) remove(op *string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.ops {
+		if s.ops[i] == op {
+			s.ops = append(s.ops[:i], s.ops[i+1:]...)
+			break
+		}
+	}
+	s.restart()
+}
+
+// restart stops the running spinner and, if operations are still in progress, starts a new one showing them. The
+// text of a running spinner is read by its own goroutine, hence it is never updated in place.
+func (s *sharedSpinner) restart() {
+	if s.running != nil {
+		s.spinning.Store(false)
+		_ = s.running.Stop()
+		s.running = nil
+	}
+	if len(s.ops) == 0 {
+		return
+	}
+
+	text := *s.ops[0]
+	if len(s.ops) > 1 {
+		text = fmt.Sprintf("%s (and %d more)", text, len(s.ops)-1)
+	}
+	s.running, _ = s.template.Start(text)
+	s.spinning.Store(true)
+}
+
+// lineWriter serializes the writes of the printers sharing it. While the shared spinner is running, its line is
+// cleared before each write, so that the spinner is redrawn below what has been written.
+type lineWriter struct {
+	mu       sync.Mutex
+	w        io.Writer
+	spinning *atomic.Bool
+}
+
+// Write implements the io.Writer interface.
+func (l *lineWriter) Write(b []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.spinning.Load() {
+		if _, err := io.WriteString(l.w, clearLine); err != nil {
+			return 0, err
+		}
+	}
+	return l.w.Write(b)
+}
@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/pterm/pterm"
+)
+
+var _ = Describe("Concurrent func", func() {
+	var (
+		buf        *bytes.Buffer
+		printer    *Printer
+		concurrent *Printer
+	)
+
+	BeforeEach(func() {
+		buf = &bytes.Buffer{}
+		printer = NewPrinter(pterm.LogLevelInfo, pterm.LogFormatterColorful, buf)
+	})
+
+	JustBeforeEach(func() {
+		concurrent = printer.Concurrent()
+	})
+
+	It("should return a printer sharing a single spinner", func() {
+		Expect(concurrent.shared).ShouldNot(BeNil())
+		Expect(concurrent.Concurrent()).Should(BeIdenticalTo(concurrent))
+		Expect(printer.shared).Should(BeNil())
+	})
+
+	It("should serialize the writes of concurrent loggers", func() {
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				concurrent.Logger.Info(fmt.Sprintf("message %d", i))
+			}(i)
+		}
+		wg.Wait()
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		Expect(lines).Should(HaveLen(10))
+		for i := 0; i < 10; i++ {
+			Expect(lines).Should(ContainElement(MatchRegexp(`INFO\s+message %d\s*$`, i)))
+		}
+	})
+
+	Context("with styling disabled", func() {
+		JustBeforeEach(func() {
+			concurrent.DisableStyling = true
+		})
+
+		It("should not start the spinner", func() {
+			done := concurrent.StartOperation("installing")
+			Expect(concurrent.shared.running).Should(BeNil())
+			done()
+		})
+	})
+
+	Context("with styling enabled", func() {
+		JustBeforeEach(func() {
+			concurrent.DisableStyling = false
+		})
+
+		It("should keep the spinner running until the last operation is done", func() {
+			first := concurrent.StartOperation("installing first")
+			second := concurrent.StartOperation("installing second")
+			Expect(concurrent.shared.running.Text).Should(Equal("installing first (and 1 more)"))
+
+			first()
+			first()
+			Expect(concurrent.shared.running.Text).Should(Equal("installing second"))
+
+			second()
+			Expect(concurrent.shared.running).Should(BeNil())
+			Expect(concurrent.shared.spinning.Load()).Should(BeFalse())
+		})
+	})
+
+	Context("lineWriter", func() {
+		It("should clear the line of the running spinner before writing", func() {
+			concurrent.shared.spinning.Store(true)
+			_, err := concurrent.DefaultText.Writer.Write([]byte("message\n"))
+			concurrent.shared.spinning.Store(false)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(buf.String()).Should(Equal(clearLine + "message\n"))
+		})
+	})
+})
@@ -17,6 +17,7 @@ package output
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -25,6 +26,7 @@ import (
 
 	isatty "github.com/mattn/go-isatty"
 	"github.com/pterm/pterm"
+	"gopkg.in/yaml.v3"
 )
 
 // TableHeader is used to print out the correct header for a command.
@@ -37,8 +39,26 @@ const (
 	IndexList
 	// ArtifactInfo identifies the header for artifact info.
 	ArtifactInfo
+	// InstallPlan identifies the header for the artifact install dry-run.
+	InstallPlan
+	// ArtifactInstalled identifies the header for the list of installed artifacts.
+	ArtifactInstalled
+	// ArtifactVerify identifies the header for the verification of the installed artifacts.
+	ArtifactVerify
+	// RegistryCatalog identifies the header for the repositories of a registry.
+	RegistryCatalog
+	// RegistryTags identifies the header for the tags of a repository.
+	RegistryTags
+	// ArtifactReferrers identifies the header for the artifacts attached to an artifact.
+	ArtifactReferrers
+	// TrustedKeys identifies the header for the trusted public keys.
+	TrustedKeys
 )
 
+// NoColorEnv is the environment variable disabling the colors and the styling when set to a non-empty value,
+// see https://no-color.org.
+const NoColorEnv = "NO_COLOR"
+
 var spinnerCharset = []string{"⠈⠁", "⠈⠑", "⠈⠱", "⠈⡱", "⢀⡱", "⢄⡱", "⢄⡱", "⢆⡱", "⢎⡱", "⢎⡰", "⢎⡠", "⢎⡀", "⢎⠁", "⠎⠁", "⠊⠁"}
 
 // NewProgressBar returns a new progress bar printer.
@@ -63,6 +83,16 @@ type Printer struct {
 	ProgressBar    *pterm.ProgressbarPrinter
 	Spinner        *pterm.SpinnerPrinter
 	DisableStyling bool
+	// JSONOutput is set when the results of the commands must be printed as JSON to Output.
+	JSONOutput bool
+	// YAMLOutput is set when the results of the commands must be printed as YAML to Output.
+	YAMLOutput bool
+	// Output is where the results printed as JSON or YAML are written, stdout if not set.
+	Output io.Writer
+	// Quiet is set when nothing but the errors and the results of the commands must be printed.
+	Quiet bool
+	// shared is the spinner shared by the printers returned by Concurrent.
+	shared *sharedSpinner
 }
 
 // NewPrinter returns a printer ready to be used.
@@ -71,8 +101,9 @@ func NewPrinter(logLevel pterm.LogLevel, logFormatter pterm.LogFormatter, writer
 	// If we are not in a tty then make sure that the disableStyling variable is set to true since
 	// we use it elsewhere to check if we are in a tty or not. We force the disableStyling to true
 	// only if it is set to false and we are not in a tty. Otherwise let it as it is, false if the
-	// user has not set it (default) otherwise true.
-	if (logFormatter != pterm.LogFormatterJSON && !isatty.IsTerminal(os.Stdout.Fd())) || logFormatter == pterm.LogFormatterJSON {
+	// user has not set it (default) otherwise true. The same goes when colors are disabled through NoColorEnv.
+	if (logFormatter != pterm.LogFormatterJSON && !isatty.IsTerminal(os.Stdout.Fd())) || logFormatter == pterm.LogFormatterJSON ||
+		os.Getenv(NoColorEnv) != "" {
 		disableStyling = true
 	}
 
@@ -154,11 +185,25 @@ func (p *Printer) PrintTable(header TableHeader, data [][]string) error {
 
 	switch header {
 	case ArtifactSearch:
-		table = [][]string{{"INDEX", "ARTIFACT", "TYPE", "REGISTRY", "REPOSITORY"}}
+		table = [][]string{{"INDEX", "ARTIFACT", "TYPE", "REGISTRY", "REPOSITORY", "DESCRIPTION"}}
 	case IndexList:
 		table = [][]string{{"NAME", "URL", "ADDED", "UPDATED"}}
 	case ArtifactInfo:
 		table = [][]string{{"REF", "TAGS"}}
+	case InstallPlan:
+		table = [][]string{{"REF", "DIGEST", "TYPE", "DESTINATION"}}
+	case ArtifactInstalled:
+		table = [][]string{{"NAME", "TYPE", "REF", "DIGEST", "PATH", "CAPABILITIES"}}
+	case ArtifactVerify:
+		table = [][]string{{"NAME", "PATH", "STATUS"}}
+	case RegistryCatalog:
+		table = [][]string{{"REPOSITORY"}}
+	case RegistryTags:
+		table = [][]string{{"TAG"}}
+	case ArtifactReferrers:
+		table = [][]string{{"ARTIFACT TYPE", "DIGEST", "MEDIA TYPE", "SIZE"}}
+	case TrustedKeys:
+		table = [][]string{{"NAME", "ALGORITHM", "FINGERPRINT"}}
 	default:
 		return fmt.Errorf("unsupported output table")
 	}
@@ -168,6 +213,77 @@ func (p *Printer) PrintTable(header TableHeader, data [][]string) error {
 	return p.TablePrinter.WithData(table).Render()
 }
 
+// StructuredOutput returns true when the results of the commands must be printed as JSON or YAML.
+func (p *Printer) StructuredOutput() bool {
+	return p.JSONOutput || p.YAMLOutput
+}
+
+// PrintResults prints the results of a command in the format requested to the printer: v is printed as JSON
+// or YAML, otherwise data is printed as a table with the given header.
+func (p *Printer) PrintResults(v interface{}, header TableHeader, data [][]string) error {
+	if p.StructuredOutput() {
+		return p.PrintStructured(v)
+	}
+	return p.PrintTable(header, data)
+}
+
+// PrintStructured prints the given value as YAML if requested, otherwise as JSON.
+func (p *Printer) PrintStructured(v interface{}) error {
+	if p.YAMLOutput {
+		return p.PrintYAML(v)
+	}
+	return p.PrintJSON(v)
+}
+
+// PrintJSON prints the given value as indented JSON to the output of the printer.
+func (p *Printer) PrintJSON(v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to marshal output: %w", err)
+	}
+	_, err = fmt.Fprintln(p.output(), string(data))
+	return err
+}
+
+// PrintYAML prints the given value as YAML to the output of the printer. The value is marshaled as JSON
+// first, so that the same keys are used in both formats.
+func (p *Printer) PrintYAML(v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("unable to marshal output: %w", err)
+	}
+
+	// JSON is valid YAML: decoding it into a node keeps the order of the keys.
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return fmt.Errorf("unable to marshal output: %w", err)
+	}
+	blockStyle(&node)
+
+	enc := yaml.NewEncoder(p.output())
+	enc.SetIndent(2)
+	if err := enc.Encode(&node); err != nil {
+		return fmt.Errorf("unable to marshal output: %w", err)
+	}
+	return enc.Close()
+}
+
+// blockStyle resets the flow and quoting styles inherited from JSON, letting the encoder choose them.
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, n := range node.Content {
+		blockStyle(n)
+	}
+}
+
+// output returns the writer of the results, stdout if not set.
+func (p *Printer) output() io.Writer {
+	if p.Output == nil {
+		return os.Stdout
+	}
+	return p.Output
+}
+
 // WithWriter sets the writer for the current printer.
 func (p Printer) WithWriter(writer io.Writer) *Printer {
 	if writer != nil {
@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"os"
 
 	"github.com/gookit/color"
 	. "github.com/onsi/ginkgo/v2"
@@ -130,6 +131,20 @@ var _ = Describe("NewPrinter func", func() {
 			})
 		})
 	})
+
+	Context("with NO_COLOR", func() {
+		BeforeEach(func() {
+			logFormatter = pterm.LogFormatterColorful
+			Expect(os.Setenv(NoColorEnv, "1")).To(Succeed())
+			DeferCleanup(os.Unsetenv, NoColorEnv)
+		})
+
+		It("should disable styling and colors", func() {
+			Expect(printer.DisableStyling).Should(BeTrue())
+			Expect(pterm.RawOutput).Should(BeTrue())
+			Expect(pterm.PrintColor).Should(BeFalse())
+		})
+	})
 })
 
 var _ = Describe("CheckErr func", func() {
@@ -261,7 +276,7 @@ var _ = Describe("PrintTable func", func() {
 		})
 
 		It("should print header", func() {
-			header := []string{"INDEX", "ARTIFACT", "TYPE", "REGISTRY", "REPOSITORY"}
+			header := []string{"INDEX", "ARTIFACT", "TYPE", "REGISTRY", "REPOSITORY", "DESCRIPTION"}
 			for _, col := range header {
 				Expect(buf).Should(gbytes.Say(col))
 			}
@@ -349,3 +364,96 @@ var _ = Describe("FormatTitleAsLoggerInfo func", func() {
 	})
 
 })
+
+var _ = Describe("PrintJSON func", func() {
+	var (
+		printer *Printer
+		buf     *bytes.Buffer
+		err     error
+		value   interface{}
+	)
+
+	BeforeEach(func() {
+		buf = &bytes.Buffer{}
+		printer = &Printer{Output: buf}
+	})
+
+	JustBeforeEach(func() {
+		err = printer.PrintJSON(value)
+	})
+
+	Context("serializable value", func() {
+		BeforeEach(func() {
+			value = []map[string]string{{"name": "k8saudit", "status": "installed"}}
+		})
+
+		It("should print the value as indented json to the output", func() {
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(buf.String()).Should(Equal("[\n  {\n    \"name\": \"k8saudit\",\n    \"status\": \"installed\"\n  }\n]\n"))
+		})
+	})
+
+	Context("non serializable value", func() {
+		BeforeEach(func() {
+			value = make(chan int)
+		})
+
+		It("should error", func() {
+			Expect(err).Should(HaveOccurred())
+			Expect(buf.Len()).Should(BeZero())
+		})
+	})
+})
+
+var _ = Describe("PrintResults func", func() {
+	var (
+		printer *Printer
+		buf     *bytes.Buffer
+		err     error
+		value   interface{}
+	)
+
+	BeforeEach(func() {
+		buf = &bytes.Buffer{}
+		printer = &Printer{Output: buf, TablePrinter: pterm.DefaultTable.WithHasHeader().WithSeparator("\t").WithWriter(buf)}
+		value = []struct {
+			Name string   `json:"name"`
+			Ref  string   `json:"ref"`
+			Tags []string `json:"tags"`
+		}{{Name: "k8saudit", Ref: "ghcr.io/falcosecurity/plugins/plugin/k8saudit", Tags: []string{"0.1.0", "latest"}}}
+	})
+
+	JustBeforeEach(func() {
+		err = printer.PrintResults(value, IndexList, [][]string{{"k8saudit", "ghcr.io/falcosecurity/plugins/plugin/k8saudit", "", ""}})
+	})
+
+	Context("text output", func() {
+		It("should print the table", func() {
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(buf.String()).Should(ContainSubstring("NAME"))
+			Expect(buf.String()).Should(ContainSubstring("k8saudit"))
+		})
+	})
+
+	Context("json output", func() {
+		BeforeEach(func() {
+			printer.JSONOutput = true
+		})
+
+		It("should print the value as json", func() {
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(buf.String()).Should(HavePrefix("[\n  {\n    \"name\": \"k8saudit\","))
+		})
+	})
+
+	Context("yaml output", func() {
+		BeforeEach(func() {
+			printer.YAMLOutput = true
+		})
+
+		It("should print the value as yaml with the json keys in order", func() {
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(buf.String()).Should(Equal("- name: k8saudit\n  ref: ghcr.io/falcosecurity/plugins/plugin/k8saudit\n  tags:\n    - 0.1.0\n    - latest\n"))
+		})
+	})
+})
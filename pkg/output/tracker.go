@@ -19,6 +19,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"time"
 
 	v1 "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/pterm/pterm"
@@ -39,20 +40,105 @@ func NewTracker(printer *Printer, msg string) Tracker {
 	}
 }
 
+const (
+	// progressBarRefreshInterval is how often the progress bar title is refreshed with the transfer stats.
+	progressBarRefreshInterval = 200 * time.Millisecond
+	// progressLogInterval is how often a log line with the transfer stats is printed when not in a tty.
+	progressLogInterval = 5 * time.Second
+)
+
+// Progress describes the state of the transfer of a layer.
+type Progress struct {
+	// Descriptor of the layer being transferred.
+	Descriptor v1.Descriptor
+	// Written is the number of bytes transferred so far.
+	Written int64
+	// Elapsed is the time passed since the transfer started.
+	Elapsed time.Duration
+}
+
+// Rate returns the average transfer rate in bytes per second.
+func (p Progress) Rate() float64 {
+	if p.Elapsed <= 0 {
+		return 0
+	}
+	return float64(p.Written) / p.Elapsed.Seconds()
+}
+
+// ETA returns the estimated time needed to complete the transfer, or zero if it's not possible to estimate it.
+func (p Progress) ETA() time.Duration {
+	rate := p.Rate()
+	remaining := p.Descriptor.Size - p.Written
+	if rate <= 0 || remaining <= 0 {
+		return 0
+	}
+	return time.Duration(float64(remaining) / rate * float64(time.Second)).Round(time.Second)
+}
+
+// String returns the transfer stats in a human readable format.
+func (p Progress) String() string {
+	return fmt.Sprintf("%s / %s, %s/s, ETA %s",
+		humanBytes(float64(p.Written)), humanBytes(float64(p.Descriptor.Size)), humanBytes(p.Rate()), p.ETA())
+}
+
+// humanBytes formats a number of bytes using binary prefixes.
+func humanBytes(b float64) string {
+	const unit = 1024
+	units := []string{"B", "KiB", "MiB", "GiB", "TiB"}
+	i := 0
+	for b >= unit && i < len(units)-1 {
+		b /= unit
+		i++
+	}
+	if i == 0 {
+		return fmt.Sprintf("%.0f %s", b, units[i])
+	}
+	return fmt.Sprintf("%.1f %s", b, units[i])
+}
+
 // ProgressTracker tracks the progress of pull and push operations.
 type ProgressTracker struct {
 	oras.Target
 	*Printer
 	msg string
+	// OnProgress is called with the transfer stats while a layer is being transferred.
+	// By default, they are shown in the progress bar when in a tty, or periodically logged otherwise.
+	OnProgress func(Progress)
+	// interval is the minimum time between two consecutive OnProgress calls.
+	interval time.Duration
 }
 
 // NewProgressTracker returns a new ProgressTracker ready to be used.
 func NewProgressTracker(printer *Printer, target oras.Target, msg string) *ProgressTracker {
-	return &ProgressTracker{
+	t := &ProgressTracker{
 		Target:  target,
 		Printer: printer,
 		msg:     msg,
 	}
+
+	if !t.showProgressBar() {
+		t.interval = progressLogInterval
+		t.OnProgress = t.logProgress
+	} else {
+		t.interval = progressBarRefreshInterval
+		t.OnProgress = t.updateProgressBar
+	}
+
+	return t
+}
+
+func (t *ProgressTracker) title(p Progress) string {
+	return fmt.Sprintf("%s layer %s", t.msg, p.Descriptor.Digest.Encoded()[:12])
+}
+
+func (t *ProgressTracker) updateProgressBar(p Progress) {
+	if t.ProgressBar != nil && t.ProgressBar.IsActive {
+		t.ProgressBar = t.ProgressBar.UpdateTitle(fmt.Sprintf("%s (%s)", t.title(p), p))
+	}
+}
+
+func (t *ProgressTracker) logProgress(p Progress) {
+	t.Logger.Info(t.title(p), t.Logger.Args("progress", p.String()))
 }
 
 // Push reimplements the Push function of the oras.Target interface adding the needed logic for the progress bar.
@@ -61,7 +147,7 @@ func (t *ProgressTracker) Push(ctx context.Context, expected v1.Descriptor, cont
 
 	t.Logger.Info(fmt.Sprintf("%s layer %s", t.msg, d))
 
-	if !t.Printer.DisableStyling {
+	if t.showProgressBar() {
 		t.ProgressBar, _ = NewProgressBar().
 			WithTotal(int(expected.Size)).
 			WithTitle(fmt.Sprintf("%s layer %s", t.msg, d)).
@@ -72,18 +158,26 @@ func (t *ProgressTracker) Push(ctx context.Context, expected v1.Descriptor, cont
 		Reader:      content,
 		descriptor:  expected,
 		progressBar: t.ProgressBar,
+		onProgress:  t.OnProgress,
+		interval:    t.interval,
+		start:       time.Now(),
 	}
 	if err := t.Target.Push(ctx, expected, reader); err != nil {
 		return err
 	}
 
-	if !t.Printer.DisableStyling {
+	if t.showProgressBar() {
 		_, _ = t.ProgressBar.Stop()
 	}
 
 	return nil
 }
 
+// showProgressBar reports whether the progress of the transfers is shown in a progress bar, rather than logged.
+func (t *ProgressTracker) showProgressBar() bool {
+	return !t.Printer.DisableStyling && t.Printer.shared == nil
+}
+
 // Exists if the layer already exists it prints out the correct message.
 func (t *ProgressTracker) Exists(ctx context.Context, target v1.Descriptor) (bool, error) { //nolint:gocritic,lll // needed to implement the oras.Target interface
 	d := target.Digest.Encoded()[:12]
@@ -101,13 +195,30 @@ type trackedReader struct {
 	io.Reader
 	descriptor  v1.Descriptor
 	progressBar *pterm.ProgressbarPrinter
+	onProgress  func(Progress)
+	interval    time.Duration
+	start       time.Time
+	lastReport  time.Time
+	written     int64
 }
 
-// Read implements the logic of the progress bar.
+// Read implements the logic of the progress bar, and reports the transfer stats at most once per interval.
 func (tr *trackedReader) Read(p []byte) (n int, err error) {
 	n, err = tr.Reader.Read(p)
+	tr.written += int64(n)
 	if tr.progressBar != nil && tr.progressBar.IsActive {
 		tr.progressBar = tr.progressBar.Add(n)
 	}
+
+	if tr.onProgress != nil {
+		if now := time.Now(); now.Sub(tr.lastReport) >= tr.interval && now.Sub(tr.start) >= tr.interval {
+			tr.lastReport = now
+			tr.onProgress(Progress{
+				Descriptor: tr.descriptor,
+				Written:    tr.written,
+				Elapsed:    now.Sub(tr.start),
+			})
+		}
+	}
 	return n, err
 }
@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/opencontainers/go-digest"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+var _ = Describe("Progress", func() {
+	desc := v1.Descriptor{
+		Digest: digest.FromString("layer"),
+		Size:   10 * 1024 * 1024,
+	}
+
+	It("should compute rate and ETA", func() {
+		p := Progress{Descriptor: desc, Written: 2 * 1024 * 1024, Elapsed: 2 * time.Second}
+		Expect(p.Rate()).Should(BeNumerically("==", 1024*1024))
+		Expect(p.ETA()).Should(Equal(8 * time.Second))
+		Expect(p.String()).Should(Equal("2.0 MiB / 10.0 MiB, 1.0 MiB/s, ETA 8s"))
+	})
+
+	It("should not estimate anything before the transfer starts", func() {
+		p := Progress{Descriptor: desc}
+		Expect(p.Rate()).Should(BeZero())
+		Expect(p.ETA()).Should(BeZero())
+	})
+})
+
+var _ = Describe("trackedReader", func() {
+	It("should report the bytes read to the progress callback", func() {
+		content := strings.Repeat("x", 1024)
+		var reports []Progress
+		tr := &trackedReader{
+			Reader:     strings.NewReader(content),
+			descriptor: v1.Descriptor{Size: int64(len(content))},
+			onProgress: func(p Progress) { reports = append(reports, p) },
+			start:      time.Now().Add(-time.Second),
+		}
+
+		buf := make([]byte, 256)
+		var out bytes.Buffer
+		_, err := io.CopyBuffer(&out, tr, buf)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(out.String()).Should(Equal(content))
+		Expect(reports).ShouldNot(BeEmpty())
+		Expect(reports[len(reports)-1].Written).Should(BeEquivalentTo(len(content)))
+	})
+
+	It("should throttle the progress callback", func() {
+		calls := 0
+		tr := &trackedReader{
+			Reader:     strings.NewReader(strings.Repeat("x", 1024)),
+			onProgress: func(Progress) { calls++ },
+			interval:   time.Hour,
+			start:      time.Now(),
+		}
+
+		_, err := io.CopyBuffer(io.Discard, tr, make([]byte, 16))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(calls).Should(BeZero())
+	})
+})
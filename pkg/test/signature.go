@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2026 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+
+	"github.com/falcosecurity/falcoctl/pkg/oci/repository"
+)
+
+const (
+	// simpleSigningMediaType is the media type of the layers holding the payloads signed by cosign.
+	simpleSigningMediaType = "application/vnd.dev.cosign.simplesigning.v1+json"
+	// signatureAnnotation is the annotation of the layers holding the base64 encoded signature of their payload.
+	signatureAnnotation = "dev.cosignproject.cosign/signature"
+)
+
+// GenerateCosignKey generates an ECDSA key pair, as cosign generate-key-pair does, and writes its public key as a PEM
+// file with the given name in dir, whose path is returned.
+func GenerateCosignKey(dir, name string) (*ecdsa.PrivateKey, string, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, "", err
+	}
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		return nil, "", err
+	}
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600); err != nil {
+		return nil, "", err
+	}
+	return key, path, nil
+}
+
+// CosignSign attaches to the artifact pointed by ref, in a plain HTTP registry, a signature made with key following
+// the cosign convention, i.e. as a manifest tagged after the digest of the artifact. The signed payload claims the
+// given digest, the one of the artifact when empty.
+func CosignSign(ctx context.Context, ref, digest string, key *ecdsa.PrivateKey) error {
+	repo, err := repository.NewRepository(ref, repository.WithPlainHTTP(true))
+	if err != nil {
+		return err
+	}
+	desc, err := repo.Resolve(ctx, repo.Reference.Reference)
+	if err != nil {
+		return err
+	}
+	if digest == "" {
+		digest = desc.Digest.String()
+	}
+
+	payload, err := json.Marshal(map[string]any{
+		"critical": map[string]any{
+			"identity": map[string]string{"docker-reference": repo.Reference.Registry + "/" + repo.Reference.Repository},
+			"image":    map[string]string{"docker-manifest-digest": digest},
+			"type":     "cosign container image signature",
+		},
+		"optional": nil,
+	})
+	if err != nil {
+		return err
+	}
+	hash := sha256.Sum256(payload)
+	sig, err := key.Sign(rand.Reader, hash[:], crypto.SHA256)
+	if err != nil {
+		return err
+	}
+
+	layer, err := oras.PushBytes(ctx, repo, simpleSigningMediaType, payload)
+	if err != nil {
+		return err
+	}
+	layer.Annotations = map[string]string{signatureAnnotation: base64.StdEncoding.EncodeToString(sig)}
+	config, err := oras.PushBytes(ctx, repo, v1.MediaTypeImageConfig, []byte("{}"))
+	if err != nil {
+		return err
+	}
+	manifest, err := oras.PackManifest(ctx, repo, oras.PackManifestVersion1_0, "",
+		oras.PackManifestOptions{Layers: []v1.Descriptor{layer}, ConfigDescriptor: &config})
+	if err != nil {
+		return err
+	}
+
+	return repo.Tag(ctx, manifest, strings.Replace(desc.Digest.String(), ":", "-", 1)+".sig")
+}